@@ -0,0 +1,359 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// An OIDCProvider is an OpenID Connect provider, as described by its
+// discovery document (see DiscoverOIDC).
+type OIDCProvider struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint   string   `json:"device_authorization_endpoint,omitempty"`
+	UserinfoEndpoint              string   `json:"userinfo_endpoint,omitempty"`
+	ScopesSupported               []string `json:"scopes_supported,omitempty"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported,omitempty"`
+
+	// HTTPClient is the HTTP client used to communicate with the
+	// provider. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client `json:"-"`
+}
+
+// An OAuthClient identifies an application (such as a CLI) that is
+// registered with an OpenID Connect provider.
+type OAuthClient struct {
+	ID     string
+	Secret string // empty for public clients
+
+	// Scopes are the scopes to request. If empty, only "openid" is
+	// requested.
+	Scopes []string
+}
+
+func (c OAuthClient) scope() string {
+	if len(c.Scopes) == 0 {
+		return "openid"
+	}
+	return strings.Join(c.Scopes, " ")
+}
+
+// OAuthError is an error response from an OAuth2 endpoint.
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth2 error %s: %s", e.Code, e.Description)
+	}
+	return "oauth2 error " + e.Code
+}
+
+// DiscoverOIDC fetches the OpenID Connect discovery document of the
+// provider whose issuer URL is issuer (e.g., the issuer returned by
+// the Sourcegraph API's OIDC configuration endpoint). If hc is nil,
+// http.DefaultClient is used.
+func DiscoverOIDC(ctx context.Context, hc *http.Client, issuer string) (*OIDCProvider, error) {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	resp, err := ctxhttp.Get(ctx, hc, wellKnown)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery: %s: HTTP %s", wellKnown, resp.Status)
+	}
+
+	var p OIDCProvider
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return nil, fmt.Errorf("OIDC discovery: %s: %s", wellKnown, err)
+	}
+	if strings.TrimSuffix(p.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, fmt.Errorf("OIDC discovery: issuer %q in discovery document does not match %q", p.Issuer, issuer)
+	}
+	if p.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC discovery: %s: no token endpoint", wellKnown)
+	}
+	p.HTTPClient = hc
+	return &p, nil
+}
+
+func (p *OIDCProvider) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// postForm POSTs form to endpoint and decodes the JSON response into
+// v. OAuth2 error responses are returned as *OAuthError.
+func (p *OIDCProvider) postForm(ctx context.Context, endpoint string, client OAuthClient, form url.Values, v interface{}) error {
+	form.Set("client_id", client.ID)
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if client.Secret != "" {
+		req.SetBasicAuth(url.QueryEscape(client.ID), url.QueryEscape(client.Secret))
+	}
+
+	resp, err := ctxhttp.Do(ctx, p.httpClient(), req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var oerr OAuthError
+		if json.Unmarshal(body, &oerr) == nil && oerr.Code != "" {
+			return &oerr
+		}
+		return fmt.Errorf("%s: HTTP %s", endpoint, resp.Status)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// tokenResponse is a successful response from the token endpoint.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func (r *tokenResponse) token() *Token {
+	t := &Token{AccessToken: r.AccessToken, TokenType: r.TokenType, RefreshToken: r.RefreshToken, IDToken: r.IDToken}
+	if r.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	return t
+}
+
+func (p *OIDCProvider) requestToken(ctx context.Context, client OAuthClient, form url.Values) (*Token, error) {
+	var tr tokenResponse
+	if err := p.postForm(ctx, p.TokenEndpoint, client, form, &tr); err != nil {
+		return nil, err
+	}
+	if tr.AccessToken == "" {
+		return nil, fmt.Errorf("%s: response has no access token", p.TokenEndpoint)
+	}
+	return tr.token(), nil
+}
+
+// DeviceAuthorization is the provider's response to a device
+// authorization request (RFC 8628). The user must visit
+// VerificationURI and enter UserCode (or visit
+// VerificationURIComplete, if set) to approve the login.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"` // polling interval in seconds
+}
+
+// defaultDeviceInterval is the polling interval (in units of
+// deviceIntervalUnit) used if the provider doesn't specify one.
+const defaultDeviceInterval = 5
+
+// deviceIntervalUnit is the unit of device flow polling intervals and
+// expiry times (which is only changed by tests).
+var deviceIntervalUnit = time.Second
+
+// DeviceFlow logs in using the OAuth2 device authorization grant (RFC
+// 8628), which is suitable for CLIs and other clients that can't
+// receive a browser redirect. It calls prompt with the user code and
+// verification URI (which prompt should display to the user), then
+// polls the provider until the user approves or denies the login, the
+// code expires, or ctx is done.
+//
+// The returned TokenSource refreshes the token using its refresh token
+// (if any) when it expires. Because ctx usually bounds only the login
+// (e.g., with a deadline for the user to approve it), refreshes are not
+// tied to ctx; they are limited only by p.HTTPClient's timeout.
+func (p *OIDCProvider) DeviceFlow(ctx context.Context, client OAuthClient, prompt func(*DeviceAuthorization) error) (TokenSource, error) {
+	if p.DeviceAuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("OIDC provider %s does not support the device authorization grant", p.Issuer)
+	}
+
+	var da DeviceAuthorization
+	if err := p.postForm(ctx, p.DeviceAuthorizationEndpoint, client, url.Values{"scope": {client.scope()}}, &da); err != nil {
+		return nil, err
+	}
+	if err := prompt(&da); err != nil {
+		return nil, err
+	}
+
+	interval := defaultDeviceInterval * deviceIntervalUnit
+	if da.Interval > 0 {
+		interval = time.Duration(da.Interval) * deviceIntervalUnit
+	}
+	var expired <-chan time.Time
+	if da.ExpiresIn > 0 {
+		expired = time.After(time.Duration(da.ExpiresIn) * deviceIntervalUnit)
+	}
+
+	form := url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"device_code": {da.DeviceCode},
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-expired:
+			return nil, &OAuthError{Code: "expired_token", Description: "the device code expired before the login was approved"}
+		case <-time.After(interval):
+		}
+
+		tok, err := p.requestToken(ctx, client, form)
+		if oerr, ok := err.(*OAuthError); ok {
+			switch oerr.Code {
+			case "authorization_pending":
+				continue
+			case "slow_down":
+				interval += defaultDeviceInterval * deviceIntervalUnit
+				continue
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return p.newTokenSource(context.Background(), client, tok), nil
+	}
+}
+
+// NewPKCEVerifier returns a new random PKCE code verifier (RFC 7636)
+// for use with AuthCodeURL and Exchange.
+func NewPKCEVerifier() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic("NewPKCEVerifier: " + err.Error())
+	}
+	return base64URLEncode(b)
+}
+
+func base64URLEncode(b []byte) string {
+	return strings.TrimRight(base64.URLEncoding.EncodeToString(b), "=")
+}
+
+// AuthCodeURL returns the URL of the provider's authorization page,
+// which the user should open in a browser to log in using the
+// authorization code flow. After the user logs in, the provider
+// redirects to redirectURI with "code" and "state" query parameters;
+// pass the code (after checking that the state matches) to Exchange
+// along with the same codeVerifier (see NewPKCEVerifier).
+func (p *OIDCProvider) AuthCodeURL(client OAuthClient, redirectURI, state, codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	v := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {client.ID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {client.scope()},
+		"state":                 {state},
+		"code_challenge":        {base64URLEncode(sum[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.AuthorizationEndpoint + sep + v.Encode()
+}
+
+// Exchange exchanges an authorization code (obtained by the flow
+// started with AuthCodeURL) for a token. The returned TokenSource
+// refreshes the token using its refresh token (if any) when it
+// expires. As with DeviceFlow, ctx applies only to the exchange, not
+// to later refreshes.
+func (p *OIDCProvider) Exchange(ctx context.Context, client OAuthClient, code, redirectURI, codeVerifier string) (TokenSource, error) {
+	tok, err := p.requestToken(ctx, client, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {codeVerifier},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.newTokenSource(context.Background(), client, tok), nil
+}
+
+// TokenSource returns a TokenSource that returns tok until it
+// expires and then refreshes it using its refresh token. It can be
+// used to resume a session with a previously saved token.
+//
+// Every refresh is made with ctx, so ctx must outlive the returned
+// TokenSource; pass context.Background() unless refreshes should stop
+// when some longer-lived context (such as the program's) is done.
+func (p *OIDCProvider) TokenSource(ctx context.Context, client OAuthClient, tok *Token) TokenSource {
+	return p.newTokenSource(ctx, client, tok)
+}
+
+func (p *OIDCProvider) newTokenSource(ctx context.Context, client OAuthClient, tok *Token) TokenSource {
+	return &refreshingTokenSource{ctx: ctx, p: p, client: client, tok: tok}
+}
+
+// refreshingTokenSource is a TokenSource that refreshes its token
+// using the refresh token grant when it expires.
+type refreshingTokenSource struct {
+	ctx    context.Context // used for every refresh
+	p      *OIDCProvider
+	client OAuthClient
+
+	mu  sync.Mutex
+	tok *Token
+}
+
+func (s *refreshingTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tok.Valid() {
+		return s.tok, nil
+	}
+	if s.tok == nil || s.tok.RefreshToken == "" {
+		return nil, &OAuthError{Code: "invalid_grant", Description: "token expired and no refresh token is available; log in again"}
+	}
+
+	tok, err := s.p.requestToken(s.ctx, s.client, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {s.tok.RefreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tok.RefreshToken == "" {
+		// The provider may not rotate refresh tokens.
+		tok.RefreshToken = s.tok.RefreshToken
+	}
+	s.tok = tok
+	return tok, nil
+}
@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestOIDCProvider_DeviceFlow(t *testing.T) {
+	defer func(u time.Duration) { deviceIntervalUnit = u }(deviceIntervalUnit)
+	deviceIntervalUnit = time.Millisecond
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	writeJSON := func(w http.ResponseWriter, status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, &OIDCProvider{
+			Issuer:                      server.URL,
+			AuthorizationEndpoint:       server.URL + "/authorize",
+			TokenEndpoint:               server.URL + "/token",
+			DeviceAuthorizationEndpoint: server.URL + "/device",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.FormValue("client_id"); got != "cli" {
+			t.Errorf("got client_id %q, want %q", got, "cli")
+		}
+		writeJSON(w, http.StatusOK, &DeviceAuthorization{DeviceCode: "dc", UserCode: "ABCD", VerificationURI: server.URL + "/verify", Interval: 1, ExpiresIn: 1000})
+	})
+	polls := 0
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			if polls++; polls < 3 {
+				writeJSON(w, http.StatusBadRequest, &OAuthError{Code: "authorization_pending"})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"access_token": "a1", "refresh_token": "r", "expires_in": 1})
+		case "refresh_token":
+			if got := r.FormValue("refresh_token"); got != "r" {
+				t.Errorf("got refresh_token %q, want %q", got, "r")
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"access_token": "a2", "expires_in": 3600})
+		default:
+			t.Errorf("unexpected grant_type %q", r.FormValue("grant_type"))
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p, err := DiscoverOIDC(ctx, nil, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prompted *DeviceAuthorization
+	ts, err := p.DeviceFlow(ctx, OAuthClient{ID: "cli"}, func(da *DeviceAuthorization) error {
+		prompted = da
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompted == nil || prompted.UserCode != "ABCD" {
+		t.Errorf("got prompt %+v, want user code ABCD", prompted)
+	}
+	if polls != 3 {
+		t.Errorf("got %d polls, want 3", polls)
+	}
+
+	// The login's context doesn't affect refreshes.
+	cancel()
+
+	// The first token expires within expiryDelta, so it is refreshed.
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "a2" || tok.RefreshToken != "r" {
+		t.Errorf("got token %+v, want refreshed token a2 (keeping refresh token r)", tok)
+	}
+}
+
+func TestDiscoverOIDC_issuerMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&OIDCProvider{Issuer: "https://evil.example.com", TokenEndpoint: "https://evil.example.com/token"})
+	}))
+	defer server.Close()
+
+	if _, err := DiscoverOIDC(context.Background(), nil, server.URL); err == nil {
+		t.Error("got nil error, want issuer mismatch error")
+	}
+}
+
+func TestDiscoverOIDC_canceled(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := DiscoverOIDC(ctx, nil, server.URL)
+		errc <- err
+	}()
+	select {
+	case err := <-errc:
+		if err != context.DeadlineExceeded {
+			t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("DiscoverOIDC did not return after its context was done")
+	}
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Token is an OAuth2 token (as issued by an OpenID Connect provider)
+// used to authenticate API requests.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type,omitempty"` // defaults to "Bearer"
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+
+	// Expiry is when the access token expires. If zero, the token
+	// doesn't expire.
+	Expiry time.Time `json:"expiry,omitempty"`
+}
+
+// expiryDelta is how long before its actual expiry a token is
+// considered expired, to allow for clock skew and request latency.
+const expiryDelta = 10 * time.Second
+
+// Valid returns true if t is non-nil, has an access token, and is not
+// (about to be) expired.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(expiryDelta).Before(t.Expiry)
+}
+
+// SetAuthHeader sets the Authorization header of r to authenticate
+// with t (as "Bearer xxx", unless t.TokenType specifies another
+// scheme).
+func (t *Token) SetAuthHeader(r *http.Request) {
+	tokenType := t.TokenType
+	if tokenType == "" {
+		tokenType = "Bearer"
+	}
+	r.Header.Set("Authorization", tokenType+" "+t.AccessToken)
+}
+
+// A TokenSource returns tokens, refreshing them as needed.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// StaticTokenSource returns a TokenSource that always returns t.
+func StaticTokenSource(t *Token) TokenSource {
+	return staticTokenSource{t}
+}
+
+type staticTokenSource struct{ t *Token }
+
+func (s staticTokenSource) Token() (*Token, error) { return s.t, nil }
+
+// FileTokenSource returns a TokenSource that reads the token from the
+// key file at path, which contains either a JSON-encoded Token (as
+// saved after logging in) or just an access token. The file is reread
+// whenever it is modified, so tokens rotated by another process (or
+// by a secrets manager that updates a mounted file) are picked up
+// without restarting. Token returns an error if the file's token is
+// expired.
+func FileTokenSource(path string) TokenSource {
+	return &fileTokenSource{path: path}
+}
+
+type fileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	tok     *Token
+	modTime time.Time
+}
+
+func (s *fileTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+	if s.tok == nil || !fi.ModTime().Equal(s.modTime) {
+		data, err := ioutil.ReadFile(s.path)
+		if err != nil {
+			return nil, err
+		}
+		tok, err := parseKeyFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("reading token from %s: %s", s.path, err)
+		}
+		s.tok, s.modTime = tok, fi.ModTime()
+	}
+
+	if !s.tok.Valid() {
+		return nil, fmt.Errorf("token in %s is expired", s.path)
+	}
+	return s.tok, nil
+}
+
+// parseKeyFile parses the contents of a key file (see
+// FileTokenSource).
+func parseKeyFile(data []byte) (*Token, error) {
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return nil, fmt.Errorf("key file is empty")
+	}
+	if !strings.HasPrefix(text, "{") {
+		return &Token{AccessToken: text}, nil
+	}
+	var tok Token
+	if err := json.Unmarshal(data, &tok); err != nil {
+		return nil, err
+	}
+	if tok.AccessToken == "" {
+		return nil, fmt.Errorf("key file has no access_token")
+	}
+	return &tok, nil
+}
+
+// TokenTransport is an HTTP transport that adds an "Authorization:
+// Bearer xxx" header (with a token obtained from Source) to each
+// request.
+type TokenTransport struct {
+	Source TokenSource
+
+	// Transport is the underlying HTTP transport to use. If nil,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *TokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var transport http.RoundTripper
+	if t.Transport != nil {
+		transport = t.Transport
+	} else {
+		transport = http.DefaultTransport
+	}
+
+	tok, err := t.Source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	// To set extra headers, we must make a copy of the Request so
+	// that we don't modify the Request we were given. This is required
+	// by the specification of http.RoundTripper.
+	req = cloneRequest(req)
+	tok.SetAuthHeader(req)
+
+	// Make the HTTP request.
+	return transport.RoundTrip(req)
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileTokenSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+
+	ts := FileTokenSource(path)
+	if _, err := ts.Token(); err == nil {
+		t.Fatal("err == nil for missing key file")
+	}
+
+	if err := ioutil.WriteFile(path, []byte("t1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "t1" {
+		t.Errorf("got access token %q, want %q", tok.AccessToken, "t1")
+	}
+
+	// Rotate the token (with a later mtime, in case the filesystem's
+	// mtime resolution is coarse).
+	if err := ioutil.WriteFile(path, []byte(`{"access_token":"t2","token_type":"Custom"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	tok, err = ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "t2" || tok.TokenType != "Custom" {
+		t.Errorf("got token %+v, want rotated token t2", tok)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"access_token":"t3","expiry":"2000-01-01T00:00:00Z"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	later = later.Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Token(); err == nil {
+		t.Error("err == nil for expired token")
+	}
+}
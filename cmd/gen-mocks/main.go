@@ -0,0 +1,301 @@
+// Command gen-mocks generates mock implementations of the *Service
+// interfaces in a Go package (such as the sourcegraph package). For
+// each file x.go that defines a FooService interface, it writes
+// x_mock.go containing a MockFooService struct with a Method_ func
+// field per interface method, and methods that call those fields.
+//
+// Each mock also has a Calls field (of type *MockCalls, which the
+// package must define) that records calls to its methods, and a
+// NewMockFooService constructor that sets it. Methods whose func field
+// is nil return an error made by mockNotImplemented (which the package
+// must also define) instead of panicking.
+//
+// It is run by go generate in the sourcegraph package:
+//
+//	//go:generate go run ../cmd/gen-mocks/main.go -w
+//
+// With -check, it exits with a nonzero status if any mock file is out
+// of date (for use in CI).
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	dir   = flag.String("d", ".", "directory of the package whose *Service interfaces to mock")
+	write = flag.Bool("w", false, "write mock files (instead of printing them to stdout)")
+	check = flag.Bool("check", false, "exit with a nonzero status if any mock file is out of date")
+)
+
+func main() {
+	log.SetFlags(0)
+	flag.Parse()
+
+	files, err := generate(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stale := false
+	for _, name := range names {
+		src := files[name]
+		file := filepath.Join(*dir, name)
+		switch {
+		case *check:
+			old, err := ioutil.ReadFile(file)
+			if err != nil || !bytes.Equal(old, src) {
+				log.Printf("%s is out of date", file)
+				stale = true
+			}
+		case *write:
+			if err := ioutil.WriteFile(file, src, 0644); err != nil {
+				log.Fatal(err)
+			}
+		default:
+			fmt.Printf("// %s\n%s\n", file, src)
+		}
+	}
+	if stale {
+		os.Exit(1)
+	}
+}
+
+// generate returns the contents of the mock files for the *Service
+// interfaces in the package in dir, keyed by file name.
+func generate(dir string) (map[string][]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		name := fi.Name()
+		return !strings.HasSuffix(name, "_test.go") && !strings.HasSuffix(name, "_mock.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("found %d packages in %s, want 1", len(pkgs), dir)
+	}
+
+	files := map[string][]byte{}
+	for _, pkg := range pkgs {
+		for filename, file := range pkg.Files {
+			src, err := generateFile(fset, pkg.Name, file)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", filename, err)
+			}
+			if src != nil {
+				files[strings.TrimSuffix(filepath.Base(filename), ".go")+"_mock.go"] = src
+			}
+		}
+	}
+	return files, nil
+}
+
+// generateFile returns the mock file for the *Service interfaces in
+// file, or nil if it defines none.
+func generateFile(fset *token.FileSet, pkgName string, file *ast.File) ([]byte, error) {
+	var body bytes.Buffer
+	usedPkgs := map[string]struct{}{}
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok || !ast.IsExported(ts.Name.Name) || !strings.HasSuffix(ts.Name.Name, "Service") {
+				continue
+			}
+			if err := writeMock(&body, fset, ts.Name.Name, iface); err != nil {
+				return nil, fmt.Errorf("%s: %s", ts.Name.Name, err)
+			}
+			ast.Inspect(iface, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if x, ok := sel.X.(*ast.Ident); ok {
+						usedPkgs[x.Name] = struct{}{}
+					}
+				}
+				return true
+			})
+		}
+	}
+	if body.Len() == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// generated by gen-mocks; DO NOT EDIT\n\npackage %s\n\n", pkgName)
+	var stdImports, otherImports []string
+	for _, imp := range file.Imports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+		name := path.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if _, used := usedPkgs[name]; !used {
+			continue
+		}
+		spec := imp.Path.Value
+		if imp.Name != nil {
+			spec = imp.Name.Name + " " + spec
+		}
+		if strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".") {
+			otherImports = append(otherImports, spec)
+		} else {
+			stdImports = append(stdImports, spec)
+		}
+	}
+	switch imports := append(stdImports, otherImports...); {
+	case len(imports) == 1:
+		fmt.Fprintf(&buf, "import %s\n\n", imports[0])
+	case len(imports) > 1:
+		groups := strings.Join(stdImports, "\n")
+		if len(stdImports) > 0 && len(otherImports) > 0 {
+			groups += "\n\n"
+		}
+		groups += strings.Join(otherImports, "\n")
+		fmt.Fprintf(&buf, "import (\n%s\n)\n\n", groups)
+	}
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+// writeMock writes the mock type for the named interface to w.
+func writeMock(w *bytes.Buffer, fset *token.FileSet, name string, iface *ast.InterfaceType) error {
+	type method struct {
+		name, params, args, results string
+
+		recordArgs  string   // args to record (without "...")
+		resultTypes []string // result types
+	}
+	var methods []method
+	for _, field := range iface.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return fmt.Errorf("embedded interfaces are not supported")
+		}
+
+		var params, args, recordArgs []string
+		if ft.Params != nil {
+			for _, p := range ft.Params.List {
+				typ := exprString(fset, p.Type)
+				_, variadic := p.Type.(*ast.Ellipsis)
+				names := p.Names
+				if len(names) == 0 {
+					names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("a%d", len(params)))}
+				}
+				for _, n := range names {
+					params = append(params, n.Name+" "+typ)
+					recordArgs = append(recordArgs, n.Name)
+					if variadic {
+						args = append(args, n.Name+"...")
+					} else {
+						args = append(args, n.Name)
+					}
+				}
+			}
+		}
+
+		var results []string
+		if ft.Results != nil {
+			for _, r := range ft.Results.List {
+				for i := 0; i < len(r.Names) || i == 0; i++ {
+					results = append(results, exprString(fset, r.Type))
+				}
+			}
+		}
+		var resultsStr string
+		switch len(results) {
+		case 0:
+		case 1:
+			resultsStr = results[0]
+		default:
+			resultsStr = "(" + strings.Join(results, ", ") + ")"
+		}
+
+		for _, n := range field.Names {
+			methods = append(methods, method{
+				name:        n.Name,
+				params:      strings.Join(params, ", "),
+				args:        strings.Join(args, ", "),
+				results:     resultsStr,
+				recordArgs:  strings.Join(recordArgs, ", "),
+				resultTypes: results,
+			})
+		}
+	}
+
+	mockName := "Mock" + name
+	fmt.Fprintf(w, "type %s struct {\n", mockName)
+	for _, m := range methods {
+		fmt.Fprintf(w, "%s_ func(%s) %s\n", m.name, m.params, m.results)
+	}
+	fmt.Fprintf(w, "\n// Calls, if set, records the calls to the mock's methods.\nCalls *MockCalls\n")
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "// New%[1]s returns a new %[1]s\n// that records calls to its methods.\n", mockName)
+	fmt.Fprintf(w, "func New%[1]s() *%[1]s {\nreturn &%[1]s{Calls: &MockCalls{}}\n}\n\n", mockName)
+	for _, m := range methods {
+		ret := "return "
+		if m.results == "" {
+			ret = ""
+		}
+		recordArgs := ""
+		if m.recordArgs != "" {
+			recordArgs = ", " + m.recordArgs
+		}
+		fmt.Fprintf(w, "func (s %s) %s(%s) %s {\n", mockName, m.name, m.params, m.results)
+		fmt.Fprintf(w, "s.Calls.record(%q%s)\n", m.name, recordArgs)
+		fmt.Fprintf(w, "if s.%s_ == nil {\n%s}\n", m.name, notImplemented(name+"."+m.name, m.resultTypes))
+		fmt.Fprintf(w, "%ss.%s_(%s)\n}\n\n", ret, m.name, m.args)
+	}
+	return nil
+}
+
+// notImplemented returns the statements that a mock method (with the
+// given result types) executes if its func field is nil: it returns
+// zero values and an error if its last result is an error, and panics
+// otherwise.
+func notImplemented(method string, results []string) string {
+	if len(results) == 0 || results[len(results)-1] != "error" {
+		return fmt.Sprintf("panic(%q)\n", "mock method "+method+" is not implemented")
+	}
+	var buf bytes.Buffer
+	var vars []string
+	for i, typ := range results[:len(results)-1] {
+		v := fmt.Sprintf("r%d", i)
+		fmt.Fprintf(&buf, "var %s %s\n", v, typ)
+		vars = append(vars, v)
+	}
+	vars = append(vars, fmt.Sprintf("mockNotImplemented(%q)", method))
+	fmt.Fprintf(&buf, "return %s\n", strings.Join(vars, ", "))
+	return buf.String()
+}
+
+func exprString(fset *token.FileSet, x ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, x)
+	return buf.String()
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gen-mocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := `package p
+
+import (
+	"io"
+	"net/http"
+)
+
+type WidgetsService interface {
+	Get(id int) (*http.Response, error)
+	Upload(io.Reader, ...string) error
+	Reset()
+}
+
+type notAService interface {
+	Get() error
+}
+`
+	if err := ioutil.WriteFile(filepath.Join(dir, "widgets.go"), []byte(src), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := generate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+	got := string(files["widgets_mock.go"])
+	want := `// generated by gen-mocks; DO NOT EDIT
+
+package p
+
+import (
+	"io"
+	"net/http"
+)
+
+type MockWidgetsService struct {
+	Get_    func(id int) (*http.Response, error)
+	Upload_ func(a0 io.Reader, a1 ...string) error
+	Reset_  func()
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockWidgetsService returns a new MockWidgetsService
+// that records calls to its methods.
+func NewMockWidgetsService() *MockWidgetsService {
+	return &MockWidgetsService{Calls: &MockCalls{}}
+}
+
+func (s MockWidgetsService) Get(id int) (*http.Response, error) {
+	s.Calls.record("Get", id)
+	if s.Get_ == nil {
+		var r0 *http.Response
+		return r0, mockNotImplemented("WidgetsService.Get")
+	}
+	return s.Get_(id)
+}
+
+func (s MockWidgetsService) Upload(a0 io.Reader, a1 ...string) error {
+	s.Calls.record("Upload", a0, a1)
+	if s.Upload_ == nil {
+		return mockNotImplemented("WidgetsService.Upload")
+	}
+	return s.Upload_(a0, a1...)
+}
+
+func (s MockWidgetsService) Reset() {
+	s.Calls.record("Reset")
+	if s.Reset_ == nil {
+		panic("mock method WidgetsService.Reset is not implemented")
+	}
+	s.Reset_()
+}
+`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestSourcegraphMocksUpToDate checks that the sourcegraph package's
+// mocks match its service interfaces. If it fails, run go generate in
+// the sourcegraph package.
+func TestSourcegraphMocksUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "sourcegraph")
+	files, err := generate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, src := range files {
+		old, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if string(old) != string(src) {
+			t.Errorf("%s is out of date; run go generate in the sourcegraph package", name)
+		}
+	}
+
+	// Check that no stale mock files remain for removed interfaces.
+	existing, _ := filepath.Glob(filepath.Join(dir, "*_mock.go"))
+	for _, f := range existing {
+		if _, ok := files[filepath.Base(f)]; !ok && !strings.HasSuffix(f, "_test.go") {
+			t.Errorf("%s has no corresponding service interface", f)
+		}
+	}
+}
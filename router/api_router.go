@@ -33,11 +33,14 @@ const (
 	UserSettingsUpdate    = "user.settings.update"
 	UserComputeStats      = "user.compute-stats"
 
-	Person = "person"
+	Person              = "person"
+	PersonStats         = "person.stats"
+	PersonCollaborators = "person.collaborators"
 
 	RepoPullRequests              = "repo.pull-requests"
 	RepoPullRequest               = "repo.pull-request"
 	RepoPullRequestMerge          = "repo.pull-request.merge"
+	RepoPullRequestAffectedDefs   = "repo.pull-request.affected-defs"
 	RepoPullRequestComments       = "repo.pull-request.comments"
 	RepoPullRequestCommentsCreate = "repo.pull-request.comments.create"
 	RepoPullRequestCommentsEdit   = "repo.pull-request.comments.edit"
@@ -67,10 +70,14 @@ const (
 	RepoBuildDataEntry = "repo.build-data.entry"
 	RepoTreeEntry      = "repo.tree.entry"
 	RepoTreeSearch     = "repo.tree.search"
+	RepoFileSearch     = "repo.file-search"
 	RepoRefreshProfile = "repo.refresh-profile"
 	RepoRefreshVCSData = "repo.refresh-vcs-data"
 	RepoComputeStats   = "repo.compute-stats"
 
+	ReposResolveImportPath = "repos.resolve-import-path"
+	ReposResolvePackage    = "repos.resolve-package"
+
 	RepoSettings       = "repo.settings"
 	RepoSettingsUpdate = "repo.settings.update"
 
@@ -80,6 +87,128 @@ const (
 
 	RepoBuild = "repo.build"
 
+	RepoNotificationDestinations       = "repo.notification-destinations"
+	RepoNotificationDestinationsCreate = "repo.notification-destinations.create"
+	RepoNotificationDestination        = "repo.notification-destination"
+	RepoNotificationDestinationUpdate  = "repo.notification-destination.update"
+	RepoNotificationDestinationDelete  = "repo.notification-destination.delete"
+	RepoNotificationDestinationTest    = "repo.notification-destination.test"
+
+	RepoPullRequestTrackerLinks = "repo.pull-request.tracker-links"
+	RepoCommitTrackerLinks      = "repo.commit.tracker-links"
+	TrackerIssueLinks           = "tracker-issue.links"
+	TrackerLinksCreate          = "tracker-links.create"
+	TrackerLinkDelete           = "tracker-link.delete"
+
+	AuthOIDCConfig = "auth.oidc-config"
+
+	AuditEvents       = "audit-events"
+	AuditEventsExport = "audit-events.export"
+
+	AdminTestEmail  = "admin.test-email"
+	AdminMigrations = "admin.migrations"
+
+	MonitoringAlerts         = "monitoring.alerts"
+	MonitoringAlert          = "monitoring.alert"
+	MonitoringAlertUpdate    = "monitoring.alert.update"
+	MonitoringSilences       = "monitoring.silences"
+	MonitoringSilencesCreate = "monitoring.silences.create"
+	MonitoringSilenceDelete  = "monitoring.silence.delete"
+
+	RepoPullRequestExport = "repo.pull-request.export"
+	RepoIssueExport       = "repo.issue.export"
+
+	RepoPullRequestReviews       = "repo.pull-request.reviews"
+	RepoPullRequestReviewsCreate = "repo.pull-request.reviews.create"
+	RepoPullRequestReview        = "repo.pull-request.review"
+	RepoPullRequestReviewSubmit  = "repo.pull-request.review.submit"
+	RepoPullRequestReviewDismiss = "repo.pull-request.review.dismiss"
+
+	RepoPullRequestFiles = "repo.pull-request.files"
+	RepoPullRequestDiff  = "repo.pull-request.diff"
+
+	BuildLogStream = "build.log.stream"
+
+	OrgTeams            = "org.teams"
+	OrgTeamsCreate      = "org.teams.create"
+	OrgTeam             = "org.team"
+	OrgTeamMemberAdd    = "org.team.member.add"
+	OrgTeamMemberRemove = "org.team.member.remove"
+
+	DefCallers = "def.callers"
+	DefCallees = "def.callees"
+
+	RepoIssuesCreate     = "repo.issues.create"
+	RepoIssueEdit        = "repo.issue.edit"
+	RepoIssueLabelsAdd   = "repo.issue.labels.add"
+	RepoIssueLabelRemove = "repo.issue.label.remove"
+	RepoIssueAssignees   = "repo.issue.assignees"
+	RepoIssueEvents      = "repo.issue.events"
+
+	UserTokens       = "user.tokens"
+	UserTokensCreate = "user.tokens.create"
+	UserTokenRevoke  = "user.token.revoke"
+
+	UserEmailsAdd       = "user.emails.add"
+	UserEmailRemove     = "user.email.remove"
+	UserEmailSetPrimary = "user.email.set-primary"
+
+	ReposBatch = "repos.batch"
+	DefsBatch  = "defs.batch"
+
+	RepoPullRequestReviewComments       = "repo.pull-request.review-comments"
+	RepoPullRequestReviewCommentsCreate = "repo.pull-request.review-comments.create"
+	RepoPullRequestReviewComment        = "repo.pull-request.review-comment"
+	RepoPullRequestReviewCommentEdit    = "repo.pull-request.review-comment.edit"
+	RepoPullRequestReviewCommentDelete  = "repo.pull-request.review-comment.delete"
+
+	Notifications            = "notifications"
+	NotificationsMarkAllRead = "notifications.mark-all-read"
+	NotificationMarkRead     = "notification.mark-read"
+
+	RepoIssueSubscribe         = "repo.issue.subscribe"
+	RepoIssueUnsubscribe       = "repo.issue.unsubscribe"
+	RepoPullRequestSubscribe   = "repo.pull-request.subscribe"
+	RepoPullRequestUnsubscribe = "repo.pull-request.unsubscribe"
+	BuildSubscribe             = "build.subscribe"
+	BuildUnsubscribe           = "build.unsubscribe"
+
+	RepoCounterRecordHit = "repo.counter.record-hit"
+
+	RepoTreeAnnotations = "repo.tree.annotations"
+
+	GraphQL = "graphql"
+
+	MetaStatus = "meta.status"
+	MetaConfig = "meta.config"
+
+	RepoCollaborators          = "repo.collaborators"
+	RepoCollaboratorAdd        = "repo.collaborator.add"
+	RepoCollaboratorRemove     = "repo.collaborator.remove"
+	RepoCollaboratorPermission = "repo.collaborator.permission"
+
+	RepoPullRequestEvents = "repo.pull-request.events"
+
+	RepoPullRequestsComments = "repo.pull-requests.comments"
+	RepoIssuesComments       = "repo.issues.comments"
+
+	RepoPullRequestsCreate = "repo.pull-requests.create"
+	RepoPullRequestEdit    = "repo.pull-request.edit"
+
+	RepoArchive = "repo.archive"
+
+	BuildsQueue      = "builds.queue"
+	BuildsQueueStats = "builds.queue.stats"
+
+	RepoStatsSummary = "repo.stats.summary"
+
+	PeopleInvite     = "people.invite"
+	PersonDeactivate = "person.deactivate"
+	PersonReactivate = "person.reactivate"
+
+	PersonRepos = "person.repos"
+	OrgRepos    = "org.repos"
+
 	RepoCommits        = "repo.commits"
 	RepoCommit         = "repo.commit"
 	RepoCompareCommits = "repo.compare-commits"
@@ -105,6 +234,7 @@ const (
 	Delta                   = "delta"
 	DeltaUnits              = "delta.units"
 	DeltaDefs               = "delta.defs"
+	DeltaAPIChanges         = "delta.api-changes"
 	DeltaDependencies       = "delta.dependencies"
 	DeltaFiles              = "delta.files"
 	DeltaAffectedAuthors    = "delta.affected-authors"
@@ -118,6 +248,8 @@ const (
 
 	Markdown = "markdown"
 
+	Operation = "operation"
+
 	ExtGitHubReceiveWebhook = "ext.github.receive-webhook"
 
 	// Redirects for old routes.
@@ -140,28 +272,38 @@ func NewAPIRouter(base *mux.Router) *mux.Router {
 	base.Path("/builds").Methods("GET").Name(Builds)
 	builds := base.PathPrefix("/builds").Subrouter()
 	builds.Path("/next").Methods("POST").Name(BuildDequeueNext)
+	builds.Path("/queue").Methods("GET").Name(BuildsQueue)
+	builds.Path("/queue/stats").Methods("GET").Name(BuildsQueueStats)
 	buildPath := "/{BID}"
 	builds.Path(buildPath).Methods("GET").Name(Build)
 	builds.Path(buildPath).Methods("PUT").Name(BuildUpdate)
 	build := builds.PathPrefix(buildPath).Subrouter()
 	build.Path("/log").Methods("GET").Name(BuildLog)
+	build.Path("/log/stream").Methods("GET").Name(BuildLogStream)
 	build.Path("/tasks").Methods("GET").Name(BuildTasks)
 	build.Path("/tasks").Methods("POST").Name(BuildTasksCreate)
 	build.Path("/tasks/{TaskID}").Methods("PUT").Name(BuildTaskUpdate)
 	build.Path("/tasks/{TaskID}/log").Methods("GET").Name(BuildTaskLog)
+	build.Path("/subscription").Methods("PUT").Name(BuildSubscribe)
+	build.Path("/subscription").Methods("DELETE").Name(BuildUnsubscribe)
 
 	base.Path("/repos").Methods("GET").Name(Repos)
 	base.Path("/repos").Methods("POST").Name(ReposCreate)
+	base.Path("/repos/.resolve-import-path").Methods("GET").Name(ReposResolveImportPath)
+	base.Path("/repos/.resolve-package").Methods("GET").Name(ReposResolvePackage)
+	base.Path("/repos/.batch").Methods("POST").Name(ReposBatch)
 
 	base.Path("/repos/github.com/{owner:[^/]+}/{repo:[^/]+}/{what:(?:badges|counters)}/{which}.{Format}").Methods("GET").Name(RedirectOldRepoBadgesAndCounters)
 
 	repoRev := base.PathPrefix(`/repos/` + RepoRevSpecPattern).PostMatchFunc(FixRepoRevSpecVars).BuildVarsFunc(PrepareRepoRevSpecRouteVars).Subrouter()
 	repoRev.Path("/.stats").Methods("PUT").Name(RepoComputeStats)
 	repoRev.Path("/.stats").Methods("GET").Name(RepoStats)
+	repoRev.Path("/.stats/summary").Methods("GET").Name(RepoStatsSummary)
 	repoRev.Path("/.status").Methods("GET").Name(RepoCombinedStatus)
 	repoRev.Path("/.status").Methods("POST").Name(RepoStatusCreate)
 	repoRev.Path("/.authors").Methods("GET").Name(RepoAuthors)
 	repoRev.Path("/.readme").Methods("GET").Name(RepoReadme)
+	repoRev.Path("/.archive.{Format}").Methods("GET").Name(RepoArchive)
 	repoRev.Path("/.build").Methods("GET").Name(RepoBuild)
 	repoRev.Path("/.builds").Methods("POST").Name(RepoBuildsCreate)
 	repoRev.Path("/.dependencies").Methods("GET").Name(RepoDependencies)
@@ -181,37 +323,75 @@ func NewAPIRouter(base *mux.Router) *mux.Router {
 	repo.Path("/.settings").Methods("PUT").Name(RepoSettingsUpdate)
 	repo.Path("/.commits").Methods("GET").Name(RepoCommits)
 	repo.Path("/.commits/{Rev:" + PathComponentNoLeadingDot + "}/.compare").Methods("GET").Name(RepoCompareCommits)
+	repo.Path("/.commits/{Rev:" + PathComponentNoLeadingDot + "}/.tracker-links").Methods("GET").Name(RepoCommitTrackerLinks)
 	repo.Path("/.commits/{Rev:" + PathComponentNoLeadingDot + "}").Methods("GET").Name(RepoCommit)
 	repo.Path("/.branches").Methods("GET").Name(RepoBranches)
 	repo.Path("/.tags").Methods("GET").Name(RepoTags)
 	repo.Path("/.badges").Methods("GET").Name(RepoBadges)
 	repo.Path("/.counters").Methods("GET").Name(RepoCounters)
+	repo.Path("/.collaborators").Methods("GET").Name(RepoCollaborators)
+	repo.Path("/.collaborators/" + PersonSpecPattern).Methods("PUT").Name(RepoCollaboratorAdd)
+	repo.Path("/.collaborators/" + PersonSpecPattern).Methods("DELETE").Name(RepoCollaboratorRemove)
+	repo.Path("/.collaborators/" + PersonSpecPattern + "/permission").Methods("GET").Name(RepoCollaboratorPermission)
 	repo.Path("/.counters/{Counter}.{Format}").Methods("GET").Name(RepoCounter)
+	repo.Path("/.counters/{Counter}/hits").Methods("POST").Name(RepoCounterRecordHit)
 
 	repo.Path("/.pulls").Methods("GET").Name(RepoPullRequests)
+	repo.Path("/.pulls").Methods("POST").Name(RepoPullRequestsCreate)
+	repo.Path("/.pulls/.comments").Methods("GET").Name(RepoPullRequestsComments)
 	pullPath := "/.pulls/{Pull}"
 	repo.Path(pullPath).Methods("GET").Name(RepoPullRequest)
+	repo.Path(pullPath).Methods("PATCH", "PUT").Name(RepoPullRequestEdit)
 	pull := repo.PathPrefix(pullPath).Subrouter()
 	pull.Path("/merge").Methods("PUT").Name(RepoPullRequestMerge)
+	pull.Path("/affected-defs").Methods("GET").Name(RepoPullRequestAffectedDefs)
+	pull.Path("/export").Methods("GET").Name(RepoPullRequestExport)
+	pull.Path("/files").Methods("GET").Name(RepoPullRequestFiles)
+	pull.Path("/diff").Methods("GET").Name(RepoPullRequestDiff)
+	pull.Path("/events").Methods("GET").Name(RepoPullRequestEvents)
+	pull.Path("/tracker-links").Methods("GET").Name(RepoPullRequestTrackerLinks)
 	pull.Path("/comments").Methods("GET").Name(RepoPullRequestComments)
 	pull.Path("/comments").Methods("POST").Name(RepoPullRequestCommentsCreate)
 	pull.Path("/comments/{CommentID}").Methods("PATCH", "PUT").Name(RepoPullRequestCommentsEdit)
 	pull.Path("/comments/{CommentID}").Methods("DELETE").Name(RepoPullRequestCommentsDelete)
+	pull.Path("/reviews").Methods("GET").Name(RepoPullRequestReviews)
+	pull.Path("/reviews").Methods("POST").Name(RepoPullRequestReviewsCreate)
+	pull.Path("/reviews/{ReviewID}").Methods("GET").Name(RepoPullRequestReview)
+	pull.Path("/reviews/{ReviewID}/events").Methods("POST").Name(RepoPullRequestReviewSubmit)
+	pull.Path("/reviews/{ReviewID}/dismissal").Methods("PUT").Name(RepoPullRequestReviewDismiss)
+	pull.Path("/review-comments").Methods("GET").Name(RepoPullRequestReviewComments)
+	pull.Path("/review-comments").Methods("POST").Name(RepoPullRequestReviewCommentsCreate)
+	pull.Path("/review-comments/{CommentID}").Methods("GET").Name(RepoPullRequestReviewComment)
+	pull.Path("/review-comments/{CommentID}").Methods("PATCH", "PUT").Name(RepoPullRequestReviewCommentEdit)
+	pull.Path("/review-comments/{CommentID}").Methods("DELETE").Name(RepoPullRequestReviewCommentDelete)
+	pull.Path("/subscription").Methods("PUT").Name(RepoPullRequestSubscribe)
+	pull.Path("/subscription").Methods("DELETE").Name(RepoPullRequestUnsubscribe)
 
 	repo.Path("/.issues").Methods("GET").Name(RepoIssues)
+	repo.Path("/.issues").Methods("POST").Name(RepoIssuesCreate)
+	repo.Path("/.issues/.comments").Methods("GET").Name(RepoIssuesComments)
 	issuePath := "/.issues/{Issue}"
 	repo.Path(issuePath).Methods("GET").Name(RepoIssue)
+	repo.Path(issuePath).Methods("PATCH", "PUT").Name(RepoIssueEdit)
 	issue := repo.PathPrefix(issuePath).Subrouter()
+	issue.Path("/export").Methods("GET").Name(RepoIssueExport)
 	issue.Path("/comments").Methods("GET").Name(RepoIssueComments)
 	issue.Path("/comments").Methods("POST").Name(RepoIssueCommentsCreate)
 	issue.Path("/comments/{CommentID}").Methods("PATCH", "PUT").Name(RepoIssueCommentsEdit)
 	issue.Path("/comments/{CommentID}").Methods("DELETE").Name(RepoIssueCommentsDelete)
+	issue.Path("/labels").Methods("POST").Name(RepoIssueLabelsAdd)
+	issue.Path("/labels/{Label}").Methods("DELETE").Name(RepoIssueLabelRemove)
+	issue.Path("/assignees").Methods("PUT").Name(RepoIssueAssignees)
+	issue.Path("/events").Methods("GET").Name(RepoIssueEvents)
+	issue.Path("/subscription").Methods("PUT").Name(RepoIssueSubscribe)
+	issue.Path("/subscription").Methods("DELETE").Name(RepoIssueUnsubscribe)
 
 	deltaPath := "/.deltas/{Rev:.+}..{DeltaHeadRev:" + PathComponentNoLeadingDot + "}"
 	repo.Path(deltaPath).Methods("GET").Name(Delta)
 	deltas := repo.PathPrefix(deltaPath).Subrouter()
 	deltas.Path("/.units").Methods("GET").Name(DeltaUnits)
 	deltas.Path("/.defs").Methods("GET").Name(DeltaDefs)
+	deltas.Path("/.api-changes").Methods("GET").Name(DeltaAPIChanges)
 	deltas.Path("/.dependencies").Methods("GET").Name(DeltaDependencies)
 	deltas.Path("/.files").Methods("GET").Name(DeltaFiles)
 	deltas.Path("/.affected-authors").Methods("GET").Name(DeltaAffectedAuthors)
@@ -221,13 +401,31 @@ func NewAPIRouter(base *mux.Router) *mux.Router {
 
 	repo.Path("/.deltas-incoming").Methods("GET").Name(DeltasIncoming)
 
+	repo.Path("/.notification-destinations").Methods("GET").Name(RepoNotificationDestinations)
+	repo.Path("/.notification-destinations").Methods("POST").Name(RepoNotificationDestinationsCreate)
+	notificationDestPath := "/.notification-destinations/{DestinationID}"
+	repo.Path(notificationDestPath).Methods("GET").Name(RepoNotificationDestination)
+	repo.Path(notificationDestPath).Methods("PUT").Name(RepoNotificationDestinationUpdate)
+	repo.Path(notificationDestPath).Methods("DELETE").Name(RepoNotificationDestinationDelete)
+	repo.Path(notificationDestPath + "/test").Methods("POST").Name(RepoNotificationDestinationTest)
+
 	// See router_util/tree_route.go for an explanation of how we match tree
 	// entry routes.
 	repoRev.Path("/.tree" + TreeEntryPathPattern).PostMatchFunc(FixTreeEntryVars).BuildVarsFunc(PrepareTreeEntryRouteVars).Methods("GET").Name(RepoTreeEntry)
 
 	repoRev.Path("/.tree-search").Methods("GET").Name(RepoTreeSearch)
-
-	base.Path(`/people/` + PersonSpecPattern).Methods("GET").Name(Person)
+	repoRev.Path("/.file-search" + TreeEntryPathPattern).PostMatchFunc(FixTreeEntryVars).BuildVarsFunc(PrepareTreeEntryRouteVars).Methods("GET").Name(RepoFileSearch)
+	repoRev.Path("/.annotations" + TreeEntryPathPattern).PostMatchFunc(FixTreeEntryVars).BuildVarsFunc(PrepareTreeEntryRouteVars).Methods("GET").Name(RepoTreeAnnotations)
+
+	base.Path("/people/.invites").Methods("POST").Name(PeopleInvite)
+	personPath := `/people/` + PersonSpecPattern
+	base.Path(personPath).Methods("GET").Name(Person)
+	person := base.PathPrefix(personPath).Subrouter()
+	person.Path("/stats").Methods("GET").Name(PersonStats)
+	person.Path("/collaborators").Methods("GET").Name(PersonCollaborators)
+	person.Path("/repos").Methods("GET").Name(PersonRepos)
+	person.Path("/deactivation").Methods("PUT").Name(PersonDeactivate)
+	person.Path("/deactivation").Methods("DELETE").Name(PersonReactivate)
 
 	base.Path("/users").Methods("GET").Name(Users)
 	userPath := `/users/` + UserSpecPattern
@@ -237,6 +435,9 @@ func NewAPIRouter(base *mux.Router) *mux.Router {
 	user.Path("/clients").Methods("GET").Name(UserClients)
 	user.Path("/authors").Methods("GET").Name(UserAuthors)
 	user.Path("/emails").Methods("GET").Name(UserEmails)
+	user.Path("/emails").Methods("POST").Name(UserEmailsAdd)
+	user.Path("/emails/{Email}").Methods("DELETE").Name(UserEmailRemove)
+	user.Path("/emails/{Email}/primary").Methods("PUT").Name(UserEmailSetPrimary)
 	user.Path("/repo-contributions").Methods("GET").Name(UserRepoContributions)
 	user.Path("/repo-dependencies").Methods("GET").Name(UserRepoDependencies)
 	user.Path("/repo-dependents").Methods("GET").Name(UserRepoDependents)
@@ -244,6 +445,9 @@ func NewAPIRouter(base *mux.Router) *mux.Router {
 	user.Path("/stats").Methods("PUT").Name(UserComputeStats)
 	user.Path("/settings").Methods("GET").Name(UserSettings)
 	user.Path("/settings").Methods("PUT").Name(UserSettingsUpdate)
+	user.Path("/tokens").Methods("GET").Name(UserTokens)
+	user.Path("/tokens").Methods("POST").Name(UserTokensCreate)
+	user.Path("/tokens/{TokenID}").Methods("DELETE").Name(UserTokenRevoke)
 	base.Path("/external-users/github/{GitHubUserSpec}").Methods("GET").Name(UserFromGitHub)
 
 	orgPath := "/orgs/{OrgSpec}"
@@ -252,14 +456,49 @@ func NewAPIRouter(base *mux.Router) *mux.Router {
 	org.Path("/settings").Methods("GET").Name(OrgSettings)
 	org.Path("/settings").Methods("PUT").Name(OrgSettingsUpdate)
 	org.Path("/members").Methods("GET").Name(OrgMembers)
+	org.Path("/repos").Methods("GET").Name(OrgRepos)
+	org.Path("/teams").Methods("GET").Name(OrgTeams)
+	org.Path("/teams").Methods("POST").Name(OrgTeamsCreate)
+	org.Path("/teams/{Team}").Methods("GET").Name(OrgTeam)
+	org.Path("/teams/{Team}/members/{UserSpec}").Methods("PUT").Name(OrgTeamMemberAdd)
+	org.Path("/teams/{Team}/members/{UserSpec}").Methods("DELETE").Name(OrgTeamMemberRemove)
+
+	// The GraphQL API is served outside of the API root (see
+	// IsRootRoute).
+	base.Path("/.api/graphql").Methods("POST").Name(GraphQL)
+
+	base.Path("/").Methods("GET").Name(MetaStatus)
+	base.Path("/meta/config").Methods("GET").Name(MetaConfig)
 
 	base.Path("/search").Methods("GET").Name(Search)
 	base.Path("/search/complete").Methods("GET").Name(SearchComplete)
 	base.Path("/search/suggestions").Methods("GET").Name(SearchSuggestions)
 
+	base.Path("/operations/{OperationID}").Methods("GET").Name(Operation)
+
+	base.Path("/auth/oidc-config").Methods("GET").Name(AuthOIDCConfig)
+
+	base.Path("/tracker-links").Methods("POST").Name(TrackerLinksCreate)
+	base.Path("/tracker-links/{TrackerLinkID}").Methods("DELETE").Name(TrackerLinkDelete)
+	base.Path("/tracker-issues/{Tracker}/{TrackerIssueKey}/links").Methods("GET").Name(TrackerIssueLinks)
+
+	base.Path("/audit-events").Methods("GET").Name(AuditEvents)
+	base.Path("/audit-events/export").Methods("GET").Name(AuditEventsExport)
+
+	base.Path("/admin/test-email").Methods("POST").Name(AdminTestEmail)
+	base.Path("/admin/migrations").Methods("GET").Name(AdminMigrations)
+
+	base.Path("/monitoring/alerts").Methods("GET").Name(MonitoringAlerts)
+	base.Path("/monitoring/alerts/{AlertName}").Methods("GET").Name(MonitoringAlert)
+	base.Path("/monitoring/alerts/{AlertName}").Methods("PUT").Name(MonitoringAlertUpdate)
+	base.Path("/monitoring/silences").Methods("GET").Name(MonitoringSilences)
+	base.Path("/monitoring/silences").Methods("POST").Name(MonitoringSilencesCreate)
+	base.Path("/monitoring/silences/{SilenceID}").Methods("DELETE").Name(MonitoringSilenceDelete)
+
 	base.Path("/snippet").Methods("GET", "POST", "ORIGIN").Name(Snippet)
 
 	base.Path("/.defs").Methods("GET").Name(Defs)
+	base.Path("/.defs/.batch").Methods("POST").Name(DefsBatch)
 
 	// See router_util/def_route.go for an explanation of how we match def
 	// routes.
@@ -272,11 +511,17 @@ func NewAPIRouter(base *mux.Router) *mux.Router {
 	def.Path("/.clients").Methods("GET").Name(DefClients)
 	def.Path("/.dependents").Methods("GET").Name(DefDependents)
 	def.Path("/.versions").Methods("GET").Name(DefVersions)
+	def.Path("/.callers").Methods("GET").Name(DefCallers)
+	def.Path("/.callees").Methods("GET").Name(DefCallees)
 
 	base.Path("/.units").Methods("GET").Name(Units)
 	unitPath := `/.units/{UnitType}/{Unit:.*}`
 	repoRev.Path(unitPath).Methods("GET").Name(Unit)
 
+	base.Path("/notifications").Methods("GET").Name(Notifications)
+	base.Path("/notifications").Methods("PUT").Name(NotificationsMarkAllRead)
+	base.Path("/notifications/{NotificationID}/read").Methods("PUT").Name(NotificationMarkRead)
+
 	base.Path("/markdown").Methods("POST").Name(Markdown)
 
 	base.Path("/ext/github/webhook").Methods("POST").Name(ExtGitHubReceiveWebhook)
@@ -293,3 +538,17 @@ func NewAPIRouter(base *mux.Router) *mux.Router {
 // returning it. It can be used by external packages that use this API
 // router and want to add additional routes to it.
 var ExtraConfig func(base, user *mux.Router)
+
+// rootRoutes are the routes whose paths are relative to the server's
+// root instead of the API root.
+var rootRoutes = map[string]bool{
+	GraphQL: true,
+}
+
+// IsRootRoute reports whether the named route's path (such as
+// "/.api/graphql") is relative to the server's root URL (such as
+// "https://sourcegraph.com/") instead of the API root URL (such as
+// "https://sourcegraph.com/api/").
+func IsRootRoute(routeName string) bool {
+	return rootRoutes[routeName]
+}
@@ -48,6 +48,11 @@ func TestMatch(t *testing.T) {
 			path:        "/repos/.invalidrepo",
 			wantNoMatch: true,
 		},
+		{
+			path:          "/repos/.resolve-import-path",
+			wantRouteName: ReposResolveImportPath,
+			wantVars:      map[string]string{},
+		},
 
 		// Repo sub-routes
 		{
@@ -144,6 +149,16 @@ func TestMatch(t *testing.T) {
 			wantRouteName: RepoTreeEntry,
 			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Rev": "myrev/subrev", "Path": "my/file"},
 		},
+		{
+			path:          "/repos/repohost.com/foo@mycommitid/.file-search/my/file",
+			wantRouteName: RepoFileSearch,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Rev": "mycommitid", "Path": "my/file"},
+		},
+		{
+			path:          "/repos/repohost.com/foo@mycommitid/.annotations/my/file",
+			wantRouteName: RepoTreeAnnotations,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Rev": "mycommitid", "Path": "my/file"},
+		},
 
 		// Units
 		{
@@ -287,6 +302,255 @@ func TestMatch(t *testing.T) {
 			wantRouteName: Person,
 			wantVars:      map[string]string{"PersonSpec": "alice@-x-yJAANTud-iAVVw=="},
 		},
+		{
+			path:          "/people/alice/stats",
+			wantRouteName: PersonStats,
+			wantVars:      map[string]string{"PersonSpec": "alice"},
+		},
+		{
+			path:          "/people/alice@example.com/stats",
+			wantRouteName: PersonStats,
+			wantVars:      map[string]string{"PersonSpec": "alice@example.com"},
+		},
+		{
+			path:          "/people/alice/collaborators",
+			wantRouteName: PersonCollaborators,
+			wantVars:      map[string]string{"PersonSpec": "alice"},
+		},
+
+		// Tracker links
+		{
+			path:          "/repos/repohost.com/foo/.pulls/1/tracker-links",
+			wantRouteName: RepoPullRequestTrackerLinks,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Pull": "1"},
+		},
+		{
+			path:          "/repos/repohost.com/foo/.commits/abcd/.tracker-links",
+			wantRouteName: RepoCommitTrackerLinks,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Rev": "abcd"},
+		},
+		{
+			path:          "/tracker-issues/jira/PROJ-1/links",
+			wantRouteName: TrackerIssueLinks,
+			wantVars:      map[string]string{"Tracker": "jira", "TrackerIssueKey": "PROJ-1"},
+		},
+
+		// Audit log
+		{
+			path:          "/audit-events",
+			wantRouteName: AuditEvents,
+			wantVars:      map[string]string{},
+		},
+		{
+			path:          "/audit-events/export",
+			wantRouteName: AuditEventsExport,
+			wantVars:      map[string]string{},
+		},
+
+		// Admin
+		{
+			path:          "/admin/migrations",
+			wantRouteName: AdminMigrations,
+			wantVars:      map[string]string{},
+		},
+
+		// Repo collaborators
+		{
+			path:          "/repos/repohost.com/foo/.collaborators",
+			wantRouteName: RepoCollaborators,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo"},
+		},
+		{
+			path:          "/repos/repohost.com/foo/.collaborators/alice/permission",
+			wantRouteName: RepoCollaboratorPermission,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "PersonSpec": "alice"},
+		},
+		{
+			path:          "/repos/repohost.com/foo/.collaborators/a@b.com/permission",
+			wantRouteName: RepoCollaboratorPermission,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "PersonSpec": "a@b.com"},
+		},
+
+		// Meta
+		{
+			path:          "/",
+			wantRouteName: MetaStatus,
+			wantVars:      map[string]string{},
+		},
+		{
+			path:          "/meta/config",
+			wantRouteName: MetaConfig,
+			wantVars:      map[string]string{},
+		},
+
+		// Monitoring
+		{
+			path:          "/monitoring/alerts/frontend_5xx_responses",
+			wantRouteName: MonitoringAlert,
+			wantVars:      map[string]string{"AlertName": "frontend_5xx_responses"},
+		},
+		{
+			path:          "/monitoring/silences",
+			wantRouteName: MonitoringSilences,
+			wantVars:      map[string]string{},
+		},
+
+		// Exports
+		{
+			path:          "/repos/repohost.com/foo/.pulls/1/export",
+			wantRouteName: RepoPullRequestExport,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Pull": "1"},
+		},
+		{
+			path:          "/repos/repohost.com/foo/.issues/1/export",
+			wantRouteName: RepoIssueExport,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Issue": "1"},
+		},
+
+		// Repository comments
+		{
+			path:          "/repos/repohost.com/foo/.pulls/.comments",
+			wantRouteName: RepoPullRequestsComments,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo"},
+		},
+		{
+			path:          "/repos/repohost.com/foo/.issues/.comments",
+			wantRouteName: RepoIssuesComments,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo"},
+		},
+
+		// Pull request events
+		{
+			path:          "/repos/repohost.com/foo/.pulls/1/events",
+			wantRouteName: RepoPullRequestEvents,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Pull": "1"},
+		},
+
+		// Repository archives
+		{
+			path:          "/repos/repohost.com/foo@mybranch/.archive.tar.gz",
+			wantRouteName: RepoArchive,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Rev": "mybranch", "Format": "tar.gz"},
+		},
+
+		// Repository statistics
+		{
+			path:          "/repos/repohost.com/foo@mybranch/.stats/summary",
+			wantRouteName: RepoStatsSummary,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Rev": "mybranch"},
+		},
+
+		// Repositories by owner
+		{
+			path:          "/people/alice/repos",
+			wantRouteName: PersonRepos,
+			wantVars:      map[string]string{"PersonSpec": "alice"},
+		},
+		{
+			path:          "/orgs/o/repos",
+			wantRouteName: OrgRepos,
+			wantVars:      map[string]string{"OrgSpec": "o"},
+		},
+
+		// Build queue
+		{
+			path:          "/builds/queue",
+			wantRouteName: BuildsQueue,
+			wantVars:      map[string]string{},
+		},
+		{
+			path:          "/builds/queue/stats",
+			wantRouteName: BuildsQueueStats,
+			wantVars:      map[string]string{},
+		},
+
+		// Pull request reviews
+		{
+			path:          "/repos/repohost.com/foo/.pulls/1/reviews",
+			wantRouteName: RepoPullRequestReviews,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Pull": "1"},
+		},
+		{
+			path:          "/repos/repohost.com/foo/.pulls/1/reviews/2",
+			wantRouteName: RepoPullRequestReview,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Pull": "1", "ReviewID": "2"},
+		},
+
+		// Pull request files
+		{
+			path:          "/repos/repohost.com/foo/.pulls/1/files",
+			wantRouteName: RepoPullRequestFiles,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Pull": "1"},
+		},
+		{
+			path:          "/repos/repohost.com/foo/.pulls/1/diff",
+			wantRouteName: RepoPullRequestDiff,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Pull": "1"},
+		},
+
+		// Builds
+		{
+			path:          "/builds/123/log/stream",
+			wantRouteName: BuildLogStream,
+			wantVars:      map[string]string{"BID": "123"},
+		},
+
+		// Org teams
+		{
+			path:          "/orgs/o/teams",
+			wantRouteName: OrgTeams,
+			wantVars:      map[string]string{"OrgSpec": "o"},
+		},
+		{
+			path:          "/orgs/o/teams/t",
+			wantRouteName: OrgTeam,
+			wantVars:      map[string]string{"OrgSpec": "o", "Team": "t"},
+		},
+
+		// Issues
+		{
+			path:          "/repos/repohost.com/foo/.issues/1/events",
+			wantRouteName: RepoIssueEvents,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Issue": "1"},
+		},
+
+		// User tokens
+		{
+			path:          "/users/u/tokens",
+			wantRouteName: UserTokens,
+			wantVars:      map[string]string{"UserSpec": "u"},
+		},
+
+		// Pull request review comments
+		{
+			path:          "/repos/repohost.com/foo/.pulls/1/review-comments",
+			wantRouteName: RepoPullRequestReviewComments,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Pull": "1"},
+		},
+		{
+			path:          "/repos/repohost.com/foo/.pulls/1/review-comments/2",
+			wantRouteName: RepoPullRequestReviewComment,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "Pull": "1", "CommentID": "2"},
+		},
+
+		// Notifications
+		{
+			path:          "/notifications",
+			wantRouteName: Notifications,
+			wantVars:      map[string]string{},
+		},
+
+		// Notification destinations
+		{
+			path:          "/repos/repohost.com/foo/.notification-destinations",
+			wantRouteName: RepoNotificationDestinations,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo"},
+		},
+		{
+			path:          "/repos/repohost.com/foo/.notification-destinations/3",
+			wantRouteName: RepoNotificationDestination,
+			wantVars:      map[string]string{"RepoSpec": "repohost.com/foo", "DestinationID": "3"},
+		},
 	}
 	for _, test := range tests {
 		var routeMatch mux.RouteMatch
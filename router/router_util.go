@@ -1,8 +1,24 @@
 package router
 
+import (
+	"net/http"
+
+	"github.com/fossas/mux"
+)
+
 func MapToArray(m map[string]string) (a []string) {
 	for k, v := range m {
 		a = append(a, k, v)
 	}
 	return
 }
+
+// MatchRoute returns the name and variables of the route in r that
+// matches req. If no route matches, name is "" and vars is nil.
+func MatchRoute(r *mux.Router, req *http.Request) (name string, vars map[string]string) {
+	var m mux.RouteMatch
+	if !r.Match(req, &m) || m.Route == nil {
+		return "", nil
+	}
+	return m.Route.GetName(), m.Vars
+}
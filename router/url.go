@@ -0,0 +1,62 @@
+package router
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/fossas/mux"
+)
+
+var (
+	apiRouterOnce sync.Once
+	apiRouter     *mux.Router
+)
+
+// URLTo generates the URL of the named API route (relative to the API
+// root; e.g., "/repos/github.com/foo/bar@mybranch/.builds") with the
+// given route variables. It is like BuildURL, using a router created
+// by NewAPIRouter(nil) on the first call to URLTo (so ExtraConfig, if
+// used, must be set before then).
+func URLTo(routeName string, routeVars map[string]string) (*url.URL, error) {
+	apiRouterOnce.Do(func() { apiRouter = NewAPIRouter(nil) })
+	return BuildURL(apiRouter, routeName, routeVars)
+}
+
+// BuildURL generates the URL of the route in r named routeName with
+// the given route variables. It returns an error if there is no such
+// route, if a route variable that the route requires is missing or
+// empty, or if a route variable's value can't be represented in the
+// route's path (such as a Rev containing a "/." path component).
+//
+// Route variable values are unescaped; the returned URL's Path holds
+// them as-is and its String and EscapedPath methods percent-encode
+// them. Slashes in values (such as a Rev of "feature/foo") are kept
+// as path separators, which the route patterns accept.
+func BuildURL(r *mux.Router, routeName string, routeVars map[string]string) (*url.URL, error) {
+	rt := r.Get(routeName)
+	if rt == nil {
+		return nil, fmt.Errorf("no Sourcegraph API route named %q", routeName)
+	}
+
+	for name, val := range routeVars {
+		if val == "" && !optionalRouteVars[name] {
+			return nil, fmt.Errorf("route %q: route variable %q is empty", routeName, name)
+		}
+	}
+
+	u, err := rt.URL(MapToArray(routeVars)...)
+	if err != nil {
+		return nil, fmt.Errorf("route %q: %s", routeName, err)
+	}
+	return u, nil
+}
+
+// optionalRouteVars are the route variables that may be empty. An
+// empty Rev refers to the repository's default branch, and an empty
+// tree entry Path refers to the repository's root directory (see
+// PrepareTreeEntryRouteVars).
+var optionalRouteVars = map[string]bool{
+	"Rev":  true,
+	"Path": true,
+}
@@ -0,0 +1,74 @@
+package router
+
+import "testing"
+
+func TestURLTo(t *testing.T) {
+	tests := []struct {
+		routeName string
+		routeVars map[string]string
+		want      string
+		wantErr   bool
+	}{
+		{
+			routeName: RepoBuildsCreate,
+			routeVars: map[string]string{"RepoSpec": "a.com/b"},
+			want:      "/repos/a.com/b/.builds",
+		},
+		{
+			routeName: RepoBuildsCreate,
+			routeVars: map[string]string{"RepoSpec": "a.com/b", "Rev": "feature/foo"},
+			want:      "/repos/a.com/b@feature/foo/.builds",
+		},
+		{
+			routeName: RepoCommit,
+			routeVars: map[string]string{"RepoSpec": "a.com/b", "Rev": "feature/foo"},
+			want:      "/repos/a.com/b/.commits/feature/foo",
+		},
+		{
+			routeName: RepoCommit,
+			routeVars: map[string]string{"RepoSpec": "a.com/b", "Rev": "a b#c%d"},
+			want:      "/repos/a.com/b/.commits/a%20b%23c%25d",
+		},
+		{
+			routeName: RepoTreeEntry,
+			routeVars: map[string]string{"RepoSpec": "a.com/b", "Rev": "v1", "Path": "dir/my file.go"},
+			want:      "/repos/a.com/b@v1/.tree/dir/my%20file.go",
+		},
+		{
+			routeName: RepoTreeEntry,
+			routeVars: map[string]string{"RepoSpec": "a.com/b", "Rev": "v1", "Path": ""},
+			want:      "/repos/a.com/b@v1/.tree/",
+		},
+		{
+			routeName: RepoTreeEntry,
+			routeVars: map[string]string{"RepoSpec": "a.com/b", "Rev": "v1", "Path": "."},
+			want:      "/repos/a.com/b@v1/.tree",
+		},
+		{
+			routeName: GraphQL,
+			want:      "/.api/graphql",
+		},
+
+		// Errors
+		{routeName: "no-such-route", wantErr: true},
+		{routeName: RepoCommit, routeVars: map[string]string{"RepoSpec": "a.com/b"}, wantErr: true},
+		{routeName: RepoCommit, routeVars: map[string]string{"RepoSpec": "", "Rev": "v1"}, wantErr: true},
+		{routeName: RepoCommit, routeVars: map[string]string{"RepoSpec": "a.com/b", "Rev": "feature/.foo"}, wantErr: true},
+	}
+	for _, test := range tests {
+		u, err := URLTo(test.routeName, test.routeVars)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s %v: got URL %q, want error", test.routeName, test.routeVars, u)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %v: %s", test.routeName, test.routeVars, err)
+			continue
+		}
+		if got := u.String(); got != test.want {
+			t.Errorf("%s %v: got URL %q, want %q", test.routeName, test.routeVars, got, test.want)
+		}
+	}
+}
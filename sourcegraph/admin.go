@@ -0,0 +1,174 @@
+package sourcegraph
+
+import (
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"golang.org/x/net/context"
+)
+
+// AdminService communicates with the endpoints in the Sourcegraph API
+// that site admins use to check the configuration and health of the
+// server. Only site admins may call its methods.
+type AdminService interface {
+	// SendTestEmail makes the server send a test email using its
+	// configured SMTP settings, and reports the outcome. A failed
+	// delivery is described by the returned report; the error is
+	// non-nil only if the test couldn't be run.
+	SendTestEmail(opt *TestEmailOptions) (*EmailDeliveryReport, Response, error)
+
+	// ListMigrations lists the server's background (out-of-band) data
+	// migrations and their progress. See WaitForMigrations.
+	ListMigrations() ([]*Migration, Response, error)
+}
+
+// adminService implements AdminService.
+type adminService struct {
+	client *Client
+}
+
+var _ AdminService = &adminService{}
+
+// TestEmailOptions specifies options for AdminService.SendTestEmail.
+type TestEmailOptions struct {
+	// To is the address to send the test email to. If empty, it is
+	// sent to the primary email address of the authenticated user.
+	To string `json:",omitempty"`
+}
+
+// An EmailDeliveryReport describes the outcome of an attempt to
+// deliver an email.
+type EmailDeliveryReport struct {
+	// Delivered is whether the SMTP server accepted the email.
+	Delivered bool
+
+	// To is the address that the email was sent to.
+	To string
+
+	// SMTPServer is the address (host:port) of the SMTP server that the
+	// server connected to (or tried to).
+	SMTPServer string `json:",omitempty"`
+
+	// Error describes why delivery failed (e.g., a connection, TLS, or
+	// authentication error). It is empty if Delivered is true.
+	Error string `json:",omitempty"`
+
+	// Transcript is the log of the SMTP conversation, with credentials
+	// redacted, for diagnosing failed deliveries.
+	Transcript []string `json:",omitempty"`
+}
+
+func (s *adminService) SendTestEmail(opt *TestEmailOptions) (*EmailDeliveryReport, Response, error) {
+	url, err := s.client.URL(router.AdminTestEmail, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opt == nil {
+		opt = &TestEmailOptions{}
+	}
+	req, err := s.client.NewRequest("POST", url.String(), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var report EmailDeliveryReport
+	resp, err := s.client.Do(req, &report)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &report, resp, nil
+}
+
+// A Migration is a background data migration that the server runs
+// after an upgrade, while it continues to serve requests.
+type Migration struct {
+	ID          string
+	Description string `json:",omitempty"`
+
+	// Percent is how much of the migration is done, from 0 to 100.
+	Percent float64
+
+	// Failed is whether the migration stopped because of an error (and
+	// will not complete without intervention). Migrations that are
+	// still running may have recent (retried) errors in Errors.
+	Failed bool `json:",omitempty"`
+
+	// Errors is a sample of the most recent errors that the migration
+	// encountered, oldest first.
+	Errors []*MigrationError `json:",omitempty"`
+
+	Started     *time.Time `json:",omitempty"`
+	LastUpdated *time.Time `json:",omitempty"`
+}
+
+// Complete returns true if the migration has finished.
+func (m *Migration) Complete() bool { return m.Percent >= 100 }
+
+// A MigrationError is an error that occurred while running a
+// migration.
+type MigrationError struct {
+	Message string
+	Time    time.Time
+}
+
+func (s *adminService) ListMigrations() ([]*Migration, Response, error) {
+	var migrations []*Migration
+	resp, err := s.client.DoList(router.AdminMigrations, nil, nil, &migrations)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return migrations, resp, nil
+}
+
+// A MigrationFailedError is returned by WaitForMigrations if a
+// migration failed.
+type MigrationFailedError struct {
+	Migration *Migration
+}
+
+func (e *MigrationFailedError) Error() string {
+	msg := "migration " + e.Migration.ID + " failed"
+	if n := len(e.Migration.Errors); n > 0 {
+		msg += ": " + e.Migration.Errors[n-1].Message
+	}
+	return msg
+}
+
+// WaitForMigrations polls s.ListMigrations every interval (or
+// DefaultPollInterval, if interval is zero) until all migrations are
+// complete or ctx is done, and returns the last list of migrations. If
+// a migration failed, a *MigrationFailedError is returned.
+func WaitForMigrations(ctx context.Context, s AdminService, interval time.Duration) ([]*Migration, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		migrations, _, err := s.ListMigrations()
+		if err != nil {
+			return nil, err
+		}
+		complete := true
+		for _, m := range migrations {
+			if m.Failed {
+				return migrations, &MigrationFailedError{Migration: m}
+			}
+			if !m.Complete() {
+				complete = false
+			}
+		}
+		if complete {
+			return migrations, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return migrations, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+var _ AdminService = &MockAdminService{}
@@ -0,0 +1,97 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"golang.org/x/net/context"
+)
+
+func TestAdminService_SendTestEmail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &EmailDeliveryReport{
+		To:         "a@example.com",
+		SMTPServer: "smtp.example.com:587",
+		Error:      "535 authentication failed",
+		Transcript: []string{"EHLO sourcegraph", "AUTH PLAIN [redacted]", "535 authentication failed"},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.AdminTestEmail, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"To":"a@example.com"}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	report, _, err := client.Admin.SendTestEmail(&TestEmailOptions{To: "a@example.com"})
+	if err != nil {
+		t.Errorf("Admin.SendTestEmail returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Admin.SendTestEmail returned %+v, want %+v", report, want)
+	}
+}
+
+func TestAdminService_ListMigrations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Migration{{ID: "m1", Percent: 42.5, Errors: []*MigrationError{{Message: "x", Time: time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC)}}}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.AdminMigrations, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	migrations, _, err := client.Admin.ListMigrations()
+	if err != nil {
+		t.Errorf("Admin.ListMigrations returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(migrations, want) {
+		t.Errorf("Admin.ListMigrations returned %+v, want %+v", migrations, want)
+	}
+}
+
+func TestWaitForMigrations(t *testing.T) {
+	polls := 0
+	s := MockAdminService{
+		ListMigrations_: func() ([]*Migration, Response, error) {
+			polls++
+			return []*Migration{{ID: "m1", Percent: float64(50 * polls)}, {ID: "m2", Percent: 100}}, nil, nil
+		},
+	}
+	if _, err := WaitForMigrations(context.Background(), s, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if polls != 2 {
+		t.Errorf("got %d polls, want 2", polls)
+	}
+
+	s.ListMigrations_ = func() ([]*Migration, Response, error) {
+		return []*Migration{{ID: "m1", Failed: true, Errors: []*MigrationError{{Message: "boom"}}}}, nil, nil
+	}
+	_, err := WaitForMigrations(context.Background(), s, time.Millisecond)
+	if err, ok := err.(*MigrationFailedError); !ok || err.Error() != "migration m1 failed: boom" {
+		t.Errorf("got error %v, want *MigrationFailedError", err)
+	}
+}
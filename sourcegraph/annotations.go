@@ -0,0 +1,81 @@
+package sourcegraph
+
+import (
+	"sourcegraph.com/sourcegraph/vcsstore/vcsclient"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// AnnotationsService communicates with the Sourcegraph API endpoints
+// that annotate the contents of files with syntax classes and links
+// to defs. It lets editor plugins highlight and hyperlink code without
+// analyzing it locally.
+type AnnotationsService interface {
+	// List lists the annotations for a file at a revision, sorted by
+	// StartByte (and, for annotations that start at the same byte, by
+	// decreasing length, so that enclosing annotations come first).
+	// An invalid byte range in opt is reported as a *ValidationError
+	// without sending a request.
+	List(entry TreeEntrySpec, opt *AnnotationsListOptions) ([]*Annotation, Response, error)
+}
+
+// annotationsService implements AnnotationsService.
+type annotationsService struct {
+	client *Client
+}
+
+var _ AnnotationsService = &annotationsService{}
+
+// An Annotation describes a byte range of a file. A syntax annotation
+// has a Class; a link annotation has a URL (and, if the range is a
+// def's name at its definition, Def is true). Annotations may overlap.
+type Annotation struct {
+	// StartByte and EndByte are the byte offsets of the annotated
+	// range (EndByte is exclusive).
+	StartByte int
+	EndByte   int
+
+	// Class is the syntax class of the range (such as "kwd" or
+	// "str"), for syntax annotations.
+	Class string `json:",omitempty"`
+
+	// URL is the URL of the def that the range refers to, for link
+	// annotations.
+	URL string `json:",omitempty"`
+
+	// Def is whether the range is the definition of the def at URL
+	// (as opposed to a reference to it).
+	Def bool `json:",omitempty"`
+}
+
+// AnnotationsListOptions specifies options for AnnotationsService.List.
+type AnnotationsListOptions struct {
+	// StartByte and EndByte, if set, restrict the list to annotations
+	// that overlap the byte range (EndByte is exclusive). A zero
+	// EndByte means the end of the file.
+	StartByte int `url:",omitempty" json:",omitempty"`
+	EndByte   int `url:",omitempty" json:",omitempty"`
+
+	// NoSyntax omits syntax annotations, and NoLinks omits link
+	// annotations.
+	NoSyntax bool `url:",omitempty" json:",omitempty"`
+	NoLinks  bool `url:",omitempty" json:",omitempty"`
+}
+
+func (s *annotationsService) List(entry TreeEntrySpec, opt *AnnotationsListOptions) ([]*Annotation, Response, error) {
+	if opt != nil {
+		if err := validateFileRange(vcsclient.FileRange{StartByte: int64(opt.StartByte), EndByte: int64(opt.EndByte)}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var anns []*Annotation
+	resp, err := s.client.DoList(router.RepoTreeAnnotations, entry.RouteVars(), opt, &anns)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return anns, resp, nil
+}
+
+var _ AnnotationsService = &MockAnnotationsService{}
@@ -0,0 +1,26 @@
+// generated by gen-mocks; DO NOT EDIT
+
+package sourcegraph
+
+type MockAnnotationsService struct {
+	List_ func(entry TreeEntrySpec, opt *AnnotationsListOptions) ([]*Annotation, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockAnnotationsService returns a new MockAnnotationsService
+// that records calls to its methods.
+func NewMockAnnotationsService() *MockAnnotationsService {
+	return &MockAnnotationsService{Calls: &MockCalls{}}
+}
+
+func (s MockAnnotationsService) List(entry TreeEntrySpec, opt *AnnotationsListOptions) ([]*Annotation, Response, error) {
+	s.Calls.record("List", entry, opt)
+	if s.List_ == nil {
+		var r0 []*Annotation
+		var r1 Response
+		return r0, r1, mockNotImplemented("AnnotationsService.List")
+	}
+	return s.List_(entry, opt)
+}
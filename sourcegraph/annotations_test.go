@@ -0,0 +1,52 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestAnnotationsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Annotation{
+		{StartByte: 0, EndByte: 4, Class: "kwd"},
+		{StartByte: 5, EndByte: 8, URL: "/r.com/x/.GoPackage/r.com/x/.def/F", Def: true},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoTreeAnnotations, map[string]string{"RepoSpec": "r.com/x", "Rev": "v", "Path": "a/b.go"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"StartByte": "1", "EndByte": "10", "NoSyntax": "true"})
+
+		writeJSON(w, want)
+	})
+
+	entry := TreeEntrySpec{RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "v"}, Path: "a/b.go"}
+	anns, _, err := client.Annotations.List(entry, &AnnotationsListOptions{StartByte: 1, EndByte: 10, NoSyntax: true})
+	if err != nil {
+		t.Errorf("Annotations.List returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(anns, want) {
+		t.Errorf("Annotations.List returned %+v, want %+v", anns, want)
+	}
+}
+
+func TestAnnotationsService_List_invalidRange(t *testing.T) {
+	entry := TreeEntrySpec{RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}}, Path: "f"}
+	for _, opt := range []*AnnotationsListOptions{{StartByte: -1}, {StartByte: 5, EndByte: 3}} {
+		_, _, err := NewClient(nil).Annotations.List(entry, opt)
+		if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("%+v: got error %v (%T), want *ValidationError", opt, err, err)
+		}
+	}
+}
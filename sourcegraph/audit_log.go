@@ -0,0 +1,179 @@
+package sourcegraph
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// AuditLogService communicates with the endpoints in the Sourcegraph
+// API that query the instance's audit log, which records the actions
+// that users (and the system) perform. Only site admins may access the
+// audit log.
+type AuditLogService interface {
+	// List lists audit events matching opt, oldest first. To fetch the
+	// next page, set opt.Cursor to the returned list's NextCursor.
+	List(opt *AuditEventListOptions) (*AuditEventList, Response, error)
+
+	// Export streams audit events matching opt, oldest first. If
+	// opt.Follow is set, the stream stays open and delivers new events
+	// as they are recorded. The caller must close the returned stream.
+	Export(opt *AuditEventExportOptions) (*AuditEventStream, Response, error)
+}
+
+// auditLogService implements AuditLogService.
+type auditLogService struct {
+	client *Client
+}
+
+var _ AuditLogService = &auditLogService{}
+
+// An AuditEvent records an action performed on the instance.
+type AuditEvent struct {
+	// ID uniquely identifies the event. It may be used as a cursor to
+	// list or export the events after it.
+	ID string
+
+	// Actor is the login of the user who performed the action. It is
+	// empty for actions performed by the system.
+	Actor string `json:",omitempty"`
+
+	// Action is the action that was performed (e.g.,
+	// "repo.settings.update").
+	Action string
+
+	// Target is the resource that the action was performed on.
+	Target AuditTarget
+
+	Timestamp time.Time
+
+	// Metadata holds action-specific details.
+	Metadata map[string]string `json:",omitempty"`
+}
+
+// AuditTarget identifies the resource that an audited action was
+// performed on.
+type AuditTarget struct {
+	// Type is the type of resource (e.g., "repo", "user", "org", or
+	// "build").
+	Type string
+
+	// Spec holds the route variables of the resource's spec (e.g., the
+	// result of (RepoSpec).RouteVars() for a repository), so it can be
+	// parsed with the corresponding Unmarshal function (e.g.,
+	// UnmarshalRepoSpec).
+	Spec map[string]string `json:",omitempty"`
+}
+
+// AuditEventFilter specifies which audit events to list or export. Its
+// fields are combined with AND.
+type AuditEventFilter struct {
+	Actor      string `url:",omitempty" json:",omitempty"` // only events performed by this user
+	Action     string `url:",omitempty" json:",omitempty"` // only events with this action (or, if it ends in ".", with actions with this prefix)
+	TargetType string `url:",omitempty" json:",omitempty"` // only events whose target has this type
+
+	TimeRangeOptions // only events within this time range
+}
+
+// AuditEventListOptions specifies options for AuditLogService.List.
+type AuditEventListOptions struct {
+	AuditEventFilter
+
+	// Cursor is the NextCursor of the previous page of results (or the
+	// ID of the event to list the events after). If empty, the first
+	// page is listed.
+	Cursor string `url:",omitempty" json:",omitempty"`
+
+	PerPage int `url:",omitempty" json:",omitempty"`
+}
+
+// An AuditEventList is a page of audit events.
+type AuditEventList struct {
+	Events []*AuditEvent
+
+	// NextCursor is the cursor of the next page of results. It is
+	// empty if this is the last page.
+	NextCursor string `json:",omitempty"`
+}
+
+func (s *auditLogService) List(opt *AuditEventListOptions) (*AuditEventList, Response, error) {
+	var list AuditEventList
+	resp, err := s.client.DoGet(router.AuditEvents, nil, opt, &list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &list, resp, nil
+}
+
+// AuditEventExportOptions specifies options for
+// AuditLogService.Export.
+type AuditEventExportOptions struct {
+	AuditEventFilter
+
+	// Cursor is the ID of the event to export the events after (e.g.,
+	// the Cursor of a previous stream that was interrupted). If empty,
+	// the export starts at the oldest matching event.
+	Cursor string `url:",omitempty" json:",omitempty"`
+
+	// Follow is whether to keep the stream open after the existing
+	// events have been sent, and send new events as they are recorded.
+	Follow bool `url:",omitempty" json:",omitempty"`
+}
+
+// auditEventStreamContentType is the content type of an audit event
+// export (newline-delimited JSON-encoded AuditEvents).
+const auditEventStreamContentType = "application/x-ndjson"
+
+func (s *auditLogService) Export(opt *AuditEventExportOptions) (*AuditEventStream, Response, error) {
+	url, err := s.client.URL(router.AuditEventsExport, nil, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", auditEventStreamContentType)
+
+	resp, err := s.client.Do(req, preserveBody)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	body := s.client.streamBody(resp.(*HTTPResponse).Body, -1)
+	return &AuditEventStream{body: body, dec: json.NewDecoder(body)}, resp, nil
+}
+
+// An AuditEventStream is a stream of audit events returned by
+// AuditLogService.Export.
+type AuditEventStream struct {
+	body   io.ReadCloser
+	dec    *json.Decoder
+	cursor string
+}
+
+// Next returns the next event in the stream. It returns io.EOF when
+// the stream ends. If the stream was opened with Follow, Next blocks
+// until a new event is recorded.
+func (s *AuditEventStream) Next() (*AuditEvent, error) {
+	var ev AuditEvent
+	if err := s.dec.Decode(&ev); err != nil {
+		return nil, err
+	}
+	s.cursor = ev.ID
+	return &ev, nil
+}
+
+// Cursor returns the ID of the last event returned by Next. If the
+// stream is interrupted, pass it as the Cursor option of a new Export
+// call to resume after that event.
+func (s *AuditEventStream) Cursor() string { return s.cursor }
+
+// Close closes the stream.
+func (s *AuditEventStream) Close() error { return s.body.Close() }
+
+var _ AuditLogService = &MockAuditLogService{}
@@ -0,0 +1,101 @@
+package sourcegraph
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestAuditLogService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &AuditEventList{
+		Events: []*AuditEvent{{
+			ID:        "e1",
+			Actor:     "alice",
+			Action:    "repo.settings.update",
+			Target:    AuditTarget{Type: "repo", Spec: RepoSpec{URI: "r.com/x"}.RouteVars()},
+			Timestamp: time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC),
+		}},
+		NextCursor: "e1",
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.AuditEvents, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Actor": "alice", "Until": "2015-01-01T00:00:00Z", "Cursor": "e0"})
+
+		writeJSON(w, want)
+	})
+
+	until := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	list, _, err := client.AuditLog.List(&AuditEventListOptions{AuditEventFilter: AuditEventFilter{Actor: "alice", TimeRangeOptions: TimeRangeOptions{Until: &until}}, Cursor: "e0"})
+	if err != nil {
+		t.Errorf("AuditLog.List returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("AuditLog.List returned %+v, want %+v", list, want)
+	}
+}
+
+func TestAuditLogService_Export(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*AuditEvent{
+		{ID: "e1", Action: "user.login", Target: AuditTarget{Type: "user"}, Timestamp: time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{ID: "e2", Action: "user.logout", Target: AuditTarget{Type: "user"}, Timestamp: time.Date(2015, 1, 2, 3, 4, 6, 0, time.UTC)},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.AuditEventsExport, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Follow": "true"})
+
+		w.Header().Set("Content-Type", auditEventStreamContentType)
+		enc := json.NewEncoder(w)
+		for _, ev := range want {
+			enc.Encode(ev)
+		}
+	})
+
+	stream, _, err := client.AuditLog.Export(&AuditEventExportOptions{Follow: true})
+	if err != nil {
+		t.Fatalf("AuditLog.Export returned error: %v", err)
+	}
+	defer stream.Close()
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	var events []*AuditEvent
+	for {
+		ev, err := stream.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, ev)
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("AuditLog.Export streamed %+v, want %+v", events, want)
+	}
+	if cursor := stream.Cursor(); cursor != "e2" {
+		t.Errorf("got Cursor %q, want %q", cursor, "e2")
+	}
+}
@@ -0,0 +1,55 @@
+package sourcegraph
+
+import "github.com/fossas/go-sourcegraph/router"
+
+// AuthService communicates with the authentication-related endpoints
+// in the Sourcegraph API.
+type AuthService interface {
+	// GetOIDCConfig fetches the configuration of the OpenID Connect
+	// provider that the server uses to authenticate users. Clients can
+	// use it (with the helpers in the auth package) to log in and
+	// obtain a token:
+	//
+	//	conf, _, err := client.Auth.GetOIDCConfig()
+	//	...
+	//	p, err := auth.DiscoverOIDC(ctx, nil, conf.Issuer)
+	//	...
+	//	ts, err := p.DeviceFlow(ctx, auth.OAuthClient{ID: conf.ClientID, Scopes: conf.Scopes}, prompt)
+	//	...
+	//	client.Credentials = sourcegraph.TokenSourceCredentials(ts)
+	GetOIDCConfig() (*OIDCConfig, Response, error)
+}
+
+// authService implements AuthService.
+type authService struct {
+	client *Client
+}
+
+var _ AuthService = &authService{}
+
+// OIDCConfig describes the OpenID Connect provider that a Sourcegraph
+// server uses to authenticate users.
+type OIDCConfig struct {
+	// Issuer is the provider's issuer URL, which is used to discover
+	// the provider's endpoints.
+	Issuer string
+
+	// ClientID is the OAuth2 client ID that public clients (such as
+	// CLIs) should use.
+	ClientID string
+
+	// Scopes are the scopes that clients should request.
+	Scopes []string `json:",omitempty"`
+}
+
+func (s *authService) GetOIDCConfig() (*OIDCConfig, Response, error) {
+	var conf OIDCConfig
+	resp, err := s.client.DoGet(router.AuthOIDCConfig, nil, nil, &conf)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &conf, resp, nil
+}
+
+var _ AuthService = &MockAuthService{}
@@ -0,0 +1,37 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestAuthService_GetOIDCConfig(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &OIDCConfig{Issuer: "https://accounts.example.com", ClientID: "cli", Scopes: []string{"openid", "email"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.AuthOIDCConfig, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	conf, _, err := client.Auth.GetOIDCConfig()
+	if err != nil {
+		t.Errorf("Auth.GetOIDCConfig returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(conf, want) {
+		t.Errorf("Auth.GetOIDCConfig returned %+v, want %+v", conf, want)
+	}
+}
@@ -0,0 +1,47 @@
+package sourcegraph
+
+import "sync"
+
+// fanOut calls fetch(i) for each i in [0, n), with at most
+// concurrency (or DefaultBatchConcurrency, if zero) calls in flight at
+// once. It is used by batch methods (such as DefsService.GetMulti)
+// when the server doesn't support a batch endpoint. If any call fails,
+// no further calls are made, and its response and error are returned.
+func fanOut(n, concurrency int, fetch func(i int) (Response, error)) (Response, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	var (
+		mu       sync.Mutex
+		failResp Response
+		failErr  error
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency)
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return failErr != nil
+	}
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		if failed() {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if resp, err := fetch(i); err != nil {
+				mu.Lock()
+				if failErr == nil {
+					failResp, failErr = resp, err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	return failResp, failErr
+}
@@ -14,6 +14,13 @@ type BuildDataService interface {
 	// FileSystem returns a virtual filesystem interface to the build
 	// data for a repo at a specific commit.
 	FileSystem(repo RepoRevSpec) (rwvfs.FileSystem, error)
+
+	// Download opens a build data file for reading. If the server
+	// provides a digest of the file's contents, the contents are
+	// verified as they are read (see BuildDataDownloadOptions). Large
+	// files may be downloaded directly from object storage (see
+	// SignedURL). Callers must close the returned reader.
+	Download(file BuildDataFileSpec, opt *BuildDataDownloadOptions) (io.ReadCloser, Response, error)
 }
 
 type buildDataService struct {
@@ -47,6 +54,35 @@ func (s *BuildDataFileSpec) RouteVars() map[string]string {
 	return m
 }
 
+// BuildDataDownloadOptions specifies options for
+// (BuildDataService).Download.
+type BuildDataDownloadOptions struct {
+	// ChecksumSidecar is whether to fetch the file's checksum sidecar
+	// file (at the file's path plus ".sha256") to verify the file's
+	// contents if the server doesn't provide a Digest header.
+	//
+	// Reading the last byte of a file whose contents don't match its
+	// digest fails with a *ChecksumMismatchError.
+	ChecksumSidecar bool
+}
+
+func (s *buildDataService) Download(file BuildDataFileSpec, opt *BuildDataDownloadOptions) (io.ReadCloser, Response, error) {
+	url, err := s.client.URL(router.RepoBuildDataEntry, file.RouteVars(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opt == nil {
+		opt = &BuildDataDownloadOptions{}
+	}
+	return s.client.download(req, opt.ChecksumSidecar)
+}
+
 // GetBuildDataFile is a helper function that calls Stat and Open on
 // the FileSystem returned for file's RepoRevSpec. Callers are
 // responsible for closing the file (unless an error is returned).
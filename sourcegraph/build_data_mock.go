@@ -1,11 +1,42 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
-import "sourcegraph.com/sourcegraph/rwvfs"
+import (
+	"io"
+
+	"sourcegraph.com/sourcegraph/rwvfs"
+)
 
 type MockBuildDataService struct {
 	FileSystem_ func(repo RepoRevSpec) (rwvfs.FileSystem, error)
+	Download_   func(file BuildDataFileSpec, opt *BuildDataDownloadOptions) (io.ReadCloser, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockBuildDataService returns a new MockBuildDataService
+// that records calls to its methods.
+func NewMockBuildDataService() *MockBuildDataService {
+	return &MockBuildDataService{Calls: &MockCalls{}}
 }
 
 func (s MockBuildDataService) FileSystem(repo RepoRevSpec) (rwvfs.FileSystem, error) {
+	s.Calls.record("FileSystem", repo)
+	if s.FileSystem_ == nil {
+		var r0 rwvfs.FileSystem
+		return r0, mockNotImplemented("BuildDataService.FileSystem")
+	}
 	return s.FileSystem_(repo)
 }
+
+func (s MockBuildDataService) Download(file BuildDataFileSpec, opt *BuildDataDownloadOptions) (io.ReadCloser, Response, error) {
+	s.Calls.record("Download", file, opt)
+	if s.Download_ == nil {
+		var r0 io.ReadCloser
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildDataService.Download")
+	}
+	return s.Download_(file, opt)
+}
@@ -1,10 +1,16 @@
 package sourcegraph
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"sort"
@@ -87,3 +93,113 @@ func fileInfoNames(fis []os.FileInfo) []string {
 	}
 	return names
 }
+
+func TestBuildDataService_Download(t *testing.T) {
+	want := []byte("hello")
+	sum := sha256.Sum256(want)
+
+	tests := map[string]struct {
+		digestHeader string
+		sidecar      string
+		wantErr      bool
+	}{
+		"no digest":       {},
+		"valid digest":    {digestHeader: "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])},
+		"invalid digest":  {digestHeader: "SHA-256=" + base64.StdEncoding.EncodeToString(make([]byte, 32)), wantErr: true},
+		"valid sidecar":   {sidecar: hex.EncodeToString(sum[:]) + "  b\n"},
+		"invalid sidecar": {sidecar: hex.EncodeToString(make([]byte, 32)), wantErr: true},
+	}
+	for label, test := range tests {
+		setup()
+
+		file := BuildDataFileSpec{RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "c"}, Path: "a/b"}
+		mux.HandleFunc(urlPath(t, router.RepoBuildDataEntry, file.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			if test.digestHeader != "" {
+				w.Header().Set(DigestHeader, test.digestHeader)
+			}
+			w.Write(want)
+		})
+		if test.sidecar != "" {
+			file.Path += checksumSidecarSuffix
+			mux.HandleFunc(urlPath(t, router.RepoBuildDataEntry, file.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(test.sidecar))
+			})
+			file.Path = "a/b"
+		}
+
+		f, _, err := client.BuildData.Download(file, &BuildDataDownloadOptions{ChecksumSidecar: test.sidecar != ""})
+		if err != nil {
+			t.Errorf("%s: BuildData.Download returned error: %v", label, err)
+			teardown()
+			continue
+		}
+		data, err := ioutil.ReadAll(f)
+		f.Close()
+		if _, mismatch := err.(*ChecksumMismatchError); mismatch != test.wantErr {
+			t.Errorf("%s: got error %v, want checksum mismatch: %v", label, err, test.wantErr)
+		}
+		if !test.wantErr && !reflect.DeepEqual(data, want) {
+			t.Errorf("%s: got data %q, want %q", label, data, want)
+		}
+		teardown()
+	}
+}
+
+func TestBuildDataService_Download_signedURL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []byte("hello, world")
+	sum := sha256.Sum256(want)
+
+	file := BuildDataFileSpec{RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "c"}, Path: "a/b"}
+	mux.HandleFunc(urlPath(t, router.RepoBuildDataEntry, file.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(AcceptSignedURLHeader) == "" {
+			t.Errorf("no %s header", AcceptSignedURLHeader)
+		}
+		w.Header().Set("Content-Type", SignedURLContentType)
+		json.NewEncoder(w).Encode(&SignedURL{
+			URL:    server.URL + "/object?sig=s",
+			Size:   int64(len(want)),
+			Digest: "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:]),
+		})
+	})
+	var requests int
+	mux.HandleFunc("/object", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "" {
+			t.Error("API credentials sent to signed URL")
+		}
+		w.Header().Set("ETag", `"e"`)
+		if rng := r.Header.Get("Range"); rng != "" {
+			// Serve the rest of the contents.
+			if rng != "bytes=5-" || r.Header.Get("If-Range") != `"e"` {
+				t.Errorf("got Range %q and If-Range %q", rng, r.Header.Get("If-Range"))
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 5-%d/%d", len(want)-1, len(want)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(want[5:])
+			return
+		}
+		// Simulate an interrupted download.
+		w.Header().Set("Content-Length", strconv.Itoa(len(want)))
+		w.Write(want[:5])
+	})
+
+	f, _, err := client.BuildData.Download(file, nil)
+	if err != nil {
+		t.Fatalf("BuildData.Download returned error: %v", err)
+	}
+	data, err := ioutil.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("got data %q, want %q", data, want)
+	}
+	if requests != 2 {
+		t.Errorf("got %d object requests, want 2 (initial and resumed)", requests)
+	}
+}
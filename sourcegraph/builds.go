@@ -3,7 +3,7 @@ package sourcegraph
 import (
 	"errors"
 	"fmt"
-	"net/http"
+	"io"
 	"time"
 
 	"strconv"
@@ -21,6 +21,18 @@ type BuildsService interface {
 	// List builds.
 	List(opt *BuildListOptions) ([]*Build, Response, error)
 
+	// ListByQueue lists the builds in the build queue (queued builds
+	// and, optionally, active builds). Unless opt specifies another
+	// sort, they are listed in the order in which they are dequeued:
+	// highest priority first, and then oldest first. To rebalance the
+	// queue, change builds' priorities with Update.
+	ListByQueue(opt *BuildListByQueueOptions) ([]*Build, Response, error)
+
+	// GetQueueStats returns the number of queued, active, and failed
+	// builds, in total and per repository and priority, for
+	// monitoring the build queue.
+	GetQueueStats(opt *BuildQueueStatsOptions) (*BuildQueueStats, Response, error)
+
 	// Create a new build. The build will run asynchronously (Create does not
 	// wait for it to return. To monitor the build's status, use Get.)
 	Create(repoRev RepoRevSpec, opt *BuildCreateOptions) (*Build, Response, error)
@@ -45,6 +57,15 @@ type BuildsService interface {
 	// GetTaskLog gets log entries associated with a task.
 	GetTaskLog(task TaskSpec, opt *BuildGetLogOptions) (*LogEntries, Response, error)
 
+	// StreamLog returns a stream of a build's log output. The server
+	// sends new output as it is written, and ends the stream when the
+	// build finishes, so the caller can tail the log by reading until
+	// io.EOF (instead of repeatedly calling GetLog). The caller must
+	// close the stream. To stop following the log early, close the
+	// stream or use a client whose context is canceled (see
+	// Client.WithContext).
+	StreamLog(build BuildSpec, opt *BuildLogOptions) (io.ReadCloser, Response, error)
+
 	// DequeueNext returns the next queued build and marks it as
 	// having started (atomically). It is not considered an error if
 	// there are no builds in the queue; in that case, a nil build and
@@ -311,18 +332,8 @@ var ErrBuildNotFound = errors.New("build not found")
 type BuildGetOptions struct{}
 
 func (s *buildsService) Get(build BuildSpec, opt *BuildGetOptions) (*Build, Response, error) {
-	url, err := s.client.URL(router.Build, build.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var build_ *Build
-	resp, err := s.client.Do(req, &build_)
+	resp, err := s.client.DoGet(router.Build, build.RouteVars(), opt, &build_)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -345,22 +356,40 @@ type BuildListOptions struct {
 	Sort      string `url:",omitempty"`
 	Direction string `url:",omitempty"`
 
+	TimeRangeOptions
 	ListOptions
 }
 
 func (s *buildsService) List(opt *BuildListOptions) ([]*Build, Response, error) {
-	url, err := s.client.URL(router.Builds, nil, opt)
+	var builds []*Build
+	resp, err := s.client.DoList(router.Builds, nil, opt, &builds)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	return builds, resp, nil
+}
+
+type BuildListByQueueOptions struct {
+	// Active is whether to also list builds that have been dequeued
+	// and are still running.
+	Active bool `url:",omitempty"`
+
+	// Repo, if set, restricts the list to builds of this repository
+	// (specified by its URI).
+	Repo string `url:",omitempty"`
+
+	// Priority, if set, restricts the list to builds with this
+	// priority.
+	Priority *int `url:",omitempty"`
+
+	SortOptions
+	ListOptions
+}
 
+func (s *buildsService) ListByQueue(opt *BuildListByQueueOptions) ([]*Build, Response, error) {
 	var builds []*Build
-	resp, err := s.client.Do(req, &builds)
+	resp, err := s.client.DoList(router.BuildsQueue, nil, opt, &builds)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -368,19 +397,60 @@ func (s *buildsService) List(opt *BuildListOptions) ([]*Build, Response, error)
 	return builds, resp, nil
 }
 
-func (s *buildsService) Create(repoRev RepoRevSpec, opt *BuildCreateOptions) (*Build, Response, error) {
-	url, err := s.client.URL(router.RepoBuildsCreate, repoRev.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
+// BuildQueueCounts are the numbers of builds in each state, as
+// reported in BuildQueueStats.
+type BuildQueueCounts struct {
+	Queued int // queued builds that haven't started
+	Active int // started builds that haven't ended
+	Failed int // failed builds (that ended in the time range, if any)
+}
+
+// RepoBuildQueueCounts are the BuildQueueCounts of a repository's
+// builds.
+type RepoBuildQueueCounts struct {
+	Repo string // the repository URI
+	BuildQueueCounts
+}
+
+// PriorityBuildQueueCounts are the BuildQueueCounts of the builds with
+// a priority.
+type PriorityBuildQueueCounts struct {
+	Priority int
+	BuildQueueCounts
+}
+
+// BuildQueueStats describes the state of the build queue.
+type BuildQueueStats struct {
+	// BuildQueueCounts are the total counts.
+	BuildQueueCounts
 
-	req, err := s.client.NewRequest("POST", url.String(), opt)
+	// ByRepo and ByPriority are the counts per repository and per
+	// priority. Repositories and priorities with no queued, active, or
+	// failed builds are omitted.
+	ByRepo     []*RepoBuildQueueCounts     `json:",omitempty"`
+	ByPriority []*PriorityBuildQueueCounts `json:",omitempty"`
+}
+
+type BuildQueueStatsOptions struct {
+	// TimeRangeOptions restricts the failed builds that are counted to
+	// those that ended in the time range. (Queued and active builds
+	// are always counted.)
+	TimeRangeOptions
+}
+
+func (s *buildsService) GetQueueStats(opt *BuildQueueStatsOptions) (*BuildQueueStats, Response, error) {
+	var stats *BuildQueueStats
+	resp, err := s.client.DoGet(router.BuildsQueueStats, nil, opt, &stats)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
+	return stats, resp, nil
+}
+
+func (s *buildsService) Create(repoRev RepoRevSpec, opt *BuildCreateOptions) (*Build, Response, error) {
 	var build *Build
-	resp, err := s.client.Do(req, &build)
+	resp, err := s.client.DoCreate(router.RepoBuildsCreate, repoRev.RouteVars(), opt, &build)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -388,21 +458,14 @@ func (s *buildsService) Create(repoRev RepoRevSpec, opt *BuildCreateOptions) (*B
 	return build, resp, nil
 }
 
-type BuildTaskListOptions struct{ ListOptions }
+type BuildTaskListOptions struct {
+	SortOptions
+	ListOptions
+}
 
 func (s *buildsService) ListBuildTasks(build BuildSpec, opt *BuildTaskListOptions) ([]*BuildTask, Response, error) {
-	url, err := s.client.URL(router.BuildTasks, build.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var tasks []*BuildTask
-	resp, err := s.client.Do(req, &tasks)
+	resp, err := s.client.DoList(router.BuildTasks, build.RouteVars(), opt, &tasks)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -425,18 +488,8 @@ type BuildUpdate struct {
 }
 
 func (s *buildsService) Update(build BuildSpec, info BuildUpdate) (*Build, Response, error) {
-	url, err := s.client.URL(router.BuildUpdate, build.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("PUT", url.String(), info)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var updated *Build
-	resp, err := s.client.Do(req, &updated)
+	resp, err := s.client.DoUpdate(router.BuildUpdate, build.RouteVars(), info, &updated)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -445,18 +498,8 @@ func (s *buildsService) Update(build BuildSpec, info BuildUpdate) (*Build, Respo
 }
 
 func (s *buildsService) CreateTasks(build BuildSpec, tasks []*BuildTask) ([]*BuildTask, Response, error) {
-	url, err := s.client.URL(router.BuildTasksCreate, build.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("POST", url.String(), tasks)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var created []*BuildTask
-	resp, err := s.client.Do(req, &created)
+	resp, err := s.client.DoCreate(router.BuildTasksCreate, build.RouteVars(), tasks, &created)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -474,18 +517,8 @@ type TaskUpdate struct {
 }
 
 func (s *buildsService) UpdateTask(task TaskSpec, info TaskUpdate) (*BuildTask, Response, error) {
-	url, err := s.client.URL(router.BuildTaskUpdate, task.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("PUT", url.String(), info)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var updated *BuildTask
-	resp, err := s.client.Do(req, &updated)
+	resp, err := s.client.DoUpdate(router.BuildTaskUpdate, task.RouteVars(), info, &updated)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -509,18 +542,8 @@ type LogEntries struct {
 }
 
 func (s *buildsService) GetLog(build BuildSpec, opt *BuildGetLogOptions) (*LogEntries, Response, error) {
-	url, err := s.client.URL(router.BuildLog, build.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var entries *LogEntries
-	resp, err := s.client.Do(req, &entries)
+	resp, err := s.client.DoGet(router.BuildLog, build.RouteVars(), opt, &entries)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -528,8 +551,20 @@ func (s *buildsService) GetLog(build BuildSpec, opt *BuildGetLogOptions) (*LogEn
 	return entries, resp, nil
 }
 
-func (s *buildsService) GetTaskLog(task TaskSpec, opt *BuildGetLogOptions) (*LogEntries, Response, error) {
-	url, err := s.client.URL(router.BuildTaskLog, task.RouteVars(), opt)
+// BuildLogOptions specifies options for BuildsService.StreamLog.
+type BuildLogOptions struct {
+	// MinID, if set, starts the stream after the log entry with the
+	// given ID (such as the MaxID of LogEntries returned by GetLog),
+	// so that output that was already read is not sent again.
+	MinID string `url:",omitempty" json:",omitempty"`
+}
+
+// buildLogStreamContentType is the content type of a build log stream
+// (plain text, sent with chunked transfer encoding as it is written).
+const buildLogStreamContentType = "text/plain"
+
+func (s *buildsService) StreamLog(build BuildSpec, opt *BuildLogOptions) (io.ReadCloser, Response, error) {
+	url, err := s.client.URL(router.BuildLogStream, build.RouteVars(), opt)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -538,9 +573,19 @@ func (s *buildsService) GetTaskLog(task TaskSpec, opt *BuildGetLogOptions) (*Log
 	if err != nil {
 		return nil, nil, err
 	}
+	req.Header.Set("Accept", buildLogStreamContentType)
+
+	resp, err := s.client.Do(req, preserveBody)
+	if err != nil {
+		return nil, resp, err
+	}
 
+	return s.client.streamBody(resp.(*HTTPResponse).Body, -1), resp, nil
+}
+
+func (s *buildsService) GetTaskLog(task TaskSpec, opt *BuildGetLogOptions) (*LogEntries, Response, error) {
 	var entries *LogEntries
-	resp, err := s.client.Do(req, &entries)
+	resp, err := s.client.DoGet(router.BuildTaskLog, task.RouteVars(), opt, &entries)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -562,7 +607,7 @@ func (s *buildsService) DequeueNext() (*Build, Response, error) {
 	var build_ *Build
 	resp, err := s.client.Do(req, &build_)
 	if err != nil {
-		if resp_, ok := resp.(*HTTPResponse); ok && resp_.StatusCode == http.StatusNotFound {
+		if IsNotFound(err) {
 			return nil, resp, nil
 		}
 		return nil, resp, err
@@ -1,8 +1,14 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
+import "io"
+
 type MockBuildsService struct {
 	Get_            func(build BuildSpec, opt *BuildGetOptions) (*Build, Response, error)
 	List_           func(opt *BuildListOptions) ([]*Build, Response, error)
+	ListByQueue_    func(opt *BuildListByQueueOptions) ([]*Build, Response, error)
+	GetQueueStats_  func(opt *BuildQueueStatsOptions) (*BuildQueueStats, Response, error)
 	Create_         func(repoRev RepoRevSpec, opt *BuildCreateOptions) (*Build, Response, error)
 	Update_         func(build BuildSpec, info BuildUpdate) (*Build, Response, error)
 	ListBuildTasks_ func(build BuildSpec, opt *BuildTaskListOptions) ([]*BuildTask, Response, error)
@@ -10,43 +16,145 @@ type MockBuildsService struct {
 	UpdateTask_     func(task TaskSpec, info TaskUpdate) (*BuildTask, Response, error)
 	GetLog_         func(build BuildSpec, opt *BuildGetLogOptions) (*LogEntries, Response, error)
 	GetTaskLog_     func(task TaskSpec, opt *BuildGetLogOptions) (*LogEntries, Response, error)
+	StreamLog_      func(build BuildSpec, opt *BuildLogOptions) (io.ReadCloser, Response, error)
 	DequeueNext_    func() (*Build, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockBuildsService returns a new MockBuildsService
+// that records calls to its methods.
+func NewMockBuildsService() *MockBuildsService {
+	return &MockBuildsService{Calls: &MockCalls{}}
 }
 
 func (s MockBuildsService) Get(build BuildSpec, opt *BuildGetOptions) (*Build, Response, error) {
+	s.Calls.record("Get", build, opt)
+	if s.Get_ == nil {
+		var r0 *Build
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.Get")
+	}
 	return s.Get_(build, opt)
 }
 
 func (s MockBuildsService) List(opt *BuildListOptions) ([]*Build, Response, error) {
+	s.Calls.record("List", opt)
+	if s.List_ == nil {
+		var r0 []*Build
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.List")
+	}
 	return s.List_(opt)
 }
 
+func (s MockBuildsService) ListByQueue(opt *BuildListByQueueOptions) ([]*Build, Response, error) {
+	s.Calls.record("ListByQueue", opt)
+	if s.ListByQueue_ == nil {
+		var r0 []*Build
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.ListByQueue")
+	}
+	return s.ListByQueue_(opt)
+}
+
+func (s MockBuildsService) GetQueueStats(opt *BuildQueueStatsOptions) (*BuildQueueStats, Response, error) {
+	s.Calls.record("GetQueueStats", opt)
+	if s.GetQueueStats_ == nil {
+		var r0 *BuildQueueStats
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.GetQueueStats")
+	}
+	return s.GetQueueStats_(opt)
+}
+
 func (s MockBuildsService) Create(repoRev RepoRevSpec, opt *BuildCreateOptions) (*Build, Response, error) {
+	s.Calls.record("Create", repoRev, opt)
+	if s.Create_ == nil {
+		var r0 *Build
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.Create")
+	}
 	return s.Create_(repoRev, opt)
 }
 
 func (s MockBuildsService) Update(build BuildSpec, info BuildUpdate) (*Build, Response, error) {
+	s.Calls.record("Update", build, info)
+	if s.Update_ == nil {
+		var r0 *Build
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.Update")
+	}
 	return s.Update_(build, info)
 }
 
 func (s MockBuildsService) ListBuildTasks(build BuildSpec, opt *BuildTaskListOptions) ([]*BuildTask, Response, error) {
+	s.Calls.record("ListBuildTasks", build, opt)
+	if s.ListBuildTasks_ == nil {
+		var r0 []*BuildTask
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.ListBuildTasks")
+	}
 	return s.ListBuildTasks_(build, opt)
 }
 
 func (s MockBuildsService) CreateTasks(build BuildSpec, tasks []*BuildTask) ([]*BuildTask, Response, error) {
+	s.Calls.record("CreateTasks", build, tasks)
+	if s.CreateTasks_ == nil {
+		var r0 []*BuildTask
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.CreateTasks")
+	}
 	return s.CreateTasks_(build, tasks)
 }
 
 func (s MockBuildsService) UpdateTask(task TaskSpec, info TaskUpdate) (*BuildTask, Response, error) {
+	s.Calls.record("UpdateTask", task, info)
+	if s.UpdateTask_ == nil {
+		var r0 *BuildTask
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.UpdateTask")
+	}
 	return s.UpdateTask_(task, info)
 }
 
 func (s MockBuildsService) GetLog(build BuildSpec, opt *BuildGetLogOptions) (*LogEntries, Response, error) {
+	s.Calls.record("GetLog", build, opt)
+	if s.GetLog_ == nil {
+		var r0 *LogEntries
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.GetLog")
+	}
 	return s.GetLog_(build, opt)
 }
 
 func (s MockBuildsService) GetTaskLog(task TaskSpec, opt *BuildGetLogOptions) (*LogEntries, Response, error) {
+	s.Calls.record("GetTaskLog", task, opt)
+	if s.GetTaskLog_ == nil {
+		var r0 *LogEntries
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.GetTaskLog")
+	}
 	return s.GetTaskLog_(task, opt)
 }
 
-func (s MockBuildsService) DequeueNext() (*Build, Response, error) { return s.DequeueNext_() }
+func (s MockBuildsService) StreamLog(build BuildSpec, opt *BuildLogOptions) (io.ReadCloser, Response, error) {
+	s.Calls.record("StreamLog", build, opt)
+	if s.StreamLog_ == nil {
+		var r0 io.ReadCloser
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.StreamLog")
+	}
+	return s.StreamLog_(build, opt)
+}
+
+func (s MockBuildsService) DequeueNext() (*Build, Response, error) {
+	s.Calls.record("DequeueNext")
+	if s.DequeueNext_ == nil {
+		var r0 *Build
+		var r1 Response
+		return r0, r1, mockNotImplemented("BuildsService.DequeueNext")
+	}
+	return s.DequeueNext_()
+}
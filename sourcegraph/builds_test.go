@@ -1,6 +1,7 @@
 package sourcegraph
 
 import (
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
@@ -68,6 +69,75 @@ func TestBuildsService_List(t *testing.T) {
 	}
 }
 
+func TestBuildsService_ListByQueue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Build{{BID: 2, BuildConfig: BuildConfig{Queue: true, Priority: 1}}, {BID: 1, BuildConfig: BuildConfig{Queue: true, Priority: 1}}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.BuildsQueue, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Active": "true", "Priority": "1"})
+
+		writeJSON(w, want)
+	})
+
+	priority := 1
+	builds, _, err := client.Builds.ListByQueue(&BuildListByQueueOptions{Active: true, Priority: &priority})
+	if err != nil {
+		t.Errorf("Builds.ListByQueue returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	normalizeBuildTime(builds...)
+	normalizeBuildTime(want...)
+	if !reflect.DeepEqual(builds, want) {
+		t.Errorf("Builds.ListByQueue returned %+v, want %+v", builds, want)
+	}
+}
+
+func TestBuildsService_GetQueueStats(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &BuildQueueStats{
+		BuildQueueCounts: BuildQueueCounts{Queued: 3, Active: 1, Failed: 2},
+		ByRepo: []*RepoBuildQueueCounts{
+			{Repo: "r.com/x", BuildQueueCounts: BuildQueueCounts{Queued: 2, Failed: 2}},
+			{Repo: "r.com/y", BuildQueueCounts: BuildQueueCounts{Queued: 1, Active: 1}},
+		},
+		ByPriority: []*PriorityBuildQueueCounts{
+			{Priority: 0, BuildQueueCounts: BuildQueueCounts{Queued: 3, Active: 1, Failed: 2}},
+		},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.BuildsQueueStats, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	stats, _, err := client.Builds.GetQueueStats(nil)
+	if err != nil {
+		t.Errorf("Builds.GetQueueStats returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("Builds.GetQueueStats returned %+v, want %+v", stats, want)
+	}
+}
+
 func TestBuildsService_Create(t *testing.T) {
 	setup()
 	defer teardown()
@@ -219,6 +289,41 @@ func TestBuildsService_GetLog(t *testing.T) {
 	}
 }
 
+func TestBuildsService_StreamLog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.BuildLogStream, map[string]string{"BID": "1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"MinID": "5"})
+
+		for _, line := range []string{"a\n", "b\n", "c\n"} {
+			w.Write([]byte(line))
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	log, _, err := client.Builds.StreamLog(BuildSpec{BID: 1}, &BuildLogOptions{MinID: "5"})
+	if err != nil {
+		t.Fatalf("Builds.StreamLog returned error: %v", err)
+	}
+	defer log.Close()
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	data, err := ioutil.ReadAll(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a\nb\nc\n"; string(data) != want {
+		t.Errorf("Builds.StreamLog returned %q, want %q", data, want)
+	}
+}
+
 func TestBuildsService_GetTaskLog(t *testing.T) {
 	setup()
 	defer teardown()
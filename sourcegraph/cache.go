@@ -0,0 +1,250 @@
+package sourcegraph
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Cache stores HTTP responses (serialized as bytes). Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, if any.
+	Get(key string) ([]byte, bool)
+
+	// Set stores value under key.
+	Set(key string, value []byte)
+
+	// Delete removes the value stored under key.
+	Delete(key string)
+}
+
+// MemoryCache is an in-memory Cache that holds a bounded number of
+// entries, evicting the least recently used entry when it is full.
+type MemoryCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	ll      *list.List // of *memoryCacheEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key   string
+	value []byte
+}
+
+// NewMemoryCache returns a MemoryCache that holds up to maxEntries
+// entries.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{maxEntries: maxEntries, ll: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(e)
+		return e.Value.(*memoryCacheEntry).value, true
+	}
+	return nil, false
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(e)
+		e.Value.(*memoryCacheEntry).value = value
+		return
+	}
+	c.entries[key] = c.ll.PushFront(&memoryCacheEntry{key: key, value: value})
+	for c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		e := c.ll.Back()
+		c.ll.Remove(e)
+		delete(c.entries, e.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Delete implements Cache.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.ll.Remove(e)
+		delete(c.entries, key)
+	}
+}
+
+// cacheKey returns the key under which the response to req is cached.
+// Responses are cached per credential, so clients with different
+// credentials never see each other's responses.
+func cacheKey(req *http.Request) string {
+	return req.Header.Get("Authorization") + " " + req.URL.String()
+}
+
+// isCacheable returns true if req is a request whose response may be
+// cached. Whether the response is stored also depends on the response
+// (see isStorable), so that downloads and streams, whose requests look
+// like any other GET, are never stored.
+func isCacheable(req *http.Request) bool {
+	return req.Method == "GET" && req.Header.Get("Range") == ""
+}
+
+// cacheMaxAge returns the lifetime of resp (from its Cache-Control
+// max-age directive), or 0 if resp may not be cached.
+func cacheMaxAge(resp *http.Response) time.Duration {
+	if resp.StatusCode != http.StatusOK {
+		return 0
+	}
+	var maxAge time.Duration
+	for _, d := range strings.Split(resp.Header.Get("Cache-Control"), ",") {
+		d = strings.ToLower(strings.TrimSpace(d))
+		switch {
+		case d == "no-store" || d == "no-cache":
+			return 0
+		case strings.HasPrefix(d, "max-age="):
+			secs, err := strconv.Atoi(d[len("max-age="):])
+			if err != nil {
+				return 0
+			}
+			maxAge = time.Duration(secs) * time.Second
+		}
+	}
+	return maxAge
+}
+
+// cachedResponse returns the cached response to req, if any, and
+// whether it is fresh (according to its max-age). A stale response
+// that has an ETag can be revalidated (see cacheRoundTrip).
+func cachedResponse(cache Cache, req *http.Request) (resp *http.Response, fresh bool) {
+	b, ok := cache.Get(cacheKey(req))
+	if !ok {
+		return nil, false
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), req)
+	if err != nil {
+		cache.Delete(cacheKey(req))
+		return nil, false
+	}
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	return resp, err == nil && time.Since(date) < cacheMaxAge(resp)
+}
+
+// maxCachedResponseBytes is the size of the largest response body
+// that is stored in a cache.
+const maxCachedResponseBytes = 1 << 20
+
+// isStorable returns true if resp may be stored in a cache: if it is a
+// JSON API response (not a download or stream) that is fresh for some
+// time (see cacheMaxAge) or can be revalidated (it has an ETag).
+func isStorable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK || strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store") {
+		return false
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") || resp.ContentLength > maxCachedResponseBytes {
+		return false
+	}
+	return resp.Header.Get("ETag") != "" || (cacheMaxAge(resp) > 0 && resp.Header.Get("Date") != "")
+}
+
+// storeResponse stores resp (the response to req) in cache if it is
+// storable and its body is no larger than maxCachedResponseBytes. It
+// returns a response equivalent to resp (whose body has been read, if
+// it was stored).
+func storeResponse(cache Cache, req *http.Request, resp *http.Response) (*http.Response, error) {
+	if !isStorable(resp) {
+		return resp, nil
+	}
+
+	// Read at most one byte more than the limit, so that a larger body
+	// (whose length wasn't known in advance) isn't read into memory.
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxCachedResponseBytes+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedResponseBytes {
+		resp.Body = &multiReadCloser{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	b, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return nil, err
+	}
+	cache.Set(cacheKey(req), b)
+	return resp, nil
+}
+
+// multiReadCloser reads from Reader and closes Closer.
+type multiReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// cacheRoundTrip sends the cacheable request req using send, unless
+// cache holds a fresh response to it. If cache holds a stale response
+// with an ETag, the request is sent with an If-None-Match header, and
+// if the server responds with 304 Not Modified, the cached response
+// (with its headers updated from the 304 response) is returned. hit is
+// whether the response was served from cache without sending req.
+func cacheRoundTrip(cache Cache, req *http.Request, send func(*http.Request) (*http.Response, error)) (resp *http.Response, hit bool, err error) {
+	cached, fresh := cachedResponse(cache, req)
+	if fresh {
+		return cached, true, nil
+	}
+
+	sendReq := req
+	if etag := cachedETag(cached); etag != "" {
+		sendReq = new(http.Request)
+		*sendReq = *req
+		sendReq.Header = make(http.Header, len(req.Header)+1)
+		for k, v := range req.Header {
+			sendReq.Header[k] = v
+		}
+		sendReq.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err = send(sendReq)
+	if err != nil || resp.StatusCode != http.StatusNotModified || sendReq == req {
+		if cached != nil {
+			cached.Body.Close()
+		}
+		if err != nil {
+			return resp, false, err
+		}
+		resp, err = storeResponse(cache, req, resp)
+		return resp, false, err
+	}
+
+	resp.Body.Close()
+	for k, v := range resp.Header {
+		if k != "Content-Length" && k != "Transfer-Encoding" {
+			cached.Header[k] = v
+		}
+	}
+	resp, err = storeResponse(cache, req, cached)
+	return resp, false, err
+}
+
+// cachedETag returns the ETag of the cached response resp (which may
+// be nil), or "" if it has none.
+func cachedETag(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	return resp.Header.Get("ETag")
+}
@@ -0,0 +1,113 @@
+package sourcegraph
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestClient_Cache_etag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+	etag := `"v1"`
+	var requests, notModified int
+	mux.HandleFunc(urlPath(t, router.Repo, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		writeJSON(w, &Repo{URI: "r.com/x", Description: etag})
+	})
+
+	client.Cache = NewMemoryCache(10)
+	get := func() *Repo {
+		r, _, err := client.Repos.Get(repo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	if r := get(); r.Description != `"v1"` {
+		t.Errorf("got description %q, want %q", r.Description, `"v1"`)
+	}
+	if r := get(); r.Description != `"v1"` {
+		t.Errorf("got cached description %q, want %q", r.Description, `"v1"`)
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("got %d requests (%d not modified), want 2 (1 not modified)", requests, notModified)
+	}
+
+	// A changed resource is fetched again.
+	etag = `"v2"`
+	if r := get(); r.Description != `"v2"` {
+		t.Errorf("got description %q, want %q", r.Description, `"v2"`)
+	}
+	if requests != 3 || notModified != 1 {
+		t.Errorf("got %d requests (%d not modified), want 3 (1 not modified)", requests, notModified)
+	}
+}
+
+func TestIsStorable(t *testing.T) {
+	const json = "application/json; charset=utf-8"
+	tests := []struct {
+		status        int
+		header        http.Header
+		contentLength int64
+		want          bool
+	}{
+		{status: http.StatusOK, header: http.Header{"Content-Type": {json}}},
+		{status: http.StatusOK, header: http.Header{"Content-Type": {json}, "Etag": {`"x"`}}, want: true},
+		{status: http.StatusOK, header: http.Header{"Content-Type": {json}, "Etag": {`"x"`}, "Cache-Control": {"no-cache"}}, want: true},
+		{status: http.StatusOK, header: http.Header{"Content-Type": {json}, "Etag": {`"x"`}, "Cache-Control": {"no-store"}}},
+		{status: http.StatusOK, header: http.Header{"Content-Type": {json}, "Cache-Control": {"max-age=60"}, "Date": {"Wed, 21 Oct 2015 07:28:00 GMT"}}, want: true},
+		{status: http.StatusNotFound, header: http.Header{"Content-Type": {json}, "Etag": {`"x"`}}},
+
+		// Downloads and streams.
+		{status: http.StatusOK, header: http.Header{"Content-Type": {"application/octet-stream"}, "Etag": {`"x"`}}},
+		{status: http.StatusOK, header: http.Header{"Content-Type": {"text/plain"}, "Etag": {`"x"`}}},
+
+		// Too large.
+		{status: http.StatusOK, header: http.Header{"Content-Type": {json}, "Etag": {`"x"`}}, contentLength: maxCachedResponseBytes + 1},
+	}
+	for i, test := range tests {
+		if got := isStorable(&http.Response{StatusCode: test.status, Header: test.header, ContentLength: test.contentLength}); got != test.want {
+			t.Errorf("#%d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestStoreResponse_tooLarge(t *testing.T) {
+	body := strings.Repeat("a", maxCachedResponseBytes+10)
+	resp := &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": {"application/json"}, "Etag": {`"x"`}},
+		ContentLength: -1, // unknown
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+	}
+	req, _ := http.NewRequest("GET", "http://example.com/r", nil)
+
+	cache := NewMemoryCache(10)
+	resp, err := storeResponse(cache, req, resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get(cacheKey(req)); ok {
+		t.Error("response larger than maxCachedResponseBytes was cached")
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != body {
+		t.Errorf("got body of length %d, want the whole body (length %d)", len(b), len(body))
+	}
+}
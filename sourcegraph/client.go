@@ -1,19 +1,24 @@
-//go:generate gen-mocks -p github.com/fossas/go-sourcegraph/sourcegraph -n sourcegraph -o . -w
+//go:generate go run ../cmd/gen-mocks/main.go -w
 package sourcegraph
 
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
+	"runtime/debug"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 	"github.com/fossas/go-sourcegraph/router"
+	"golang.org/x/net/context"
 )
 
 const (
@@ -21,23 +26,41 @@ const (
 	userAgent      = "sourcegraph-client/" + libraryVersion
 )
 
-// A Client communicates with the Sourcegraph API.
+// A Client communicates with the Sourcegraph API. NewClient returns a
+// Client whose services use the HTTP API. The services are interfaces,
+// so they may be replaced with implementations backed by another
+// transport (as NewMockClient does with mocks).
 type Client struct {
 	// Services used to communicate with different parts of the Sourcegraph API.
-	BuildData    BuildDataService
-	Builds       BuildsService
-	Deltas       DeltasService
-	Issues       IssuesService
-	Orgs         OrgsService
-	People       PeopleService
-	PullRequests PullRequestsService
-	Repos        ReposService
-	RepoTree     RepoTreeService
-	Search       SearchService
-	Units        UnitsService
-	Users        UsersService
-	Defs         DefsService
-	Markdown     MarkdownService
+	BuildData      BuildDataService
+	Builds         BuildsService
+	Deltas         DeltasService
+	Issues         IssuesService
+	Orgs           OrgsService
+	People         PeopleService
+	PullRequests   PullRequestsService
+	ReviewComments PullRequestReviewCommentsService
+	Repos          ReposService
+	RepoCommits    RepoCommitsService
+	RepoStatuses   RepoStatusesService
+	RepoBadges     RepoBadgesService
+	RepoHooks      RepoHooksService
+	RepoTree       RepoTreeService
+	Annotations    AnnotationsService
+	Search         SearchService
+	Units          UnitsService
+	Users          UsersService
+	Defs           DefsService
+	Markdown       MarkdownService
+
+	Auth          AuthService
+	Notifications NotificationsService
+	TrackerLinks  TrackerLinksService
+	Tokens        TokensService
+	AuditLog      AuditLogService
+	Admin         AdminService
+	Monitoring    MonitoringService
+	Meta          MetaService
 
 	// Base URL for API requests, which should have a trailing slash.
 	BaseURL *url.URL
@@ -45,8 +68,98 @@ type Client struct {
 	// User agent used for HTTP requests to the Sourcegraph API.
 	UserAgent string
 
+	// PerPage, if nonzero, is the number of results per page requested
+	// by list methods whose ListOptions.PerPage is zero. Values less
+	// than DefaultPerPage are treated as DefaultPerPage, because
+	// paging loops (such as Pager) stop at the first page with fewer
+	// than DefaultPerPage results when PerPage is unset.
+	PerPage int
+
+	// Timeout, if nonzero, is the time limit for each attempt of a
+	// request, including reading the response body. It does not apply
+	// to streaming downloads (use WithContext to limit those).
+	Timeout time.Duration
+
+	// Credentials, if set, authenticates API requests. NewRequest
+	// consults it for each request to the API server (see
+	// CredentialProvider). Credentials may also be added by the HTTP
+	// client's transport (such as auth.TokenTransport), but only
+	// credentials set here are part of the Cache key.
+	Credentials CredentialProvider
+
+	// StrictValidation is whether to check decoded responses against
+	// their types' invariants (see Validator). If true, responses that
+	// violate an invariant cause Do to return an
+	// *InvalidResponseError.
+	StrictValidation bool
+
+	// MaxResponseBytes is the maximum size (in bytes) of a response
+	// body that Do will read. If a response body is larger, Do (or,
+	// for streamed downloads, reading the body) fails with
+	// ErrResponseTooLarge. For compressed responses, the limit applies
+	// to the decompressed body. If zero, there is no limit.
+	MaxResponseBytes int64
+
+	// OnError, if set, is called with each error returned by Do, URL,
+	// and NewRequest (including *PanicError values for recovered
+	// panics), before it is returned. req is nil if the error occurred
+	// before the request was created. It may be used to log errors
+	// from long-running processes.
+	OnError func(req *http.Request, err error)
+
+	// Metrics, if set, receives a measurement of each request sent by
+	// Do (see MetricsCollector).
+	Metrics MetricsCollector
+
+	// Retry, if set, configures the automatic retrying of failed
+	// requests. If nil, requests are not retried.
+	Retry *RetryPolicy
+
+	// Cache, if set, caches the JSON responses to GET requests (other
+	// than streaming downloads and responses larger than 1 MB). A
+	// cached response is reused without contacting the server until
+	// its Cache-Control max-age elapses. After that (or immediately,
+	// if it has no max-age), it is revalidated with a conditional
+	// request if it has an ETag: the request is sent with an
+	// If-None-Match header, and if the server responds with 304 Not
+	// Modified, the cached response is used.
+	//
+	// Responses are keyed by URL and by the request's Authorization
+	// header (which includes Credentials). Credentials added by the
+	// HTTP client's transport (such as auth.TokenTransport) are not
+	// part of the key, so a Cache must not be shared by Clients whose
+	// transports add different credentials; use a ClientPool for that.
+	Cache Cache
+
+	// SpecCache, if set, memoizes the results of getting
+	// repositories, defs, and people by spec, and de-duplicates
+	// concurrent identical requests for them (see SpecCache).
+	SpecCache *SpecCache
+
+	// SignedURLClient is the HTTP client used to download contents
+	// from pre-signed object storage URLs that the server returns in
+	// response to download requests (see SignedURL). It must not add
+	// Sourcegraph API credentials to requests. If nil,
+	// http.DefaultClient is used.
+	SignedURLClient *http.Client
+
 	// HTTP client used to communicate with the Sourcegraph API.
 	httpClient *http.Client
+
+	// transport is the HTTP client's original transport, and
+	// middleware wraps it (see Use).
+	transport  http.RoundTripper
+	middleware []Middleware
+
+	// features records which API routes the server doesn't support.
+	features *featureCache
+
+	// ctx, if set, cancels requests (see WithContext).
+	ctx context.Context
+
+	// progress, if set, is called as streaming downloads are read (see
+	// WithProgress).
+	progress ProgressFunc
 }
 
 // NewClient returns a new Sourcegraph API client. If httpClient is nil,
@@ -59,6 +172,18 @@ func NewClient(httpClient *http.Client) *Client {
 
 	c := new(Client)
 	c.httpClient = httpClient
+	c.features = &featureCache{}
+	c.setServices()
+
+	c.BaseURL = &url.URL{Scheme: "https", Host: "sourcegraph.com", Path: "/api/"}
+
+	c.UserAgent = userAgent
+
+	return c
+}
+
+// setServices sets c's service fields to services that use c.
+func (c *Client) setServices() {
 	c.BuildData = &buildDataService{c}
 	c.Builds = &buildsService{c}
 	c.Deltas = &deltasService{c}
@@ -66,19 +191,54 @@ func NewClient(httpClient *http.Client) *Client {
 	c.Orgs = &orgsService{c}
 	c.People = &peopleService{c}
 	c.PullRequests = &pullRequestsService{c}
+	c.ReviewComments = &pullRequestReviewCommentsService{c}
 	c.Repos = &repositoriesService{c}
+	c.RepoCommits = &repoCommitsService{c}
+	c.RepoStatuses = &repoStatusesService{c}
+	c.RepoBadges = &repoBadgesService{c}
+	c.RepoHooks = &repoHooksService{c}
 	c.RepoTree = &repoTreeService{c}
+	c.Annotations = &annotationsService{c}
 	c.Search = &searchService{c}
 	c.Units = &unitsService{c}
 	c.Users = &usersService{c}
 	c.Defs = &defsService{c}
 	c.Markdown = &markdownService{c}
+	c.Auth = &authService{c}
+	c.Notifications = &notificationsService{c}
+	c.TrackerLinks = &trackerLinksService{c}
+	c.Tokens = &tokensService{c}
+	c.AuditLog = &auditLogService{c}
+	c.Admin = &adminService{c}
+	c.Monitoring = &monitoringService{c}
+	c.Meta = &metaService{c}
+}
 
-	c.BaseURL = &url.URL{Scheme: "https", Host: "sourcegraph.com", Path: "/api/"}
-
-	c.UserAgent = userAgent
+// Clone returns a copy of c whose services use the copy. The copy's
+// fields may be modified without affecting c. It shares c's underlying
+// HTTP client (and so its connections), cache, and record of
+// unsupported API routes, so it is cheap to create per tenant or per
+// call. A mock client (with no HTTP client) keeps its mock services.
+func (c *Client) Clone() *Client {
+	c2 := *c
+	if c.BaseURL != nil {
+		u := *c.BaseURL
+		c2.BaseURL = &u
+	}
+	if c2.httpClient != nil {
+		c2.setServices()
+	}
+	return &c2
+}
 
-	return c
+// WithMaxResponseBytes returns a copy of c whose MaxResponseBytes is
+// n. It may be used to limit the response size of a single call:
+//
+//	files, _, err := client.WithMaxResponseBytes(10 << 20).Deltas.ListFiles(ds, nil)
+func (c *Client) WithMaxResponseBytes(n int64) *Client {
+	c2 := c.Clone()
+	c2.MaxResponseBytes = n
+	return c2
 }
 
 // Router is used to generate URLs for the Sourcegraph API.
@@ -96,25 +256,13 @@ func ResetRouter() {
 // and/or Port on Router, the returned URL will contain only path and
 // querystring components (and will not be an absolute URL).
 func URL(route string, routeVars map[string]string, opt interface{}) (*url.URL, error) {
-	rt := Router.Get(route)
-	if rt == nil {
-		return nil, fmt.Errorf("no Sourcegraph API route named %q", route)
-	}
-
-	routeVarsList := make([]string, 2*len(routeVars))
-	i := 0
-	for name, val := range routeVars {
-		routeVarsList[i*2] = name
-		routeVarsList[i*2+1] = val
-		i++
-	}
-	url, err := rt.URL(routeVarsList...)
+	url, err := router.BuildURL(Router, route, routeVars)
 	if err != nil {
 		return nil, err
 	}
 
 	if opt != nil {
-		err = addOptions(url, opt)
+		err = encodeOptions(url, route, routeVars, opt)
 		if err != nil {
 			return nil, err
 		}
@@ -123,16 +271,31 @@ func URL(route string, routeVars map[string]string, opt interface{}) (*url.URL,
 	return url, nil
 }
 
+// encodeOptions calls addOptions, converting a panic (e.g., in a
+// custom query-string encoder) into a *PanicError.
+func encodeOptions(u *url.URL, route string, routeVars map[string]string, opt interface{}) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Op: "encoding options", Route: route, RouteVars: routeVars, Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return addOptions(u, opt)
+}
+
 // URL generates the absolute URL to the named Sourcegraph API endpoint, using the
 // specified route variables and query options.
 func (c *Client) URL(route string, routeVars map[string]string, opt interface{}) (*url.URL, error) {
 	url, err := URL(route, routeVars, opt)
 	if err != nil {
-		return nil, err
+		return nil, c.reportError(nil, err)
 	}
+	c.addDefaultPerPage(url, opt)
 
 	// make the route URL path relative to BaseURL by trimming the leading "/"
-	url.Path = strings.TrimPrefix(url.Path, "/")
+	// (unless the route is relative to the server's root)
+	if !router.IsRootRoute(route) {
+		url.Path = strings.TrimPrefix(url.Path, "/")
+	}
 
 	// make the route URL path relative to BaseURL's path and not the path parent
 	baseURL := *c.BaseURL
@@ -153,26 +316,40 @@ func (c *Client) URL(route string, routeVars map[string]string, opt interface{})
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
 	url, err := url.Parse(urlStr)
 	if err != nil {
-		return nil, err
+		return nil, c.reportError(nil, err)
 	}
 
 	buf := new(bytes.Buffer)
 	if body != nil {
-		err := json.NewEncoder(buf).Encode(body)
+		err := c.encodeBody(buf, method, url, body)
 		if err != nil {
-			return nil, err
+			return nil, c.reportError(nil, err)
 		}
 	}
 
 	req, err := http.NewRequest(method, url.String(), buf)
 	if err != nil {
-		return nil, err
+		return nil, c.reportError(nil, err)
 	}
 
 	req.Header.Add("User-Agent", c.UserAgent)
+	if err := c.setCredentials(req); err != nil {
+		return nil, c.reportError(req, err)
+	}
 	return req, nil
 }
 
+// encodeBody JSON-encodes body to w, converting a panic (e.g., in a
+// custom JSON marshaler) into a *PanicError.
+func (c *Client) encodeBody(w io.Writer, method string, u *url.URL, body interface{}) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = c.newPanicError("encoding request body", &http.Request{Method: method, URL: u}, v)
+		}
+	}()
+	return json.NewEncoder(w).Encode(body)
+}
+
 // newResponse creates a new Response for the provided http.Response.
 func newResponse(r *http.Response) Response {
 	if r == nil {
@@ -201,6 +378,16 @@ func (r *HTTPResponse) TotalCount() int {
 	return n
 }
 
+// Rate implements Response.
+func (r *HTTPResponse) Rate() *Rate { return parseRate(r.Header) }
+
+// Pagination implements Response.
+func (r *HTTPResponse) Pagination() Pagination {
+	p := parseLinkHeader(r.Header.Get("Link"))
+	p.TotalCount = r.TotalCount()
+	return p
+}
+
 type MockResponse struct{}
 
 // Response is a response from the Sourcegraph API. When using the HTTP API,
@@ -211,6 +398,14 @@ type Response interface {
 	// body. If the endpoint did not return a total count, then TotalCount
 	// returns -1.
 	TotalCount() int
+
+	// Rate is the client's rate limit as of the response, or nil if the
+	// response didn't report a rate limit.
+	Rate() *Rate
+
+	// Pagination is the position of the response's page in the list
+	// (for responses from list methods).
+	Pagination() Pagination
 }
 
 // ListOptions specifies general pagination options for fetching a list of
@@ -246,18 +441,94 @@ func (o ListOptions) Offset() int {
 	return (o.PageOrDefault() - 1) * o.PerPageOrDefault()
 }
 
+// TimeRangeOptions restricts a list to items in a time range, so that
+// incremental syncers can fetch only the items that changed since
+// their last sync (instead of paging from the beginning). Pull
+// requests, issues, and comments are compared by the time they were
+// last updated, commits by their committer date, builds by the time
+// they were created, and repositories by the time they were last
+// updated. If Since or Until is nil, the range is unbounded on that
+// side.
+//
+// Times are sent with second precision (rounded down), so a syncer
+// that passes the latest update time it has seen as Since may receive
+// some items again, but never misses any.
+type TimeRangeOptions struct {
+	Since *time.Time `url:",omitempty" json:",omitempty"` // only items at or after this time
+	Until *time.Time `url:",omitempty" json:",omitempty"` // only items before this time
+}
+
 type doKey int // sentinel value type for (*Client).Do v parameter
 
 const preserveBody doKey = iota // when passed as v to (*Client).Do, the resp body is neither parsed nor closed
 
+// RequestOptions, when passed as v to (*Client).Do, requests a
+// representation of the response other than JSON, such as raw file
+// contents, a text/plain diff, or (where the server supports it)
+// protobuf. The response body is copied to Into as is, instead of
+// being JSON-decoded.
+type RequestOptions struct {
+	// Accept, if set, is sent as the request's Accept header (e.g.,
+	// "text/plain"). The server may ignore it for routes that have
+	// only a JSON representation; check the response's Content-Type.
+	Accept string
+
+	// Into receives the response body. If nil, the body is discarded.
+	Into io.Writer
+}
+
 // Do sends an API request and returns the API response.  The API
 // response is decoded and stored in the value pointed to by v, or
-// returned as an error if an API error has occurred. If v is
-// preserveBody, then the HTTP response body is not closed by Do; the
-// caller is responsible for closing it.
+// returned as an error if an API error has occurred. If v is a
+// *[]byte, the response body is stored in it without being decoded;
+// if v is a *RequestOptions, the body is written to its Into field.
+// If v is preserveBody, then the HTTP response body is not closed by
+// Do; the caller is responsible for closing it.
+//
+// Unless req has an Accept-Encoding header, Do requests a gzip or
+// deflate compressed response and transparently decompresses it. (To
+// request an uncompressed response, set Accept-Encoding to
+// "identity".)
+//
+// If the server doesn't support the requested API route, a
+// *NotSupportedError is returned, and subsequent requests to the
+// route fail immediately with the same error.
 func (c *Client) Do(req *http.Request, v interface{}) (Response, error) {
+	start := time.Now()
+	resp, err := c.do(req, v)
+	c.observeRequest(req, start, resp, err)
+	return resp, c.reportError(req, err)
+}
+
+func (c *Client) do(req *http.Request, v interface{}) (Response, error) {
+	route, err := c.checkRouteSupported(req)
+	if err != nil {
+		return nil, err
+	}
+	if opt, ok := v.(*RequestOptions); ok && opt.Accept != "" {
+		req.Header.Set("Accept", opt.Accept)
+	}
+	if v != preserveBody && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
 	var resp Response
-	rawResp, err := c.httpClient.Do(req)
+	rawResp, done, err := c.send(req, v == preserveBody)
+	if done != nil && v != preserveBody {
+		defer done()
+	}
+	if rawResp != nil && v != preserveBody && rawResp.Body != nil {
+		if err := decompressBody(rawResp); err != nil {
+			return newResponse(rawResp), err
+		}
+	}
+	if rawResp != nil && c.MaxResponseBytes > 0 {
+		if rawResp.ContentLength > c.MaxResponseBytes {
+			rawResp.Body.Close()
+			return newResponse(rawResp), ErrResponseTooLarge
+		}
+		rawResp.Body = &limitedBody{rc: rawResp.Body, n: c.MaxResponseBytes}
+	}
 	if rawResp != nil {
 		if v != preserveBody && rawResp.Body != nil {
 			defer rawResp.Body.Close()
@@ -268,6 +539,10 @@ func (c *Client) Do(req *http.Request, v interface{}) (Response, error) {
 			// a sentinel error returned by the HTTP client's
 			// CheckRedirect func).
 			if err := CheckResponse(rawResp); err != nil {
+				if route != "" && isRouteNotFound(rawResp, err) {
+					c.features.markUnsupported(route)
+					return resp, notSupportedError(route)
+				}
 				// even though there was an error, we still return the response
 				// in case the caller wants to inspect it further
 				return resp, err
@@ -278,27 +553,87 @@ func (c *Client) Do(req *http.Request, v interface{}) (Response, error) {
 		return resp, err
 	}
 
+	return resp, c.decodeResponse(req, rawResp.Body, v)
+}
+
+// decodeResponse reads the response body into v (see Do),
+// converting a panic (e.g., in a custom JSON unmarshaler or Validate
+// method) into a *PanicError.
+func (c *Client) decodeResponse(req *http.Request, body io.Reader, v interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = c.newPanicError("decoding response", req, r)
+		}
+	}()
+
 	if v != nil {
 		if bp, ok := v.(*[]byte); ok {
-			*bp, err = ioutil.ReadAll(rawResp.Body)
+			*bp, err = ioutil.ReadAll(body)
+		} else if opt, ok := v.(*RequestOptions); ok {
+			if opt.Into != nil {
+				_, err = io.Copy(opt.Into, body)
+			}
 		} else if v != preserveBody {
-			err = json.NewDecoder(rawResp.Body).Decode(v)
+			err = json.NewDecoder(body).Decode(v)
 		}
 	}
+	if err == ErrResponseTooLarge {
+		return err
+	}
 	if err != nil {
-		return resp, fmt.Errorf("error reading response from %s %s: %s", req.Method, req.URL.RequestURI(), err)
+		return fmt.Errorf("error reading response from %s %s: %s", req.Method, req.URL.RequestURI(), err)
+	}
+	if c.StrictValidation && v != nil && v != preserveBody {
+		if err := validateResponse(v); err != nil {
+			return &InvalidResponseError{Method: req.Method, URL: req.URL.RequestURI(), Err: err}
+		}
+	}
+	return nil
+}
+
+// ErrResponseTooLarge is returned when a response body exceeds the
+// Client's MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// limitedBody is a response body that fails with ErrResponseTooLarge
+// if more than n bytes are read from it.
+type limitedBody struct {
+	rc io.ReadCloser
+	n  int64 // bytes remaining
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// Check whether the body has more data.
+		var b [1]byte
+		if n, _ := l.rc.Read(b[:]); n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, io.EOF
 	}
-	return resp, nil
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.rc.Read(p)
+	l.n -= int64(n)
+	return n, err
 }
 
+func (l *limitedBody) Close() error { return l.rc.Close() }
+
 // addOptions adds the parameters in opt as URL query parameters to u. opt
-// must be a struct whose fields may contain "url" tags.
+// must be a struct whose fields may contain "url" tags. If opt is a list
+// options struct, its sort options are checked (see SortOptions).
 func addOptions(u *url.URL, opt interface{}) error {
 	v := reflect.ValueOf(opt)
 	if v.Kind() == reflect.Ptr && v.IsNil() {
 		return nil
 	}
 
+	if err := checkSort(opt); err != nil {
+		return err
+	}
+
 	qs, err := query.Values(opt)
 	if err != nil {
 		return err
@@ -311,18 +646,33 @@ func addOptions(u *url.URL, opt interface{}) error {
 // NewMockClient returns a mockable Client for use in tests.
 func NewMockClient() *Client {
 	return &Client{
-		BuildData:    &MockBuildDataService{},
-		Builds:       &MockBuildsService{},
-		Deltas:       &MockDeltasService{},
-		Issues:       &MockIssuesService{},
-		Orgs:         &MockOrgsService{},
-		People:       &MockPeopleService{},
-		PullRequests: &MockPullRequestsService{},
-		Repos:        &MockReposService{},
-		RepoTree:     &MockRepoTreeService{},
-		Search:       &MockSearchService{},
-		Units:        &MockUnitsService{},
-		Users:        &MockUsersService{},
-		Defs:         &MockDefsService{},
+		BuildData:      &MockBuildDataService{},
+		Builds:         &MockBuildsService{},
+		Deltas:         &MockDeltasService{},
+		Issues:         &MockIssuesService{},
+		Orgs:           &MockOrgsService{},
+		People:         &MockPeopleService{},
+		PullRequests:   &MockPullRequestsService{},
+		ReviewComments: &MockPullRequestReviewCommentsService{},
+		Repos:          &MockReposService{},
+		RepoCommits:    &MockRepoCommitsService{},
+		RepoStatuses:   &MockRepoStatusesService{},
+		RepoBadges:     &MockRepoBadgesService{},
+		RepoHooks:      &MockRepoHooksService{},
+		RepoTree:       &MockRepoTreeService{},
+		Annotations:    &MockAnnotationsService{},
+		Search:         &MockSearchService{},
+		Units:          &MockUnitsService{},
+		Users:          &MockUsersService{},
+		Defs:           &MockDefsService{},
+
+		Auth:          &MockAuthService{},
+		Notifications: &MockNotificationsService{},
+		TrackerLinks:  &MockTrackerLinksService{},
+		Tokens:        &MockTokensService{},
+		AuditLog:      &MockAuditLogService{},
+		Admin:         &MockAdminService{},
+		Monitoring:    &MockMonitoringService{},
+		Meta:          &MockMetaService{},
 	}
 }
@@ -0,0 +1,96 @@
+package sourcegraph
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ClientOptions are defaults applied to every request sent by a
+// Client (see WithOptions). Zero-valued fields leave the Client's
+// corresponding setting unchanged.
+type ClientOptions struct {
+	// BaseURL is the base URL for API requests (see Client.BaseURL).
+	BaseURL *url.URL
+
+	// UserAgent is the user agent for HTTP requests (see
+	// Client.UserAgent).
+	UserAgent string
+
+	// PerPage is the default number of results per page for list
+	// methods (see Client.PerPage).
+	PerPage int
+
+	// Timeout is the time limit for each request attempt (see
+	// Client.Timeout).
+	Timeout time.Duration
+}
+
+// WithOptions returns a copy of c (see Clone) with opt's nonzero
+// fields set. Multi-tenant services may use it to derive per-tenant
+// clients from one shared client:
+//
+//	base := sourcegraph.NewClient(httpClient)
+//	...
+//	c := base.WithOptions(sourcegraph.ClientOptions{BaseURL: tenant.APIURL, PerPage: 100})
+func (c *Client) WithOptions(opt ClientOptions) *Client {
+	c2 := c.Clone()
+	if opt.BaseURL != nil {
+		u := *opt.BaseURL
+		c2.BaseURL = &u
+	}
+	if opt.UserAgent != "" {
+		c2.UserAgent = opt.UserAgent
+	}
+	if opt.PerPage != 0 {
+		c2.PerPage = opt.PerPage
+	}
+	if opt.Timeout != 0 {
+		c2.Timeout = opt.Timeout
+	}
+	return c2
+}
+
+// Options returns c's current settings for the fields of
+// ClientOptions.
+func (c *Client) Options() ClientOptions {
+	opt := ClientOptions{UserAgent: c.UserAgent, PerPage: c.PerPage, Timeout: c.Timeout}
+	if c.BaseURL != nil {
+		u := *c.BaseURL
+		opt.BaseURL = &u
+	}
+	return opt
+}
+
+var listOptionsType = reflect.TypeOf(ListOptions{})
+
+// addDefaultPerPage adds c.PerPage to u's query if opt (which may be
+// a nil pointer) is a list options struct (one with ListOptions
+// fields) whose PerPage wasn't set.
+func (c *Client) addDefaultPerPage(u *url.URL, opt interface{}) {
+	if c.PerPage <= 0 || opt == nil {
+		return
+	}
+	t := reflect.TypeOf(opt)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	if f, ok := t.FieldByName("ListOptions"); !ok || f.Type != listOptionsType {
+		return
+	}
+
+	q := u.Query()
+	if q.Get("PerPage") != "" {
+		return
+	}
+	perPage := c.PerPage
+	if perPage < DefaultPerPage {
+		perPage = DefaultPerPage
+	}
+	q.Set("PerPage", strconv.Itoa(perPage))
+	u.RawQuery = q.Encode()
+}
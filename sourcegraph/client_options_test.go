@@ -0,0 +1,116 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestClient_WithOptions(t *testing.T) {
+	c := NewClient(nil)
+	orig := c.Options()
+
+	u, _ := url.Parse("https://example.com/api/")
+	c2 := c.WithOptions(ClientOptions{BaseURL: u, PerPage: 50, Timeout: time.Second})
+	u.Host = "modified.example.com"
+
+	want := ClientOptions{BaseURL: &url.URL{Scheme: "https", Host: "example.com", Path: "/api/"}, UserAgent: userAgent, PerPage: 50, Timeout: time.Second}
+	if got := c2.Options(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got options %+v, want %+v", got, want)
+	}
+	if got := c.Options(); !reflect.DeepEqual(got, orig) {
+		t.Errorf("WithOptions modified the original client: got %+v, want %+v", got, orig)
+	}
+	if c2.Repos.(*repositoriesService).client != c2 {
+		t.Error("copy's services don't use the copy")
+	}
+}
+
+func TestClient_Clone(t *testing.T) {
+	c := NewClient(nil)
+	c2 := c.Clone()
+	c2.BaseURL.Path = "/other/"
+	if c.BaseURL.Path != "/api/" {
+		t.Errorf("modifying the copy's BaseURL modified the original's: %q", c.BaseURL.Path)
+	}
+
+	m := NewMockClient()
+	if m.Clone().Repos != m.Repos {
+		t.Error("copy of mock client doesn't keep its mock services")
+	}
+}
+
+func TestClient_PerPage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var wantPerPage string
+	var called bool
+	mux.HandleFunc(urlPath(t, router.Repos, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("PerPage"); got != wantPerPage {
+			t.Errorf("got PerPage %q, want %q", got, wantPerPage)
+		}
+		writeJSON(w, []*Repo{})
+	})
+
+	tests := []struct {
+		clientPerPage int
+		opt           *RepoListOptions
+		want          string
+	}{
+		{0, nil, ""},
+		{50, nil, "50"},
+		{50, &RepoListOptions{}, "50"},
+		{50, &RepoListOptions{ListOptions: ListOptions{PerPage: 3}}, "3"},
+		{5, nil, "10"},
+	}
+	for _, test := range tests {
+		called, wantPerPage = false, test.want
+		c := client.WithOptions(ClientOptions{PerPage: test.clientPerPage})
+		if _, _, err := c.Repos.List(test.opt); err != nil {
+			t.Errorf("Repos.List: %s", err)
+		}
+		if !called {
+			t.Fatal("!called")
+		}
+	}
+
+	// Options without ListOptions are unaffected.
+	u, err := client.WithOptions(ClientOptions{PerPage: 50}).URL(router.Repo, map[string]string{"RepoSpec": "h.com/r"}, &RepoGetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.RawQuery != "" {
+		t.Errorf("got query %q, want none", u.RawQuery)
+	}
+}
+
+func TestClient_Timeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	mux.HandleFunc(urlPath(t, router.Repo, map[string]string{"RepoSpec": "h.com/r"}), func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-unblock:
+		case <-time.After(5 * time.Second):
+		}
+		writeJSON(w, &Repo{})
+	})
+
+	c := client.WithOptions(ClientOptions{Timeout: 50 * time.Millisecond})
+	start := time.Now()
+	if _, _, err := c.Repos.Get(RepoSpec{URI: "h.com/r"}, nil); err == nil {
+		t.Fatal("err == nil")
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("request took %s, want it to time out after 50ms", d)
+	}
+}
@@ -1,12 +1,17 @@
 package sourcegraph
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -93,6 +98,8 @@ func testBody(t *testing.T, r *http.Request, want string) {
 }
 
 func TestClient_URL(t *testing.T) {
+	since := time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC)
+	sinceNonUTC := time.Date(2015, 1, 2, 3, 4, 5, 999, time.FixedZone("", 2*60*60))
 	tests := []struct {
 		base      string
 		route     string
@@ -119,6 +126,30 @@ func TestClient_URL(t *testing.T) {
 		route:     router.Repo,
 		routeVars: map[string]string{"RepoSpec": "github.com/gorilla/mux"},
 		exp:       "http://localhost:3000/api/repos/github.com/gorilla/mux",
+	}, {
+		base:      "https://sourcegraph.com/api/",
+		route:     router.RepoIssues,
+		routeVars: map[string]string{"RepoSpec": "github.com/gorilla/mux"},
+		opt:       &IssueListOptions{TimeRangeOptions: TimeRangeOptions{Since: &since}},
+		exp:       "https://sourcegraph.com/api/repos/github.com/gorilla/mux/.issues?Since=2015-01-02T03%3A04%3A05Z",
+	}, {
+		base:  "https://sourcegraph.com/api/",
+		route: router.Repos,
+		opt:   &RepoListOptions{Sort: "updated", Direction: Ascending, TimeRangeOptions: TimeRangeOptions{Since: &since}},
+		exp:   "https://sourcegraph.com/api/repos?Direction=asc&Since=2015-01-02T03%3A04%3A05Z&Sort=updated",
+	}, {
+		base:  "https://sourcegraph.com/api/",
+		route: router.Builds,
+		opt:   &BuildListOptions{Sort: "updated_at", TimeRangeOptions: TimeRangeOptions{Since: &sinceNonUTC}},
+		exp:   "https://sourcegraph.com/api/builds?Since=2015-01-02T03%3A04%3A05%2B02%3A00&Sort=updated_at",
+	}, {
+		base:  "https://sourcegraph.com/api/",
+		route: router.GraphQL,
+		exp:   "https://sourcegraph.com/.api/graphql",
+	}, {
+		base:  "http://localhost:3000/api",
+		route: router.GraphQL,
+		exp:   "http://localhost:3000/.api/graphql",
 	}}
 	for _, test := range tests {
 		func() {
@@ -144,3 +175,218 @@ func TestClient_URL(t *testing.T) {
 func normalizeTime(tm *time.Time) {
 	*tm = tm.In(time.UTC)
 }
+
+func TestClient_MaxResponseBytes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		// Omit the Content-Length header by flushing early.
+		w.Write([]byte(`"`))
+		w.(http.Flusher).Flush()
+		w.Write([]byte(strings.Repeat("a", 100) + `"`))
+	})
+	mux.HandleFunc("/sized", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.Write(make([]byte, 100))
+	})
+
+	for _, path := range []string{"big", "sized"} {
+		req, err := client.NewRequest("GET", server.URL+"/"+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v interface{}
+		if _, err := client.WithMaxResponseBytes(10).Do(req, &v); err != ErrResponseTooLarge {
+			t.Errorf("%s: got error %v, want ErrResponseTooLarge", path, err)
+		}
+		var b []byte
+		if _, err := client.WithMaxResponseBytes(10).Do(req, &b); err != ErrResponseTooLarge {
+			t.Errorf("%s: got error %v, want ErrResponseTooLarge", path, err)
+		}
+	}
+
+	// Responses within the limit are read normally.
+	mux.HandleFunc("/small", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("abc"))
+	})
+	req, _ := client.NewRequest("GET", server.URL+"/small", nil)
+	var b []byte
+	if _, err := client.WithMaxResponseBytes(3).Do(req, &b); err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "abc" {
+		t.Errorf("got %q, want %q", b, "abc")
+	}
+	if client.MaxResponseBytes != 0 {
+		t.Error("WithMaxResponseBytes modified the original client")
+	}
+}
+
+func TestClient_Do_rateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	reset := time.Unix(1500000000, 0)
+	remaining := "1"
+	mux.HandleFunc("/r", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "2")
+		w.Header().Set("X-RateLimit-Remaining", remaining)
+		w.Header().Set("X-RateLimit-Reset", "1500000000")
+		if remaining == "0" {
+			http.Error(w, `{"Message":"slow down"}`, http.StatusForbidden)
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+
+	req, _ := client.NewRequest("GET", server.URL+"/r", nil)
+	resp, err := client.Do(req, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&Rate{Limit: 2, Remaining: 1, Reset: reset}); !reflect.DeepEqual(resp.Rate(), want) {
+		t.Errorf("got rate %+v, want %+v", resp.Rate(), want)
+	}
+
+	remaining = "0"
+	_, err = client.Do(req, nil)
+	rateErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("got error %v (%T), want *RateLimitError", err, err)
+	}
+	if want := (Rate{Limit: 2, Remaining: 0, Reset: reset}); !reflect.DeepEqual(rateErr.Rate, want) {
+		t.Errorf("got rate %+v, want %+v", rateErr.Rate, want)
+	}
+	if !IsRateLimited(err) || !IsHTTPErrorCode(err, http.StatusForbidden) {
+		t.Errorf("got IsRateLimited %v, IsHTTPErrorCode %v, want true", IsRateLimited(err), IsHTTPErrorCode(err, http.StatusForbidden))
+	}
+}
+
+func TestClient_Do_decompress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	compress := map[string]func(io.Writer) io.WriteCloser{
+		"gzip":    func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate": func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+	}
+	for encoding, newWriter := range compress {
+		newWriter := newWriter
+		mux.HandleFunc("/"+encoding, func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Accept-Encoding"); got != acceptEncoding {
+				t.Errorf("got Accept-Encoding %q, want %q", got, acceptEncoding)
+			}
+			w.Header().Set("Content-Encoding", r.URL.Path[1:])
+			zw := newWriter(w)
+			zw.Write([]byte(`"` + strings.Repeat("a", 100) + `"`))
+			zw.Close()
+		})
+
+		req, _ := client.NewRequest("GET", server.URL+"/"+encoding, nil)
+		var v string
+		if _, err := client.Do(req, &v); err != nil {
+			t.Errorf("%s: %s", encoding, err)
+		} else if want := strings.Repeat("a", 100); v != want {
+			t.Errorf("%s: got %q, want %q", encoding, v, want)
+		}
+
+		// The limit applies to the decompressed body.
+		req, _ = client.NewRequest("GET", server.URL+"/"+encoding, nil)
+		if _, err := client.WithMaxResponseBytes(50).Do(req, &v); err != ErrResponseTooLarge {
+			t.Errorf("%s: got error %v, want ErrResponseTooLarge", encoding, err)
+		}
+	}
+}
+
+func TestClient_Do_requestOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.StrictValidation = true
+
+	want := "diff --git a/f b/f\n"
+	mux.HandleFunc("/r", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Accept"), "text/plain"; got != want {
+			t.Errorf("got Accept %q, want %q", got, want)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(want))
+	})
+
+	req, _ := client.NewRequest("GET", server.URL+"/r", nil)
+	var buf bytes.Buffer
+	if _, err := client.Do(req, &RequestOptions{Accept: "text/plain", Into: &buf}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("got body %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCheckResponse_rateLimit(t *testing.T) {
+	tests := []struct {
+		code        int
+		header      http.Header
+		rateLimited bool
+	}{
+		{code: http.StatusTooManyRequests, rateLimited: true},
+		{code: http.StatusForbidden},
+		{code: http.StatusForbidden, header: http.Header{"X-Ratelimit-Limit": {"5"}, "X-Ratelimit-Remaining": {"2"}}},
+		{code: http.StatusForbidden, header: http.Header{"X-Ratelimit-Limit": {"5"}, "X-Ratelimit-Remaining": {"0"}}, rateLimited: true},
+	}
+	for i, test := range tests {
+		resp := &http.Response{StatusCode: test.code, Header: test.header, Body: ioutil.NopCloser(strings.NewReader(""))}
+		if err := CheckResponse(resp); IsRateLimited(err) != test.rateLimited {
+			t.Errorf("#%d: got error %v, want rate limited %v", i, err, test.rateLimited)
+		}
+	}
+}
+
+type panickyJSON struct{}
+
+func (panickyJSON) MarshalJSON() ([]byte, error) { panic("marshal") }
+func (*panickyJSON) UnmarshalJSON([]byte) error  { panic("unmarshal") }
+
+func TestClient_Do_recoversPanic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+	mux.HandleFunc(urlPath(t, router.Repo, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, struct{}{})
+	})
+
+	var hookErrs []error
+	client.OnError = func(req *http.Request, err error) { hookErrs = append(hookErrs, err) }
+
+	u, err := client.URL(router.Repo, repo.RouteVars(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := client.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Do(req, &panickyJSON{})
+	pe, ok := err.(*PanicError)
+	if !ok {
+		t.Fatalf("got err %v, want *PanicError", err)
+	}
+	if pe.Op != "decoding response" || pe.Route != router.Repo || pe.RouteVars["RepoSpec"] != "r.com/x" || pe.Value != "unmarshal" {
+		t.Errorf("got PanicError %+v", pe)
+	}
+
+	u, err = client.URL(router.ReposCreate, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.NewRequest("POST", u.String(), panickyJSON{})
+	if pe, ok := err.(*PanicError); !ok || pe.Op != "encoding request body" || pe.Route != router.ReposCreate {
+		t.Errorf("got err %v, want *PanicError for request body", err)
+	}
+
+	if len(hookErrs) != 2 || !IsPanic(hookErrs[0]) || !IsPanic(hookErrs[1]) {
+		t.Errorf("got OnError errors %v, want 2 *PanicError values", hookErrs)
+	}
+}
@@ -0,0 +1,86 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxCommentBodyLength is the maximum length (in characters) of a
+// comment body that the server accepts.
+const MaxCommentBodyLength = 65536
+
+// A ValidationError is returned by methods that validate their
+// arguments before sending a request (such as
+// PullRequestsService.CreateComment) if an argument is invalid. No
+// request is sent.
+type ValidationError struct {
+	Field    string   // the invalid field (e.g., "Body")
+	Problems []string // descriptions of each problem with the field
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, strings.Join(e.Problems, "; "))
+}
+
+// NormalizeCommentBody returns body with line endings converted to
+// "\n" and trailing whitespace removed from each line and from the end
+// of the body. CreateComment and EditComment normalize comment bodies
+// before validating and sending them.
+//
+// Because trailing whitespace is removed, markdown hard line breaks
+// must be written with a trailing backslash, not two trailing spaces.
+func NormalizeCommentBody(body string) string {
+	body = strings.Replace(body, "\r\n", "\n", -1)
+	body = strings.Replace(body, "\r", "\n", -1)
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// ValidateCommentBody returns a *ValidationError listing the problems
+// with a (normalized) comment body: if it is empty, longer than
+// MaxCommentBodyLength characters, not valid UTF-8, or contains
+// control characters other than tab and newline.
+func ValidateCommentBody(body string) error {
+	var problems []string
+	if strings.TrimSpace(body) == "" {
+		problems = append(problems, "body is empty")
+	}
+	if n := utf8.RuneCountInString(body); n > MaxCommentBodyLength {
+		problems = append(problems, fmt.Sprintf("body is %d characters long (maximum is %d)", n, MaxCommentBodyLength))
+	}
+	if !utf8.ValidString(body) {
+		problems = append(problems, "body is not valid UTF-8")
+	}
+	for i, r := range body {
+		if (r < 0x20 && r != '\t' && r != '\n') || r == 0x7f {
+			problems = append(problems, fmt.Sprintf("body contains control character %U at byte offset %d", r, i))
+			break
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Field: "Body", Problems: problems}
+	}
+	return nil
+}
+
+// prepareCommentBody normalizes and validates a comment body (which
+// may be nil only if the body is not required, as when editing other
+// fields of a comment), and returns a pointer to the normalized body.
+// It does not modify *body.
+func prepareCommentBody(body *string, required bool) (*string, error) {
+	if body == nil {
+		if !required {
+			return nil, nil
+		}
+		body = new(string)
+	}
+	normalized := NormalizeCommentBody(*body)
+	if err := ValidateCommentBody(normalized); err != nil {
+		return nil, err
+	}
+	return &normalized, nil
+}
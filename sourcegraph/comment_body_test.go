@@ -0,0 +1,53 @@
+package sourcegraph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeCommentBody(t *testing.T) {
+	tests := map[string]string{
+		"a":                  "a",
+		"a \r\nb\t\rc\n\n\n": "a\nb\nc",
+		"  a\n\n  b  ":       "  a\n\n  b",
+	}
+	for body, want := range tests {
+		if got := NormalizeCommentBody(body); got != want {
+			t.Errorf("%q: got %q, want %q", body, got, want)
+		}
+	}
+}
+
+func TestValidateCommentBody(t *testing.T) {
+	tests := []struct {
+		body         string
+		wantProblems []string
+	}{
+		{"a\n\tb", nil},
+		{"", []string{"body is empty"}},
+		{"a\x07b", []string{"body contains control character U+0007 at byte offset 1"}},
+		{strings.Repeat("é", MaxCommentBodyLength), nil},
+		{strings.Repeat("a", MaxCommentBodyLength+1) + "\x00", []string{
+			"body is 65538 characters long (maximum is 65536)",
+			"body contains control character U+0000 at byte offset 65537",
+		}},
+	}
+	for _, test := range tests {
+		err := ValidateCommentBody(test.body)
+		if test.wantProblems == nil {
+			if err != nil {
+				t.Errorf("%.20q: got error %v, want nil", test.body, err)
+			}
+			continue
+		}
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			t.Errorf("%.20q: got error %v, want *ValidationError", test.body, err)
+			continue
+		}
+		if verr.Field != "Body" || !reflect.DeepEqual(verr.Problems, test.wantProblems) {
+			t.Errorf("%.20q: got %s %q, want Body %q", test.body, verr.Field, verr.Problems, test.wantProblems)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package sourcegraph
+
+import "github.com/fossas/go-sourcegraph/router"
+
+// RepoCommitsService communicates with the Sourcegraph API endpoints
+// that fetch and compare a repository's commits.
+type RepoCommitsService interface {
+	// Get fetches a commit.
+	Get(commit CommitSpec) (*Commit, Response, error)
+
+	// List lists a repository's commits.
+	List(repo RepoSpec, opt *RepoListCommitsOptions) ([]*Commit, Response, error)
+
+	// Compare compares two revisions of a repository (such as branch
+	// names or commit IDs), returning the commits and file diffs in
+	// head that aren't in base.
+	Compare(repo RepoSpec, base, head string) (*CommitComparison, Response, error)
+}
+
+// repoCommitsService implements RepoCommitsService.
+type repoCommitsService struct {
+	client *Client
+}
+
+var _ RepoCommitsService = &repoCommitsService{}
+
+// CommitSpec specifies a commit in a repository.
+type CommitSpec struct {
+	Repo RepoSpec
+	ID   string // the full commit ID (or any revision specifier)
+}
+
+func (s CommitSpec) RouteVars() map[string]string {
+	return RepoRevSpec{RepoSpec: s.Repo, Rev: s.ID}.RouteVars()
+}
+
+// A CommitComparison is the result of comparing two revisions of a
+// repository.
+type CommitComparison struct {
+	// BaseCommitID and HeadCommitID are the resolved commit IDs of
+	// the compared revisions.
+	BaseCommitID string
+	HeadCommitID string
+
+	// MergeBase is the commit ID of the best common ancestor of the
+	// base and head commits.
+	MergeBase string `json:",omitempty"`
+
+	// Ahead and Behind are the number of commits in head that are not
+	// in base, and vice versa.
+	Ahead  int
+	Behind int
+
+	// Commits are the commits in head that are not in base, oldest
+	// first.
+	Commits []*Commit
+
+	// Files are the diffs of the files changed between the merge base
+	// and head.
+	Files []*FileDiff `json:",omitempty"`
+}
+
+func (s *repoCommitsService) Get(commit CommitSpec) (*Commit, Response, error) {
+	var commit_ *Commit
+	resp, err := s.client.DoGet(router.RepoCommit, commit.RouteVars(), nil, &commit_)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return commit_, resp, nil
+}
+
+func (s *repoCommitsService) List(repo RepoSpec, opt *RepoListCommitsOptions) ([]*Commit, Response, error) {
+	var commits []*Commit
+	resp, err := s.client.DoList(router.RepoCommits, repo.RouteVars(), opt, &commits)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return commits, resp, nil
+}
+
+// repoCompareCommitsOptions holds the query parameters of a
+// RepoCompareCommits request (whose Rev route variable is the head).
+type repoCompareCommitsOptions struct {
+	Base string
+}
+
+func (s *repoCommitsService) Compare(repo RepoSpec, base, head string) (*CommitComparison, Response, error) {
+	var cmp *CommitComparison
+	routeVars := RepoRevSpec{RepoSpec: repo, Rev: head}.RouteVars()
+	resp, err := s.client.DoGet(router.RepoCompareCommits, routeVars, &repoCompareCommitsOptions{Base: base}, &cmp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return cmp, resp, nil
+}
+
+var _ RepoCommitsService = &MockRepoCommitsService{}
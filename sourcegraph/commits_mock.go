@@ -0,0 +1,48 @@
+// generated by gen-mocks; DO NOT EDIT
+
+package sourcegraph
+
+type MockRepoCommitsService struct {
+	Get_     func(commit CommitSpec) (*Commit, Response, error)
+	List_    func(repo RepoSpec, opt *RepoListCommitsOptions) ([]*Commit, Response, error)
+	Compare_ func(repo RepoSpec, base string, head string) (*CommitComparison, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockRepoCommitsService returns a new MockRepoCommitsService
+// that records calls to its methods.
+func NewMockRepoCommitsService() *MockRepoCommitsService {
+	return &MockRepoCommitsService{Calls: &MockCalls{}}
+}
+
+func (s MockRepoCommitsService) Get(commit CommitSpec) (*Commit, Response, error) {
+	s.Calls.record("Get", commit)
+	if s.Get_ == nil {
+		var r0 *Commit
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoCommitsService.Get")
+	}
+	return s.Get_(commit)
+}
+
+func (s MockRepoCommitsService) List(repo RepoSpec, opt *RepoListCommitsOptions) ([]*Commit, Response, error) {
+	s.Calls.record("List", repo, opt)
+	if s.List_ == nil {
+		var r0 []*Commit
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoCommitsService.List")
+	}
+	return s.List_(repo, opt)
+}
+
+func (s MockRepoCommitsService) Compare(repo RepoSpec, base string, head string) (*CommitComparison, Response, error) {
+	s.Calls.record("Compare", repo, base, head)
+	if s.Compare_ == nil {
+		var r0 *CommitComparison
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoCommitsService.Compare")
+	}
+	return s.Compare_(repo, base, head)
+}
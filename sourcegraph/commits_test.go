@@ -0,0 +1,135 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+)
+
+func TestRepoCommitsService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	commit := CommitSpec{Repo: RepoSpec{URI: "r.com/x"}, ID: "c"}
+	want := &Commit{Commit: &vcs.Commit{ID: "c", Message: "m"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCommit, map[string]string{"RepoSpec": "r.com/x", "Rev": "c"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	commit_, _, err := client.RepoCommits.Get(commit)
+	if err != nil {
+		t.Errorf("RepoCommits.Get returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(commit_, want) {
+		t.Errorf("RepoCommits.Get returned %+v, want %+v", commit_, want)
+	}
+}
+
+func TestRepoCommitsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Commit{{Commit: &vcs.Commit{ID: "c"}}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCommits, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Head": "master"})
+
+		writeJSON(w, want)
+	})
+
+	commits, _, err := client.RepoCommits.List(RepoSpec{URI: "r.com/x"}, &RepoListCommitsOptions{Head: "master"})
+	if err != nil {
+		t.Errorf("RepoCommits.List returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(commits, want) {
+		t.Errorf("RepoCommits.List returned %+v, want %+v", commits, want)
+	}
+}
+
+func TestRepoCommitsService_List_verification(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Commit{
+		{Commit: &vcs.Commit{ID: "c"}, Verification: &Verification{Verified: true, Reason: VerificationValid, Signer: "a <a@example.com>"}},
+		{Commit: &vcs.Commit{ID: "p"}, Verification: &Verification{Reason: VerificationUnsigned}},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCommits, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Head": "master", "IncludeVerification": "true"})
+
+		writeJSON(w, want)
+	})
+
+	commits, _, err := client.RepoCommits.List(RepoSpec{URI: "r.com/x"}, &RepoListCommitsOptions{Head: "master", IncludeVerification: true})
+	if err != nil {
+		t.Errorf("RepoCommits.List returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(commits, want) {
+		t.Errorf("RepoCommits.List returned %+v, want %+v", commits, want)
+	}
+}
+
+func TestRepoCommitsService_Compare(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &CommitComparison{
+		BaseCommitID: "b",
+		HeadCommitID: "h",
+		MergeBase:    "b",
+		Ahead:        1,
+		Commits:      []*Commit{{Commit: &vcs.Commit{ID: "h"}}},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCompareCommits, map[string]string{"RepoSpec": "r.com/x", "Rev": "feature/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Base": "master"})
+
+		writeJSON(w, want)
+	})
+
+	cmp, _, err := client.RepoCommits.Compare(RepoSpec{URI: "r.com/x"}, "master", "feature/x")
+	if err != nil {
+		t.Errorf("RepoCommits.Compare returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(cmp, want) {
+		t.Errorf("RepoCommits.Compare returned %+v, want %+v", cmp, want)
+	}
+}
@@ -0,0 +1,84 @@
+package sourcegraph
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is the Accept-Encoding header that Do sends with
+// requests that don't set one. Do decompresses the response itself
+// (see decompressBody), so that deflate responses are supported too
+// and MaxResponseBytes limits the decompressed size. If the Client has
+// middleware, the response is decompressed before the middleware sees
+// it (see decompressTransport).
+const acceptEncoding = "gzip, deflate"
+
+// decompressBody replaces resp's body with its decompressed contents
+// if resp has a gzip or deflate Content-Encoding. The decompressed
+// body's length is unknown, so resp.ContentLength is set to -1.
+func decompressBody(resp *http.Response) error {
+	var newReader func(io.Reader) (io.ReadCloser, error)
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		newReader = func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }
+	case "deflate":
+		// HTTP's deflate coding is zlib-wrapped (RFC 7230).
+		newReader = zlib.NewReader
+	default:
+		return nil
+	}
+
+	zr, err := newReader(resp.Body)
+	if err == io.EOF {
+		// Empty body (e.g., for a HEAD request or 204 No Content).
+		zr = nil
+	} else if err != nil {
+		resp.Body.Close()
+		return fmt.Errorf("decompressing %s response: %s", resp.Header.Get("Content-Encoding"), err)
+	}
+	if zr != nil {
+		resp.Body = &decompressedBody{zr: zr, rc: resp.Body}
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// decompressedBody reads the decompressed contents of a response body
+// and closes both the decompressor and the underlying body.
+type decompressedBody struct {
+	zr io.ReadCloser
+	rc io.ReadCloser
+}
+
+func (b *decompressedBody) Read(p []byte) (int, error) { return b.zr.Read(p) }
+
+func (b *decompressedBody) Close() error {
+	b.zr.Close()
+	return b.rc.Close()
+}
+
+// decompressTransport decompresses the responses to requests that Do
+// asked to be compressed (those whose Accept-Encoding is
+// acceptEncoding). It wraps a Client's original transport, beneath its
+// middleware, so that middleware (such as DebugLog) sees the same
+// response body that Do decodes.
+type decompressTransport struct{ next http.RoundTripper }
+
+// RoundTrip implements http.RoundTripper.
+func (t *decompressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil || req.Header.Get("Accept-Encoding") != acceptEncoding {
+		return resp, err
+	}
+	if err := decompressBody(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
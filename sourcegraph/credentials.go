@@ -0,0 +1,65 @@
+package sourcegraph
+
+import (
+	"net/http"
+
+	"github.com/fossas/go-sourcegraph/auth"
+)
+
+// A CredentialProvider adds credentials to each API request made by a
+// Client (see Client.Credentials). It is consulted for every request,
+// so it may return different credentials over time (for example, when
+// an OAuth2 access token is refreshed). Implementations must be safe
+// for concurrent use.
+type CredentialProvider interface {
+	// SetCredentials adds credentials to req (typically by setting its
+	// Authorization header).
+	SetCredentials(req *http.Request) error
+}
+
+// StaticToken returns a CredentialProvider that authenticates requests
+// with the given access token (as "Authorization: Bearer
+// <accessToken>").
+func StaticToken(accessToken string) CredentialProvider {
+	return TokenSourceCredentials(auth.StaticTokenSource(&auth.Token{AccessToken: accessToken}))
+}
+
+// TokenSourceCredentials returns a CredentialProvider that
+// authenticates requests with the tokens returned by ts. To refresh
+// OAuth2 tokens as they expire, use a TokenSource returned by an
+// auth.OIDCProvider (with a context that outlives the client, since
+// the TokenSource uses it for every refresh):
+//
+//	ts := provider.TokenSource(context.Background(), oauthClient, savedToken)
+//	client.Credentials = sourcegraph.TokenSourceCredentials(ts)
+func TokenSourceCredentials(ts auth.TokenSource) CredentialProvider {
+	return tokenSourceCredentials{ts}
+}
+
+type tokenSourceCredentials struct{ ts auth.TokenSource }
+
+func (c tokenSourceCredentials) SetCredentials(req *http.Request) error {
+	tok, err := c.ts.Token()
+	if err != nil {
+		return err
+	}
+	tok.SetAuthHeader(req)
+	return nil
+}
+
+// KeyFileCredentials returns a CredentialProvider that authenticates
+// requests with the token in the key file at path, which is reread
+// when it changes (see auth.FileTokenSource).
+func KeyFileCredentials(path string) CredentialProvider {
+	return TokenSourceCredentials(auth.FileTokenSource(path))
+}
+
+// setCredentials adds c.Credentials (if set) to req, unless req is
+// sent to a host other than the API server's (so that credentials
+// aren't leaked to other hosts).
+func (c *Client) setCredentials(req *http.Request) error {
+	if c.Credentials == nil || c.BaseURL == nil || req.URL.Host != c.BaseURL.Host {
+		return nil
+	}
+	return c.Credentials.SetCredentials(req)
+}
@@ -0,0 +1,67 @@
+package sourcegraph
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/auth"
+)
+
+type countingTokenSource struct{ n int }
+
+func (s *countingTokenSource) Token() (*auth.Token, error) {
+	s.n++
+	if s.n > 2 {
+		return nil, errors.New("refresh failed")
+	}
+	return &auth.Token{AccessToken: string('0' + byte(s.n))}, nil
+}
+
+func TestClient_Credentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var got []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+	})
+
+	ts := &countingTokenSource{}
+	client.Credentials = TokenSourceCredentials(ts)
+	for i := 0; i < 2; i++ {
+		req, err := client.NewRequest("GET", server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Do(req, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []string{"Bearer 1", "Bearer 2"}; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got Authorization headers %q, want %q", got, want)
+	}
+
+	if _, err := client.NewRequest("GET", server.URL, nil); err == nil {
+		t.Error("err == nil when the token source fails")
+	}
+
+	// Credentials must not be sent to other hosts.
+	req, err := client.NewRequest("GET", "https://example.com/x", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := req.Header.Get("Authorization"); h != "" {
+		t.Errorf("got Authorization header %q for another host, want none", h)
+	}
+}
+
+func TestStaticToken(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://example.com", nil)
+	if err := StaticToken("t").SetCredentials(req); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := req.Header.Get("Authorization"), "Bearer t"; got != want {
+		t.Errorf("got Authorization header %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,225 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A DebugLogger receives the logs written by DebugLog. *log.Logger
+// implements DebugLogger.
+type DebugLogger interface {
+	Printf(format string, v ...interface{})
+}
+
+// DefaultDebugBodyBytes is the number of bytes of each request and
+// response body that DebugLog logs if DebugOptions.MaxBodyBytes is
+// zero.
+const DefaultDebugBodyBytes = 4096
+
+// DebugOptions configures DebugLog.
+type DebugOptions struct {
+	// MaxBodyBytes is the number of bytes of each request and response
+	// body to log; longer bodies are truncated. If zero,
+	// DefaultDebugBodyBytes is used. If negative, bodies are not
+	// logged.
+	MaxBodyBytes int
+}
+
+// redacted replaces the values of headers, query parameters, and body
+// fields that contain credentials in debug logs.
+const redacted = "[redacted]"
+
+// debugRedactedHeaders are the headers whose values DebugLog redacts.
+var debugRedactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// debugSecretName matches the names of query parameters and body
+// fields whose values DebugLog redacts.
+var debugSecretName = regexp.MustCompile(`(?i)token|password|secret|credential|api_?key`)
+
+// debugSecretField matches JSON object fields and form values whose
+// names match debugSecretName, capturing the name. A JSON string value
+// may be unterminated (if the body was truncated in the middle of it).
+var debugSecretField = regexp.MustCompile(`(?i)("[^"]*(?:token|password|secret|credential|api_?key)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*(?:"|$)|\b([\w.]*(?:token|password|secret|credential|api_?key)[\w.]*=)[^&\s]*`)
+
+// DebugLog returns a Middleware that logs each request sent by a
+// Client and its response (method, URL, headers, status, time until
+// the response headers were received, and the first bytes of each
+// body) to logger. The values of the Authorization, Proxy-Authorization,
+// and cookie headers, and of query parameters and JSON and form body
+// fields whose names contain "token", "password", "secret", or
+// similar words, are redacted. It is intended for debugging
+// integrations, not for production use:
+//
+//	client.Use(sourcegraph.DebugLog(log.New(os.Stderr, "sourcegraph: ", log.LstdFlags), nil))
+//
+// A response body is logged when it is closed (so that logging doesn't
+// delay streaming downloads), and only the bytes read from it by then
+// are logged.
+func DebugLog(logger DebugLogger, opt *DebugOptions) Middleware {
+	maxBody := DefaultDebugBodyBytes
+	if opt != nil && opt.MaxBodyBytes != 0 {
+		maxBody = opt.MaxBodyBytes
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			var buf bytes.Buffer
+			fmt.Fprintf(&buf, "--> %s %s", req.Method, redactURL(req.URL))
+			writeDebugHeaders(&buf, req.Header)
+			if maxBody > 0 && req.Body != nil && req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					b, _ := ioutil.ReadAll(io.LimitReader(body, int64(maxBody)+1))
+					body.Close()
+					writeDebugBody(&buf, b, maxBody)
+				}
+			}
+			logger.Printf("%s", buf.String())
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			elapsed := time.Since(start)
+			if err != nil {
+				logger.Printf("<-- %s %s: error after %s: %s", req.Method, redactURL(req.URL), elapsed, err)
+				return resp, err
+			}
+
+			buf.Reset()
+			fmt.Fprintf(&buf, "<-- %s %s %s (%s)", resp.Status, req.Method, redactURL(req.URL), elapsed)
+			writeDebugHeaders(&buf, resp.Header)
+			logger.Printf("%s", buf.String())
+
+			if maxBody > 0 && resp.Body != nil {
+				resp.Body = &debugBody{
+					ReadCloser: resp.Body,
+					max:        maxBody,
+					logf: func(b []byte) {
+						var buf bytes.Buffer
+						fmt.Fprintf(&buf, "<-- body of %s %s", req.Method, redactURL(req.URL))
+						writeDebugBody(&buf, b, maxBody)
+						logger.Printf("%s", buf.String())
+					},
+				}
+			}
+			return resp, nil
+		})
+	}
+}
+
+// WithDebug returns a copy of c that logs its requests and responses
+// to logger (see DebugLog).
+func (c *Client) WithDebug(logger DebugLogger, opt *DebugOptions) *Client {
+	c2 := c.Clone()
+	c2.Use(DebugLog(logger, opt))
+	return c2
+}
+
+// debugBody is a response body that records the first max bytes read
+// from it (plus one, to detect truncation) and logs them when it is
+// closed.
+type debugBody struct {
+	io.ReadCloser
+	max  int
+	logf func([]byte)
+
+	buf  bytes.Buffer
+	once sync.Once
+}
+
+func (b *debugBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := b.max + 1 - b.buf.Len(); room > 0 {
+		if room > n {
+			room = n
+		}
+		b.buf.Write(p[:room])
+	}
+	return n, err
+}
+
+func (b *debugBody) Close() error {
+	b.once.Do(func() { b.logf(b.buf.Bytes()) })
+	return b.ReadCloser.Close()
+}
+
+// redactURL returns u as a string with the values of query parameters
+// that may contain credentials redacted.
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" && u.User == nil {
+		return u.String()
+	}
+	u2 := *u
+	if u2.User != nil {
+		u2.User = url.User(u2.User.Username())
+	}
+	q := u2.Query()
+	for k := range q {
+		if debugSecretName.MatchString(k) {
+			q[k] = []string{redacted}
+		}
+	}
+	u2.RawQuery = q.Encode()
+	return u2.String()
+}
+
+// writeDebugHeaders writes h (with credentials, and headers whose
+// names match debugSecretName, redacted) to buf, one header per line,
+// sorted by name.
+func writeDebugHeaders(buf *bytes.Buffer, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(h[name], ", ")
+		if debugRedactedHeaders[http.CanonicalHeaderKey(name)] || debugSecretName.MatchString(name) {
+			value = redacted
+		}
+		fmt.Fprintf(buf, "\n    %s: %s", name, value)
+	}
+}
+
+// writeDebugBody writes body (truncated to max bytes, with
+// credentials redacted) to buf.
+func writeDebugBody(buf *bytes.Buffer, body []byte, max int) {
+	if len(body) == 0 {
+		return
+	}
+	truncated := len(body) > max
+	if truncated {
+		body = body[:max]
+	}
+	s := RedactSecrets(string(body))
+	fmt.Fprintf(buf, "\n    %s", strings.TrimRight(s, "\n"))
+	if truncated {
+		buf.WriteString(" [truncated]")
+	}
+}
+
+// RedactSecrets returns s (a JSON or form-encoded body, or a URL or
+// query string) with the values of fields and query parameters whose
+// names contain "token", "password", "secret", or similar words
+// replaced, as DebugLog does. It can be used to scrub other recordings
+// of API traffic.
+func RedactSecrets(s string) string {
+	return debugSecretField.ReplaceAllStringFunc(s, func(m string) string {
+		sub := debugSecretField.FindStringSubmatch(m)
+		if sub[1] != "" {
+			return sub[1] + `"` + redacted + `"`
+		}
+		return sub[2] + redacted
+	})
+}
@@ -0,0 +1,86 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+type testDebugLogger struct{ logs []string }
+
+func (l *testDebugLogger) Printf(format string, v ...interface{}) {
+	l.logs = append(l.logs, fmt.Sprintf(format, v...))
+}
+
+func TestDebugLog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(urlPath(t, router.UserTokensCreate, map[string]string{"UserSpec": "u"}), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=s3cr3t")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"ID":"1","Token":"t0k3n","Note":"n"}`)
+	})
+
+	var logger testDebugLogger
+	c := client.WithDebug(&logger, nil)
+	c.Credentials = StaticToken("mytoken")
+	if _, _, err := c.Tokens.Create(UserSpec{Login: "u"}, &AccessTokenCreateRequest{Note: "n", Scopes: []string{"read"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logger.logs) != 3 {
+		t.Fatalf("got %d logs, want 3 (request, response, response body):\n%s", len(logger.logs), strings.Join(logger.logs, "\n"))
+	}
+	all := strings.Join(logger.logs, "\n")
+	for _, secret := range []string{"mytoken", "t0k3n", "s3cr3t"} {
+		if strings.Contains(all, secret) {
+			t.Errorf("logs contain secret %q:\n%s", secret, all)
+		}
+	}
+	for i, want := range []string{"--> POST ", "<-- 201 Created POST ", "<-- body of POST "} {
+		if !strings.HasPrefix(logger.logs[i], want) {
+			t.Errorf("log %d: got %q, want prefix %q", i, logger.logs[i], want)
+		}
+	}
+	for _, want := range []string{"Authorization: [redacted]", `"Note":"n"`, `"Token":"[redacted]"`} {
+		if !strings.Contains(all, want) {
+			t.Errorf("logs don't contain %q:\n%s", want, all)
+		}
+	}
+
+	// The original client doesn't log.
+	logger.logs = nil
+	if _, _, err := client.Tokens.Create(UserSpec{Login: "u"}, &AccessTokenCreateRequest{Note: "n", Scopes: []string{"read"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.logs) != 0 {
+		t.Errorf("original client logged %d messages", len(logger.logs))
+	}
+}
+
+func TestWriteDebugBody(t *testing.T) {
+	tests := []struct {
+		body string
+		max  int
+		want string
+	}{
+		{`{"a":"b"}`, 100, `{"a":"b"}`},
+		{`{"access_token":"x\"y","b":1}`, 100, `{"access_token":"[redacted]","b":1}`},
+		{`grant_type=refresh&refresh_token=abc&x=1`, 100, `grant_type=refresh&refresh_token=[redacted]&x=1`},
+		{`{"Password":"abcdefgh"}`, 16, `{"Password":"[redacted]" [truncated]`},
+		{`0123456789`, 4, `0123 [truncated]`},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		writeDebugBody(&buf, []byte(test.body), test.max)
+		if got := strings.TrimPrefix(buf.String(), "\n    "); got != test.want {
+			t.Errorf("%q (max %d): got %q, want %q", test.body, test.max, got, test.want)
+		}
+	}
+}
@@ -21,12 +21,29 @@ type DefsService interface {
 	// Get fetches a def.
 	Get(def DefSpec, opt *DefGetOptions) (*Def, Response, error)
 
+	// GetMulti fetches multiple defs in a single request. The returned
+	// slice has an element for each element of defs (which is nil if
+	// the def doesn't exist). If the server doesn't support batch
+	// requests, the defs are fetched with concurrent Get requests (at
+	// most DefaultBatchConcurrency at once).
+	GetMulti(defs []DefSpec, opt *DefGetOptions) ([]*Def, Response, error)
+
 	// List defs.
 	List(opt *DefListOptions) ([]*Def, Response, error)
 
-	// ListRefs lists references to def.
+	// ListRefs lists references to def, optionally restricted to
+	// refs in a set of repositories or files (see
+	// DefListRefsOptions).
 	ListRefs(def DefSpec, opt *DefListRefsOptions) ([]*Ref, Response, error)
 
+	// ListCallers lists the defs (in any repository, unless
+	// restricted by opt) whose bodies call def, with the call sites.
+	ListCallers(def DefSpec, opt *DefListCallersOptions) ([]*DefCall, Response, error)
+
+	// ListCallees lists the defs (in any repository) that def's body
+	// calls, with the call sites.
+	ListCallees(def DefSpec, opt *DefListCalleesOptions) ([]*DefCall, Response, error)
+
 	// ListExamples lists examples for def.
 	ListExamples(def DefSpec, opt *DefListExamplesOptions) ([]*Example, Response, error)
 
@@ -164,23 +181,51 @@ type DefGetOptions struct {
 }
 
 func (s *defsService) Get(def DefSpec, opt *DefGetOptions) (*Def, Response, error) {
-	url, err := s.client.URL(router.Def, def.RouteVars(), opt)
+	var def_ *Def
+	resp, err := s.client.getCached(specCacheDefs, router.Def, def.RouteVars(), opt, &def_)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
+	return def_, resp, nil
+}
+
+// defsBatchRequest is the body of a DefsBatch request.
+type defsBatchRequest struct {
+	Defs    []DefSpec
+	Options *DefGetOptions `json:",omitempty"`
+}
+
+func (s *defsService) GetMulti(defs []DefSpec, opt *DefGetOptions) ([]*Def, Response, error) {
+	if len(defs) == 0 {
+		return nil, nil, nil
 	}
 
-	var def_ *Def
-	resp, err := s.client.Do(req, &def_)
-	if err != nil {
+	var defs_ []*Def
+	resp, err := s.client.DoCreate(router.DefsBatch, nil, &defsBatchRequest{Defs: defs, Options: opt}, &defs_)
+	if err == nil {
+		if len(defs_) != len(defs) {
+			return nil, resp, fmt.Errorf("DefsService.GetMulti: got %d defs, want %d", len(defs_), len(defs))
+		}
+		return defs_, resp, nil
+	}
+	if !IsNotSupported(err) {
 		return nil, resp, err
 	}
 
-	return def_, resp, nil
+	defs_ = make([]*Def, len(defs))
+	resp, err = fanOut(len(defs), 0, func(i int) (Response, error) {
+		def, resp, err := s.Get(defs[i], opt)
+		if IsNotFound(err) {
+			return resp, nil
+		}
+		defs_[i] = def
+		return resp, err
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	return defs_, nil, nil
 }
 
 // DefListOptions specifies options for DefsService.List.
@@ -321,18 +366,8 @@ func (o *DefListOptions) DefFilters() []store.DefFilter {
 }
 
 func (s *defsService) List(opt *DefListOptions) ([]*Def, Response, error) {
-	url, err := s.client.URL(router.Defs, nil, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var defs []*Def
-	resp, err := s.client.Do(req, &defs)
+	resp, err := s.client.DoList(router.Defs, nil, opt, &defs)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -352,30 +387,94 @@ func (vs Refs) Len() int           { return len(vs) }
 func (vs Refs) Swap(i, j int)      { vs[i], vs[j] = vs[j], vs[i] }
 func (vs Refs) Less(i, j int) bool { return vs[i].sortKey() < vs[j].sortKey() }
 
+// DefListRefsOptions specifies options for DefsService.ListRefs.
 type DefListRefsOptions struct {
 	Authorship bool   `url:",omitempty"` // whether to fetch authorship info about the refs
 	Repo       string `url:",omitempty"` // only fetch refs from this repository URI
+
+	// Repos, if set, restricts the list to refs from these
+	// repositories (in addition to Repo, if set). It may be used to
+	// find references to a def across a set of repositories in a
+	// single request.
+	Repos []string `url:",comma,omitempty"`
+
+	// Files, if set, restricts the list to refs in these files (paths
+	// relative to the root of the ref's repository).
+	Files []string `url:",comma,omitempty"`
+
+	SortOptions
 	ListOptions
 }
 
 func (s *defsService) ListRefs(def DefSpec, opt *DefListRefsOptions) ([]*Ref, Response, error) {
-	url, err := s.client.URL(router.DefRefs, def.RouteVars(), opt)
+	var defRefs []*Ref
+	resp, err := s.client.DoList(router.DefRefs, def.RouteVars(), opt, &defRefs)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
+	return defRefs, resp, nil
+}
+
+// A DefCall is an edge in a call graph, returned by
+// DefsService.ListCallers and DefsService.ListCallees.
+type DefCall struct {
+	// Def is the caller (for ListCallers) or the callee (for
+	// ListCallees). It may be in a different repository than the def
+	// whose callers or callees were listed.
+	Def *Def
+
+	// CallSites are the refs in the caller's body that call the
+	// callee.
+	CallSites []*Ref `json:",omitempty"`
+}
+
+// DefListCallersOptions specifies options for DefsService.ListCallers.
+type DefListCallersOptions struct {
+	// Repo, if set, restricts the list to callers in this repository
+	// (URI).
+	Repo string `url:",omitempty" json:",omitempty"`
+
+	// IncludeCallSites is whether to include the call sites
+	// (DefCall.CallSites).
+	IncludeCallSites bool `url:",omitempty" json:",omitempty"`
+
+	SortOptions
+	ListOptions
+}
+
+// DefListCalleesOptions specifies options for DefsService.ListCallees.
+type DefListCalleesOptions struct {
+	// Repo, if set, restricts the list to callees defined in this
+	// repository (URI).
+	Repo string `url:",omitempty" json:",omitempty"`
+
+	// IncludeCallSites is whether to include the call sites
+	// (DefCall.CallSites).
+	IncludeCallSites bool `url:",omitempty" json:",omitempty"`
+
+	SortOptions
+	ListOptions
+}
+
+func (s *defsService) ListCallers(def DefSpec, opt *DefListCallersOptions) ([]*DefCall, Response, error) {
+	var calls []*DefCall
+	resp, err := s.client.DoList(router.DefCallers, def.RouteVars(), opt, &calls)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	var defRefs []*Ref
-	resp, err := s.client.Do(req, &defRefs)
+	return calls, resp, nil
+}
+
+func (s *defsService) ListCallees(def DefSpec, opt *DefListCalleesOptions) ([]*DefCall, Response, error) {
+	var calls []*DefCall
+	resp, err := s.client.DoList(router.DefCallees, def.RouteVars(), opt, &calls)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return defRefs, resp, nil
+	return calls, resp, nil
 }
 
 // Example is a usage example of a def.
@@ -383,9 +482,15 @@ type Example struct {
 	graph.Ref
 
 	// SrcHTML is the formatted HTML source code of the example, with links to
-	// definitions.
+	// definitions. It is only set if DefListExamplesOptions.Formatted is true.
+	// The server sanitizes it, so it is safe to embed in a page.
 	SrcHTML template.HTML `json:",omitempty"`
 
+	// Src is the plain-text source code of the example (including its
+	// context lines). It is only set if DefListExamplesOptions.Formatted
+	// is false.
+	Src string `json:",omitempty"`
+
 	// SourceCode contains the parsed source for this example, if requested via
 	// DefListExamplesOptions.
 	SourceCode *SourceCode `json:",omitempty"`
@@ -409,8 +514,16 @@ func (vs Examples) Less(i, j int) bool { return vs[i].sortKey() < vs[j].sortKey(
 
 // DefListExamplesOptions specifies options for DefsService.ListExamples.
 type DefListExamplesOptions struct {
+	// Formatted is whether to return each example's source code as
+	// HTML (in SrcHTML) instead of plain text (in Src).
 	Formatted bool `url:",omitempty"`
 
+	// ContextLines is the number of lines of source code before and
+	// after each example's ref to include (which determines the
+	// example's StartLine and EndLine). If zero, the server's default
+	// is used.
+	ContextLines int `url:",omitempty"`
+
 	// Filter by a specific Repo URI
 	Repo string `url:",omitempty"`
 
@@ -421,22 +534,13 @@ type DefListExamplesOptions struct {
 	// the contents.
 	TokenizedSource bool `url:",omitempty"`
 
+	SortOptions
 	ListOptions
 }
 
 func (s *defsService) ListExamples(def DefSpec, opt *DefListExamplesOptions) ([]*Example, Response, error) {
-	url, err := s.client.URL(router.DefExamples, def.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var examples []*Example
-	resp, err := s.client.Do(req, &examples)
+	resp, err := s.client.DoList(router.DefExamples, def.RouteVars(), opt, &examples)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -482,22 +586,13 @@ type AugmentedDefAuthor struct {
 
 // DefListAuthorsOptions specifies options for DefsService.ListAuthors.
 type DefListAuthorsOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *defsService) ListAuthors(def DefSpec, opt *DefListAuthorsOptions) ([]*AugmentedDefAuthor, Response, error) {
-	url, err := s.client.URL(router.DefAuthors, def.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var authors []*AugmentedDefAuthor
-	resp, err := s.client.Do(req, &authors)
+	resp, err := s.client.DoList(router.DefAuthors, def.RouteVars(), opt, &authors)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -522,22 +617,13 @@ type AugmentedDefClient struct {
 
 // DefListClientsOptions specifies options for DefsService.ListClients.
 type DefListClientsOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *defsService) ListClients(def DefSpec, opt *DefListClientsOptions) ([]*AugmentedDefClient, Response, error) {
-	url, err := s.client.URL(router.DefClients, def.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var clients []*AugmentedDefClient
-	resp, err := s.client.Do(req, &clients)
+	resp, err := s.client.DoList(router.DefClients, def.RouteVars(), opt, &clients)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -557,22 +643,13 @@ type AugmentedDefDependent struct {
 
 // DefListDependentsOptions specifies options for DefsService.ListDependents.
 type DefListDependentsOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *defsService) ListDependents(def DefSpec, opt *DefListDependentsOptions) ([]*AugmentedDefDependent, Response, error) {
-	url, err := s.client.URL(router.DefDependents, def.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var dependents []*AugmentedDefDependent
-	resp, err := s.client.Do(req, &dependents)
+	resp, err := s.client.DoList(router.DefDependents, def.RouteVars(), opt, &dependents)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -581,22 +658,13 @@ func (s *defsService) ListDependents(def DefSpec, opt *DefListDependentsOptions)
 }
 
 type DefListVersionsOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *defsService) ListVersions(def DefSpec, opt *DefListVersionsOptions) ([]*Def, Response, error) {
-	url, err := s.client.URL(router.DefVersions, def.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var defVersions []*Def
-	resp, err := s.client.Do(req, &defVersions)
+	resp, err := s.client.DoList(router.DefVersions, def.RouteVars(), opt, &defVersions)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -1,42 +1,136 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 type MockDefsService struct {
 	Get_            func(def DefSpec, opt *DefGetOptions) (*Def, Response, error)
+	GetMulti_       func(defs []DefSpec, opt *DefGetOptions) ([]*Def, Response, error)
 	List_           func(opt *DefListOptions) ([]*Def, Response, error)
 	ListRefs_       func(def DefSpec, opt *DefListRefsOptions) ([]*Ref, Response, error)
+	ListCallers_    func(def DefSpec, opt *DefListCallersOptions) ([]*DefCall, Response, error)
+	ListCallees_    func(def DefSpec, opt *DefListCalleesOptions) ([]*DefCall, Response, error)
 	ListExamples_   func(def DefSpec, opt *DefListExamplesOptions) ([]*Example, Response, error)
 	ListAuthors_    func(def DefSpec, opt *DefListAuthorsOptions) ([]*AugmentedDefAuthor, Response, error)
 	ListClients_    func(def DefSpec, opt *DefListClientsOptions) ([]*AugmentedDefClient, Response, error)
 	ListDependents_ func(def DefSpec, opt *DefListDependentsOptions) ([]*AugmentedDefDependent, Response, error)
 	ListVersions_   func(def DefSpec, opt *DefListVersionsOptions) ([]*Def, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockDefsService returns a new MockDefsService
+// that records calls to its methods.
+func NewMockDefsService() *MockDefsService {
+	return &MockDefsService{Calls: &MockCalls{}}
 }
 
 func (s MockDefsService) Get(def DefSpec, opt *DefGetOptions) (*Def, Response, error) {
+	s.Calls.record("Get", def, opt)
+	if s.Get_ == nil {
+		var r0 *Def
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.Get")
+	}
 	return s.Get_(def, opt)
 }
 
-func (s MockDefsService) List(opt *DefListOptions) ([]*Def, Response, error) { return s.List_(opt) }
+func (s MockDefsService) GetMulti(defs []DefSpec, opt *DefGetOptions) ([]*Def, Response, error) {
+	s.Calls.record("GetMulti", defs, opt)
+	if s.GetMulti_ == nil {
+		var r0 []*Def
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.GetMulti")
+	}
+	return s.GetMulti_(defs, opt)
+}
+
+func (s MockDefsService) List(opt *DefListOptions) ([]*Def, Response, error) {
+	s.Calls.record("List", opt)
+	if s.List_ == nil {
+		var r0 []*Def
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.List")
+	}
+	return s.List_(opt)
+}
 
 func (s MockDefsService) ListRefs(def DefSpec, opt *DefListRefsOptions) ([]*Ref, Response, error) {
+	s.Calls.record("ListRefs", def, opt)
+	if s.ListRefs_ == nil {
+		var r0 []*Ref
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.ListRefs")
+	}
 	return s.ListRefs_(def, opt)
 }
 
+func (s MockDefsService) ListCallers(def DefSpec, opt *DefListCallersOptions) ([]*DefCall, Response, error) {
+	s.Calls.record("ListCallers", def, opt)
+	if s.ListCallers_ == nil {
+		var r0 []*DefCall
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.ListCallers")
+	}
+	return s.ListCallers_(def, opt)
+}
+
+func (s MockDefsService) ListCallees(def DefSpec, opt *DefListCalleesOptions) ([]*DefCall, Response, error) {
+	s.Calls.record("ListCallees", def, opt)
+	if s.ListCallees_ == nil {
+		var r0 []*DefCall
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.ListCallees")
+	}
+	return s.ListCallees_(def, opt)
+}
+
 func (s MockDefsService) ListExamples(def DefSpec, opt *DefListExamplesOptions) ([]*Example, Response, error) {
+	s.Calls.record("ListExamples", def, opt)
+	if s.ListExamples_ == nil {
+		var r0 []*Example
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.ListExamples")
+	}
 	return s.ListExamples_(def, opt)
 }
 
 func (s MockDefsService) ListAuthors(def DefSpec, opt *DefListAuthorsOptions) ([]*AugmentedDefAuthor, Response, error) {
+	s.Calls.record("ListAuthors", def, opt)
+	if s.ListAuthors_ == nil {
+		var r0 []*AugmentedDefAuthor
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.ListAuthors")
+	}
 	return s.ListAuthors_(def, opt)
 }
 
 func (s MockDefsService) ListClients(def DefSpec, opt *DefListClientsOptions) ([]*AugmentedDefClient, Response, error) {
+	s.Calls.record("ListClients", def, opt)
+	if s.ListClients_ == nil {
+		var r0 []*AugmentedDefClient
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.ListClients")
+	}
 	return s.ListClients_(def, opt)
 }
 
 func (s MockDefsService) ListDependents(def DefSpec, opt *DefListDependentsOptions) ([]*AugmentedDefDependent, Response, error) {
+	s.Calls.record("ListDependents", def, opt)
+	if s.ListDependents_ == nil {
+		var r0 []*AugmentedDefDependent
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.ListDependents")
+	}
 	return s.ListDependents_(def, opt)
 }
 
 func (s MockDefsService) ListVersions(def DefSpec, opt *DefListVersionsOptions) ([]*Def, Response, error) {
+	s.Calls.record("ListVersions", def, opt)
+	if s.ListVersions_ == nil {
+		var r0 []*Def
+		var r1 Response
+		return r0, r1, mockNotImplemented("DefsService.ListVersions")
+	}
 	return s.ListVersions_(def, opt)
 }
@@ -38,6 +38,71 @@ func TestDefsService_Get(t *testing.T) {
 	}
 }
 
+func TestDefsService_GetMulti(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Def{{Def: graph.Def{Name: "a"}}, nil}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.DefsBatch, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Defs":[{"Repo":"r.com/x","CommitID":"","UnitType":"t","Unit":"u","Path":"a"},{"Repo":"r.com/x","CommitID":"","UnitType":"t","Unit":"u","Path":"b"}]}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	defs, _, err := client.Defs.GetMulti([]DefSpec{
+		{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "a"},
+		{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "b"},
+	}, nil)
+	if err != nil {
+		t.Errorf("Defs.GetMulti returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(defs, want) {
+		t.Errorf("Defs.GetMulti returned %+v, want %+v", defs, want)
+	}
+}
+
+func TestDefsService_GetMulti_fanOut(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// The batch route isn't handled, so the server responds as if it
+	// doesn't support it.
+	for _, path := range []string{"a", "b"} {
+		path := path
+		mux.HandleFunc(urlPath(t, router.Def, map[string]string{"RepoSpec": "r.com/x", "UnitType": "t", "Unit": "u", "Path": path}), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			if path == "b" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"Message":"def not found"}`))
+				return
+			}
+			writeJSON(w, &Def{Def: graph.Def{Name: path}})
+		})
+	}
+
+	defs, _, err := client.Defs.GetMulti([]DefSpec{
+		{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "a"},
+		{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "b"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Defs.GetMulti returned error: %v", err)
+	}
+
+	if want := []*Def{{Def: graph.Def{Name: "a"}}, nil}; !reflect.DeepEqual(defs, want) {
+		t.Errorf("Defs.GetMulti returned %+v, want %+v", defs, want)
+	}
+}
+
 func TestDefsService_List(t *testing.T) {
 	setup()
 	defer teardown()
@@ -96,12 +161,16 @@ func TestDefsService_ListRefs(t *testing.T) {
 	mux.HandleFunc(urlPath(t, router.DefRefs, map[string]string{"RepoSpec": "r.com/x", "UnitType": "t", "Unit": "u", "Path": "p"}), func(w http.ResponseWriter, r *http.Request) {
 		called = true
 		testMethod(t, r, "GET")
-		testFormValues(t, r, values{"Authorship": "true"})
+		testFormValues(t, r, values{"Authorship": "true", "Repos": "r.com/x,r2.com/y", "Files": "a.go,b/c.go"})
 
 		writeJSON(w, want)
 	})
 
-	refs, _, err := client.Defs.ListRefs(DefSpec{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "p"}, &DefListRefsOptions{Authorship: true})
+	refs, _, err := client.Defs.ListRefs(DefSpec{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "p"}, &DefListRefsOptions{
+		Authorship: true,
+		Repos:      []string{"r.com/x", "r2.com/y"},
+		Files:      []string{"a.go", "b/c.go"},
+	})
 	if err != nil {
 		t.Errorf("Defs.ListRefs returned error: %v", err)
 	}
@@ -115,6 +184,64 @@ func TestDefsService_ListRefs(t *testing.T) {
 	}
 }
 
+func TestDefsService_ListCallers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*DefCall{{Def: &Def{Def: graph.Def{DefKey: graph.DefKey{Repo: "r2.com/y", Path: "q"}}}, CallSites: []*Ref{{Ref: graph.Ref{File: "f"}}}}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.DefCallers, map[string]string{"RepoSpec": "r.com/x", "UnitType": "t", "Unit": "u", "Path": "p"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"IncludeCallSites": "true", "PerPage": "20"})
+
+		writeJSON(w, want)
+	})
+
+	calls, _, err := client.Defs.ListCallers(DefSpec{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "p"}, &DefListCallersOptions{IncludeCallSites: true, ListOptions: ListOptions{PerPage: 20}})
+	if err != nil {
+		t.Errorf("Defs.ListCallers returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Defs.ListCallers returned %+v, want %+v", calls, want)
+	}
+}
+
+func TestDefsService_ListCallees(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*DefCall{{Def: &Def{Def: graph.Def{DefKey: graph.DefKey{Repo: "r.com/x", Path: "q"}}}}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.DefCallees, map[string]string{"RepoSpec": "r.com/x", "UnitType": "t", "Unit": "u", "Path": "p"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Repo": "r.com/x"})
+
+		writeJSON(w, want)
+	})
+
+	calls, _, err := client.Defs.ListCallees(DefSpec{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "p"}, &DefListCalleesOptions{Repo: "r.com/x"})
+	if err != nil {
+		t.Errorf("Defs.ListCallees returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Defs.ListCallees returned %+v, want %+v", calls, want)
+	}
+}
+
 func TestDefsService_ListExamples(t *testing.T) {
 	setup()
 	defer teardown()
@@ -143,6 +270,35 @@ func TestDefsService_ListExamples(t *testing.T) {
 	}
 }
 
+func TestDefsService_ListExamples_plainText(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Example{{Ref: graph.Ref{Repo: "r2.com/y", File: "f"}, Src: "a\nb(p)\nc", StartLine: 9, EndLine: 11}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.DefExamples, map[string]string{"RepoSpec": "r.com/x", "UnitType": "t", "Unit": "u", "Path": "p"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"ContextLines": "1"})
+
+		writeJSON(w, want)
+	})
+
+	examples, _, err := client.Defs.ListExamples(DefSpec{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "p"}, &DefListExamplesOptions{ContextLines: 1})
+	if err != nil {
+		t.Errorf("Defs.ListExamples returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(examples, want) {
+		t.Errorf("Defs.ListExamples returned %+v, want %+v", examples, want)
+	}
+}
+
 func TestDefsService_ListAuthors(t *testing.T) {
 	setup()
 	defer teardown()
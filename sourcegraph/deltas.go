@@ -25,6 +25,13 @@ type DeltasService interface {
 	// ListDefs lists definitions added/changed/deleted in a delta.
 	ListDefs(ds DeltaSpec, opt *DeltaListDefsOptions) (*DeltaDefs, Response, error)
 
+	// ListAPIChanges compares the exported definitions in the base
+	// and head of a delta and classifies each difference as an
+	// addition, a removal, or a signature change. It is intended for
+	// generating compatibility reports between two releases (e.g.,
+	// with the base and head revs set to two tags).
+	ListAPIChanges(ds DeltaSpec, opt *DeltaListAPIChangesOptions) (*DeltaAPIChanges, Response, error)
+
 	// ListDependencies lists dependencies added/changed/deleted in a
 	// delta.
 	ListDependencies(ds DeltaSpec, opt *DeltaListDependenciesOptions) (*DeltaDependencies, Response, error)
@@ -146,18 +153,8 @@ func (d *Delta) BaseAndHeadBuildsSuccessful() bool {
 type DeltaGetOptions struct{}
 
 func (s *deltasService) Get(ds DeltaSpec, opt *DeltaGetOptions) (*Delta, Response, error) {
-	url, err := s.client.URL(router.Delta, ds.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var delta *Delta
-	resp, err := s.client.Do(req, &delta)
+	resp, err := s.client.DoGet(router.Delta, ds.RouteVars(), opt, &delta)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -205,18 +202,8 @@ func deltaUnitLess(a, b *unit.SourceUnit) bool {
 type DeltaListUnitsOptions struct{}
 
 func (s *deltasService) ListUnits(ds DeltaSpec, opt *DeltaListUnitsOptions) ([]*UnitDelta, Response, error) {
-	url, err := s.client.URL(router.DeltaUnits, ds.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var units []*UnitDelta
-	resp, err := s.client.Do(req, &units)
+	resp, err := s.client.DoList(router.DeltaUnits, ds.RouteVars(), opt, &units)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -241,6 +228,7 @@ func (f DeltaFilter) DefFilters() []store.DefFilter {
 // DeltaListDefsOptions specifies options for ListDefs.
 type DeltaListDefsOptions struct {
 	DeltaFilter
+	SortOptions
 	ListOptions
 }
 
@@ -286,29 +274,82 @@ func deltaDefLess(a, b *Def) bool {
 }
 
 func (s *deltasService) ListDefs(ds DeltaSpec, opt *DeltaListDefsOptions) (*DeltaDefs, Response, error) {
-	url, err := s.client.URL(router.DeltaDefs, ds.RouteVars(), opt)
+	var defs *DeltaDefs
+	resp, err := s.client.DoGet(router.DeltaDefs, ds.RouteVars(), opt, &defs)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
+	return defs, resp, nil
+}
+
+// DeltaListAPIChangesOptions specifies options for ListAPIChanges.
+type DeltaListAPIChangesOptions struct {
+	DeltaFilter
+	SortOptions
+	ListOptions
+}
+
+// An APIChangeKind classifies how an exported definition differs
+// between the base and head of a delta.
+type APIChangeKind string
+
+const (
+	// APIChangeAdded means the def is exported in the head but not in
+	// the base.
+	APIChangeAdded APIChangeKind = "added"
+
+	// APIChangeRemoved means the def is exported in the base but not in
+	// the head (it was deleted or unexported).
+	APIChangeRemoved APIChangeKind = "removed"
+
+	// APIChangeSignatureChanged means the def is exported in both the
+	// base and head but its signature (type, parameters, etc.)
+	// changed.
+	APIChangeSignatureChanged APIChangeKind = "signature-changed"
+)
+
+// An APIChange is a difference in a single exported definition
+// between the base and head of a delta.
+type APIChange struct {
+	Kind APIChangeKind
+
+	DefDelta
+}
+
+// DeltaAPIChanges describes the differences in exported definitions
+// between the base and head of a delta.
+type DeltaAPIChanges struct {
+	Changes []*APIChange
+}
+
+// Compatible is whether the head is backward compatible with the
+// base; that is, no exported defs were removed and none had their
+// signatures changed. Nil entries in Changes are ignored.
+func (c *DeltaAPIChanges) Compatible() bool {
+	for _, ch := range c.Changes {
+		if ch != nil && ch.Kind != APIChangeAdded {
+			return false
+		}
 	}
+	return true
+}
 
-	var defs *DeltaDefs
-	resp, err := s.client.Do(req, &defs)
+func (s *deltasService) ListAPIChanges(ds DeltaSpec, opt *DeltaListAPIChangesOptions) (*DeltaAPIChanges, Response, error) {
+	var changes *DeltaAPIChanges
+	resp, err := s.client.DoGet(router.DeltaAPIChanges, ds.RouteVars(), opt, &changes)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return defs, resp, nil
+	return changes, resp, nil
 }
 
 // DeltaListDependenciesOptions specifies options for
 // ListDependencies.
 type DeltaListDependenciesOptions struct {
 	DeltaFilter
+	SortOptions
 	ListOptions
 }
 
@@ -323,18 +364,8 @@ type DeltaDependencies struct {
 }
 
 func (s *deltasService) ListDependencies(ds DeltaSpec, opt *DeltaListDependenciesOptions) (*DeltaDependencies, Response, error) {
-	url, err := s.client.URL(router.DeltaDependencies, ds.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var dependencies *DeltaDependencies
-	resp, err := s.client.Do(req, &dependencies)
+	resp, err := s.client.DoGet(router.DeltaDependencies, ds.RouteVars(), opt, &dependencies)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -411,18 +442,8 @@ func (d *DeltaFiles) DiffStat() diff.Stat {
 }
 
 func (s *deltasService) ListFiles(ds DeltaSpec, opt *DeltaListFilesOptions) (*DeltaFiles, Response, error) {
-	url, err := s.client.URL(router.DeltaFiles, ds.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var files *DeltaFiles
-	resp, err := s.client.Do(req, &files)
+	resp, err := s.client.DoGet(router.DeltaFiles, ds.RouteVars(), opt, &files)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -450,22 +471,13 @@ type DeltaAffectedPerson struct {
 // ListAffectedAuthors.
 type DeltaListAffectedAuthorsOptions struct {
 	DeltaFilter
+	SortOptions
 	ListOptions
 }
 
 func (s *deltasService) ListAffectedAuthors(ds DeltaSpec, opt *DeltaListAffectedAuthorsOptions) ([]*DeltaAffectedPerson, Response, error) {
-	url, err := s.client.URL(router.DeltaAffectedAuthors, ds.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var authors []*DeltaAffectedPerson
-	resp, err := s.client.Do(req, &authors)
+	resp, err := s.client.DoList(router.DeltaAffectedAuthors, ds.RouteVars(), opt, &authors)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -477,22 +489,13 @@ func (s *deltasService) ListAffectedAuthors(ds DeltaSpec, opt *DeltaListAffected
 // ListAffectedClients.
 type DeltaListAffectedClientsOptions struct {
 	DeltaFilter
+	SortOptions
 	ListOptions
 }
 
 func (s *deltasService) ListAffectedClients(ds DeltaSpec, opt *DeltaListAffectedClientsOptions) ([]*DeltaAffectedPerson, Response, error) {
-	url, err := s.client.URL(router.DeltaAffectedClients, ds.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var clients []*DeltaAffectedPerson
-	resp, err := s.client.Do(req, &clients)
+	resp, err := s.client.DoList(router.DeltaAffectedClients, ds.RouteVars(), opt, &clients)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -521,22 +524,13 @@ type DeltaListAffectedDependentsOptions struct {
 	NotFormatted bool `url:",omitempty"`
 
 	DeltaFilter
+	SortOptions
 	ListOptions
 }
 
 func (s *deltasService) ListAffectedDependents(ds DeltaSpec, opt *DeltaListAffectedDependentsOptions) ([]*DeltaAffectedRepo, Response, error) {
-	url, err := s.client.URL(router.DeltaAffectedDependents, ds.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var dependents []*DeltaAffectedRepo
-	resp, err := s.client.Do(req, &dependents)
+	resp, err := s.client.DoList(router.DeltaAffectedDependents, ds.RouteVars(), opt, &dependents)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -557,22 +551,13 @@ type DeltaReviewer struct {
 
 type DeltaListReviewersOptions struct {
 	DeltaFilter
+	SortOptions
 	ListOptions
 }
 
 func (s *deltasService) ListReviewers(ds DeltaSpec, opt *DeltaListReviewersOptions) ([]*DeltaReviewer, Response, error) {
-	url, err := s.client.URL(router.DeltaReviewers, ds.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var reviewers []*DeltaReviewer
-	resp, err := s.client.Do(req, &reviewers)
+	resp, err := s.client.DoList(router.DeltaReviewers, ds.RouteVars(), opt, &reviewers)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -584,22 +569,13 @@ func (s *deltasService) ListReviewers(ds DeltaSpec, opt *DeltaListReviewersOptio
 // ListIncoming.
 type DeltaListIncomingOptions struct {
 	DeltaFilter
+	SortOptions
 	ListOptions
 }
 
 func (s *deltasService) ListIncoming(rr RepoRevSpec, opt *DeltaListIncomingOptions) ([]*Delta, Response, error) {
-	url, err := s.client.URL(router.DeltasIncoming, rr.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var deltas []*Delta
-	resp, err := s.client.Do(req, &deltas)
+	resp, err := s.client.DoList(router.DeltasIncoming, rr.RouteVars(), opt, &deltas)
 	if err != nil {
 		return nil, resp, err
 	}
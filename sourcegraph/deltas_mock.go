@@ -1,9 +1,12 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 type MockDeltasService struct {
 	Get_                    func(ds DeltaSpec, opt *DeltaGetOptions) (*Delta, Response, error)
 	ListUnits_              func(ds DeltaSpec, opt *DeltaListUnitsOptions) ([]*UnitDelta, Response, error)
 	ListDefs_               func(ds DeltaSpec, opt *DeltaListDefsOptions) (*DeltaDefs, Response, error)
+	ListAPIChanges_         func(ds DeltaSpec, opt *DeltaListAPIChangesOptions) (*DeltaAPIChanges, Response, error)
 	ListDependencies_       func(ds DeltaSpec, opt *DeltaListDependenciesOptions) (*DeltaDependencies, Response, error)
 	ListFiles_              func(ds DeltaSpec, opt *DeltaListFilesOptions) (*DeltaFiles, Response, error)
 	ListAffectedAuthors_    func(ds DeltaSpec, opt *DeltaListAffectedAuthorsOptions) ([]*DeltaAffectedPerson, Response, error)
@@ -11,44 +14,123 @@ type MockDeltasService struct {
 	ListAffectedDependents_ func(ds DeltaSpec, opt *DeltaListAffectedDependentsOptions) ([]*DeltaAffectedRepo, Response, error)
 	ListReviewers_          func(ds DeltaSpec, opt *DeltaListReviewersOptions) ([]*DeltaReviewer, Response, error)
 	ListIncoming_           func(rr RepoRevSpec, opt *DeltaListIncomingOptions) ([]*Delta, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockDeltasService returns a new MockDeltasService
+// that records calls to its methods.
+func NewMockDeltasService() *MockDeltasService {
+	return &MockDeltasService{Calls: &MockCalls{}}
 }
 
 func (s MockDeltasService) Get(ds DeltaSpec, opt *DeltaGetOptions) (*Delta, Response, error) {
+	s.Calls.record("Get", ds, opt)
+	if s.Get_ == nil {
+		var r0 *Delta
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.Get")
+	}
 	return s.Get_(ds, opt)
 }
 
 func (s MockDeltasService) ListUnits(ds DeltaSpec, opt *DeltaListUnitsOptions) ([]*UnitDelta, Response, error) {
+	s.Calls.record("ListUnits", ds, opt)
+	if s.ListUnits_ == nil {
+		var r0 []*UnitDelta
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListUnits")
+	}
 	return s.ListUnits_(ds, opt)
 }
 
 func (s MockDeltasService) ListDefs(ds DeltaSpec, opt *DeltaListDefsOptions) (*DeltaDefs, Response, error) {
+	s.Calls.record("ListDefs", ds, opt)
+	if s.ListDefs_ == nil {
+		var r0 *DeltaDefs
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListDefs")
+	}
 	return s.ListDefs_(ds, opt)
 }
 
+func (s MockDeltasService) ListAPIChanges(ds DeltaSpec, opt *DeltaListAPIChangesOptions) (*DeltaAPIChanges, Response, error) {
+	s.Calls.record("ListAPIChanges", ds, opt)
+	if s.ListAPIChanges_ == nil {
+		var r0 *DeltaAPIChanges
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListAPIChanges")
+	}
+	return s.ListAPIChanges_(ds, opt)
+}
+
 func (s MockDeltasService) ListDependencies(ds DeltaSpec, opt *DeltaListDependenciesOptions) (*DeltaDependencies, Response, error) {
+	s.Calls.record("ListDependencies", ds, opt)
+	if s.ListDependencies_ == nil {
+		var r0 *DeltaDependencies
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListDependencies")
+	}
 	return s.ListDependencies_(ds, opt)
 }
 
 func (s MockDeltasService) ListFiles(ds DeltaSpec, opt *DeltaListFilesOptions) (*DeltaFiles, Response, error) {
+	s.Calls.record("ListFiles", ds, opt)
+	if s.ListFiles_ == nil {
+		var r0 *DeltaFiles
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListFiles")
+	}
 	return s.ListFiles_(ds, opt)
 }
 
 func (s MockDeltasService) ListAffectedAuthors(ds DeltaSpec, opt *DeltaListAffectedAuthorsOptions) ([]*DeltaAffectedPerson, Response, error) {
+	s.Calls.record("ListAffectedAuthors", ds, opt)
+	if s.ListAffectedAuthors_ == nil {
+		var r0 []*DeltaAffectedPerson
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListAffectedAuthors")
+	}
 	return s.ListAffectedAuthors_(ds, opt)
 }
 
 func (s MockDeltasService) ListAffectedClients(ds DeltaSpec, opt *DeltaListAffectedClientsOptions) ([]*DeltaAffectedPerson, Response, error) {
+	s.Calls.record("ListAffectedClients", ds, opt)
+	if s.ListAffectedClients_ == nil {
+		var r0 []*DeltaAffectedPerson
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListAffectedClients")
+	}
 	return s.ListAffectedClients_(ds, opt)
 }
 
 func (s MockDeltasService) ListAffectedDependents(ds DeltaSpec, opt *DeltaListAffectedDependentsOptions) ([]*DeltaAffectedRepo, Response, error) {
+	s.Calls.record("ListAffectedDependents", ds, opt)
+	if s.ListAffectedDependents_ == nil {
+		var r0 []*DeltaAffectedRepo
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListAffectedDependents")
+	}
 	return s.ListAffectedDependents_(ds, opt)
 }
 
 func (s MockDeltasService) ListReviewers(ds DeltaSpec, opt *DeltaListReviewersOptions) ([]*DeltaReviewer, Response, error) {
+	s.Calls.record("ListReviewers", ds, opt)
+	if s.ListReviewers_ == nil {
+		var r0 []*DeltaReviewer
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListReviewers")
+	}
 	return s.ListReviewers_(ds, opt)
 }
 
 func (s MockDeltasService) ListIncoming(rr RepoRevSpec, opt *DeltaListIncomingOptions) ([]*Delta, Response, error) {
+	s.Calls.record("ListIncoming", rr, opt)
+	if s.ListIncoming_ == nil {
+		var r0 []*Delta
+		var r1 Response
+		return r0, r1, mockNotImplemented("DeltasService.ListIncoming")
+	}
 	return s.ListIncoming_(rr, opt)
 }
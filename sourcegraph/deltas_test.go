@@ -35,6 +35,17 @@ func TestDeltas(t *testing.T) {
 				"DeltaHeadRev": "headrev===headcommit",
 			},
 		},
+		{
+			spec: DeltaSpec{
+				Base: RepoRevSpec{RepoSpec: RepoSpec{URI: "samerepo"}, Rev: "base:rev"},
+				Head: RepoRevSpec{RepoSpec: RepoSpec{URI: "samerepo"}, Rev: "head:rev"},
+			},
+			wantRouteVars: map[string]string{
+				"RepoSpec":     "samerepo",
+				"Rev":          "base%3Arev",
+				"DeltaHeadRev": "head%3Arev",
+			},
+		},
 		{
 			spec: DeltaSpec{
 				Base: baseRev,
@@ -167,6 +178,64 @@ func TestDeltasService_ListDefs(t *testing.T) {
 	}
 }
 
+func TestDeltasService_ListAPIChanges(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ds := DeltaSpec{
+		Base: baseRev,
+		Head: headRev,
+	}
+	want := &DeltaAPIChanges{Changes: []*APIChange{{Kind: APIChangeRemoved, DefDelta: DefDelta{Base: &Def{Def: graph.Def{Name: "x"}}}}}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.DeltaAPIChanges, ds.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"UnitType": "t",
+			"Unit":     "u",
+		})
+
+		writeJSON(w, want)
+	})
+
+	changes, _, err := client.Deltas.ListAPIChanges(ds, &DeltaListAPIChangesOptions{DeltaFilter: DeltaFilter{UnitType: "t", Unit: "u"}})
+	if err != nil {
+		t.Errorf("Deltas.ListAPIChanges returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Deltas.ListAPIChanges returned %+v, want %+v", changes, want)
+	}
+	if changes.Compatible() {
+		t.Error("got Compatible() == true, want false (a def was removed)")
+	}
+}
+
+func TestDeltaAPIChanges_Compatible(t *testing.T) {
+	tests := []struct {
+		changes []*APIChange
+		want    bool
+	}{
+		{changes: nil, want: true},
+		{changes: []*APIChange{{Kind: APIChangeAdded}}, want: true},
+		{changes: []*APIChange{{Kind: APIChangeAdded}, nil}, want: true},
+		{changes: []*APIChange{nil, {Kind: APIChangeSignatureChanged}}, want: false},
+		{changes: []*APIChange{{Kind: APIChangeRemoved}}, want: false},
+	}
+	for i, test := range tests {
+		c := &DeltaAPIChanges{Changes: test.changes}
+		if got := c.Compatible(); got != test.want {
+			t.Errorf("#%d: got Compatible() == %v, want %v", i, got, test.want)
+		}
+	}
+}
+
 func TestDeltasService_ListDependencies(t *testing.T) {
 	setup()
 	defer teardown()
@@ -0,0 +1,167 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DigestHeader is the HTTP response header (defined in RFC 3230) in
+// which the server provides the digest of a downloaded file's
+// contents, such as "SHA-256=<base64-encoded digest>".
+const DigestHeader = "Digest"
+
+// checksumSidecarSuffix is appended to a download URL to obtain the
+// URL of its checksum sidecar file, which contains the hex-encoded
+// SHA-256 digest of the download (optionally followed by whitespace
+// and a filename, as in the output of sha256sum).
+const checksumSidecarSuffix = ".sha256"
+
+// digestAlgorithms are the digest algorithms recognized in Digest
+// headers, keyed by lowercased algorithm name.
+var digestAlgorithms = map[string]func() hash.Hash{
+	"sha-256": sha256.New,
+	"sha-512": sha512.New,
+	"md5":     md5.New,
+}
+
+// A ChecksumMismatchError is returned when reading a downloaded file
+// whose contents don't match the digest provided by the server.
+type ChecksumMismatchError struct {
+	URL       string // the URL of the download
+	Algorithm string // the digest algorithm (e.g., "sha-256")
+	Want, Got string // hex-encoded expected and actual digests
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %s: %s digest is %s, want %s", e.URL, e.Algorithm, e.Got, e.Want)
+}
+
+// parseDigestHeader returns the first digest in h (the value of a
+// Digest header) whose algorithm is recognized.
+func parseDigestHeader(h string) (algo string, sum []byte, ok bool) {
+	for _, d := range strings.Split(h, ",") {
+		d = strings.TrimSpace(d)
+		i := strings.Index(d, "=")
+		if i == -1 {
+			continue
+		}
+		algo = strings.ToLower(d[:i])
+		if _, known := digestAlgorithms[algo]; !known {
+			continue
+		}
+		sum, err := base64.StdEncoding.DecodeString(d[i+1:])
+		if err != nil {
+			continue
+		}
+		return algo, sum, true
+	}
+	return "", nil, false
+}
+
+// parseChecksumSidecar parses the contents of a checksum sidecar file.
+func parseChecksumSidecar(data []byte) ([]byte, error) {
+	f := strings.Fields(string(data))
+	if len(f) == 0 {
+		return nil, fmt.Errorf("empty checksum file")
+	}
+	return hex.DecodeString(f[0])
+}
+
+// verifyingReadCloser computes the digest of the data read from rc
+// and, upon reaching EOF, returns a *ChecksumMismatchError (instead
+// of io.EOF) if it doesn't match the expected digest.
+type verifyingReadCloser struct {
+	rc   io.ReadCloser
+	h    hash.Hash
+	algo string
+	want []byte
+	url  string
+}
+
+func (v *verifyingReadCloser) Read(p []byte) (int, error) {
+	n, err := v.rc.Read(p)
+	v.h.Write(p[:n])
+	if err == io.EOF {
+		if got := v.h.Sum(nil); !bytes.Equal(got, v.want) {
+			return n, &ChecksumMismatchError{URL: v.url, Algorithm: v.algo, Want: hex.EncodeToString(v.want), Got: hex.EncodeToString(got)}
+		}
+	}
+	return n, err
+}
+
+func (v *verifyingReadCloser) Close() error { return v.rc.Close() }
+
+// download sends req and returns the response body, which the caller
+// must close. If the server provides a digest of the contents (in a
+// Digest header or, if sidecar is true, in a checksum sidecar file),
+// the contents are verified as they are read, and reading the final
+// byte fails with a *ChecksumMismatchError if they don't match.
+//
+// If the server responds with a signed URL (see SignedURL), the
+// contents are downloaded directly from that URL.
+func (c *Client) download(req *http.Request, sidecar bool) (io.ReadCloser, Response, error) {
+	req.Header.Set(AcceptSignedURLHeader, "1")
+	resp, err := c.Do(req, preserveBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	hresp := resp.(*HTTPResponse)
+	body, digest, size := hresp.Body, hresp.Header.Get(DigestHeader), hresp.ContentLength
+
+	if isSignedURLResponse(hresp.Response) {
+		var oresp *http.Response
+		var su *SignedURL
+		body, oresp, su, err = c.downloadSigned(hresp.Response)
+		if err != nil {
+			return nil, resp, err
+		}
+		digest = su.Digest
+		if digest == "" {
+			digest = oresp.Header.Get(DigestHeader)
+		}
+		size = oresp.ContentLength
+		if su.Size > 0 {
+			size = su.Size
+		}
+	}
+	body = c.streamBody(body, size)
+
+	algo, want, ok := parseDigestHeader(digest)
+	if !ok && sidecar {
+		want, err = c.fetchChecksumSidecar(req)
+		if err != nil {
+			body.Close()
+			return nil, resp, err
+		}
+		algo, ok = "sha-256", true
+	}
+	if !ok {
+		return body, resp, nil
+	}
+	return &verifyingReadCloser{rc: body, h: digestAlgorithms[algo](), algo: algo, want: want, url: req.URL.String()}, resp, nil
+}
+
+// fetchChecksumSidecar fetches and parses the checksum sidecar file
+// for the download requested by req.
+func (c *Client) fetchChecksumSidecar(req *http.Request) ([]byte, error) {
+	u := *req.URL
+	u.Path += checksumSidecarSuffix
+	sreq, err := c.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if _, err := c.Do(sreq, &data); err != nil {
+		return nil, err
+	}
+	return parseChecksumSidecar(data)
+}
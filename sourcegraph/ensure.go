@@ -0,0 +1,149 @@
+package sourcegraph
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/go-github/github"
+)
+
+// EnsurePullRequestComment creates or updates a comment on a pull
+// request so that exactly one comment (per marker) has the given body.
+// It is intended for bots (such as CI status reporters) that post a
+// comment and then revise it on subsequent runs instead of posting a
+// new comment each time.
+//
+// The marker is embedded in the comment body as a hidden HTML comment
+// (which is not shown in the rendered comment). If a comment containing
+// the marker exists, it is edited (unless its body is already up to
+// date); otherwise a new comment is created. The marker must be
+// non-empty and may not contain "--".
+func EnsurePullRequestComment(s PullRequestsService, pull PullRequestSpec, marker, body string) (*PullRequestComment, Response, error) {
+	body, err := markCommentBody(marker, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opt := &PullRequestListCommentsOptions{}
+	var comments []*PullRequestComment
+	i, upToDate, resp, err := findMarkedComment(marker, body, opt.PerPageOrDefault(), func(page int) ([]markedComment, Response, error) {
+		opt.Page = page
+		var resp Response
+		var err error
+		comments, resp, err = s.ListComments(pull, opt)
+		cs := make([]markedComment, len(comments))
+		for i, c := range comments {
+			cs[i] = markedComment{ID: c.ID, Body: c.Body}
+		}
+		return cs, resp, err
+	})
+	switch {
+	case err != nil:
+		return nil, resp, err
+	case i == -1:
+		return s.CreateComment(pull, &PullRequestComment{PullRequestComment: github.PullRequestComment{Body: &body}})
+	case upToDate:
+		return comments[i], resp, nil
+	}
+	return s.EditComment(pull, &PullRequestComment{PullRequestComment: github.PullRequestComment{ID: comments[i].ID, Body: &body}})
+}
+
+// EnsureIssueComment is like EnsurePullRequestComment, but for comments
+// on an issue.
+func EnsureIssueComment(s IssuesService, issue IssueSpec, marker, body string) (*IssueComment, Response, error) {
+	body, err := markCommentBody(marker, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opt := &IssueListCommentsOptions{}
+	var comments []*IssueComment
+	i, upToDate, resp, err := findMarkedComment(marker, body, opt.PerPageOrDefault(), func(page int) ([]markedComment, Response, error) {
+		opt.Page = page
+		var resp Response
+		var err error
+		comments, resp, err = s.ListComments(issue, opt)
+		cs := make([]markedComment, len(comments))
+		for i, c := range comments {
+			cs[i] = markedComment{ID: c.ID, Body: c.Body}
+		}
+		return cs, resp, err
+	})
+	switch {
+	case err != nil:
+		return nil, resp, err
+	case i == -1:
+		return s.CreateComment(issue, &IssueComment{IssueComment: github.IssueComment{Body: &body}})
+	case upToDate:
+		return comments[i], resp, nil
+	}
+	return s.EditComment(issue, &IssueComment{IssueComment: github.IssueComment{ID: comments[i].ID, Body: &body}})
+}
+
+// A markedComment is the ID and body of a comment listed by
+// findMarkedComment.
+type markedComment struct {
+	ID   *int
+	Body *string
+}
+
+// findMarkedComment pages through comments (listing each page with
+// listPage, which is called with page numbers starting at 1) and
+// returns the index, in the last page listed, of the first comment that
+// contains marker's hidden HTML comment, or -1 if there is none.
+// upToDate is whether that comment's body, once normalized, is already
+// body (which is normalized).
+func findMarkedComment(marker, body string, perPage int, listPage func(page int) ([]markedComment, Response, error)) (i int, upToDate bool, resp Response, err error) {
+	var g PaginationGuard
+	for page := 1; ; page++ {
+		comments, resp, err := listPage(page)
+		if err != nil {
+			return -1, false, resp, err
+		}
+		ids := make([]string, len(comments))
+		for i, c := range comments {
+			if c.ID != nil {
+				ids[i] = strconv.Itoa(*c.ID)
+			}
+		}
+		if err := g.Add(resp, ids); err != nil {
+			return -1, false, resp, err
+		}
+
+		for i, c := range comments {
+			if c.ID == nil || c.Body == nil || !strings.Contains(*c.Body, commentMarker(marker)) {
+				continue
+			}
+			// Compare normalized bodies, because the stored body may
+			// not be normalized (e.g., if it was last edited by another
+			// client).
+			return i, NormalizeCommentBody(*c.Body) == body, resp, nil
+		}
+
+		if len(comments) < perPage {
+			return -1, false, resp, g.Done()
+		}
+	}
+}
+
+// commentMarker returns the hidden HTML comment that identifies
+// comments created by EnsurePullRequestComment and EnsureIssueComment
+// with the given marker.
+func commentMarker(marker string) string {
+	return "<!-- " + marker + " -->"
+}
+
+// markCommentBody returns the normalized body with the hidden marker
+// appended. (The body is normalized here so that it can be compared
+// with the bodies of existing comments, which were normalized when
+// they were created.)
+func markCommentBody(marker, body string) (string, error) {
+	if marker == "" {
+		return "", errors.New("comment marker is empty")
+	}
+	if strings.Contains(marker, "--") {
+		return "", errors.New(`comment marker may not contain "--"`)
+	}
+	return NormalizeCommentBody(body) + "\n\n" + commentMarker(marker), nil
+}
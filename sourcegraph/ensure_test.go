@@ -0,0 +1,110 @@
+package sourcegraph
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/go-github/github"
+)
+
+func TestEnsureIssueComment(t *testing.T) {
+	issue := IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	const wantBody = "b\n\n<!-- ci -->"
+
+	tests := map[string]struct {
+		existing             []*IssueComment
+		wantCreate, wantEdit bool
+		wantBodyAsIs         bool // whether the existing comment is returned as is
+	}{
+		"absent": {
+			existing:   []*IssueComment{{IssueComment: github.IssueComment{ID: github.Int(1), Body: github.String("hi")}}},
+			wantCreate: true,
+		},
+		"outdated": {
+			existing: []*IssueComment{
+				{IssueComment: github.IssueComment{ID: github.Int(1), Body: github.String("hi")}},
+				{IssueComment: github.IssueComment{ID: github.Int(2), Body: github.String("a\n\n<!-- ci -->")}},
+			},
+			wantEdit: true,
+		},
+		"up to date": {
+			existing: []*IssueComment{{IssueComment: github.IssueComment{ID: github.Int(2), Body: github.String(wantBody)}}},
+		},
+		"up to date, not normalized": {
+			existing:     []*IssueComment{{IssueComment: github.IssueComment{ID: github.Int(2), Body: github.String("b \r\n\r\n<!-- ci -->\r\n")}}},
+			wantBodyAsIs: true,
+		},
+	}
+	for label, test := range tests {
+		var created, edited bool
+		s := MockIssuesService{
+			ListComments_: func(issue IssueSpec, opt *IssueListCommentsOptions) ([]*IssueComment, Response, error) {
+				if opt.Page != 1 {
+					t.Errorf("%s: got page %d, want only page 1", label, opt.Page)
+				}
+				return test.existing, nil, nil
+			},
+			CreateComment_: func(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error) {
+				created = true
+				if *comment.Body != wantBody {
+					t.Errorf("%s: got created body %q, want %q", label, *comment.Body, wantBody)
+				}
+				return comment, nil, nil
+			},
+			EditComment_: func(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error) {
+				edited = true
+				if *comment.ID != 2 || *comment.Body != wantBody {
+					t.Errorf("%s: got edited comment %d with body %q, want 2 with %q", label, *comment.ID, *comment.Body, wantBody)
+				}
+				return comment, nil, nil
+			},
+		}
+
+		comment, _, err := EnsureIssueComment(s, issue, "ci", "b\n")
+		if err != nil {
+			t.Errorf("%s: %s", label, err)
+			continue
+		}
+		if created != test.wantCreate || edited != test.wantEdit {
+			t.Errorf("%s: got created %v edited %v, want %v %v", label, created, edited, test.wantCreate, test.wantEdit)
+		}
+		if !test.wantBodyAsIs && *comment.Body != wantBody {
+			t.Errorf("%s: got body %q, want %q", label, *comment.Body, wantBody)
+		}
+	}
+}
+
+func TestEnsurePullRequestComment_invalidMarker(t *testing.T) {
+	for _, marker := range []string{"", "a--b"} {
+		if _, _, err := EnsurePullRequestComment(MockPullRequestsService{}, PullRequestSpec{}, marker, "b"); err == nil {
+			t.Errorf("marker %q: got nil error, want error", marker)
+		}
+	}
+}
+
+func TestEnsurePullRequestComment_normalizesBody(t *testing.T) {
+	pull := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	existing := &PullRequestComment{PullRequestComment: github.PullRequestComment{ID: github.Int(1), Body: github.String("a\nb\n\n<!-- ci -->")}}
+	s := MockPullRequestsService{
+		ListComments_: func(pull PullRequestSpec, opt *PullRequestListCommentsOptions) ([]*PullRequestComment, Response, error) {
+			return []*PullRequestComment{existing}, nil, nil
+		},
+		CreateComment_: func(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error) {
+			t.Errorf("got CreateComment with body %q, want no change", *comment.Body)
+			return comment, nil, nil
+		},
+		EditComment_: func(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error) {
+			t.Errorf("got EditComment with body %q, want no change", *comment.Body)
+			return comment, nil, nil
+		},
+	}
+
+	// The body differs from the existing comment's only in line endings
+	// and trailing whitespace, which are normalized away.
+	comment, _, err := EnsurePullRequestComment(s, pull, "ci", "a  \r\nb\r\n\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment != existing {
+		t.Errorf("got comment %+v, want the existing comment", comment)
+	}
+}
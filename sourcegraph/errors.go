@@ -10,10 +10,14 @@ import (
 	"github.com/abec/srclib/graph"
 )
 
-// An ErrorResponse reports errors caused by an API request.
+// An ErrorResponse reports errors caused by an API request. The fields
+// other than Response are decoded from the API's JSON error body (if
+// any), except for StatusCode.
 type ErrorResponse struct {
-	Response *http.Response `json:",omitempty"` // HTTP response that caused this error
-	Message  string         // error message
+	Response   *http.Response `json:",omitempty"` // HTTP response that caused this error
+	StatusCode int            `json:"-"`          // HTTP status code of Response
+	Code       string         `json:",omitempty"` // machine-readable error code (e.g., "repo_not_found"), if any
+	Message    string         // error message
 }
 
 func IsDefError(err error) bool {
@@ -21,27 +25,44 @@ func IsDefError(err error) bool {
 }
 
 func (r *ErrorResponse) Error() string {
+	msg := r.Message
+	if r.Code != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, r.Code)
+	}
 	return fmt.Sprintf("%v %v: %d %v",
 		r.Response.Request.Method, r.Response.Request.URL,
-		r.Response.StatusCode, r.Message)
+		r.HTTPStatusCode(), msg)
 }
 
-func (r *ErrorResponse) HTTPStatusCode() int { return r.Response.StatusCode }
+func (r *ErrorResponse) HTTPStatusCode() int {
+	if r.StatusCode != 0 {
+		return r.StatusCode
+	}
+	return r.Response.StatusCode
+}
 
 // CheckResponse checks the API response for errors, and returns them if
 // present.  A response is considered an error if it has a status code outside
 // the 200 range.  API error responses are expected to have either no response
 // body, or a JSON response body that maps to ErrorResponse.  Any other
-// response body will be silently ignored.
+// response body will be silently ignored. If the client's rate limit is
+// exhausted, the error is a *RateLimitError.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
-	errorResponse := &ErrorResponse{Response: r}
+	errorResponse := &ErrorResponse{Response: r, StatusCode: r.StatusCode}
 	data, err := ioutil.ReadAll(r.Body)
 	if err == nil && data != nil {
 		json.Unmarshal(data, errorResponse)
 	}
+	if rate := parseRate(r.Header); r.StatusCode == http.StatusTooManyRequests || (r.StatusCode == http.StatusForbidden && rate != nil && rate.Remaining == 0) {
+		rateErr := &RateLimitError{ErrorResponse: errorResponse}
+		if rate != nil {
+			rateErr.Rate = *rate
+		}
+		return rateErr
+	}
 	return errorResponse
 }
 
@@ -59,3 +80,28 @@ func IsHTTPErrorCode(err error, statusCode int) bool {
 	}
 	return false
 }
+
+// IsNotFound reports whether err is an API error with HTTP status 404
+// Not Found.
+func IsNotFound(err error) bool { return IsHTTPErrorCode(err, http.StatusNotFound) }
+
+// IsUnauthorized reports whether err is an API error with HTTP status
+// 401 Unauthorized (i.e., the client's credentials are missing or
+// invalid).
+func IsUnauthorized(err error) bool { return IsHTTPErrorCode(err, http.StatusUnauthorized) }
+
+// IsConflict reports whether err is an API error with HTTP status 409
+// Conflict.
+func IsConflict(err error) bool { return IsHTTPErrorCode(err, http.StatusConflict) }
+
+// ErrorCode returns the machine-readable error code of err if it is an
+// API error (*ErrorResponse or *RateLimitError), or "" otherwise.
+func ErrorCode(err error) string {
+	switch e := err.(type) {
+	case *ErrorResponse:
+		return e.Code
+	case *RateLimitError:
+		return e.Code
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestCheckResponse_errorCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+	mux.HandleFunc(urlPath(t, router.Repo, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"Code":"repo_not_found","Message":"no such repo"}`))
+	})
+
+	_, _, err := client.Repos.Get(repo, nil)
+	e, ok := err.(*ErrorResponse)
+	if !ok {
+		t.Fatalf("got error %v (%T), want *ErrorResponse", err, err)
+	}
+	if e.StatusCode != http.StatusNotFound || e.Code != "repo_not_found" || e.Message != "no such repo" {
+		t.Errorf("got %+v, want StatusCode 404, Code repo_not_found, and Message %q", e, "no such repo")
+	}
+	if code := ErrorCode(err); code != "repo_not_found" {
+		t.Errorf("got ErrorCode %q, want %q", code, "repo_not_found")
+	}
+}
+
+func TestIsHTTPErrorCode_helpers(t *testing.T) {
+	errWithStatus := func(code int) error {
+		return &ErrorResponse{Response: &http.Response{StatusCode: code}, StatusCode: code}
+	}
+	tests := []struct {
+		err                                         error
+		notFound, unauthorized, forbidden, conflict bool
+	}{
+		{err: nil},
+		{err: ErrForbidden, forbidden: true},
+		{err: errWithStatus(http.StatusNotFound), notFound: true},
+		{err: errWithStatus(http.StatusUnauthorized), unauthorized: true},
+		{err: errWithStatus(http.StatusForbidden), forbidden: true},
+		{err: errWithStatus(http.StatusConflict), conflict: true},
+		{err: &RateLimitError{ErrorResponse: errWithStatus(http.StatusForbidden).(*ErrorResponse)}, forbidden: true},
+		{err: errWithStatus(http.StatusInternalServerError)},
+	}
+	for i, test := range tests {
+		if got := IsNotFound(test.err); got != test.notFound {
+			t.Errorf("#%d: IsNotFound: got %v, want %v", i, got, test.notFound)
+		}
+		if got := IsUnauthorized(test.err); got != test.unauthorized {
+			t.Errorf("#%d: IsUnauthorized: got %v, want %v", i, got, test.unauthorized)
+		}
+		if got := IsForbidden(test.err); got != test.forbidden {
+			t.Errorf("#%d: IsForbidden: got %v, want %v", i, got, test.forbidden)
+		}
+		if got := IsConflict(test.err); got != test.conflict {
+			t.Errorf("#%d: IsConflict: got %v, want %v", i, got, test.conflict)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package sourcegraph
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// ErrNotSupported is the error message prefix of NotSupportedError.
+// Use IsNotSupported to check whether an error indicates that the
+// server doesn't support an API endpoint.
+var ErrNotSupported = errors.New("API endpoint not supported by server")
+
+// A NotSupportedError is returned by (*Client).Do when the server
+// doesn't support the requested API endpoint (typically because the
+// server is older than the client library).
+type NotSupportedError struct {
+	// Route is the name of the unsupported API route.
+	Route string
+}
+
+func (e *NotSupportedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrNotSupported, e.Route)
+}
+
+// IsNotSupported reports whether err indicates that the server
+// doesn't support an API endpoint.
+func IsNotSupported(err error) bool {
+	if err == ErrNotSupported {
+		return true
+	}
+	_, ok := err.(*NotSupportedError)
+	return ok
+}
+
+// newRoutes are the API routes that were added after this library's
+// initial release, which older servers may not support. Only requests
+// to these routes are checked for support: some older routes' handlers
+// respond with a bare 404 that has a meaning of its own (such as
+// BuildsService.DequeueNext's empty queue).
+var newRoutes = map[string]struct{}{
+	router.AuthOIDCConfig:                      {},
+	router.DeltaAPIChanges:                     {},
+	router.Operation:                           {},
+	router.PersonStats:                         {},
+	router.PersonCollaborators:                 {},
+	router.RepoNotificationDestinations:        {},
+	router.RepoNotificationDestinationsCreate:  {},
+	router.RepoNotificationDestination:         {},
+	router.RepoNotificationDestinationUpdate:   {},
+	router.RepoNotificationDestinationDelete:   {},
+	router.RepoNotificationDestinationTest:     {},
+	router.RepoFileSearch:                      {},
+	router.RepoPullRequestAffectedDefs:         {},
+	router.RepoPullRequestTrackerLinks:         {},
+	router.RepoCommitTrackerLinks:              {},
+	router.TrackerIssueLinks:                   {},
+	router.TrackerLinksCreate:                  {},
+	router.TrackerLinkDelete:                   {},
+	router.AuditEvents:                         {},
+	router.AuditEventsExport:                   {},
+	router.MonitoringAlerts:                    {},
+	router.MonitoringAlert:                     {},
+	router.MonitoringAlertUpdate:               {},
+	router.MonitoringSilences:                  {},
+	router.MonitoringSilencesCreate:            {},
+	router.MonitoringSilenceDelete:             {},
+	router.RepoPullRequestExport:               {},
+	router.RepoIssueExport:                     {},
+	router.RepoPullRequestReviews:              {},
+	router.RepoPullRequestReviewsCreate:        {},
+	router.RepoPullRequestReview:               {},
+	router.RepoPullRequestReviewSubmit:         {},
+	router.RepoPullRequestReviewDismiss:        {},
+	router.RepoPullRequestFiles:                {},
+	router.OrgTeams:                            {},
+	router.OrgTeamsCreate:                      {},
+	router.OrgTeam:                             {},
+	router.OrgTeamMemberAdd:                    {},
+	router.OrgTeamMemberRemove:                 {},
+	router.DefCallers:                          {},
+	router.DefCallees:                          {},
+	router.RepoIssuesCreate:                    {},
+	router.RepoIssueEdit:                       {},
+	router.RepoIssueLabelsAdd:                  {},
+	router.RepoIssueLabelRemove:                {},
+	router.RepoIssueAssignees:                  {},
+	router.RepoIssueEvents:                     {},
+	router.UserTokens:                          {},
+	router.UserTokensCreate:                    {},
+	router.UserTokenRevoke:                     {},
+	router.UserEmailsAdd:                       {},
+	router.UserEmailRemove:                     {},
+	router.UserEmailSetPrimary:                 {},
+	router.ReposBatch:                          {},
+	router.DefsBatch:                           {},
+	router.RepoPullRequestReviewComments:       {},
+	router.RepoPullRequestReviewCommentsCreate: {},
+	router.RepoPullRequestReviewComment:        {},
+	router.RepoPullRequestReviewCommentEdit:    {},
+	router.RepoPullRequestReviewCommentDelete:  {},
+	router.Notifications:                       {},
+	router.NotificationsMarkAllRead:            {},
+	router.NotificationMarkRead:                {},
+	router.RepoIssueSubscribe:                  {},
+	router.RepoIssueUnsubscribe:                {},
+	router.RepoPullRequestSubscribe:            {},
+	router.RepoPullRequestUnsubscribe:          {},
+	router.BuildSubscribe:                      {},
+	router.BuildUnsubscribe:                    {},
+	router.RepoCounterRecordHit:                {},
+	router.RepoTreeAnnotations:                 {},
+	router.GraphQL:                             {},
+	router.MetaStatus:                          {},
+	router.MetaConfig:                          {},
+	router.RepoCollaborators:                   {},
+	router.RepoCollaboratorAdd:                 {},
+	router.RepoCollaboratorRemove:              {},
+	router.RepoCollaboratorPermission:          {},
+	router.RepoPullRequestEvents:               {},
+	router.RepoPullRequestsComments:            {},
+	router.RepoIssuesComments:                  {},
+	router.RepoPullRequestsCreate:              {},
+	router.RepoPullRequestEdit:                 {},
+	router.RepoArchive:                         {},
+	router.BuildsQueue:                         {},
+	router.BuildsQueueStats:                    {},
+	router.RepoStatsSummary:                    {},
+	router.PeopleInvite:                        {},
+	router.PersonDeactivate:                    {},
+	router.PersonReactivate:                    {},
+	router.PersonRepos:                         {},
+	router.OrgRepos:                            {},
+	router.BuildLogStream:                      {},
+	router.RepoPullRequestDiff:                 {},
+	router.AdminMigrations:                     {},
+	router.AdminTestEmail:                      {},
+	router.ReposResolveImportPath:              {},
+	router.ReposResolvePackage:                 {},
+}
+
+// featureCache records which API routes the server has been found
+// not to support, and the server's status (see Client.SupportsRoute).
+type featureCache struct {
+	mu          sync.Mutex
+	unsupported map[string]struct{}
+	status      *ServerStatus
+}
+
+func (f *featureCache) isUnsupported(route string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, unsupported := f.unsupported[route]
+	return unsupported
+}
+
+func (f *featureCache) markUnsupported(route string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unsupported == nil {
+		f.unsupported = map[string]struct{}{}
+	}
+	f.unsupported[route] = struct{}{}
+}
+
+func (f *featureCache) getStatus() *ServerStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.status
+}
+
+func (f *featureCache) setStatus(status *ServerStatus) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = status
+}
+
+// SupportsRoute reports whether the server supports the named API
+// route (one of the route name constants in the router package), so
+// that callers can avoid (or replace) calls that would fail with a
+// *NotSupportedError on older servers:
+//
+//	if ok, err := client.SupportsRoute(router.PersonStats); err != nil {
+//		return err
+//	} else if ok {
+//		stats, _, err = client.People.GetStats(person, nil)
+//	}
+//
+// Routes that all servers support (those not in newRoutes) are
+// reported as supported without contacting the server. Other routes
+// are unsupported if a previous request to them failed with a
+// *NotSupportedError. Otherwise, the server's status (see
+// MetaService.Status) is fetched once and remembered, and the route is
+// supported if the server lists it. If the server doesn't list its
+// routes (or predates the status endpoint), the route is reported as
+// supported, and calls fail with a *NotSupportedError if it isn't.
+func (c *Client) SupportsRoute(name string) (bool, error) {
+	if Router.Get(name) == nil {
+		return false, fmt.Errorf("unknown API route %q", name)
+	}
+	if _, isNew := newRoutes[name]; !isNew || c.features == nil {
+		return true, nil
+	}
+	if c.features.isUnsupported(name) {
+		return false, nil
+	}
+
+	status := c.features.getStatus()
+	if status == nil {
+		var err error
+		status, _, err = c.Meta.Status()
+		if IsNotSupported(err) {
+			status = &ServerStatus{}
+		} else if err != nil {
+			return false, err
+		}
+		c.features.setStatus(status)
+	}
+
+	if len(status.Routes) > 0 && !status.HasRoute(name) {
+		c.features.markUnsupported(name)
+		return false, nil
+	}
+	return !c.features.isUnsupported(name), nil
+}
+
+// routeName returns the name of the API route that req's URL refers
+// to, or "" if it doesn't match any route (e.g., if it is not an API
+// URL).
+func (c *Client) routeName(req *http.Request) string {
+	name, _ := c.matchRoute(req)
+	return name
+}
+
+// matchRoute returns the name and variables of the API route that
+// req's URL refers to. If it doesn't match any route, name is "" and
+// vars is nil.
+func (c *Client) matchRoute(req *http.Request) (name string, vars map[string]string) {
+	if c.BaseURL == nil || req.URL.Host != c.BaseURL.Host {
+		return "", nil
+	}
+	basePath := strings.TrimSuffix(c.BaseURL.Path, "/")
+	if strings.HasPrefix(req.URL.Path, basePath+"/") {
+		u := *req.URL
+		u.Path = strings.TrimPrefix(req.URL.Path, basePath)
+		name, vars = router.MatchRoute(Router, &http.Request{Method: req.Method, URL: &u, Host: u.Host})
+		if name != "" && !router.IsRootRoute(name) {
+			return name, vars
+		}
+	}
+
+	// Root routes' paths are relative to the server's root, not to
+	// BaseURL.
+	name, vars = router.MatchRoute(Router, &http.Request{Method: req.Method, URL: req.URL, Host: req.URL.Host})
+	if !router.IsRootRoute(name) {
+		return "", nil
+	}
+	return name, vars
+}
+
+// notSupportedError returns a *NotSupportedError for route.
+func notSupportedError(route string) *NotSupportedError {
+	return &NotSupportedError{Route: route}
+}
+
+// isRouteNotFound reports whether resp is a 404 response generated
+// because the server has no such route (as opposed to a 404 response
+// from an API handler, such as for a nonexistent repository). API
+// handlers return JSON error bodies; the server's router returns
+// plain text.
+func isRouteNotFound(resp *http.Response, err error) bool {
+	if resp.StatusCode != http.StatusNotFound {
+		return false
+	}
+	if e, ok := err.(*ErrorResponse); !ok || e.Message != "" {
+		return false
+	}
+	return !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json")
+}
+
+// checkRouteSupported returns the name of the API route that req
+// refers to if it is one of newRoutes, and an error if the server is
+// already known not to support it.
+func (c *Client) checkRouteSupported(req *http.Request) (string, error) {
+	if c.features == nil {
+		return "", nil
+	}
+	route := c.routeName(req)
+	if _, isNew := newRoutes[route]; !isNew {
+		return "", nil
+	}
+	if c.features.isUnsupported(route) {
+		return route, notSupportedError(route)
+	}
+	return route, nil
+}
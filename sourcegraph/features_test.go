@@ -0,0 +1,133 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestClient_Do_notSupported(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc(urlPath(t, router.PersonStats, map[string]string{"PersonSpec": "a"}), func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.NotFound(w, r)
+	})
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.People.GetStats(PersonSpec{Login: "a"}, nil)
+		if !IsNotSupported(err) {
+			t.Fatalf("got error %v, want a NotSupportedError", err)
+		}
+		if e := err.(*NotSupportedError); e.Route != router.PersonStats {
+			t.Errorf("got route %q, want %q", e.Route, router.PersonStats)
+		}
+	}
+
+	// The second call should fail without a request to the server.
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+
+	if ok, err := client.SupportsRoute(router.PersonStats); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Error("got SupportsRoute == true after a NotSupportedError, want false")
+	}
+}
+
+func TestClient_Do_notFoundFromHandler(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(urlPath(t, router.PersonStats, map[string]string{"PersonSpec": "a"}), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"Message":"person not found"}`))
+	})
+
+	_, _, err := client.People.GetStats(PersonSpec{Login: "a"}, nil)
+	if !IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("got error %v, want HTTP 404 error", err)
+	}
+	if IsNotSupported(err) {
+		t.Error("got IsNotSupported(err) == true, want false")
+	}
+}
+
+func TestClient_SupportsRoute(t *testing.T) {
+	tests := map[string]struct {
+		status  *ServerStatus // nil if the server has no status endpoint
+		route   string
+		want    bool
+		noFetch bool // whether the route is reported without fetching the status
+	}{
+		"old route": {
+			status: &ServerStatus{Routes: []string{router.PersonStats}}, route: router.Repo, want: true, noFetch: true,
+		},
+		"listed route": {
+			status: &ServerStatus{Routes: []string{router.PersonStats}}, route: router.PersonStats, want: true,
+		},
+		"unlisted route": {
+			status: &ServerStatus{Version: "9.9.9", Routes: []string{router.PersonStats}}, route: router.GraphQL, want: false,
+		},
+		"routes not listed": {
+			status: &ServerStatus{Version: "0.0.9"}, route: router.GraphQL, want: true,
+		},
+		"no status endpoint": {
+			route: router.GraphQL, want: true,
+		},
+	}
+	for label, test := range tests {
+		func() {
+			setup()
+			defer teardown()
+
+			var calls int
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != urlPath(t, router.MetaStatus, nil) || test.status == nil {
+					http.NotFound(w, r)
+					return
+				}
+				calls++
+				writeJSON(w, test.status)
+			})
+
+			for i := 0; i < 2; i++ {
+				ok, err := client.SupportsRoute(test.route)
+				if err != nil {
+					t.Fatalf("%s: %s", label, err)
+				}
+				if ok != test.want {
+					t.Errorf("%s: got %v, want %v", label, ok, test.want)
+				}
+			}
+
+			// The status should be fetched at most once.
+			wantCalls := 0
+			if test.status != nil && !test.noFetch {
+				wantCalls = 1
+			}
+			if calls != wantCalls {
+				t.Errorf("%s: got %d status requests, want %d", label, calls, wantCalls)
+			}
+
+			// Unsupported routes should fail without a request.
+			if !test.want {
+				_, err := client.GraphQL("{ x }", nil, nil)
+				if !IsNotSupported(err) {
+					t.Errorf("%s: got error %v, want a NotSupportedError", label, err)
+				}
+			}
+		}()
+	}
+}
+
+func TestClient_SupportsRoute_unknown(t *testing.T) {
+	if _, err := NewClient(nil).SupportsRoute("no-such-route"); err == nil {
+		t.Error("err == nil")
+	}
+}
@@ -0,0 +1,91 @@
+package sourcegraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// A GraphQLError is an error reported in the "errors" list of a
+// GraphQL response.
+type GraphQLError struct {
+	Message string
+
+	// Path is the path of the response field that the error occurred
+	// in (field names and list indexes), if any.
+	Path []interface{} `json:",omitempty"`
+}
+
+func (e *GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	path := make([]string, len(e.Path))
+	for i, p := range e.Path {
+		path[i] = fmt.Sprint(p)
+	}
+	return strings.Join(path, ".") + ": " + e.Message
+}
+
+// GraphQLErrors is returned by Client.GraphQL when the response
+// reports errors.
+type GraphQLErrors []*GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "graphql: " + strings.Join(msgs, "; ")
+}
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQL sends a GraphQL query (or mutation) with the given variables
+// to the server's GraphQL API, and decodes the "data" field of the
+// response into out (if non-nil). Newer servers serve some data only
+// through the GraphQL API; the graphql subpackage has typed queries
+// for common data.
+//
+// If the response reports errors, they are returned as GraphQLErrors,
+// after any (partial) data in the response is decoded into out. If the
+// server has no GraphQL API, a *NotSupportedError is returned.
+//
+// GraphQL requests are never retried automatically, because they may
+// contain mutations.
+func (c *Client) GraphQL(query string, vars map[string]interface{}, out interface{}) (Response, error) {
+	url, err := c.URL(router.GraphQL, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest("POST", url.String(), graphQLRequest{Query: query, Variables: vars})
+	if err != nil {
+		return nil, err
+	}
+
+	var gqlResp struct {
+		Data   json.RawMessage
+		Errors GraphQLErrors
+	}
+	resp, err := c.Do(req, &gqlResp)
+	if err != nil {
+		return resp, err
+	}
+
+	if out != nil && len(gqlResp.Data) > 0 && string(gqlResp.Data) != "null" {
+		if err := json.Unmarshal(gqlResp.Data, out); err != nil {
+			return resp, fmt.Errorf("error decoding GraphQL response data: %s", err)
+		}
+	}
+	if len(gqlResp.Errors) > 0 {
+		return resp, gqlResp.Errors
+	}
+	return resp, nil
+}
@@ -0,0 +1,194 @@
+// Package graphql builds queries for the GraphQL API of newer
+// Sourcegraph servers (which serve some data only through GraphQL)
+// and defines types for their responses. Queries are sent with
+// (*sourcegraph.Client).GraphQL.
+//
+// Common queries have typed helpers:
+//
+//	repo, _, err := graphql.GetRepository(client, "github.com/foo/bar")
+//
+// Other queries may be built with NewQuery:
+//
+//	q := graphql.NewQuery("RepoURL").Var("name", "String!", name).Select(
+//		graphql.F("repository", graphql.F("url")).Arg("name", "$name"),
+//	)
+//	var out struct{ Repository *graphql.Repository }
+//	_, err := q.Do(client, &out)
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+// A Query is a GraphQL operation (a query, or a mutation if Operation
+// is "mutation") and its variables.
+type Query struct {
+	// Operation is the operation type ("query" or "mutation"). If
+	// empty, it is "query".
+	Operation string
+
+	// Name is the operation name (which servers use in logs and
+	// metrics).
+	Name string
+
+	// Fields are the top-level fields that the operation selects.
+	Fields []*Field
+
+	vars []variable
+}
+
+type variable struct {
+	name, typ string
+	value     interface{}
+}
+
+// NewQuery returns a new query with the given operation name.
+func NewQuery(name string) *Query {
+	return &Query{Name: name}
+}
+
+// Var declares a variable of the given GraphQL type (such as
+// "String!") with the given value, and returns q. Fields refer to it as
+// "$name".
+func (q *Query) Var(name, typ string, value interface{}) *Query {
+	q.vars = append(q.vars, variable{name: name, typ: typ, value: value})
+	return q
+}
+
+// Select adds top-level fields to q and returns q.
+func (q *Query) Select(fields ...*Field) *Query {
+	q.Fields = append(q.Fields, fields...)
+	return q
+}
+
+// String returns the GraphQL document for q, such as
+// "query Repo($name: String!) { repository(name: $name) { url } }".
+func (q *Query) String() string {
+	var buf bytes.Buffer
+	op := q.Operation
+	if op == "" {
+		op = "query"
+	}
+	buf.WriteString(op)
+	if q.Name != "" {
+		buf.WriteString(" " + q.Name)
+	}
+	if len(q.vars) > 0 {
+		buf.WriteString("(")
+		for i, v := range q.vars {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString("$" + v.name + ": " + v.typ)
+		}
+		buf.WriteString(")")
+	}
+	writeSelections(&buf, q.Fields)
+	return buf.String()
+}
+
+// Variables returns the values of q's variables.
+func (q *Query) Variables() map[string]interface{} {
+	if len(q.vars) == 0 {
+		return nil
+	}
+	vars := make(map[string]interface{}, len(q.vars))
+	for _, v := range q.vars {
+		vars[v.name] = v.value
+	}
+	return vars
+}
+
+// Do sends q using c and decodes the response data into out (see
+// (*sourcegraph.Client).GraphQL).
+func (q *Query) Do(c *sourcegraph.Client, out interface{}) (sourcegraph.Response, error) {
+	return c.GraphQL(q.String(), q.Variables(), out)
+}
+
+// A Field is a field selection, with optional arguments and
+// sub-selections, or an inline fragment (if On is set).
+type Field struct {
+	Name   string
+	Alias  string // if set, the response key for the field
+	Args   []Arg
+	Fields []*Field
+
+	// On, if set, makes this an inline fragment ("... on On { ... }")
+	// that selects Fields on results of the named type. Name, Alias,
+	// and Args are ignored.
+	On string
+}
+
+// An Arg is a field argument. Value is a GraphQL value literal (see
+// Quote) or a variable reference (such as "$name").
+type Arg struct {
+	Name, Value string
+}
+
+// F returns a field selection with the given sub-selections.
+func F(name string, fields ...*Field) *Field {
+	return &Field{Name: name, Fields: fields}
+}
+
+// On returns an inline fragment that selects fields on results of the
+// named type (for fields whose type is an interface or union).
+func On(typeName string, fields ...*Field) *Field {
+	return &Field{On: typeName, Fields: fields}
+}
+
+// Arg adds an argument to f and returns f.
+func (f *Field) Arg(name, value string) *Field {
+	f.Args = append(f.Args, Arg{Name: name, Value: value})
+	return f
+}
+
+// As sets f's alias and returns f.
+func (f *Field) As(alias string) *Field {
+	f.Alias = alias
+	return f
+}
+
+func (f *Field) write(buf *bytes.Buffer) {
+	if f.On != "" {
+		buf.WriteString("... on " + f.On)
+		writeSelections(buf, f.Fields)
+		return
+	}
+	if f.Alias != "" {
+		buf.WriteString(f.Alias + ": ")
+	}
+	buf.WriteString(f.Name)
+	if len(f.Args) > 0 {
+		buf.WriteString("(")
+		for i, a := range f.Args {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(a.Name + ": " + a.Value)
+		}
+		buf.WriteString(")")
+	}
+	writeSelections(buf, f.Fields)
+}
+
+func writeSelections(buf *bytes.Buffer, fields []*Field) {
+	if len(fields) == 0 {
+		return
+	}
+	buf.WriteString(" {")
+	for _, f := range fields {
+		buf.WriteString(" ")
+		f.write(buf)
+	}
+	buf.WriteString(" }")
+}
+
+// Quote returns s as a GraphQL string literal, for use as an Arg
+// value. Prefer variables for values that come from users.
+func Quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
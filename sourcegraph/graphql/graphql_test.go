@@ -0,0 +1,107 @@
+package graphql
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+func TestQuery_String(t *testing.T) {
+	q := NewQuery("Q").Var("name", "String!", "r").Var("n", "Int", 3).Select(
+		F("repository",
+			F("name"),
+			F("commit", F("oid")).Arg("rev", Quote(`a"b`)).As("head"),
+		).Arg("name", "$name"),
+		F("search", F("results", F("__typename"), On("Repository", F("url")))),
+	)
+
+	want := `query Q($name: String!, $n: Int) { repository(name: $name) { name head: commit(rev: "a\"b") { oid } } search { results { __typename ... on Repository { url } } } }`
+	if got := q.String(); got != want {
+		t.Errorf("got query\n%s\nwant\n%s", got, want)
+	}
+	if got, want := q.Variables(), map[string]interface{}{"name": "r", "n": 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got variables %+v, want %+v", got, want)
+	}
+
+	q.Operation = "mutation"
+	q.Name = ""
+	q.vars = nil
+	q.Fields = []*Field{F("a")}
+	if got, want := q.String(), "mutation { a }"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// newTestClient returns a client that sends GraphQL requests to a
+// test server that responds with data.
+func newTestClient(t *testing.T, data string) (*sourcegraph.Client, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/.api/graphql" {
+			t.Errorf("got %s %s, want POST /.api/graphql", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":` + data + `}`))
+	}))
+	c := sourcegraph.NewClient(nil)
+	c.BaseURL, _ = url.Parse(server.URL + "/api/")
+	return c, server.Close
+}
+
+func TestGetRepository(t *testing.T) {
+	c, done := newTestClient(t, `{"repository":{"id":"UmVwbzox","name":"r","url":"/r","isFork":true,"defaultBranch":{"name":"refs/heads/master","displayName":"master"}}}`)
+	defer done()
+
+	repo, _, err := GetRepository(c, "r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Repository{ID: "UmVwbzox", Name: "r", URL: "/r", IsFork: true, DefaultBranch: &GitRef{Name: "refs/heads/master", DisplayName: "master"}}
+	if !reflect.DeepEqual(repo, want) {
+		t.Errorf("got %+v, want %+v", repo, want)
+	}
+}
+
+func TestGetCommit_notFound(t *testing.T) {
+	c, done := newTestClient(t, `{"repository":null}`)
+	defer done()
+
+	commit, _, err := GetCommit(c, "r", "v")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit != nil {
+		t.Errorf("got %+v, want nil", commit)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	c, done := newTestClient(t, `{"search":{"results":{"matchCount":2,"limitHit":false,"results":[
+		{"__typename":"FileMatch","file":{"path":"a.go","url":"/r/-/blob/a.go"},"repository":{"name":"r","url":"/r"},"lineMatches":[{"preview":"foo","lineNumber":3,"offsetAndLengths":[[0,3]]}]},
+		{"__typename":"Repository","name":"r2","url":"/r2"}
+	]}}}`)
+	defer done()
+
+	results, _, err := Search(c, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &SearchResults{
+		MatchCount: 2,
+		Results: []*SearchResult{
+			{
+				Typename:    "FileMatch",
+				File:        &File{Path: "a.go", URL: "/r/-/blob/a.go"},
+				Repository:  &Repository{Name: "r", URL: "/r"},
+				LineMatches: []*LineMatch{{Preview: "foo", LineNumber: 3, OffsetAndLengths: [][2]int{{0, 3}}}},
+			},
+			{Typename: "Repository", Name: "r2", URL: "/r2"},
+		},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("got %+v, want %+v", results, want)
+	}
+}
@@ -0,0 +1,209 @@
+package graphql
+
+import (
+	"time"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+// A Repository is a repository in a GraphQL response.
+type Repository struct {
+	ID            string
+	Name          string
+	URL           string
+	Description   string
+	IsFork        bool
+	IsArchived    bool
+	DefaultBranch *GitRef
+}
+
+// A GitRef is a branch or tag.
+type GitRef struct {
+	Name        string // the full ref name, such as "refs/heads/master"
+	DisplayName string // the short name, such as "master"
+}
+
+// A Commit is a commit in a GraphQL response.
+type Commit struct {
+	OID            string
+	AbbreviatedOID string
+	Subject        string // the first line of Message
+	Message        string
+	URL            string
+	Author         *Signature
+	Committer      *Signature
+
+	// Parents are the commit's parents (only their OIDs are set).
+	Parents []*Commit
+}
+
+// A Signature is the author or committer of a commit.
+type Signature struct {
+	Person *Person
+	Date   time.Time
+}
+
+// A Person is the person in a commit signature.
+type Person struct {
+	Name  string
+	Email string
+}
+
+// SearchResults are the results of a search.
+type SearchResults struct {
+	MatchCount int
+	LimitHit   bool // whether more results exist than were returned
+	Results    []*SearchResult
+}
+
+// A SearchResult is a file, repository, or commit that matches a
+// search. Typename is the result's type ("FileMatch", "Repository", or
+// "CommitSearchResult"), which determines which fields are set.
+type SearchResult struct {
+	Typename string `json:"__typename"`
+
+	// File, Repository, and LineMatches are set for FileMatch
+	// results.
+	File        *File
+	Repository  *Repository
+	LineMatches []*LineMatch
+
+	// Name and URL are set for Repository results (and URL for
+	// CommitSearchResult results).
+	Name string
+	URL  string
+
+	// Commit is set for CommitSearchResult results.
+	Commit *Commit
+}
+
+// A File is a file in a search result.
+type File struct {
+	Path string
+	URL  string
+}
+
+// A LineMatch is a line of a file that matches a search.
+type LineMatch struct {
+	Preview    string // the line's contents
+	LineNumber int    // 0-indexed
+
+	// OffsetAndLengths are the byte offset and length of each match
+	// in Preview.
+	OffsetAndLengths [][2]int
+}
+
+func repositoryFields() []*Field {
+	return []*Field{
+		F("id"), F("name"), F("url"), F("description"), F("isFork"), F("isArchived"),
+		F("defaultBranch", F("name"), F("displayName")),
+	}
+}
+
+func commitFields() []*Field {
+	signature := func(name string) *Field {
+		return F(name, F("person", F("name"), F("email")), F("date"))
+	}
+	return []*Field{
+		F("oid"), F("abbreviatedOID"), F("subject"), F("message"), F("url"),
+		signature("author"), signature("committer"),
+		F("parents", F("oid")),
+	}
+}
+
+// RepositoryQuery returns a query for the repository named name. Its
+// response data is a RepositoryResponse.
+func RepositoryQuery(name string) *Query {
+	return NewQuery("Repository").Var("name", "String!", name).Select(
+		F("repository", repositoryFields()...).Arg("name", "$name"),
+	)
+}
+
+// RepositoryResponse is the response data of a RepositoryQuery.
+type RepositoryResponse struct {
+	Repository *Repository // nil if the repository doesn't exist
+}
+
+// GetRepository fetches the repository named name. If it doesn't
+// exist, the returned repository is nil (and err is nil).
+func GetRepository(c *sourcegraph.Client, name string) (*Repository, sourcegraph.Response, error) {
+	var data RepositoryResponse
+	resp, err := RepositoryQuery(name).Do(c, &data)
+	if err != nil {
+		return nil, resp, err
+	}
+	return data.Repository, resp, nil
+}
+
+// CommitQuery returns a query for the commit that rev (a revision
+// specifier, such as a branch name or commit ID) resolves to in the
+// repository named repo. Its response data is a CommitResponse.
+func CommitQuery(repo, rev string) *Query {
+	return NewQuery("Commit").Var("repo", "String!", repo).Var("rev", "String!", rev).Select(
+		F("repository",
+			F("commit", commitFields()...).Arg("rev", "$rev"),
+		).Arg("name", "$repo"),
+	)
+}
+
+// CommitResponse is the response data of a CommitQuery.
+type CommitResponse struct {
+	// Repository is nil if the repository doesn't exist.
+	Repository *struct {
+		Commit *Commit // nil if the revision doesn't exist
+	}
+}
+
+// GetCommit fetches the commit that rev resolves to in the repository
+// named repo. If the repository or revision doesn't exist, the
+// returned commit is nil (and err is nil).
+func GetCommit(c *sourcegraph.Client, repo, rev string) (*Commit, sourcegraph.Response, error) {
+	var data CommitResponse
+	resp, err := CommitQuery(repo, rev).Do(c, &data)
+	if err != nil || data.Repository == nil {
+		return nil, resp, err
+	}
+	return data.Repository.Commit, resp, nil
+}
+
+// SearchQuery returns a query for the results of a search (in the
+// server's search query syntax). Its response data is a
+// SearchResponse.
+func SearchQuery(query string) *Query {
+	return NewQuery("Search").Var("query", "String!", query).Select(
+		F("search",
+			F("results",
+				F("matchCount"),
+				F("limitHit"),
+				F("results",
+					F("__typename"),
+					On("FileMatch",
+						F("file", F("path"), F("url")),
+						F("repository", F("name"), F("url")),
+						F("lineMatches", F("preview"), F("lineNumber"), F("offsetAndLengths")),
+					),
+					On("Repository", F("name"), F("url")),
+					On("CommitSearchResult", F("url"), F("commit", commitFields()...)),
+				),
+			),
+		).Arg("query", "$query"),
+	)
+}
+
+// SearchResponse is the response data of a SearchQuery.
+type SearchResponse struct {
+	Search *struct {
+		Results *SearchResults
+	}
+}
+
+// Search returns the results of a search (in the server's search query
+// syntax).
+func Search(c *sourcegraph.Client, query string) (*SearchResults, sourcegraph.Response, error) {
+	var data SearchResponse
+	resp, err := SearchQuery(query).Do(c, &data)
+	if err != nil || data.Search == nil {
+		return nil, resp, err
+	}
+	return data.Search.Results, resp, nil
+}
@@ -0,0 +1,64 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestClient_GraphQL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.GraphQL, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"query":"query Q($n: String!) { repository(name: $n) { url } }","variables":{"n":"r"}}`+"\n")
+		if r.Header.Get(IdempotencyKeyHeader) != "" {
+			t.Error("GraphQL request has an idempotency key")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"repository":{"url":"/r"}}}`))
+	})
+
+	var out struct{ Repository struct{ URL string } }
+	_, err := client.GraphQL("query Q($n: String!) { repository(name: $n) { url } }", map[string]interface{}{"n": "r"}, &out)
+	if err != nil {
+		t.Errorf("GraphQL returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if want := "/r"; out.Repository.URL != want {
+		t.Errorf("got URL %q, want %q", out.Repository.URL, want)
+	}
+}
+
+func TestClient_GraphQL_errors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(urlPath(t, router.GraphQL, nil), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"a":1,"b":null},"errors":[{"message":"boom","path":["b"]}]}`))
+	})
+
+	var out struct{ A, B *int }
+	_, err := client.GraphQL("{ a b }", nil, &out)
+	want := GraphQLErrors{{Message: "boom", Path: []interface{}{"b"}}}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("got error %#v, want %#v", err, want)
+	}
+	if got, want := err.Error(), "graphql: b: boom"; got != want {
+		t.Errorf("got error message %q, want %q", got, want)
+	}
+	if out.A == nil || *out.A != 1 {
+		t.Errorf("partial data was not decoded: %+v", out)
+	}
+}
@@ -0,0 +1,125 @@
+package sourcegraph
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// IdempotencyKeyHeader is the HTTP request header that carries an
+// idempotency key. The server discards requests whose idempotency
+// key it has already seen (returning the original response), so a
+// request with a key may be retried without repeating its effects.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetrySafety classifies whether a request to an API route may be
+// automatically retried.
+type RetrySafety int
+
+const (
+	// Idempotent routes may always be retried: repeating a request has
+	// the same effect as making it once. All GET, HEAD, PUT, and
+	// DELETE routes are idempotent.
+	Idempotent RetrySafety = iota
+
+	// IdempotentWithKey routes create resources (such as comments),
+	// so repeating a request would create duplicates. Requests to
+	// these routes are sent with an idempotency key, which makes them
+	// safe to retry.
+	IdempotentWithKey
+
+	// NotIdempotent routes must never be retried automatically,
+	// because repeating a request has additional effects that an
+	// idempotency key can't prevent.
+	NotIdempotent
+)
+
+// routeRetrySafety classifies every POST route, including those that
+// only read data (such as the batch routes) and so are Idempotent.
+// Routes that aren't listed (which have only GET, HEAD, PUT, or DELETE
+// methods) are Idempotent.
+var routeRetrySafety = map[string]RetrySafety{
+	router.AdminTestEmail:                      NotIdempotent,
+	router.BuildDequeueNext:                    NotIdempotent,
+	router.BuildTasksCreate:                    IdempotentWithKey,
+	router.DefsBatch:                           Idempotent,
+	router.ExtGitHubReceiveWebhook:             NotIdempotent,
+	router.GraphQL:                             NotIdempotent,
+	router.Markdown:                            Idempotent,
+	router.MonitoringSilencesCreate:            IdempotentWithKey,
+	router.OrgTeamsCreate:                      IdempotentWithKey,
+	router.PeopleInvite:                        IdempotentWithKey,
+	router.RepoBuildsCreate:                    IdempotentWithKey,
+	router.RepoCounterRecordHit:                IdempotentWithKey,
+	router.RepoIssueCommentsCreate:             IdempotentWithKey,
+	router.RepoIssueLabelsAdd:                  Idempotent,
+	router.RepoIssuesCreate:                    IdempotentWithKey,
+	router.RepoNotificationDestinationsCreate:  IdempotentWithKey,
+	router.RepoNotificationDestinationTest:     NotIdempotent,
+	router.RepoPullRequestCommentsCreate:       IdempotentWithKey,
+	router.RepoPullRequestReviewCommentsCreate: IdempotentWithKey,
+	router.RepoPullRequestReviewSubmit:         IdempotentWithKey,
+	router.RepoPullRequestReviewsCreate:        IdempotentWithKey,
+	router.RepoPullRequestsCreate:              IdempotentWithKey,
+	router.RepoStatusCreate:                    IdempotentWithKey,
+	router.ReposBatch:                          Idempotent,
+	router.ReposCreate:                         IdempotentWithKey,
+	router.Snippet:                             Idempotent,
+	router.TrackerLinksCreate:                  IdempotentWithKey,
+	router.UserEmailsAdd:                       IdempotentWithKey,
+	router.UserTokensCreate:                    IdempotentWithKey,
+}
+
+// RouteRetrySafety returns the retry-safety classification of the
+// named API route.
+func RouteRetrySafety(route string) RetrySafety {
+	return routeRetrySafety[route]
+}
+
+// IsRetrySafe reports whether req may be retried (for example, after
+// a network error or a 5xx response) without risk of repeating its
+// effects. Requests with idempotent methods (GET, HEAD, OPTIONS, PUT,
+// and DELETE) and requests with an idempotency key are retry-safe.
+//
+// IsRetrySafe doesn't know which API route req is for, so it treats
+// other POST requests as unsafe. A Client also retries POST requests
+// to routes that are classified as Idempotent (such as ReposBatch).
+func IsRetrySafe(req *http.Request) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// isRetrySafe is like IsRetrySafe, but it also reports requests to
+// routes that are classified as Idempotent as retry-safe.
+func (c *Client) isRetrySafe(req *http.Request) bool {
+	if IsRetrySafe(req) {
+		return true
+	}
+	// Only listed routes are known to be POST routes; unmatched
+	// requests (whose route name is "") must not be retried.
+	safety, ok := routeRetrySafety[c.routeName(req)]
+	return ok && safety == Idempotent
+}
+
+// NewIdempotencyKey returns a new random idempotency key.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic("NewIdempotencyKey: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
+
+// setIdempotencyKey adds an idempotency key header to req if route
+// is classified as IdempotentWithKey (and req doesn't already have
+// one).
+func setIdempotencyKey(req *http.Request, route string) {
+	if RouteRetrySafety(route) == IdempotentWithKey && req.Header.Get(IdempotencyKeyHeader) == "" {
+		req.Header.Set(IdempotencyKeyHeader, NewIdempotencyKey())
+	}
+}
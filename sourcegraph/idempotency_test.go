@@ -0,0 +1,98 @@
+package sourcegraph
+
+import (
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"github.com/sourcegraph/go-github/github"
+)
+
+func TestIdempotencyKey_createComment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/foo"}, Number: 22}
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestCommentsCreate, pullSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if key := r.Header.Get(IdempotencyKeyHeader); len(key) != 32 {
+			t.Errorf("got idempotency key %q, want a 32-char key", key)
+		}
+		writeJSON(w, &PullRequestComment{})
+	})
+
+	if _, _, err := client.PullRequests.CreateComment(pullSpec, &PullRequestComment{PullRequestComment: github.PullRequestComment{Body: github.String("hi")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestIsRetrySafe(t *testing.T) {
+	tests := []struct {
+		method string
+		key    string
+		want   bool
+	}{
+		{"GET", "", true},
+		{"PUT", "", true},
+		{"DELETE", "", true},
+		{"POST", "", false},
+		{"POST", "k", true},
+		{"PATCH", "", false},
+	}
+	for _, test := range tests {
+		req, _ := http.NewRequest(test.method, "http://example.com", nil)
+		if test.key != "" {
+			req.Header.Set(IdempotencyKeyHeader, test.key)
+		}
+		if got := IsRetrySafe(req); got != test.want {
+			t.Errorf("%s (key %q): got IsRetrySafe == %v, want %v", test.method, test.key, got, test.want)
+		}
+	}
+}
+
+func TestRouteRetrySafety(t *testing.T) {
+	if got := RouteRetrySafety(router.RepoPullRequestCommentsCreate); got != IdempotentWithKey {
+		t.Errorf("got %v, want IdempotentWithKey", got)
+	}
+	if got := RouteRetrySafety(router.BuildDequeueNext); got != NotIdempotent {
+		t.Errorf("got %v, want NotIdempotent", got)
+	}
+	if got := RouteRetrySafety(router.Repo); got != Idempotent {
+		t.Errorf("got %v, want Idempotent", got)
+	}
+}
+
+// TestRouteRetrySafety_postRoutes checks that every POST route is
+// classified in routeRetrySafety, so that new POST routes aren't
+// treated as Idempotent by default.
+func TestRouteRetrySafety_postRoutes(t *testing.T) {
+	src, err := ioutil.ReadFile("../router/api_router.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes := regexp.MustCompile(`\.Methods\([^)]*"POST"[^)]*\)\.Name\((\w+)\)`).FindAllSubmatch(src, -1)
+	if len(routes) == 0 {
+		t.Fatal("found no POST routes")
+	}
+	names := map[string]string{} // route constant name -> route name
+	for _, m := range regexp.MustCompile(`(?m)^\t(\w+)\s*=\s*"([^"]+)"$`).FindAllSubmatch(src, -1) {
+		names[string(m[1])] = string(m[2])
+	}
+	for _, m := range routes {
+		route, ok := names[string(m[1])]
+		if !ok {
+			t.Errorf("unknown route constant router.%s", m[1])
+			continue
+		}
+		if _, ok := routeRetrySafety[route]; !ok {
+			t.Errorf("POST route router.%s is not classified in routeRetrySafety", m[1])
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Package issueref finds references to issues and pull requests in
+// free text. It is the implementation shared by
+// sourcegraph.ParseReferences and the references package.
+package issueref
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A Ref is a reference to an issue or pull request in text.
+type Ref struct {
+	// Repo is the URI of the repository of the issue or pull request.
+	// It is empty for "#123" references, which refer to the base
+	// repository.
+	Repo string
+
+	// Pull is whether the reference is a pull request URL. (Short
+	// references and issue URLs refer to issues.)
+	Pull bool
+
+	// Number is the number of the issue or pull request.
+	Number int
+
+	// Start and End are the byte offsets of the reference in the text
+	// it was found in (so text[Start:End] is the reference). For URLs,
+	// they span the whole URL, except for trailing punctuation.
+	Start, End int
+}
+
+var (
+	codePattern     = regexp.MustCompile("(?s)```.*?(```|$)|`[^`\n]*`")
+	urlPattern      = regexp.MustCompile(`https?://\S+`)
+	urlRefPattern   = regexp.MustCompile(`^https?://([^\s/]+/[^\s/]+/[^\s/#?]+)/(pull|issues)/(\d+)`)
+	shortRefPattern = regexp.MustCompile(`([\w.-]+/[\w.-]+)?#(\d+)`)
+)
+
+// Find returns the references to issues and pull requests in text (in
+// markdown), in the order in which they appear, and a copy of text in
+// which code spans, code blocks, and URLs are replaced by spaces (so
+// that callers may scan it for other kinds of references). A reference
+// that occurs more than once is returned for each occurrence.
+// "owner/repo#123" references are resolved relative to the host of the
+// base repository, whose URI is baseURI. References in code are
+// ignored.
+func Find(baseURI, text string) (refs []Ref, rest string) {
+	// Blank out code, and then URLs (after finding references in
+	// them), so that they aren't scanned for short references.
+	scan := []byte(codePattern.ReplaceAllStringFunc(text, func(s string) string { return strings.Repeat(" ", len(s)) }))
+
+	for _, m := range urlPattern.FindAllIndex(scan, -1) {
+		start, end := m[0], m[0]+len(strings.TrimRight(string(scan[m[0]:m[1]]), ".,;:!?)'\""))
+		if sm := urlRefPattern.FindSubmatch(scan[start:end]); sm != nil {
+			if n, err := strconv.Atoi(string(sm[3])); err == nil {
+				refs = append(refs, Ref{Repo: string(sm[1]), Pull: string(sm[2]) == "pull", Number: n, Start: start, End: end})
+			}
+		}
+		for i := m[0]; i < m[1]; i++ {
+			scan[i] = ' '
+		}
+	}
+
+	rest = string(scan)
+	for _, m := range shortRefPattern.FindAllStringSubmatchIndex(rest, -1) {
+		if !BoundaryBefore(rest, m[0], "&/") || (m[1] < len(rest) && IsWordByte(rest[m[1]])) {
+			continue
+		}
+		n, err := strconv.Atoi(rest[m[4]:m[5]])
+		if err != nil {
+			continue
+		}
+		var repo string
+		if m[2] != -1 {
+			repo = RepoHost(baseURI) + rest[m[2]:m[3]]
+		}
+		refs = append(refs, Ref{Repo: repo, Number: n, Start: m[0], End: m[1]})
+	}
+
+	// URL and short references were found separately.
+	sort.Sort(refsByStart(refs))
+	return refs, rest
+}
+
+// refsByStart sorts refs by Start.
+type refsByStart []Ref
+
+func (v refsByStart) Len() int           { return len(v) }
+func (v refsByStart) Less(i, j int) bool { return v[i].Start < v[j].Start }
+func (v refsByStart) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+
+// BoundaryBefore reports whether the reference starting at s[i] is at
+// the beginning of s or is preceded by a character that is neither a
+// word character nor one of the disallowed characters.
+func BoundaryBefore(s string, i int, disallowed string) bool {
+	if i == 0 {
+		return true
+	}
+	c := s[i-1]
+	return !IsWordByte(c) && strings.IndexByte(disallowed, c) == -1
+}
+
+// IsWordByte reports whether c is an ASCII letter, digit, or
+// underscore.
+func IsWordByte(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// RepoHost returns the host component of a repository URI (such as
+// "github.com/" for "github.com/o/r"), including the trailing slash,
+// or "" if the URI has only 2 path components.
+func RepoHost(uri string) string {
+	parts := strings.Split(uri, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[0] + "/"
+}
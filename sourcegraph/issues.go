@@ -7,6 +7,7 @@ import (
 
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fossas/go-sourcegraph/router"
 )
@@ -23,14 +24,58 @@ type IssuesService interface {
 	// ListComments lists comments on a issue.
 	ListComments(issue IssueSpec, opt *IssueListCommentsOptions) ([]*IssueComment, Response, error)
 
-	// CreateComment creates a comment on an issue.
+	// ListAllComments lists the comments on all of a repository's
+	// issues (including the main discussions of its pull requests),
+	// least recently updated first by default. It is for exporting or
+	// analyzing a repository's comments without listing each issue's
+	// comments.
+	ListAllComments(repo RepoSpec, opt *IssueListAllCommentsOptions) ([]*RepoIssueComment, Response, error)
+
+	// CreateComment creates a comment on an issue. The comment body is
+	// normalized and validated as in PullRequestsService.CreateComment.
 	CreateComment(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error)
 
-	// EditComment updates a comment on an issue.
+	// EditComment updates a comment on an issue. The comment body (if
+	// set) is normalized and validated as in
+	// PullRequestsService.CreateComment.
 	EditComment(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error)
 
 	// DeleteComment deletes a comment on an issue.
 	DeleteComment(issue IssueSpec, commentID int) (Response, error)
+
+	// Export fetches a self-contained archive of an issue (with all of
+	// its comments and events), for archival or migration.
+	Export(issue IssueSpec) (*IssueArchive, Response, error)
+
+	// Create creates an issue in a repository. The issue must have a
+	// title, and its body (if set) is normalized and validated as in
+	// PullRequestsService.CreateComment.
+	Create(repo RepoSpec, issue *IssueRequest) (*Issue, Response, error)
+
+	// Edit updates an issue. Only the fields of edit that are set are
+	// changed.
+	Edit(issue IssueSpec, edit *IssueRequest) (*Issue, Response, error)
+
+	// Close closes an issue.
+	Close(issue IssueSpec) (*Issue, Response, error)
+
+	// Reopen reopens a closed issue.
+	Reopen(issue IssueSpec) (*Issue, Response, error)
+
+	// AddLabels adds labels to an issue and returns all of the issue's
+	// labels. Adding a label that the issue already has has no effect.
+	AddLabels(issue IssueSpec, labels []string) ([]github.Label, Response, error)
+
+	// RemoveLabel removes a label from an issue.
+	RemoveLabel(issue IssueSpec, label string) (Response, error)
+
+	// SetAssignees replaces an issue's assignees with the given users
+	// (by login). If logins is empty, all assignees are removed.
+	SetAssignees(issue IssueSpec, logins []string) (*Issue, Response, error)
+
+	// ListEvents lists the events (other than comments) in the history
+	// of an issue, such as its being closed, reopened, or labeled.
+	ListEvents(issue IssueSpec, opt *IssueListEventsOptions) ([]*IssueEvent, Response, error)
 }
 
 // issuesService implements IssuesService.
@@ -72,30 +117,27 @@ type Issue struct {
 }
 
 // Spec returns the IssueSpec that specifies r.
+//
+// The repository is derived from r's HTMLURL (e.g.,
+// "https://github.com/foo/bar/issues/1" yields the repository
+// "github.com/foo/bar"). If HTMLURL is not set or is not of that form,
+// the returned spec's Repo is empty.
 func (r *Issue) Spec() IssueSpec {
-	// Extract the URI from the HTMLURL field.
-	uri := strings.Join(strings.Split(strings.TrimPrefix(*r.HTMLURL, "https://"), "/")[0:3], "/")
-	return IssueSpec{
-		Repo:   RepoSpec{URI: uri},
-		Number: *r.Number,
+	var spec IssueSpec
+	if r.Number != nil {
+		spec.Number = *r.Number
+	}
+	if r.HTMLURL != nil {
+		spec.Repo = repoFromHTMLURL(*r.HTMLURL, "/issues/")
 	}
+	return spec
 }
 
 type IssueGetOptions struct{}
 
 func (s *issuesService) Get(issue IssueSpec, opt *IssueGetOptions) (*Issue, Response, error) {
-	url, err := s.client.URL(router.RepoIssue, issue.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var issue_ *Issue
-	resp, err := s.client.Do(req, &issue_)
+	resp, err := s.client.DoGet(router.RepoIssue, issue.RouteVars(), opt, &issue_)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -105,22 +147,14 @@ func (s *issuesService) Get(issue IssueSpec, opt *IssueGetOptions) (*Issue, Resp
 
 type IssueListOptions struct {
 	State string `url:",omitempty"` // "open", "closed", or "all"
+	TimeRangeOptions
+	SortOptions
 	ListOptions
 }
 
 func (s *issuesService) ListByRepo(repo RepoSpec, opt *IssueListOptions) ([]*Issue, Response, error) {
-	url, err := s.client.URL(router.RepoIssues, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var issues []*Issue
-	resp, err := s.client.Do(req, &issues)
+	resp, err := s.client.DoList(router.RepoIssues, repo.RouteVars(), opt, &issues)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -129,6 +163,8 @@ func (s *issuesService) ListByRepo(repo RepoSpec, opt *IssueListOptions) ([]*Iss
 }
 
 type IssueListCommentsOptions struct {
+	TimeRangeOptions
+	SortOptions
 	ListOptions
 }
 
@@ -150,18 +186,39 @@ func (s IssueCommentSpec) RouteVars() map[string]string {
 }
 
 func (s *issuesService) ListComments(issue IssueSpec, opt *IssueListCommentsOptions) ([]*IssueComment, Response, error) {
-	url, err := s.client.URL(router.RepoIssueComments, issue.RouteVars(), opt)
+	var comments []*IssueComment
+	resp, err := s.client.DoList(router.RepoIssueComments, issue.RouteVars(), opt, &comments)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	return comments, resp, nil
+}
 
-	var comments []*IssueComment
-	resp, err := s.client.Do(req, &comments)
+// A RepoIssueComment is a comment on one of a repository's issues, as
+// listed by IssuesService.ListAllComments.
+type RepoIssueComment struct {
+	// Number is the number of the issue (or pull request) that the
+	// comment is on.
+	Number int
+
+	*IssueComment
+}
+
+// IssueListAllCommentsOptions specifies options for
+// IssuesService.ListAllComments.
+type IssueListAllCommentsOptions struct {
+	// TimeRangeOptions restricts the comments listed to those updated
+	// in the time range.
+	TimeRangeOptions
+
+	SortOptions
+	ListOptions
+}
+
+func (s *issuesService) ListAllComments(repo RepoSpec, opt *IssueListAllCommentsOptions) ([]*RepoIssueComment, Response, error) {
+	var comments []*RepoIssueComment
+	resp, err := s.client.DoList(router.RepoIssuesComments, repo.RouteVars(), opt, &comments)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -170,18 +227,15 @@ func (s *issuesService) ListComments(issue IssueSpec, opt *IssueListCommentsOpti
 }
 
 func (s *issuesService) CreateComment(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error) {
-	url, err := s.client.URL(router.RepoIssueCommentsCreate, issue.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("POST", url.String(), comment)
+	body, err := prepareCommentBody(comment.Body, true)
 	if err != nil {
 		return nil, nil, err
 	}
+	normalized := *comment
+	normalized.Body = body
 
 	var createdComment IssueComment
-	resp, err := s.client.Do(req, &createdComment)
+	resp, err := s.client.DoCreate(router.RepoIssueCommentsCreate, issue.RouteVars(), &normalized, &createdComment)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -194,12 +248,19 @@ func (s *issuesService) EditComment(issue IssueSpec, comment *IssueComment) (*Is
 		return nil, nil, fmt.Errorf("comment ID not specified")
 	}
 
+	body, err := prepareCommentBody(comment.Body, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	normalized := *comment
+	normalized.Body = body
+
 	url, err := s.client.URL(router.RepoIssueCommentsEdit, IssueCommentSpec{Issue: issue, Comment: *comment.ID}.RouteVars(), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest("PATCH", url.String(), comment)
+	req, err := s.client.NewRequest("PATCH", url.String(), &normalized)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -214,17 +275,219 @@ func (s *issuesService) EditComment(issue IssueSpec, comment *IssueComment) (*Is
 }
 
 func (s *issuesService) DeleteComment(issue IssueSpec, commentID int) (Response, error) {
-	url, err := s.client.URL(router.RepoIssueCommentsDelete, IssueCommentSpec{Issue: issue, Comment: commentID}.RouteVars(), nil)
+	resp, err := s.client.DoDelete(router.RepoIssueCommentsDelete, IssueCommentSpec{Issue: issue, Comment: commentID}.RouteVars())
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := s.client.NewRequest("DELETE", url.String(), nil)
+	return resp, nil
+}
+
+// ArchiveVersion is the current version of the archive format returned
+// by PullRequestsService.Export and IssuesService.Export.
+const ArchiveVersion = 1
+
+// Types of IssueEvent (the values of its Event field). The
+// "committed", "reviewed", and "commented" events occur only in pull
+// request timelines (see PullRequestsService.ListEvents).
+const (
+	IssueEventCommitted  = "committed"
+	IssueEventReviewed   = "reviewed"
+	IssueEventCommented  = "commented"
+	IssueEventLabeled    = "labeled"
+	IssueEventUnlabeled  = "unlabeled"
+	IssueEventAssigned   = "assigned"
+	IssueEventUnassigned = "unassigned"
+	IssueEventClosed     = "closed"
+	IssueEventReopened   = "reopened"
+	IssueEventMerged     = "merged"
+)
+
+// An IssueEvent is an event in the history of an issue or pull request,
+// such as its being closed or labeled. Which of its optional fields are
+// set depends on its Event.
+type IssueEvent struct {
+	ID int
+
+	// Actor is the user who caused the event (e.g., who closed the
+	// issue or pushed the commit).
+	Actor UserSpec
+
+	// Event is the type of event, such as "closed", "reopened",
+	// "merged", "labeled", or "assigned" (see the IssueEvent*
+	// constants).
+	Event string
+
+	// CommitID is the commit that caused the event (e.g., the commit
+	// that closed the issue, or the merge commit for "merged" events),
+	// if any.
+	CommitID string `json:",omitempty"`
+
+	// Commit is the commit that was pushed, for "committed" events.
+	Commit *Commit `json:",omitempty"`
+
+	// Review is the review, for "reviewed" events.
+	Review *PullRequestReview `json:",omitempty"`
+
+	// Comment is the comment, for "commented" events.
+	Comment *IssueComment `json:",omitempty"`
+
+	// Label is the label that was added or removed, for "labeled" and
+	// "unlabeled" events.
+	Label string `json:",omitempty"`
+
+	// Assignee is the user who was assigned or unassigned, for
+	// "assigned" and "unassigned" events.
+	Assignee *UserSpec `json:",omitempty"`
+
+	Created time.Time
+}
+
+// An IssueArchive is a self-contained document describing an issue and
+// all of its activity, as returned by IssuesService.Export. See
+// PullRequestArchive.
+type IssueArchive struct {
+	// Version is the archive format version (ArchiveVersion, for
+	// archives created by the current server).
+	Version int
+
+	// Exported is when the archive was created.
+	Exported time.Time
+
+	Issue *Issue
+
+	Comments []*IssueComment `json:",omitempty"`
+
+	Events []*IssueEvent `json:",omitempty"`
+}
+
+func (s *issuesService) Export(issue IssueSpec) (*IssueArchive, Response, error) {
+	var archive *IssueArchive
+	resp, err := s.client.DoGet(router.RepoIssueExport, issue.RouteVars(), nil, &archive)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	return archive, resp, nil
+}
+
+// Issue states (see IssueRequest.State).
+const (
+	IssueStateOpen   = "open"
+	IssueStateClosed = "closed"
+)
+
+// An IssueRequest specifies the fields of an issue to create (with
+// IssuesService.Create) or to change (with IssuesService.Edit). On
+// edit, nil and empty fields are left unchanged; use RemoveLabel and
+// SetAssignees to remove labels and assignees.
+type IssueRequest struct {
+	Title *string `json:",omitempty"`
+	Body  *string `json:",omitempty"`
+
+	// State is the issue's state (IssueStateOpen or IssueStateClosed).
+	// New issues are always open.
+	State string `json:",omitempty"`
+
+	// Labels are the names of labels to add to the issue.
+	Labels []string `json:",omitempty"`
+
+	// Assignees are the logins of the users to assign to the issue.
+	Assignees []string `json:",omitempty"`
+}
+
+func (s *issuesService) Create(repo RepoSpec, issue *IssueRequest) (*Issue, Response, error) {
+	if issue.Title == nil || strings.TrimSpace(*issue.Title) == "" {
+		return nil, nil, &ValidationError{Field: "Title", Problems: []string{"must not be empty"}}
+	}
+	if issue.State != "" && issue.State != IssueStateOpen {
+		return nil, nil, &ValidationError{Field: "State", Problems: []string{"new issues must be open"}}
+	}
+
+	body, err := prepareCommentBody(issue.Body, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	normalized := *issue
+	normalized.Body = body
+
+	var created Issue
+	resp, err := s.client.DoCreate(router.RepoIssuesCreate, repo.RouteVars(), &normalized, &created)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &created, resp, nil
+}
+
+func (s *issuesService) Edit(issue IssueSpec, edit *IssueRequest) (*Issue, Response, error) {
+	if edit.Title != nil && strings.TrimSpace(*edit.Title) == "" {
+		return nil, nil, &ValidationError{Field: "Title", Problems: []string{"must not be empty"}}
+	}
+	switch edit.State {
+	case "", IssueStateOpen, IssueStateClosed:
+	default:
+		return nil, nil, &ValidationError{Field: "State", Problems: []string{fmt.Sprintf("unrecognized issue state %q", edit.State)}}
+	}
+
+	body, err := prepareCommentBody(edit.Body, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	normalized := *edit
+	normalized.Body = body
+
+	url, err := s.client.URL(router.RepoIssueEdit, issue.RouteVars(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("PATCH", url.String(), &normalized)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updated Issue
+	resp, err := s.client.Do(req, &updated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &updated, resp, nil
+}
+
+func (s *issuesService) Close(issue IssueSpec) (*Issue, Response, error) {
+	return s.Edit(issue, &IssueRequest{State: IssueStateClosed})
+}
+
+func (s *issuesService) Reopen(issue IssueSpec) (*Issue, Response, error) {
+	return s.Edit(issue, &IssueRequest{State: IssueStateOpen})
+}
+
+func (s *issuesService) AddLabels(issue IssueSpec, labels []string) ([]github.Label, Response, error) {
+	if len(labels) == 0 {
+		return nil, nil, &ValidationError{Field: "Labels", Problems: []string{"no labels specified"}}
+	}
+
+	var allLabels []github.Label
+	resp, err := s.client.DoCreate(router.RepoIssueLabelsAdd, issue.RouteVars(), labels, &allLabels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return allLabels, resp, nil
+}
+
+// issueLabelRouteVars returns the route variables for the given label
+// of an issue.
+func issueLabelRouteVars(issue IssueSpec, label string) map[string]string {
+	rv := issue.RouteVars()
+	rv["Label"] = label
+	return rv
+}
+
+func (s *issuesService) RemoveLabel(issue IssueSpec, label string) (Response, error) {
+	resp, err := s.client.DoDelete(router.RepoIssueLabelRemove, issueLabelRouteVars(issue, label))
 	if err != nil {
 		return nil, err
 	}
@@ -232,4 +495,34 @@ func (s *issuesService) DeleteComment(issue IssueSpec, commentID int) (Response,
 	return resp, nil
 }
 
+func (s *issuesService) SetAssignees(issue IssueSpec, logins []string) (*Issue, Response, error) {
+	if logins == nil {
+		logins = []string{}
+	}
+
+	var updated Issue
+	resp, err := s.client.DoUpdate(router.RepoIssueAssignees, issue.RouteVars(), logins, &updated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &updated, resp, nil
+}
+
+type IssueListEventsOptions struct {
+	TimeRangeOptions
+	SortOptions
+	ListOptions
+}
+
+func (s *issuesService) ListEvents(issue IssueSpec, opt *IssueListEventsOptions) ([]*IssueEvent, Response, error) {
+	var events []*IssueEvent
+	resp, err := s.client.DoList(router.RepoIssueEvents, issue.RouteVars(), opt, &events)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return events, resp, nil
+}
+
 var _ IssuesService = &MockIssuesService{}
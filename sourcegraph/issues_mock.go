@@ -1,34 +1,191 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
+import "github.com/sourcegraph/go-github/github"
+
 type MockIssuesService struct {
-	Get_           func(issue IssueSpec, opt *IssueGetOptions) (*Issue, Response, error)
-	ListByRepo_    func(repo RepoSpec, opt *IssueListOptions) ([]*Issue, Response, error)
-	ListComments_  func(issue IssueSpec, opt *IssueListCommentsOptions) ([]*IssueComment, Response, error)
-	CreateComment_ func(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error)
-	EditComment_   func(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error)
-	DeleteComment_ func(issue IssueSpec, commentID int) (Response, error)
+	Get_             func(issue IssueSpec, opt *IssueGetOptions) (*Issue, Response, error)
+	ListByRepo_      func(repo RepoSpec, opt *IssueListOptions) ([]*Issue, Response, error)
+	ListComments_    func(issue IssueSpec, opt *IssueListCommentsOptions) ([]*IssueComment, Response, error)
+	ListAllComments_ func(repo RepoSpec, opt *IssueListAllCommentsOptions) ([]*RepoIssueComment, Response, error)
+	CreateComment_   func(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error)
+	EditComment_     func(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error)
+	DeleteComment_   func(issue IssueSpec, commentID int) (Response, error)
+	Export_          func(issue IssueSpec) (*IssueArchive, Response, error)
+	Create_          func(repo RepoSpec, issue *IssueRequest) (*Issue, Response, error)
+	Edit_            func(issue IssueSpec, edit *IssueRequest) (*Issue, Response, error)
+	Close_           func(issue IssueSpec) (*Issue, Response, error)
+	Reopen_          func(issue IssueSpec) (*Issue, Response, error)
+	AddLabels_       func(issue IssueSpec, labels []string) ([]github.Label, Response, error)
+	RemoveLabel_     func(issue IssueSpec, label string) (Response, error)
+	SetAssignees_    func(issue IssueSpec, logins []string) (*Issue, Response, error)
+	ListEvents_      func(issue IssueSpec, opt *IssueListEventsOptions) ([]*IssueEvent, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockIssuesService returns a new MockIssuesService
+// that records calls to its methods.
+func NewMockIssuesService() *MockIssuesService {
+	return &MockIssuesService{Calls: &MockCalls{}}
 }
 
 func (s MockIssuesService) Get(issue IssueSpec, opt *IssueGetOptions) (*Issue, Response, error) {
+	s.Calls.record("Get", issue, opt)
+	if s.Get_ == nil {
+		var r0 *Issue
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.Get")
+	}
 	return s.Get_(issue, opt)
 }
 
 func (s MockIssuesService) ListByRepo(repo RepoSpec, opt *IssueListOptions) ([]*Issue, Response, error) {
+	s.Calls.record("ListByRepo", repo, opt)
+	if s.ListByRepo_ == nil {
+		var r0 []*Issue
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.ListByRepo")
+	}
 	return s.ListByRepo_(repo, opt)
 }
 
 func (s MockIssuesService) ListComments(issue IssueSpec, opt *IssueListCommentsOptions) ([]*IssueComment, Response, error) {
+	s.Calls.record("ListComments", issue, opt)
+	if s.ListComments_ == nil {
+		var r0 []*IssueComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.ListComments")
+	}
 	return s.ListComments_(issue, opt)
 }
 
+func (s MockIssuesService) ListAllComments(repo RepoSpec, opt *IssueListAllCommentsOptions) ([]*RepoIssueComment, Response, error) {
+	s.Calls.record("ListAllComments", repo, opt)
+	if s.ListAllComments_ == nil {
+		var r0 []*RepoIssueComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.ListAllComments")
+	}
+	return s.ListAllComments_(repo, opt)
+}
+
 func (s MockIssuesService) CreateComment(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error) {
+	s.Calls.record("CreateComment", issue, comment)
+	if s.CreateComment_ == nil {
+		var r0 *IssueComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.CreateComment")
+	}
 	return s.CreateComment_(issue, comment)
 }
 
 func (s MockIssuesService) EditComment(issue IssueSpec, comment *IssueComment) (*IssueComment, Response, error) {
+	s.Calls.record("EditComment", issue, comment)
+	if s.EditComment_ == nil {
+		var r0 *IssueComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.EditComment")
+	}
 	return s.EditComment_(issue, comment)
 }
 
 func (s MockIssuesService) DeleteComment(issue IssueSpec, commentID int) (Response, error) {
+	s.Calls.record("DeleteComment", issue, commentID)
+	if s.DeleteComment_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("IssuesService.DeleteComment")
+	}
 	return s.DeleteComment_(issue, commentID)
 }
+
+func (s MockIssuesService) Export(issue IssueSpec) (*IssueArchive, Response, error) {
+	s.Calls.record("Export", issue)
+	if s.Export_ == nil {
+		var r0 *IssueArchive
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.Export")
+	}
+	return s.Export_(issue)
+}
+
+func (s MockIssuesService) Create(repo RepoSpec, issue *IssueRequest) (*Issue, Response, error) {
+	s.Calls.record("Create", repo, issue)
+	if s.Create_ == nil {
+		var r0 *Issue
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.Create")
+	}
+	return s.Create_(repo, issue)
+}
+
+func (s MockIssuesService) Edit(issue IssueSpec, edit *IssueRequest) (*Issue, Response, error) {
+	s.Calls.record("Edit", issue, edit)
+	if s.Edit_ == nil {
+		var r0 *Issue
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.Edit")
+	}
+	return s.Edit_(issue, edit)
+}
+
+func (s MockIssuesService) Close(issue IssueSpec) (*Issue, Response, error) {
+	s.Calls.record("Close", issue)
+	if s.Close_ == nil {
+		var r0 *Issue
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.Close")
+	}
+	return s.Close_(issue)
+}
+
+func (s MockIssuesService) Reopen(issue IssueSpec) (*Issue, Response, error) {
+	s.Calls.record("Reopen", issue)
+	if s.Reopen_ == nil {
+		var r0 *Issue
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.Reopen")
+	}
+	return s.Reopen_(issue)
+}
+
+func (s MockIssuesService) AddLabels(issue IssueSpec, labels []string) ([]github.Label, Response, error) {
+	s.Calls.record("AddLabels", issue, labels)
+	if s.AddLabels_ == nil {
+		var r0 []github.Label
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.AddLabels")
+	}
+	return s.AddLabels_(issue, labels)
+}
+
+func (s MockIssuesService) RemoveLabel(issue IssueSpec, label string) (Response, error) {
+	s.Calls.record("RemoveLabel", issue, label)
+	if s.RemoveLabel_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("IssuesService.RemoveLabel")
+	}
+	return s.RemoveLabel_(issue, label)
+}
+
+func (s MockIssuesService) SetAssignees(issue IssueSpec, logins []string) (*Issue, Response, error) {
+	s.Calls.record("SetAssignees", issue, logins)
+	if s.SetAssignees_ == nil {
+		var r0 *Issue
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.SetAssignees")
+	}
+	return s.SetAssignees_(issue, logins)
+}
+
+func (s MockIssuesService) ListEvents(issue IssueSpec, opt *IssueListEventsOptions) ([]*IssueEvent, Response, error) {
+	s.Calls.record("ListEvents", issue, opt)
+	if s.ListEvents_ == nil {
+		var r0 []*IssueEvent
+		var r1 Response
+		return r0, r1, mockNotImplemented("IssuesService.ListEvents")
+	}
+	return s.ListEvents_(issue, opt)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/go-github/github"
 
@@ -13,6 +14,35 @@ import (
 	"github.com/fossas/go-sourcegraph/router"
 )
 
+func TestIssue_Spec(t *testing.T) {
+	tests := []struct {
+		issue *Issue
+		want  IssueSpec
+	}{
+		{
+			issue: &Issue{Issue: github.Issue{Number: github.Int(1), HTMLURL: github.String("https://github.com/x/y/issues/1")}},
+			want:  IssueSpec{Repo: RepoSpec{URI: "github.com/x/y"}, Number: 1},
+		},
+		{
+			issue: &Issue{Issue: github.Issue{Number: github.Int(2), HTMLURL: github.String("http://git.example.com/a/b/c/issues/2")}},
+			want:  IssueSpec{Repo: RepoSpec{URI: "git.example.com/a/b/c"}, Number: 2},
+		},
+		{
+			issue: &Issue{Issue: github.Issue{Number: github.Int(3), HTMLURL: github.String("https://r.com")}},
+			want:  IssueSpec{Number: 3},
+		},
+		{
+			issue: &Issue{},
+			want:  IssueSpec{},
+		},
+	}
+	for _, test := range tests {
+		if got := test.issue.Spec(); got != test.want {
+			t.Errorf("%+v: got spec %+v, want %+v", test.issue, got, test.want)
+		}
+	}
+}
+
 func TestIssues(t *testing.T) {
 	tests := []struct {
 		spec          IssueSpec
@@ -106,6 +136,39 @@ func TestIssuesService_ListByRepo(t *testing.T) {
 	}
 }
 
+func TestIssuesService_ListAllComments(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*RepoIssueComment{{Number: 2, IssueComment: &IssueComment{IssueComment: github.IssueComment{ID: github.Int(1)}}}}
+	repo := RepoSpec{URI: "r.com/x"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoIssuesComments, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Sort": "created", "Direction": "desc", "Page": "2"})
+
+		writeJSON(w, want)
+	})
+
+	comments, _, err := client.Issues.ListAllComments(repo, &IssueListAllCommentsOptions{
+		SortOptions: SortOptions{Sort: "created", Direction: Descending},
+		ListOptions: ListOptions{Page: 2},
+	})
+	if err != nil {
+		t.Errorf("Issues.ListAllComments returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(comments, want) {
+		t.Errorf("Issues.ListAllComments returned %+v, want %+v", comments, want)
+	}
+}
+
 func TestIssuesService_ListComments(t *testing.T) {
 	setup()
 	defer teardown()
@@ -143,3 +206,221 @@ func TestIssuesService_ListComments(t *testing.T) {
 		t.Errorf("Issues.List returned %+v, want %+v with diff: %s", comments, want, strings.Join(pretty.Diff(want, comments), "\n"))
 	}
 }
+
+func TestIssuesService_Export(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &IssueArchive{
+		Version:  ArchiveVersion,
+		Issue:    &Issue{Issue: github.Issue{Number: github.Int(1)}},
+		Comments: []*IssueComment{{IssueComment: github.IssueComment{ID: github.Int(2)}}},
+		Events:   []*IssueEvent{{ID: 3, Actor: UserSpec{Login: "u"}, Event: "closed"}},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoIssueExport, map[string]string{"RepoSpec": "r.com/x", "Issue": "1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	archive, _, err := client.Issues.Export(IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1})
+	if err != nil {
+		t.Errorf("Issues.Export returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(archive, want) {
+		t.Errorf("Issues.Export returned %+v, want %+v", archive, want)
+	}
+}
+
+func TestIssuesService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &Issue{Issue: github.Issue{Number: github.Int(1)}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoIssuesCreate, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Title":"t","Body":"b","Labels":["bug"]}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	issue, _, err := client.Issues.Create(RepoSpec{URI: "r.com/x"}, &IssueRequest{Title: github.String("t"), Body: github.String("b  \r\n"), Labels: []string{"bug"}})
+	if err != nil {
+		t.Errorf("Issues.Create returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(issue, want) {
+		t.Errorf("Issues.Create returned %+v, want %+v", issue, want)
+	}
+}
+
+func TestIssuesService_Create_invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tests := map[string]*IssueRequest{
+		"Title": {Title: github.String(" ")},
+		"State": {Title: github.String("t"), State: IssueStateClosed},
+	}
+	for field, issue := range tests {
+		_, _, err := client.Issues.Create(RepoSpec{URI: "r.com/x"}, issue)
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("%s: got error %v, want ValidationError for field %s", field, err, field)
+		}
+	}
+}
+
+func TestIssuesService_Close(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &Issue{Issue: github.Issue{Number: github.Int(1), State: github.String("closed")}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoIssueEdit, map[string]string{"RepoSpec": "r.com/x", "Issue": "1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"State":"closed"}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	issue, _, err := client.Issues.Close(IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1})
+	if err != nil {
+		t.Errorf("Issues.Close returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(issue, want) {
+		t.Errorf("Issues.Close returned %+v, want %+v", issue, want)
+	}
+}
+
+func TestIssuesService_AddLabels(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []github.Label{{Name: github.String("bug")}, {Name: github.String("ui")}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoIssueLabelsAdd, map[string]string{"RepoSpec": "r.com/x", "Issue": "1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `["ui"]`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	labels, _, err := client.Issues.AddLabels(IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, []string{"ui"})
+	if err != nil {
+		t.Errorf("Issues.AddLabels returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("Issues.AddLabels returned %+v, want %+v", labels, want)
+	}
+}
+
+func TestIssuesService_RemoveLabel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoIssueLabelRemove, map[string]string{"RepoSpec": "r.com/x", "Issue": "1", "Label": "bug"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "DELETE")
+	})
+
+	_, err := client.Issues.RemoveLabel(IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, "bug")
+	if err != nil {
+		t.Errorf("Issues.RemoveLabel returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestIssuesService_SetAssignees(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &Issue{Issue: github.Issue{Number: github.Int(1)}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoIssueAssignees, map[string]string{"RepoSpec": "r.com/x", "Issue": "1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+		testBody(t, r, "[]\n")
+
+		writeJSON(w, want)
+	})
+
+	issue, _, err := client.Issues.SetAssignees(IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, nil)
+	if err != nil {
+		t.Errorf("Issues.SetAssignees returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(issue, want) {
+		t.Errorf("Issues.SetAssignees returned %+v, want %+v", issue, want)
+	}
+}
+
+func TestIssuesService_ListEvents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*IssueEvent{{ID: 1, Actor: UserSpec{Login: "u"}, Event: "labeled"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoIssueEvents, map[string]string{"RepoSpec": "r.com/x", "Issue": "1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Since": "2015-01-02T00:00:00Z", "PerPage": "10"})
+
+		writeJSON(w, want)
+	})
+
+	since := time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)
+	events, _, err := client.Issues.ListEvents(IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, &IssueListEventsOptions{
+		TimeRangeOptions: TimeRangeOptions{Since: &since},
+		ListOptions:      ListOptions{PerPage: 10},
+	})
+	if err != nil {
+		t.Errorf("Issues.ListEvents returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Issues.ListEvents returned %+v, want %+v", events, want)
+	}
+}
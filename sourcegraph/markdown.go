@@ -2,8 +2,14 @@ package sourcegraph
 
 import "github.com/fossas/go-sourcegraph/router"
 
+// MarkdownService communicates with the Sourcegraph API endpoint that
+// renders markdown.
 type MarkdownService interface {
-	Render(markdown []byte, opt MarkdownOpt) (*MarkdownData, Response, error)
+	// Render renders markdown as HTML, in the same way that the
+	// Sourcegraph web UI renders comment bodies and descriptions. If
+	// opt specifies a repository, issue references (such as "#123")
+	// and @mentions are linked in the context of that repository.
+	Render(markdown []byte, opt *MarkdownOptions) (*MarkdownData, Response, error)
 }
 
 type markdownService struct {
@@ -12,11 +18,24 @@ type markdownService struct {
 
 type MarkdownRequestBody struct {
 	Markdown []byte
-	MarkdownOpt
+	MarkdownOptions
 }
 
-type MarkdownOpt struct {
+// MarkdownOptions specifies options for MarkdownService.Render.
+type MarkdownOptions struct {
 	EnableCheckboxes bool
+
+	// Repo, if set, is the repository that the markdown belongs to
+	// (e.g., the repository of the issue or pull request that it is a
+	// comment on). References to issues and pull requests (such as
+	// "#123" and "owner/repo#123") and commit IDs are linked relative
+	// to it, as in ParseReferences.
+	Repo *RepoSpec `json:",omitempty"`
+
+	// Issue, if nonzero, is the number of the issue or pull request
+	// (in Repo) that the markdown belongs to. The server uses it to
+	// link @mentions of the issue's participants.
+	Issue int `json:",omitempty"`
 }
 
 type MarkdownData struct {
@@ -24,15 +43,22 @@ type MarkdownData struct {
 	Checklist *Checklist
 }
 
-func (m *markdownService) Render(markdown []byte, opt MarkdownOpt) (*MarkdownData, Response, error) {
+func (m *markdownService) Render(markdown []byte, opt *MarkdownOptions) (*MarkdownData, Response, error) {
+	if opt == nil {
+		opt = &MarkdownOptions{}
+	}
+	if opt.Issue != 0 && opt.Repo == nil {
+		return nil, nil, &ValidationError{Field: "Issue", Problems: []string{"requires Repo to be set"}}
+	}
+
 	url, err := m.client.URL(router.Markdown, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	req, err := m.client.NewRequest("POST", url.String(), &MarkdownRequestBody{
-		Markdown:    markdown,
-		MarkdownOpt: opt,
+		Markdown:        markdown,
+		MarkdownOptions: *opt,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -1,9 +1,26 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 type MockMarkdownService struct {
-	Render_ func(markdown []byte, opt MarkdownOpt) (*MarkdownData, Response, error)
+	Render_ func(markdown []byte, opt *MarkdownOptions) (*MarkdownData, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockMarkdownService returns a new MockMarkdownService
+// that records calls to its methods.
+func NewMockMarkdownService() *MockMarkdownService {
+	return &MockMarkdownService{Calls: &MockCalls{}}
 }
 
-func (s MockMarkdownService) Render(markdown []byte, opt MarkdownOpt) (*MarkdownData, Response, error) {
+func (s MockMarkdownService) Render(markdown []byte, opt *MarkdownOptions) (*MarkdownData, Response, error) {
+	s.Calls.record("Render", markdown, opt)
+	if s.Render_ == nil {
+		var r0 *MarkdownData
+		var r1 Response
+		return r0, r1, mockNotImplemented("MarkdownService.Render")
+	}
 	return s.Render_(markdown, opt)
 }
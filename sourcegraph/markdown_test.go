@@ -14,8 +14,12 @@ func TestMarkdown(t *testing.T) {
 	defer teardown()
 
 	input := MarkdownRequestBody{
-		Markdown:    []byte(`raw markdown`),
-		MarkdownOpt: MarkdownOpt{EnableCheckboxes: true},
+		Markdown: []byte(`raw markdown`),
+		MarkdownOptions: MarkdownOptions{
+			EnableCheckboxes: true,
+			Repo:             &RepoSpec{URI: "r.com/x"},
+			Issue:            1,
+		},
 	}
 	want := &MarkdownData{
 		Rendered: []byte(`i am rendered`),
@@ -41,7 +45,7 @@ func TestMarkdown(t *testing.T) {
 		writeJSON(w, want)
 	})
 
-	got, _, err := client.Markdown.Render(input.Markdown, input.MarkdownOpt)
+	got, _, err := client.Markdown.Render(input.Markdown, &input.MarkdownOptions)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -52,3 +56,16 @@ func TestMarkdown(t *testing.T) {
 		t.Errorf("got %+v, want %+v", got, want)
 	}
 }
+
+func TestMarkdown_issueWithoutRepo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(urlPath(t, router.Markdown, nil), func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	if _, _, err := client.Markdown.Render([]byte("x"), &MarkdownOptions{Issue: 1}); err == nil {
+		t.Error("got nil error, want validation error")
+	}
+}
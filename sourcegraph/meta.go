@@ -0,0 +1,87 @@
+package sourcegraph
+
+import "github.com/fossas/go-sourcegraph/router"
+
+// MetaService communicates with the endpoints in the Sourcegraph API
+// that describe the server itself. Clients that talk to servers of
+// different versions may use them (or Client.SupportsRoute) to check
+// which API routes and features are available.
+type MetaService interface {
+	// Status fetches the server's version and the API routes it
+	// supports. It is served at the root of the API.
+	Status() (*ServerStatus, Response, error)
+
+	// Config fetches the server's public configuration, including
+	// which optional features are enabled.
+	Config() (*ServerConfig, Response, error)
+}
+
+// metaService implements MetaService.
+type metaService struct {
+	client *Client
+}
+
+var _ MetaService = &metaService{}
+
+// ServerStatus describes a Sourcegraph server's version and API.
+type ServerStatus struct {
+	// Version is the server's version (e.g., "0.1.0"). Development
+	// builds may report a non-numeric version.
+	Version string
+
+	// Routes are the names of the API routes that the server supports
+	// (which are the route name constants in the router package). It
+	// is empty if the server doesn't list its routes.
+	Routes []string `json:",omitempty"`
+}
+
+// HasRoute reports whether s lists the named API route.
+func (s *ServerStatus) HasRoute(name string) bool {
+	for _, r := range s.Routes {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ServerConfig is a Sourcegraph server's public configuration.
+type ServerConfig struct {
+	// AppURL is the URL of the server's web app.
+	AppURL string `json:",omitempty"`
+
+	// Features are the names of the optional features that are enabled
+	// on the server (e.g., "builds" or "graphql").
+	Features []string `json:",omitempty"`
+}
+
+// HasFeature reports whether the named optional feature is enabled on
+// the server.
+func (c *ServerConfig) HasFeature(name string) bool {
+	for _, f := range c.Features {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *metaService) Status() (*ServerStatus, Response, error) {
+	var status ServerStatus
+	resp, err := s.client.DoGet(router.MetaStatus, nil, nil, &status)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &status, resp, nil
+}
+
+func (s *metaService) Config() (*ServerConfig, Response, error) {
+	var config ServerConfig
+	resp, err := s.client.DoGet(router.MetaConfig, nil, nil, &config)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &config, resp, nil
+}
+
+var _ MetaService = &MockMetaService{}
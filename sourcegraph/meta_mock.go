@@ -0,0 +1,37 @@
+// generated by gen-mocks; DO NOT EDIT
+
+package sourcegraph
+
+type MockMetaService struct {
+	Status_ func() (*ServerStatus, Response, error)
+	Config_ func() (*ServerConfig, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockMetaService returns a new MockMetaService
+// that records calls to its methods.
+func NewMockMetaService() *MockMetaService {
+	return &MockMetaService{Calls: &MockCalls{}}
+}
+
+func (s MockMetaService) Status() (*ServerStatus, Response, error) {
+	s.Calls.record("Status")
+	if s.Status_ == nil {
+		var r0 *ServerStatus
+		var r1 Response
+		return r0, r1, mockNotImplemented("MetaService.Status")
+	}
+	return s.Status_()
+}
+
+func (s MockMetaService) Config() (*ServerConfig, Response, error) {
+	s.Calls.record("Config")
+	if s.Config_ == nil {
+		var r0 *ServerConfig
+		var r1 Response
+		return r0, r1, mockNotImplemented("MetaService.Config")
+	}
+	return s.Config_()
+}
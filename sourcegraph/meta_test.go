@@ -0,0 +1,71 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestMetaService_Status(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &ServerStatus{Version: "0.1.0", Routes: []string{router.Repo, router.PersonStats}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.MetaStatus, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	status, _, err := client.Meta.Status()
+	if err != nil {
+		t.Errorf("Meta.Status returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("Meta.Status returned %+v, want %+v", status, want)
+	}
+	if !status.HasRoute(router.PersonStats) || status.HasRoute(router.GraphQL) {
+		t.Errorf("HasRoute: got wrong result for %v", status.Routes)
+	}
+}
+
+func TestMetaService_Config(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &ServerConfig{AppURL: "https://sourcegraph.example.com", Features: []string{"builds"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.MetaConfig, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	config, _, err := client.Meta.Config()
+	if err != nil {
+		t.Errorf("Meta.Config returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("Meta.Config returned %+v, want %+v", config, want)
+	}
+	if !config.HasFeature("builds") || config.HasFeature("graphql") {
+		t.Errorf("HasFeature: got wrong result for %v", config.Features)
+	}
+}
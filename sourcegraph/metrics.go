@@ -0,0 +1,74 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"time"
+)
+
+// A MetricsCollector receives a measurement of each request sent by a
+// Client (see Client.Metrics). It is called synchronously after each
+// request, so it must be fast and safe for concurrent use. Package
+// prommetrics provides a MetricsCollector that exports Prometheus
+// metrics.
+type MetricsCollector interface {
+	ObserveRequest(m RequestMetrics)
+}
+
+// RequestMetrics describes a request sent by a Client.
+type RequestMetrics struct {
+	// Route is the name of the API route that the request was sent to
+	// (such as router.Repo), or "" if the request's URL doesn't match
+	// an API route.
+	Route string
+
+	Method string
+
+	// StatusCode is the HTTP status code of the response, or 0 if no
+	// response was received (e.g., because of a network error).
+	StatusCode int
+
+	// Duration is the time it took to send the request (including any
+	// retries) and read and decode the response. For streaming
+	// downloads, it doesn't include the time spent reading the body.
+	Duration time.Duration
+
+	// Err is the error returned by Do, if any.
+	Err error
+}
+
+// StatusClass returns the class of the response's HTTP status code
+// ("2xx", "3xx", "4xx", or "5xx"), or "error" if no response was
+// received.
+func (m RequestMetrics) StatusClass() string {
+	if m.StatusCode < 100 || m.StatusCode > 599 {
+		return "error"
+	}
+	return string('0'+byte(m.StatusCode/100)) + "xx"
+}
+
+// WithMetrics returns a copy of c whose Metrics is m. It may be used
+// to record the requests made by a single call (or group of calls)
+// separately.
+func (c *Client) WithMetrics(m MetricsCollector) *Client {
+	c2 := c.Clone()
+	c2.Metrics = m
+	return c2
+}
+
+// observeRequest reports a request that was sent at start and resulted
+// in resp and err to c.Metrics (if set).
+func (c *Client) observeRequest(req *http.Request, start time.Time, resp Response, err error) {
+	if c.Metrics == nil {
+		return
+	}
+	m := RequestMetrics{
+		Route:    c.routeName(req),
+		Method:   req.Method,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if hr, ok := resp.(*HTTPResponse); ok && hr != nil && hr.Response != nil {
+		m.StatusCode = hr.StatusCode
+	}
+	c.Metrics.ObserveRequest(m)
+}
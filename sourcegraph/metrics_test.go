@@ -0,0 +1,56 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+type recordingMetrics struct {
+	mu sync.Mutex
+	ms []RequestMetrics
+}
+
+func (r *recordingMetrics) ObserveRequest(m RequestMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ms = append(r.ms, m)
+}
+
+func TestClient_Metrics(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(urlPath(t, router.Repo, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+
+	metrics := &recordingMetrics{}
+	client.Metrics = metrics
+
+	if _, _, err := client.Repos.Get(RepoSpec{URI: "r.com/x"}, nil); err == nil {
+		t.Fatal("err == nil")
+	}
+
+	if len(metrics.ms) != 1 {
+		t.Fatalf("got %d observed requests, want 1", len(metrics.ms))
+	}
+	m := metrics.ms[0]
+	if m.Route != router.Repo || m.Method != "GET" || m.StatusCode != http.StatusNotFound || m.Err == nil {
+		t.Errorf("got metrics %+v, want route %q, method GET, status 404, and an error", m, router.Repo)
+	}
+	if got, want := m.StatusClass(), "4xx"; got != want {
+		t.Errorf("got status class %q, want %q", got, want)
+	}
+}
+
+func TestRequestMetrics_StatusClass(t *testing.T) {
+	tests := map[int]string{0: "error", 200: "2xx", 304: "3xx", 503: "5xx"}
+	for code, want := range tests {
+		if got := (RequestMetrics{StatusCode: code}).StatusClass(); got != want {
+			t.Errorf("%d: got status class %q, want %q", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package sourcegraph
+
+import "net/http"
+
+// A Middleware wraps the HTTP transport used by a Client (see
+// Client.Use). It returns a RoundTripper that typically does something
+// with each request or response (such as logging it, recording
+// metrics, or adding headers) and calls next to send the request.
+//
+// As required by the http.RoundTripper contract, a middleware that
+// modifies requests must modify a copy of the request, not the request
+// it was given.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter that allows the use of an ordinary
+// func as an http.RoundTripper (for example, in a Middleware):
+//
+//	client.Use(func(next http.RoundTripper) http.RoundTripper {
+//		return sourcegraph.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next.RoundTrip(req)
+//			log.Printf("%s %s (%s)", req.Method, req.URL, time.Since(start))
+//			return resp, err
+//		})
+//	})
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Use adds middleware to the chain that wraps c's HTTP transport.
+// Middleware is called in the order in which it was added: the first
+// middleware added sees each request first (and its response last).
+//
+// The middleware sees each attempt of a retried request (see
+// Client.Retry), but not requests served from c.Cache without
+// contacting the server. Compressed responses are decompressed before
+// the middleware sees them. Use does not modify the *http.Client passed
+// to NewClient, and it does not affect copies of c made (by
+// WithContext, for example) before it is called.
+func (c *Client) Use(mw ...Middleware) {
+	// Copy the slice so that copies of c that share its backing array
+	// aren't affected.
+	c.middleware = append(c.middleware[:len(c.middleware):len(c.middleware)], mw...)
+	if c.httpClient == nil {
+		return // mock client
+	}
+	if c.transport == nil {
+		c.transport = c.httpClient.Transport
+		if c.transport == nil {
+			c.transport = http.DefaultTransport
+		}
+	}
+
+	var t http.RoundTripper = &decompressTransport{c.transport}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		t = c.middleware[i](t)
+	}
+	hc := *c.httpClient
+	hc.Transport = t
+	c.httpClient = &hc
+}
@@ -0,0 +1,113 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Use(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				req2 := *req
+				req2.Header = http.Header{}
+				for k, v := range req.Header {
+					req2.Header[k] = v
+				}
+				req2.Header.Add("X-Middleware", name)
+				return next.RoundTrip(&req2)
+			})
+		}
+	}
+
+	var header []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header["X-Middleware"]
+	})
+
+	client.Use(mw("a"))
+	client.Use(mw("b"), mw("c"))
+
+	req, err := client.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("got middleware calls %v, want %v", calls, want)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(header, want) {
+		t.Errorf("got X-Middleware header %v, want %v", header, want)
+	}
+	if len(req.Header["X-Middleware"]) != 0 {
+		t.Error("middleware modified the original request")
+	}
+}
+
+func TestClient_Use_doesNotAffectEarlierCopies(t *testing.T) {
+	hc := &http.Client{}
+	c := NewClient(hc)
+	c2 := c.WithMaxResponseBytes(1)
+
+	c.Use(func(next http.RoundTripper) http.RoundTripper { return next })
+
+	if hc.Transport != nil {
+		t.Error("Use modified the *http.Client passed to NewClient")
+	}
+	if c2.httpClient != hc || len(c2.middleware) != 0 {
+		t.Error("Use modified a copy of the client made before it was called")
+	}
+}
+
+func TestClient_Use_decompressed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`"a"`))
+		zw.Close()
+	})
+
+	var body []byte
+	client.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			body, err = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			return resp, err
+		})
+	})
+
+	req, err := client.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v string
+	if _, err := client.Do(req, &v); err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `"a"` {
+		t.Errorf("middleware got body %q, want %q", body, `"a"`)
+	}
+	if v != "a" {
+		t.Errorf("got %q, want %q", v, "a")
+	}
+}
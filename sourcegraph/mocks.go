@@ -0,0 +1,235 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// A MockCall is a call to a method of a mock service (such as
+// MockReposService).
+type MockCall struct {
+	Method string        // the method name (e.g., "Get")
+	Args   []interface{} // the arguments
+}
+
+// MockCalls records the calls to the methods of a mock service (see
+// the mock services' Calls fields). It is safe for concurrent use.
+type MockCalls struct {
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+// record records a call. It does nothing if c is nil.
+func (c *MockCalls) record(method string, args ...interface{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, MockCall{Method: method, Args: args})
+}
+
+// All returns all of the recorded calls, in the order in which they
+// were made.
+func (c *MockCalls) All() []MockCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]MockCall(nil), c.calls...)
+}
+
+// Count returns the number of recorded calls to the named method.
+func (c *MockCalls) Count(method string) int {
+	n := 0
+	for _, call := range c.All() {
+		if call.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// MockT is the subset of testing.TB used by the MockCalls assertion
+// helpers.
+type MockT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// AssertCalled reports an error to t unless the named method was
+// called. If args are given, the method must have been called with
+// arguments that are deeply equal to them.
+func (c *MockCalls) AssertCalled(t MockT, method string, args ...interface{}) {
+	for _, call := range c.All() {
+		if call.Method == method && (len(args) == 0 || reflect.DeepEqual(call.Args, args)) {
+			return
+		}
+	}
+	if len(args) == 0 {
+		t.Errorf("%s was not called", method)
+	} else {
+		t.Errorf("%s was not called with args %+v (calls: %+v)", method, args, c.All())
+	}
+}
+
+// AssertNotCalled reports an error to t if the named method was
+// called.
+func (c *MockCalls) AssertNotCalled(t MockT, method string) {
+	if n := c.Count(method); n != 0 {
+		t.Errorf("%s was called %d times, want 0", method, n)
+	}
+}
+
+// mockError is an error returned by a mock service method. It
+// implements HTTPStatusCode so that, for example, IsNotFound reports
+// whether a fixture was not found.
+type mockError struct {
+	msg        string
+	statusCode int
+}
+
+func (e *mockError) Error() string       { return e.msg }
+func (e *mockError) HTTPStatusCode() int { return e.statusCode }
+
+// mockNotImplemented returns the error returned by a mock method whose
+// func field is nil.
+func mockNotImplemented(method string) error {
+	return &mockError{msg: fmt.Sprintf("mock method %s is not implemented", method), statusCode: http.StatusNotImplemented}
+}
+
+// mockNotFound returns the error returned by a mock method when no
+// fixture matches spec.
+func mockNotFound(method string, spec interface{}) error {
+	return &mockError{msg: fmt.Sprintf("%s: no fixture for %+v", method, spec), statusCode: http.StatusNotFound}
+}
+
+// WithPull adds a pull request fixture to s: Get returns pull for
+// spec, and ListByRepo includes it (regardless of the list options)
+// for spec's repository. Get returns an error for which IsNotFound is
+// true for specs with no fixture (unless s.Get_ was already set, in
+// which case it is called).
+func (s *MockPullRequestsService) WithPull(spec PullRequestSpec, pull *PullRequest) *MockPullRequestsService {
+	get, list := s.Get_, s.ListByRepo_
+	s.Get_ = func(pull_ PullRequestSpec, opt *PullRequestGetOptions) (*PullRequest, Response, error) {
+		if pull_ == spec {
+			return pull, nil, nil
+		}
+		if get != nil {
+			return get(pull_, opt)
+		}
+		return nil, nil, mockNotFound("PullRequestsService.Get", pull_)
+	}
+	s.ListByRepo_ = func(repo RepoSpec, opt *PullRequestListOptions) ([]*PullRequest, Response, error) {
+		var pulls []*PullRequest
+		if list != nil {
+			var err error
+			if pulls, _, err = list(repo, opt); err != nil {
+				return nil, nil, err
+			}
+		}
+		if repo == spec.Repo {
+			pulls = append(pulls, pull)
+		}
+		return pulls, nil, nil
+	}
+	return s
+}
+
+// WithIssue adds an issue fixture to s (see
+// MockPullRequestsService.WithPull).
+func (s *MockIssuesService) WithIssue(spec IssueSpec, issue *Issue) *MockIssuesService {
+	get, list := s.Get_, s.ListByRepo_
+	s.Get_ = func(issue_ IssueSpec, opt *IssueGetOptions) (*Issue, Response, error) {
+		if issue_ == spec {
+			return issue, nil, nil
+		}
+		if get != nil {
+			return get(issue_, opt)
+		}
+		return nil, nil, mockNotFound("IssuesService.Get", issue_)
+	}
+	s.ListByRepo_ = func(repo RepoSpec, opt *IssueListOptions) ([]*Issue, Response, error) {
+		var issues []*Issue
+		if list != nil {
+			var err error
+			if issues, _, err = list(repo, opt); err != nil {
+				return nil, nil, err
+			}
+		}
+		if repo == spec.Repo {
+			issues = append(issues, issue)
+		}
+		return issues, nil, nil
+	}
+	return s
+}
+
+// WithRepo adds a repository fixture to s: Get and GetMulti return repo
+// for spec. Get returns an error for which IsNotFound is true for
+// specs with no fixture (unless s.Get_ was already set, in which case
+// it is called).
+func (s *MockReposService) WithRepo(spec RepoSpec, repo *Repo) *MockReposService {
+	get := s.Get_
+	s.Get_ = func(repo_ RepoSpec, opt *RepoGetOptions) (*Repo, Response, error) {
+		if repo_ == spec {
+			return repo, nil, nil
+		}
+		if get != nil {
+			return get(repo_, opt)
+		}
+		return nil, nil, mockNotFound("ReposService.Get", repo_)
+	}
+	s.GetMulti_ = func(repos []RepoSpec, opt *RepoGetOptions) ([]*Repo, Response, error) {
+		repos_ := make([]*Repo, len(repos))
+		for i, repo := range repos {
+			var err error
+			if repos_[i], _, err = s.Get_(repo, opt); err != nil && !IsNotFound(err) {
+				return nil, nil, err
+			}
+		}
+		return repos_, nil, nil
+	}
+	return s
+}
+
+// WithUser adds a user fixture to s: Get returns user for spec (see
+// MockReposService.WithRepo).
+func (s *MockUsersService) WithUser(spec UserSpec, user *User) *MockUsersService {
+	get := s.Get_
+	s.Get_ = func(user_ UserSpec, opt *UserGetOptions) (*User, Response, error) {
+		if user_ == spec {
+			return user, nil, nil
+		}
+		if get != nil {
+			return get(user_, opt)
+		}
+		return nil, nil, mockNotFound("UsersService.Get", user_)
+	}
+	return s
+}
+
+// WithDef adds a def fixture to s: Get and GetMulti return def for
+// spec (see MockReposService.WithRepo).
+func (s *MockDefsService) WithDef(spec DefSpec, def *Def) *MockDefsService {
+	get := s.Get_
+	s.Get_ = func(def_ DefSpec, opt *DefGetOptions) (*Def, Response, error) {
+		if def_ == spec {
+			return def, nil, nil
+		}
+		if get != nil {
+			return get(def_, opt)
+		}
+		return nil, nil, mockNotFound("DefsService.Get", def_)
+	}
+	s.GetMulti_ = func(defs []DefSpec, opt *DefGetOptions) ([]*Def, Response, error) {
+		defs_ := make([]*Def, len(defs))
+		for i, def := range defs {
+			var err error
+			if defs_[i], _, err = s.Get_(def, opt); err != nil && !IsNotFound(err) {
+				return nil, nil, err
+			}
+		}
+		return defs_, nil, nil
+	}
+	return s
+}
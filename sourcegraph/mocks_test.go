@@ -0,0 +1,83 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMockPullRequestsService_WithPull(t *testing.T) {
+	repo := RepoSpec{URI: "r.com/x"}
+	spec := PullRequestSpec{Repo: repo, Number: 1}
+	want := &PullRequest{}
+
+	s := NewMockPullRequestsService().WithPull(spec, want)
+
+	pull, _, err := s.Get(spec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pull != want {
+		t.Errorf("Get returned %+v, want %+v", pull, want)
+	}
+
+	if _, _, err := s.Get(PullRequestSpec{Repo: repo, Number: 2}, nil); !IsNotFound(err) {
+		t.Errorf("Get of missing fixture returned err %v, want not found", err)
+	}
+
+	pulls, _, err := s.ListByRepo(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pulls, []*PullRequest{want}) {
+		t.Errorf("ListByRepo returned %+v, want %+v", pulls, []*PullRequest{want})
+	}
+
+	s.Calls.AssertCalled(t, "Get", spec, (*PullRequestGetOptions)(nil))
+	s.Calls.AssertNotCalled(t, "Merge")
+	if n := s.Calls.Count("Get"); n != 2 {
+		t.Errorf("got %d Get calls, want 2", n)
+	}
+}
+
+func TestMockReposService_WithRepo_GetMulti(t *testing.T) {
+	spec := RepoSpec{URI: "r.com/x"}
+	want := &Repo{URI: "r.com/x"}
+
+	s := NewMockReposService().WithRepo(spec, want)
+
+	repos, _, err := s.GetMulti([]RepoSpec{spec, {URI: "r.com/y"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(repos, []*Repo{want, nil}) {
+		t.Errorf("GetMulti returned %+v, want %+v", repos, []*Repo{want, nil})
+	}
+}
+
+func TestMock_notImplemented(t *testing.T) {
+	var s MockUsersService
+	_, _, err := s.Get(UserSpec{Login: "u"}, nil)
+	if !IsHTTPErrorCode(err, http.StatusNotImplemented) {
+		t.Errorf("got err %v, want not implemented", err)
+	}
+}
+
+type mockT struct{ errs []string }
+
+func (t *mockT) Errorf(format string, args ...interface{}) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func TestMockCalls_AssertCalled(t *testing.T) {
+	s := NewMockUsersService().WithUser(UserSpec{Login: "u"}, &User{Login: "u"})
+	s.Get(UserSpec{Login: "u"}, nil)
+
+	var mt mockT
+	s.Calls.AssertCalled(&mt, "Get", UserSpec{Login: "v"}, (*UserGetOptions)(nil))
+	s.Calls.AssertNotCalled(&mt, "Get")
+	if len(mt.errs) != 2 {
+		t.Errorf("got %d assertion errors, want 2: %v", len(mt.errs), mt.errs)
+	}
+}
@@ -0,0 +1,197 @@
+package sourcegraph
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// MonitoringService communicates with the endpoints in the Sourcegraph
+// API that configure the server's built-in observability alerts. Only
+// site admins may call its methods.
+type MonitoringService interface {
+	// ListAlerts lists the server's alerts and their thresholds.
+	ListAlerts(opt *AlertListOptions) ([]*Alert, Response, error)
+
+	// GetAlert fetches an alert.
+	GetAlert(alert AlertSpec) (*Alert, Response, error)
+
+	// UpdateAlertThresholds sets an alert's thresholds.
+	UpdateAlertThresholds(alert AlertSpec, thresholds AlertThresholds) (*Alert, Response, error)
+
+	// ListSilences lists alert silence windows.
+	ListSilences(opt *AlertSilenceListOptions) ([]*AlertSilence, Response, error)
+
+	// CreateSilence creates a silence window, during which the
+	// silenced alerts don't send notifications. The ID field of
+	// silence is ignored.
+	CreateSilence(silence *AlertSilence) (*AlertSilence, Response, error)
+
+	// DeleteSilence removes a silence window (ending it early, if it's
+	// in effect).
+	DeleteSilence(silence AlertSilenceSpec) (Response, error)
+}
+
+// monitoringService implements MonitoringService.
+type monitoringService struct {
+	client *Client
+}
+
+var _ MonitoringService = &monitoringService{}
+
+// AlertSpec specifies an alert.
+type AlertSpec struct {
+	Name string
+}
+
+func (s AlertSpec) RouteVars() map[string]string {
+	return map[string]string{"AlertName": s.Name}
+}
+
+// An Alert fires when an observability metric of the server crosses
+// one of its thresholds.
+type Alert struct {
+	// Name uniquely identifies the alert (e.g.,
+	// "frontend_5xx_responses").
+	Name string
+
+	// Service is the server component that the alert monitors.
+	Service string `json:",omitempty"`
+
+	Description string `json:",omitempty"`
+
+	// Unit is the unit of the alert's metric and thresholds (e.g., "%"
+	// or "s").
+	Unit string `json:",omitempty"`
+
+	AlertThresholds
+
+	// Firing is the level at which the alert is currently firing
+	// (AlertWarning or AlertCritical), or empty if it isn't firing.
+	Firing string `json:",omitempty"`
+
+	// Silenced is whether the alert is silenced by a silence window
+	// that is in effect.
+	Silenced bool `json:",omitempty"`
+}
+
+// Alert levels.
+const (
+	AlertWarning  = "warning"
+	AlertCritical = "critical"
+)
+
+// AlertThresholds are the values of an alert's metric at which it
+// fires. A nil threshold disables the corresponding alert level.
+type AlertThresholds struct {
+	Warning  *float64 `json:",omitempty"`
+	Critical *float64 `json:",omitempty"`
+}
+
+// AlertListOptions specifies options for MonitoringService.ListAlerts.
+type AlertListOptions struct {
+	Service string `url:",omitempty" json:",omitempty"` // only list alerts for this service
+	Firing  bool   `url:",omitempty" json:",omitempty"` // only list alerts that are firing
+
+	SortOptions
+	ListOptions
+}
+
+func (s *monitoringService) ListAlerts(opt *AlertListOptions) ([]*Alert, Response, error) {
+	var alerts []*Alert
+	resp, err := s.client.DoList(router.MonitoringAlerts, nil, opt, &alerts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return alerts, resp, nil
+}
+
+func (s *monitoringService) GetAlert(alert AlertSpec) (*Alert, Response, error) {
+	var a Alert
+	resp, err := s.client.DoGet(router.MonitoringAlert, alert.RouteVars(), nil, &a)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &a, resp, nil
+}
+
+func (s *monitoringService) UpdateAlertThresholds(alert AlertSpec, thresholds AlertThresholds) (*Alert, Response, error) {
+	var updated Alert
+	resp, err := s.client.DoUpdate(router.MonitoringAlertUpdate, alert.RouteVars(), thresholds, &updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &updated, resp, nil
+}
+
+// AlertSilenceSpec specifies an alert silence window.
+type AlertSilenceSpec struct {
+	ID int
+}
+
+func (s AlertSilenceSpec) RouteVars() map[string]string {
+	return map[string]string{"SilenceID": strconv.Itoa(s.ID)}
+}
+
+// An AlertSilence is a window of time during which alerts don't send
+// notifications (e.g., during planned maintenance).
+type AlertSilence struct {
+	ID int `json:",omitempty"`
+
+	// Alerts are the names of the silenced alerts. If empty, all alerts
+	// are silenced.
+	Alerts []string `json:",omitempty"`
+
+	Start time.Time
+	End   time.Time
+
+	Comment string `json:",omitempty"`
+
+	// CreatedBy is the login of the user who created the silence. It
+	// is set by the server.
+	CreatedBy string `json:",omitempty"`
+}
+
+// Spec returns the AlertSilenceSpec that specifies s.
+func (s *AlertSilence) Spec() AlertSilenceSpec {
+	return AlertSilenceSpec{ID: s.ID}
+}
+
+// AlertSilenceListOptions specifies options for
+// MonitoringService.ListSilences.
+type AlertSilenceListOptions struct {
+	Active bool `url:",omitempty" json:",omitempty"` // only list silences that are in effect
+
+	SortOptions
+	ListOptions
+}
+
+func (s *monitoringService) ListSilences(opt *AlertSilenceListOptions) ([]*AlertSilence, Response, error) {
+	var silences []*AlertSilence
+	resp, err := s.client.DoList(router.MonitoringSilences, nil, opt, &silences)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return silences, resp, nil
+}
+
+func (s *monitoringService) CreateSilence(silence *AlertSilence) (*AlertSilence, Response, error) {
+	var created AlertSilence
+	resp, err := s.client.DoCreate(router.MonitoringSilencesCreate, nil, silence, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &created, resp, nil
+}
+
+func (s *monitoringService) DeleteSilence(silence AlertSilenceSpec) (Response, error) {
+	return s.client.DoDelete(router.MonitoringSilenceDelete, silence.RouteVars())
+}
+
+var _ MonitoringService = &MockMonitoringService{}
@@ -0,0 +1,80 @@
+// generated by gen-mocks; DO NOT EDIT
+
+package sourcegraph
+
+type MockMonitoringService struct {
+	ListAlerts_            func(opt *AlertListOptions) ([]*Alert, Response, error)
+	GetAlert_              func(alert AlertSpec) (*Alert, Response, error)
+	UpdateAlertThresholds_ func(alert AlertSpec, thresholds AlertThresholds) (*Alert, Response, error)
+	ListSilences_          func(opt *AlertSilenceListOptions) ([]*AlertSilence, Response, error)
+	CreateSilence_         func(silence *AlertSilence) (*AlertSilence, Response, error)
+	DeleteSilence_         func(silence AlertSilenceSpec) (Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockMonitoringService returns a new MockMonitoringService
+// that records calls to its methods.
+func NewMockMonitoringService() *MockMonitoringService {
+	return &MockMonitoringService{Calls: &MockCalls{}}
+}
+
+func (s MockMonitoringService) ListAlerts(opt *AlertListOptions) ([]*Alert, Response, error) {
+	s.Calls.record("ListAlerts", opt)
+	if s.ListAlerts_ == nil {
+		var r0 []*Alert
+		var r1 Response
+		return r0, r1, mockNotImplemented("MonitoringService.ListAlerts")
+	}
+	return s.ListAlerts_(opt)
+}
+
+func (s MockMonitoringService) GetAlert(alert AlertSpec) (*Alert, Response, error) {
+	s.Calls.record("GetAlert", alert)
+	if s.GetAlert_ == nil {
+		var r0 *Alert
+		var r1 Response
+		return r0, r1, mockNotImplemented("MonitoringService.GetAlert")
+	}
+	return s.GetAlert_(alert)
+}
+
+func (s MockMonitoringService) UpdateAlertThresholds(alert AlertSpec, thresholds AlertThresholds) (*Alert, Response, error) {
+	s.Calls.record("UpdateAlertThresholds", alert, thresholds)
+	if s.UpdateAlertThresholds_ == nil {
+		var r0 *Alert
+		var r1 Response
+		return r0, r1, mockNotImplemented("MonitoringService.UpdateAlertThresholds")
+	}
+	return s.UpdateAlertThresholds_(alert, thresholds)
+}
+
+func (s MockMonitoringService) ListSilences(opt *AlertSilenceListOptions) ([]*AlertSilence, Response, error) {
+	s.Calls.record("ListSilences", opt)
+	if s.ListSilences_ == nil {
+		var r0 []*AlertSilence
+		var r1 Response
+		return r0, r1, mockNotImplemented("MonitoringService.ListSilences")
+	}
+	return s.ListSilences_(opt)
+}
+
+func (s MockMonitoringService) CreateSilence(silence *AlertSilence) (*AlertSilence, Response, error) {
+	s.Calls.record("CreateSilence", silence)
+	if s.CreateSilence_ == nil {
+		var r0 *AlertSilence
+		var r1 Response
+		return r0, r1, mockNotImplemented("MonitoringService.CreateSilence")
+	}
+	return s.CreateSilence_(silence)
+}
+
+func (s MockMonitoringService) DeleteSilence(silence AlertSilenceSpec) (Response, error) {
+	s.Calls.record("DeleteSilence", silence)
+	if s.DeleteSilence_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("MonitoringService.DeleteSilence")
+	}
+	return s.DeleteSilence_(silence)
+}
@@ -0,0 +1,75 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestMonitoringService_UpdateAlertThresholds(t *testing.T) {
+	setup()
+	defer teardown()
+
+	warning, critical := 5.0, 20.0
+	thresholds := AlertThresholds{Warning: &warning, Critical: &critical}
+	want := &Alert{Name: "frontend_5xx_responses", Unit: "%", AlertThresholds: thresholds}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.MonitoringAlertUpdate, AlertSpec{Name: "frontend_5xx_responses"}.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"Warning":5,"Critical":20}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	alert, _, err := client.Monitoring.UpdateAlertThresholds(AlertSpec{Name: "frontend_5xx_responses"}, thresholds)
+	if err != nil {
+		t.Errorf("Monitoring.UpdateAlertThresholds returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(alert, want) {
+		t.Errorf("Monitoring.UpdateAlertThresholds returned %+v, want %+v", alert, want)
+	}
+}
+
+func TestMonitoringService_CreateSilence(t *testing.T) {
+	setup()
+	defer teardown()
+
+	start := time.Date(2015, 1, 2, 3, 0, 0, 0, time.UTC)
+	silence := &AlertSilence{Alerts: []string{"a"}, Start: start, End: start.Add(time.Hour), Comment: "maintenance"}
+	want := &AlertSilence{ID: 1, Alerts: silence.Alerts, Start: silence.Start, End: silence.End, Comment: silence.Comment, CreatedBy: "alice"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.MonitoringSilencesCreate, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Alerts":["a"],"Start":"2015-01-02T03:00:00Z","End":"2015-01-02T04:00:00Z","Comment":"maintenance"}`+"\n")
+		if r.Header.Get(IdempotencyKeyHeader) == "" {
+			t.Errorf("no %s header", IdempotencyKeyHeader)
+		}
+
+		writeJSON(w, want)
+	})
+
+	created, _, err := client.Monitoring.CreateSilence(silence)
+	if err != nil {
+		t.Errorf("Monitoring.CreateSilence returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("Monitoring.CreateSilence returned %+v, want %+v", created, want)
+	}
+}
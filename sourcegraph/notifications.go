@@ -0,0 +1,353 @@
+package sourcegraph
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// NotificationsService communicates with the endpoints in the
+// Sourcegraph API that configure where the server sends notifications
+// about repository and build events (e.g., Slack channels and generic
+// webhooks), and that manage the authenticated user's notifications
+// and subscriptions.
+type NotificationsService interface {
+	// ListDestinations lists the notification destinations configured
+	// for a repository.
+	ListDestinations(repo RepoSpec, opt *NotificationDestinationListOptions) ([]*NotificationDestination, Response, error)
+
+	// GetDestination fetches a notification destination.
+	GetDestination(dest NotificationDestinationSpec) (*NotificationDestination, Response, error)
+
+	// CreateDestination adds a notification destination to a
+	// repository. The ID field of dest is ignored.
+	CreateDestination(repo RepoSpec, dest *NotificationDestination) (*NotificationDestination, Response, error)
+
+	// UpdateDestination replaces the configuration of a notification
+	// destination.
+	UpdateDestination(dest NotificationDestinationSpec, config *NotificationDestination) (*NotificationDestination, Response, error)
+
+	// DeleteDestination removes a notification destination.
+	DeleteDestination(dest NotificationDestinationSpec) (Response, error)
+
+	// TestDestination sends a test notification to a destination, so
+	// that its configuration can be checked.
+	TestDestination(dest NotificationDestinationSpec) (Response, error)
+
+	// List lists the authenticated user's notifications, most recently
+	// updated first unless opt specifies another sort. By default, only
+	// unread notifications are listed.
+	List(opt *NotificationListOptions) ([]*Notification, Response, error)
+
+	// MarkRead marks a notification as read.
+	MarkRead(id int) (Response, error)
+
+	// MarkAllRead marks all of the authenticated user's notifications
+	// (or all of those in opt.Repo) as read.
+	MarkAllRead(opt *NotificationMarkAllReadOptions) (Response, error)
+
+	// Subscribe subscribes the authenticated user to a thread, so that
+	// they are notified of its activity.
+	Subscribe(thread NotificationThreadSpec) (Response, error)
+
+	// Unsubscribe unsubscribes the authenticated user from a thread.
+	// They are still notified of activity that mentions them.
+	Unsubscribe(thread NotificationThreadSpec) (Response, error)
+}
+
+// notificationsService implements NotificationsService.
+type notificationsService struct {
+	client *Client
+}
+
+var _ NotificationsService = &notificationsService{}
+
+// NotificationDestinationSpec specifies a notification destination.
+type NotificationDestinationSpec struct {
+	Repo RepoSpec
+	ID   int
+}
+
+func (s NotificationDestinationSpec) RouteVars() map[string]string {
+	m := s.Repo.RouteVars()
+	m["DestinationID"] = strconv.Itoa(s.ID)
+	return m
+}
+
+// UnmarshalNotificationDestinationSpec marshals a map containing route
+// variables generated by (NotificationDestinationSpec).RouteVars() and
+// returns the equivalent NotificationDestinationSpec struct.
+func UnmarshalNotificationDestinationSpec(routeVars map[string]string) (NotificationDestinationSpec, error) {
+	repo, err := UnmarshalRepoSpec(routeVars)
+	if err != nil {
+		return NotificationDestinationSpec{}, err
+	}
+	id, err := strconv.Atoi(routeVars["DestinationID"])
+	if err != nil {
+		return NotificationDestinationSpec{}, err
+	}
+	return NotificationDestinationSpec{Repo: repo, ID: id}, nil
+}
+
+// Notification destination types.
+const (
+	NotificationSlack   = "slack"
+	NotificationWebhook = "webhook"
+)
+
+// A NotificationEvent is a kind of repository or build event that
+// notifications may be sent for.
+type NotificationEvent string
+
+const (
+	EventRepoPush          NotificationEvent = "repo.push"
+	EventBuildStarted      NotificationEvent = "build.started"
+	EventBuildSucceeded    NotificationEvent = "build.succeeded"
+	EventBuildFailed       NotificationEvent = "build.failed"
+	EventPullRequestOpened NotificationEvent = "pull-request.opened"
+	EventCommentCreated    NotificationEvent = "comment.created"
+)
+
+// A NotificationDestination is a place that the server sends
+// notifications about repository and build events to.
+type NotificationDestination struct {
+	ID int `json:",omitempty"`
+
+	// Type is the type of destination (NotificationSlack or
+	// NotificationWebhook). The corresponding field (Slack or Webhook)
+	// holds the destination's configuration.
+	Type string
+
+	Slack   *SlackDestination   `json:",omitempty"`
+	Webhook *WebhookDestination `json:",omitempty"`
+
+	// Events is the list of events to send notifications for. If
+	// empty, notifications are sent for all events.
+	Events []NotificationEvent `json:",omitempty"`
+
+	// Disabled is whether notifications to this destination are
+	// (temporarily) turned off.
+	Disabled bool `json:",omitempty"`
+}
+
+// SlackDestination configures notifications that are posted to a Slack
+// channel.
+type SlackDestination struct {
+	WebhookURL string // Slack incoming webhook URL
+	Channel    string `json:",omitempty"` // channel to post to (overrides the webhook's default channel)
+	Username   string `json:",omitempty"` // username to post as
+}
+
+// WebhookDestination configures notifications that are POSTed as JSON
+// to an arbitrary URL.
+type WebhookDestination struct {
+	URL string
+
+	// Secret, if set, is used to sign each notification payload
+	// (HMAC-SHA256, in the X-Sourcegraph-Signature header). It is never
+	// returned by the server. Use the webhooks package to decode and
+	// verify notifications.
+	Secret string `json:",omitempty"`
+}
+
+// NotificationDestinationListOptions specifies options for
+// NotificationsService.ListDestinations.
+type NotificationDestinationListOptions struct {
+	Type string `url:",omitempty" json:",omitempty"` // only list destinations of this type
+
+	SortOptions
+	ListOptions
+}
+
+func (s *notificationsService) ListDestinations(repo RepoSpec, opt *NotificationDestinationListOptions) ([]*NotificationDestination, Response, error) {
+	var dests []*NotificationDestination
+	resp, err := s.client.DoList(router.RepoNotificationDestinations, repo.RouteVars(), opt, &dests)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return dests, resp, nil
+}
+
+func (s *notificationsService) GetDestination(dest NotificationDestinationSpec) (*NotificationDestination, Response, error) {
+	var d NotificationDestination
+	resp, err := s.client.DoGet(router.RepoNotificationDestination, dest.RouteVars(), nil, &d)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &d, resp, nil
+}
+
+func (s *notificationsService) CreateDestination(repo RepoSpec, dest *NotificationDestination) (*NotificationDestination, Response, error) {
+	var created NotificationDestination
+	resp, err := s.client.DoCreate(router.RepoNotificationDestinationsCreate, repo.RouteVars(), dest, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &created, resp, nil
+}
+
+func (s *notificationsService) UpdateDestination(dest NotificationDestinationSpec, config *NotificationDestination) (*NotificationDestination, Response, error) {
+	var updated NotificationDestination
+	resp, err := s.client.DoUpdate(router.RepoNotificationDestinationUpdate, dest.RouteVars(), config, &updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &updated, resp, nil
+}
+
+func (s *notificationsService) DeleteDestination(dest NotificationDestinationSpec) (Response, error) {
+	return s.client.DoDelete(router.RepoNotificationDestinationDelete, dest.RouteVars())
+}
+
+func (s *notificationsService) TestDestination(dest NotificationDestinationSpec) (Response, error) {
+	url, err := s.client.URL(router.RepoNotificationDestinationTest, dest.RouteVars(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest("POST", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// A NotificationThreadSpec specifies a thread that a user may be
+// notified about: an issue, a pull request, or a build. Exactly one of
+// its fields must be set.
+type NotificationThreadSpec struct {
+	Issue *IssueSpec       `json:",omitempty"`
+	Pull  *PullRequestSpec `json:",omitempty"`
+	Build *BuildSpec       `json:",omitempty"`
+}
+
+// errInvalidThreadSpec is returned for a NotificationThreadSpec that
+// doesn't have exactly one field set.
+var errInvalidThreadSpec = errors.New("notification thread spec must specify exactly one of Issue, Pull, and Build")
+
+// subscriptionRoute returns the names of the routes to subscribe to
+// and unsubscribe from the thread, and the routes' variables.
+func (s NotificationThreadSpec) subscriptionRoute() (subscribe, unsubscribe string, routeVars map[string]string, err error) {
+	n := 0
+	if s.Issue != nil {
+		subscribe, unsubscribe, routeVars = router.RepoIssueSubscribe, router.RepoIssueUnsubscribe, s.Issue.RouteVars()
+		n++
+	}
+	if s.Pull != nil {
+		subscribe, unsubscribe, routeVars = router.RepoPullRequestSubscribe, router.RepoPullRequestUnsubscribe, s.Pull.RouteVars()
+		n++
+	}
+	if s.Build != nil {
+		subscribe, unsubscribe, routeVars = router.BuildSubscribe, router.BuildUnsubscribe, s.Build.RouteVars()
+		n++
+	}
+	if n != 1 {
+		return "", "", nil, errInvalidThreadSpec
+	}
+	return subscribe, unsubscribe, routeVars, nil
+}
+
+// Notification reasons (see Notification).
+const (
+	NotificationReasonAuthor          = "author"
+	NotificationReasonAssigned        = "assigned"
+	NotificationReasonMention         = "mention"
+	NotificationReasonReviewRequested = "review_requested"
+	NotificationReasonSubscribed      = "subscribed"
+)
+
+// A Notification tells a user about activity on a thread.
+type Notification struct {
+	ID int
+
+	Thread NotificationThreadSpec
+
+	// Repo is the repository of the thread (if it is an issue or pull
+	// request).
+	Repo *RepoSpec `json:",omitempty"`
+
+	// Reason is why the user was notified (one of the
+	// NotificationReason constants).
+	Reason string
+
+	// Title is a short summary of the thread (e.g., an issue's title).
+	Title string
+
+	Unread bool
+
+	UpdatedAt time.Time
+}
+
+// NotificationListOptions specifies options for
+// NotificationsService.List.
+type NotificationListOptions struct {
+	// All is whether to list read notifications as well as unread
+	// ones.
+	All bool `url:",omitempty"`
+
+	// Repo, if set, restricts the notifications listed to those in the
+	// repository with this URI.
+	Repo string `url:",omitempty"`
+
+	SortOptions
+	ListOptions
+}
+
+// NotificationMarkAllReadOptions specifies options for
+// NotificationsService.MarkAllRead.
+type NotificationMarkAllReadOptions struct {
+	// Repo, if set, restricts the notifications marked as read to
+	// those in this repository.
+	Repo *RepoSpec `json:",omitempty"`
+
+	// Before, if set, restricts the notifications marked as read to
+	// those last updated before this time (so that notifications that
+	// arrive while the user is reading aren't marked as read).
+	Before *time.Time `json:",omitempty"`
+}
+
+func (s *notificationsService) List(opt *NotificationListOptions) ([]*Notification, Response, error) {
+	var notifications []*Notification
+	resp, err := s.client.DoList(router.Notifications, nil, opt, &notifications)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return notifications, resp, nil
+}
+
+func (s *notificationsService) MarkRead(id int) (Response, error) {
+	return s.client.DoUpdate(router.NotificationMarkRead, map[string]string{"NotificationID": strconv.Itoa(id)}, nil, nil)
+}
+
+func (s *notificationsService) MarkAllRead(opt *NotificationMarkAllReadOptions) (Response, error) {
+	if opt == nil {
+		opt = &NotificationMarkAllReadOptions{}
+	}
+	return s.client.DoUpdate(router.NotificationsMarkAllRead, nil, opt, nil)
+}
+
+func (s *notificationsService) Subscribe(thread NotificationThreadSpec) (Response, error) {
+	route, _, routeVars, err := thread.subscriptionRoute()
+	if err != nil {
+		return nil, err
+	}
+	return s.client.DoUpdate(route, routeVars, nil, nil)
+}
+
+func (s *notificationsService) Unsubscribe(thread NotificationThreadSpec) (Response, error) {
+	_, route, routeVars, err := thread.subscriptionRoute()
+	if err != nil {
+		return nil, err
+	}
+	return s.client.DoDelete(route, routeVars)
+}
+
+var _ NotificationsService = &MockNotificationsService{}
@@ -0,0 +1,213 @@
+package sourcegraph
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestNotificationsService_ListDestinations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+	want := []*NotificationDestination{{ID: 1, Type: NotificationSlack, Slack: &SlackDestination{WebhookURL: "https://hooks.example.com/x", Channel: "#builds"}}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestinations, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Type": NotificationSlack, "PerPage": "1"})
+
+		writeJSON(w, want)
+	})
+
+	dests, _, err := client.Notifications.ListDestinations(repo, &NotificationDestinationListOptions{Type: NotificationSlack, ListOptions: ListOptions{PerPage: 1}})
+	if err != nil {
+		t.Errorf("Notifications.ListDestinations returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(dests, want) {
+		t.Errorf("Notifications.ListDestinations returned %+v, want %+v", dests, want)
+	}
+}
+
+func TestNotificationsService_CreateDestination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+	dest := &NotificationDestination{Type: NotificationWebhook, Webhook: &WebhookDestination{URL: "https://example.com/hook"}, Events: []NotificationEvent{EventBuildFailed}}
+	want := &NotificationDestination{ID: 2, Type: NotificationWebhook, Webhook: &WebhookDestination{URL: "https://example.com/hook"}, Events: []NotificationEvent{EventBuildFailed}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestinationsCreate, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		if r.Header.Get(IdempotencyKeyHeader) == "" {
+			t.Error("no idempotency key")
+		}
+
+		var got NotificationDestination
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(&got, dest) {
+			t.Errorf("got request body %+v, want %+v", &got, dest)
+		}
+
+		writeJSON(w, want)
+	})
+
+	created, _, err := client.Notifications.CreateDestination(repo, dest)
+	if err != nil {
+		t.Errorf("Notifications.CreateDestination returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("Notifications.CreateDestination returned %+v, want %+v", created, want)
+	}
+}
+
+func TestNotificationsService_DeleteDestination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	spec := NotificationDestinationSpec{Repo: RepoSpec{URI: "r.com/x"}, ID: 3}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestinationDelete, spec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "DELETE")
+	})
+
+	_, err := client.Notifications.DeleteDestination(spec)
+	if err != nil {
+		t.Errorf("Notifications.DeleteDestination returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestNotificationsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Notification{{
+		ID:     1,
+		Thread: NotificationThreadSpec{Issue: &IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 2}},
+		Repo:   &RepoSpec{URI: "r.com/x"},
+		Reason: NotificationReasonMention,
+		Title:  "t",
+		Unread: true,
+	}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.Notifications, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Repo": "r.com/x"})
+
+		writeJSON(w, want)
+	})
+
+	notifications, _, err := client.Notifications.List(&NotificationListOptions{Repo: "r.com/x"})
+	if err != nil {
+		t.Errorf("Notifications.List returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(notifications, want) {
+		t.Errorf("Notifications.List returned %+v, want %+v", notifications, want)
+	}
+}
+
+func TestNotificationsService_MarkRead(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.NotificationMarkRead, map[string]string{"NotificationID": "1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+	})
+
+	if _, err := client.Notifications.MarkRead(1); err != nil {
+		t.Errorf("Notifications.MarkRead returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestNotificationsService_MarkAllRead(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.NotificationsMarkAllRead, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"Repo":{"URI":"r.com/x","RID":0}}`+"\n")
+	})
+
+	if _, err := client.Notifications.MarkAllRead(&NotificationMarkAllReadOptions{Repo: &RepoSpec{URI: "r.com/x"}}); err != nil {
+		t.Errorf("Notifications.MarkAllRead returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestNotificationsService_Subscribe(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pull := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	build := BuildSpec{BID: 2}
+
+	var subscribed, unsubscribed bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestSubscribe, pull.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		subscribed = true
+		testMethod(t, r, "PUT")
+	})
+	mux.HandleFunc(urlPath(t, router.BuildUnsubscribe, build.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		unsubscribed = true
+		testMethod(t, r, "DELETE")
+	})
+
+	if _, err := client.Notifications.Subscribe(NotificationThreadSpec{Pull: &pull}); err != nil {
+		t.Errorf("Notifications.Subscribe returned error: %v", err)
+	}
+	if _, err := client.Notifications.Unsubscribe(NotificationThreadSpec{Build: &build}); err != nil {
+		t.Errorf("Notifications.Unsubscribe returned error: %v", err)
+	}
+
+	if !subscribed || !unsubscribed {
+		t.Fatal("!called")
+	}
+
+	for _, thread := range []NotificationThreadSpec{{}, {Pull: &pull, Build: &build}} {
+		if _, err := client.Notifications.Subscribe(thread); err != errInvalidThreadSpec {
+			t.Errorf("%+v: got error %v, want %v", thread, err, errInvalidThreadSpec)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package sourcegraph
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"golang.org/x/net/context"
+)
+
+// An Operation is a long-running server-side operation (such as
+// repository creation or a VCS data refresh) that was started by a
+// request whose response had status 202 Accepted. Use Poll or Wait to
+// track its progress.
+type Operation struct {
+	// ID is the operation's job ID, if the server provided one (in
+	// the X-Operation-ID response header).
+	ID string
+
+	// StatusURL is the URL of the operation's status resource, if
+	// the server provided one (in the Location response header). If
+	// empty, the status is fetched from the Operation route using ID.
+	StatusURL string
+
+	client *Client
+}
+
+// OperationStatus describes the state of an Operation.
+type OperationStatus struct {
+	// Done is whether the operation has finished (successfully or
+	// not).
+	Done bool
+
+	// Error is a description of the error that caused the operation
+	// to fail, if it failed.
+	Error string `json:",omitempty"`
+
+	// Progress is the (server-estimated) fraction of the operation
+	// that is complete, from 0 to 1.
+	Progress float64 `json:",omitempty"`
+}
+
+// ErrNotAsync is returned by (*Client).Operation when the response
+// does not describe an asynchronous operation.
+var ErrNotAsync = errors.New("response does not describe an asynchronous operation (status is not 202 Accepted or no status URL or operation ID given)")
+
+// An OperationFailedError is returned by (*Operation).Wait if the
+// operation finished unsuccessfully.
+type OperationFailedError struct {
+	Op      *Operation
+	Message string // the OperationStatus's Error field
+}
+
+func (e *OperationFailedError) Error() string {
+	return fmt.Sprintf("operation %s failed: %s", e.Op.String(), e.Message)
+}
+
+// Operation returns an Operation that tracks the asynchronous
+// operation started by the request that produced resp. If resp's
+// status is not 202 Accepted, or if it specifies neither a status URL
+// nor an operation ID, ErrNotAsync is returned.
+func (c *Client) Operation(resp Response) (*Operation, error) {
+	hr, ok := resp.(*HTTPResponse)
+	if !ok || hr == nil || hr.StatusCode != http.StatusAccepted {
+		return nil, ErrNotAsync
+	}
+	op := &Operation{
+		ID:     hr.Header.Get("X-Operation-ID"),
+		client: c,
+	}
+	if loc, err := hr.Location(); err == nil {
+		op.StatusURL = loc.String()
+	}
+	if op.ID == "" && op.StatusURL == "" {
+		return nil, ErrNotAsync
+	}
+	return op, nil
+}
+
+// String returns the operation's ID or, if it has none, its status
+// URL.
+func (o *Operation) String() string {
+	if o.ID != "" {
+		return o.ID
+	}
+	return o.StatusURL
+}
+
+// Poll fetches the current status of the operation.
+func (o *Operation) Poll() (*OperationStatus, Response, error) {
+	return o.poll(o.client)
+}
+
+// poll fetches the current status of the operation using c, which is
+// o.client or a copy of it bound to a context.
+func (o *Operation) poll(c *Client) (*OperationStatus, Response, error) {
+	urlStr := o.StatusURL
+	if urlStr == "" {
+		url, err := c.URL(router.Operation, map[string]string{"OperationID": o.ID}, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		urlStr = url.String()
+	}
+
+	req, err := c.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var status *OperationStatus
+	resp, err := c.Do(req, &status)
+	if err != nil {
+		return nil, resp, err
+	}
+	if status == nil {
+		return nil, resp, fmt.Errorf("operation %s: empty status in response", o.String())
+	}
+
+	return status, resp, nil
+}
+
+// DefaultPollInterval is the interval between polls used by
+// (*Operation).Wait if no interval is given.
+const DefaultPollInterval = time.Second
+
+// Wait polls the operation every interval (or DefaultPollInterval, if
+// interval is zero) until it is done or ctx is done. Each poll request
+// is made with ctx, so canceling ctx also aborts a poll in flight. If
+// the operation failed, the final status and an *OperationFailedError
+// are returned.
+func (o *Operation) Wait(ctx context.Context, interval time.Duration) (*OperationStatus, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	c := o.client.WithContext(ctx)
+	for {
+		status, _, err := o.poll(c)
+		if err != nil {
+			return nil, err
+		}
+		if status.Done {
+			if status.Error != "" {
+				return status, &OperationFailedError{Op: o, Message: status.Error}
+			}
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
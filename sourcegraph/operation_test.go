@@ -0,0 +1,124 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"golang.org/x/net/context"
+)
+
+func TestOperation_Wait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(urlPath(t, router.RepoRefreshVCSData, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Header().Set("X-Operation-ID", "op1")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	var polls int
+	mux.HandleFunc(urlPath(t, router.Operation, map[string]string{"OperationID": "op1"}), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		polls++
+		writeJSON(w, &OperationStatus{Done: polls == 3})
+	})
+
+	resp, err := client.Repos.RefreshVCSData(RepoSpec{URI: "r.com/x"})
+	if err != nil {
+		t.Fatalf("Repos.RefreshVCSData returned error: %v", err)
+	}
+	op, err := client.Operation(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.ID != "op1" {
+		t.Errorf("got op.ID %q, want %q", op.ID, "op1")
+	}
+
+	status, err := op.Wait(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Done {
+		t.Error("got !status.Done")
+	}
+	if polls != 3 {
+		t.Errorf("got %d polls, want 3", polls)
+	}
+}
+
+func TestOperation_Wait_failed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status/1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, &OperationStatus{Done: true, Error: "x"})
+	})
+
+	op := &Operation{StatusURL: server.URL + "/status/1", client: client}
+	_, err := op.Wait(context.Background(), time.Millisecond)
+	if e, ok := err.(*OperationFailedError); !ok || e.Message != "x" {
+		t.Errorf("got error %v, want *OperationFailedError with message %q", err, "x")
+	}
+}
+
+func TestOperation_Wait_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status/1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, &OperationStatus{})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	op := &Operation{StatusURL: server.URL + "/status/1", client: client}
+	if _, err := op.Wait(ctx, time.Hour); err != context.Canceled {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestOperation_Wait_canceledDuringPoll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mux.HandleFunc("/status/1", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Error("poll request was not canceled")
+		}
+		writeJSON(w, &OperationStatus{})
+	})
+
+	op := &Operation{StatusURL: server.URL + "/status/1", client: client}
+	if _, err := op.Wait(ctx, time.Hour); err != context.Canceled {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestOperation_Wait_nullStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("null"))
+	})
+
+	op := &Operation{StatusURL: server.URL + "/status/1", client: client}
+	if _, err := op.Wait(context.Background(), time.Millisecond); err == nil {
+		t.Error("got nil error for null status, want non-nil")
+	}
+}
+
+func TestClient_Operation_notAsync(t *testing.T) {
+	if _, err := NewClient(nil).Operation(&HTTPResponse{Response: &http.Response{StatusCode: http.StatusOK}}); err != ErrNotAsync {
+		t.Errorf("got error %v, want ErrNotAsync", err)
+	}
+}
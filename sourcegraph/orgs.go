@@ -21,6 +21,24 @@ type OrgsService interface {
 
 	// UpdateSettings updates an org's configuration settings.
 	UpdateSettings(org OrgSpec, settings OrgSettings) (Response, error)
+
+	// ListTeams lists the teams in an organization.
+	ListTeams(org OrgSpec, opt *OrgListTeamsOptions) ([]*Team, Response, error)
+
+	// GetTeam fetches a team.
+	GetTeam(team TeamSpec) (*Team, Response, error)
+
+	// CreateTeam creates a team in an organization. The team's Slug is
+	// derived from its Name if empty, and its Org field is ignored.
+	CreateTeam(org OrgSpec, team *Team) (*Team, Response, error)
+
+	// AddTeamMember adds a user (who must be a member of the
+	// organization) to a team. Adding a user who is already a member
+	// of the team is not an error.
+	AddTeamMember(team TeamSpec, user UserSpec) (Response, error)
+
+	// RemoveTeamMember removes a user from a team.
+	RemoveTeamMember(team TeamSpec, user UserSpec) (Response, error)
 }
 
 // orgsService implements OrgsService.
@@ -70,18 +88,8 @@ func ParseOrgSpec(pathComponent string) (OrgSpec, error) {
 }
 
 func (s *orgsService) Get(org OrgSpec) (*Org, Response, error) {
-	url, err := s.client.URL(router.Org, org.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var org_ *Org
-	resp, err := s.client.Do(req, &org_)
+	resp, err := s.client.DoGet(router.Org, org.RouteVars(), nil, &org_)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -90,22 +98,13 @@ func (s *orgsService) Get(org OrgSpec) (*Org, Response, error) {
 }
 
 type OrgListMembersOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *orgsService) ListMembers(org OrgSpec, opt *OrgListMembersOptions) ([]*User, Response, error) {
-	url, err := s.client.URL(router.OrgMembers, org.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var members []*User
-	resp, err := s.client.Do(req, &members)
+	resp, err := s.client.DoList(router.OrgMembers, org.RouteVars(), opt, &members)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -119,37 +118,102 @@ type OrgSettings struct {
 }
 
 func (s *orgsService) GetSettings(org OrgSpec) (*OrgSettings, Response, error) {
-	url, err := s.client.URL(router.OrgSettings, org.RouteVars(), nil)
+	var settings *OrgSettings
+	resp, err := s.client.DoGet(router.OrgSettings, org.RouteVars(), nil, &settings)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
+	return settings, resp, nil
+}
+
+func (s *orgsService) UpdateSettings(org OrgSpec, settings OrgSettings) (Response, error) {
+	resp, err := s.client.DoUpdate(router.OrgSettingsUpdate, org.RouteVars(), settings, nil)
 	if err != nil {
-		return nil, nil, err
+		return resp, err
 	}
 
-	var settings *OrgSettings
-	resp, err := s.client.Do(req, &settings)
+	return resp, nil
+}
+
+// TeamSpec specifies a team in an organization.
+type TeamSpec struct {
+	Org  OrgSpec
+	Slug string
+}
+
+func (s TeamSpec) RouteVars() map[string]string {
+	m := s.Org.RouteVars()
+	m["Team"] = s.Slug
+	return m
+}
+
+// UnmarshalTeamSpec marshals a map containing route variables
+// generated by (TeamSpec).RouteVars() and returns the equivalent
+// TeamSpec struct.
+func UnmarshalTeamSpec(routeVars map[string]string) (TeamSpec, error) {
+	org, err := ParseOrgSpec(routeVars["OrgSpec"])
+	if err != nil {
+		return TeamSpec{}, err
+	}
+	return TeamSpec{Org: org, Slug: routeVars["Team"]}, nil
+}
+
+// A Team is a named group of members of an organization.
+type Team struct {
+	Org OrgSpec
+
+	// Slug identifies the team (uniquely within its organization) in
+	// URLs.
+	Slug string
+
+	Name        string
+	Description string `json:",omitempty"`
+
+	// MemberCount is the number of users in the team.
+	MemberCount int `json:",omitempty"`
+}
+
+// Spec returns the TeamSpec that specifies t.
+func (t *Team) Spec() TeamSpec { return TeamSpec{Org: t.Org, Slug: t.Slug} }
+
+type OrgListTeamsOptions struct {
+	SortOptions
+	ListOptions
+}
+
+func (s *orgsService) ListTeams(org OrgSpec, opt *OrgListTeamsOptions) ([]*Team, Response, error) {
+	var teams []*Team
+	resp, err := s.client.DoList(router.OrgTeams, org.RouteVars(), opt, &teams)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return settings, resp, nil
+	return teams, resp, nil
 }
 
-func (s *orgsService) UpdateSettings(org OrgSpec, settings OrgSettings) (Response, error) {
-	url, err := s.client.URL(router.OrgSettingsUpdate, org.RouteVars(), nil)
+func (s *orgsService) GetTeam(team TeamSpec) (*Team, Response, error) {
+	var team_ *Team
+	resp, err := s.client.DoGet(router.OrgTeam, team.RouteVars(), nil, &team_)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("PUT", url.String(), settings)
+	return team_, resp, nil
+}
+
+func (s *orgsService) CreateTeam(org OrgSpec, team *Team) (*Team, Response, error) {
+	var created *Team
+	resp, err := s.client.DoCreate(router.OrgTeamsCreate, org.RouteVars(), team, &created)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	return created, resp, nil
+}
+
+func (s *orgsService) AddTeamMember(team TeamSpec, user UserSpec) (Response, error) {
+	resp, err := s.client.DoUpdate(router.OrgTeamMemberAdd, teamMemberRouteVars(team, user), nil, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -157,4 +221,21 @@ func (s *orgsService) UpdateSettings(org OrgSpec, settings OrgSettings) (Respons
 	return resp, nil
 }
 
+func (s *orgsService) RemoveTeamMember(team TeamSpec, user UserSpec) (Response, error) {
+	resp, err := s.client.DoDelete(router.OrgTeamMemberRemove, teamMemberRouteVars(team, user))
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// teamMemberRouteVars returns the route variables that specify a member
+// of a team.
+func teamMemberRouteVars(team TeamSpec, user UserSpec) map[string]string {
+	m := team.RouteVars()
+	m["UserSpec"] = user.PathComponent()
+	return m
+}
+
 var _ OrgsService = &MockOrgsService{}
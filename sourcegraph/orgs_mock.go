@@ -1,22 +1,111 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 type MockOrgsService struct {
-	Get_            func(org OrgSpec) (*Org, Response, error)
-	ListMembers_    func(org OrgSpec, opt *OrgListMembersOptions) ([]*User, Response, error)
-	GetSettings_    func(org OrgSpec) (*OrgSettings, Response, error)
-	UpdateSettings_ func(org OrgSpec, settings OrgSettings) (Response, error)
+	Get_              func(org OrgSpec) (*Org, Response, error)
+	ListMembers_      func(org OrgSpec, opt *OrgListMembersOptions) ([]*User, Response, error)
+	GetSettings_      func(org OrgSpec) (*OrgSettings, Response, error)
+	UpdateSettings_   func(org OrgSpec, settings OrgSettings) (Response, error)
+	ListTeams_        func(org OrgSpec, opt *OrgListTeamsOptions) ([]*Team, Response, error)
+	GetTeam_          func(team TeamSpec) (*Team, Response, error)
+	CreateTeam_       func(org OrgSpec, team *Team) (*Team, Response, error)
+	AddTeamMember_    func(team TeamSpec, user UserSpec) (Response, error)
+	RemoveTeamMember_ func(team TeamSpec, user UserSpec) (Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockOrgsService returns a new MockOrgsService
+// that records calls to its methods.
+func NewMockOrgsService() *MockOrgsService {
+	return &MockOrgsService{Calls: &MockCalls{}}
 }
 
-func (s MockOrgsService) Get(org OrgSpec) (*Org, Response, error) { return s.Get_(org) }
+func (s MockOrgsService) Get(org OrgSpec) (*Org, Response, error) {
+	s.Calls.record("Get", org)
+	if s.Get_ == nil {
+		var r0 *Org
+		var r1 Response
+		return r0, r1, mockNotImplemented("OrgsService.Get")
+	}
+	return s.Get_(org)
+}
 
 func (s MockOrgsService) ListMembers(org OrgSpec, opt *OrgListMembersOptions) ([]*User, Response, error) {
+	s.Calls.record("ListMembers", org, opt)
+	if s.ListMembers_ == nil {
+		var r0 []*User
+		var r1 Response
+		return r0, r1, mockNotImplemented("OrgsService.ListMembers")
+	}
 	return s.ListMembers_(org, opt)
 }
 
 func (s MockOrgsService) GetSettings(org OrgSpec) (*OrgSettings, Response, error) {
+	s.Calls.record("GetSettings", org)
+	if s.GetSettings_ == nil {
+		var r0 *OrgSettings
+		var r1 Response
+		return r0, r1, mockNotImplemented("OrgsService.GetSettings")
+	}
 	return s.GetSettings_(org)
 }
 
 func (s MockOrgsService) UpdateSettings(org OrgSpec, settings OrgSettings) (Response, error) {
+	s.Calls.record("UpdateSettings", org, settings)
+	if s.UpdateSettings_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("OrgsService.UpdateSettings")
+	}
 	return s.UpdateSettings_(org, settings)
 }
+
+func (s MockOrgsService) ListTeams(org OrgSpec, opt *OrgListTeamsOptions) ([]*Team, Response, error) {
+	s.Calls.record("ListTeams", org, opt)
+	if s.ListTeams_ == nil {
+		var r0 []*Team
+		var r1 Response
+		return r0, r1, mockNotImplemented("OrgsService.ListTeams")
+	}
+	return s.ListTeams_(org, opt)
+}
+
+func (s MockOrgsService) GetTeam(team TeamSpec) (*Team, Response, error) {
+	s.Calls.record("GetTeam", team)
+	if s.GetTeam_ == nil {
+		var r0 *Team
+		var r1 Response
+		return r0, r1, mockNotImplemented("OrgsService.GetTeam")
+	}
+	return s.GetTeam_(team)
+}
+
+func (s MockOrgsService) CreateTeam(org OrgSpec, team *Team) (*Team, Response, error) {
+	s.Calls.record("CreateTeam", org, team)
+	if s.CreateTeam_ == nil {
+		var r0 *Team
+		var r1 Response
+		return r0, r1, mockNotImplemented("OrgsService.CreateTeam")
+	}
+	return s.CreateTeam_(org, team)
+}
+
+func (s MockOrgsService) AddTeamMember(team TeamSpec, user UserSpec) (Response, error) {
+	s.Calls.record("AddTeamMember", team, user)
+	if s.AddTeamMember_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("OrgsService.AddTeamMember")
+	}
+	return s.AddTeamMember_(team, user)
+}
+
+func (s MockOrgsService) RemoveTeamMember(team TeamSpec, user UserSpec) (Response, error) {
+	s.Calls.record("RemoveTeamMember", team, user)
+	if s.RemoveTeamMember_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("OrgsService.RemoveTeamMember")
+	}
+	return s.RemoveTeamMember_(team, user)
+}
@@ -144,3 +144,120 @@ func TestOrgsService_UpdateSettings(t *testing.T) {
 		t.Fatal("!called")
 	}
 }
+
+func TestOrgsService_ListTeams(t *testing.T) {
+	setup()
+	defer teardown()
+
+	org := OrgSpec{Org: "o"}
+	want := []*Team{{Org: org, Slug: "t", Name: "T"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.OrgTeams, org.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"PerPage": "5"})
+
+		writeJSON(w, want)
+	})
+
+	teams, _, err := client.Orgs.ListTeams(org, &OrgListTeamsOptions{ListOptions: ListOptions{PerPage: 5}})
+	if err != nil {
+		t.Errorf("Orgs.ListTeams returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(teams, want) {
+		t.Errorf("Orgs.ListTeams returned %+v, want %+v", teams, want)
+	}
+}
+
+func TestOrgsService_GetTeam(t *testing.T) {
+	setup()
+	defer teardown()
+
+	team := TeamSpec{Org: OrgSpec{Org: "o"}, Slug: "t"}
+	want := &Team{Org: team.Org, Slug: "t", Name: "T", MemberCount: 2}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.OrgTeam, team.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	team_, _, err := client.Orgs.GetTeam(team)
+	if err != nil {
+		t.Errorf("Orgs.GetTeam returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(team_, want) {
+		t.Errorf("Orgs.GetTeam returned %+v, want %+v", team_, want)
+	}
+	if spec := team_.Spec(); spec != team {
+		t.Errorf("got spec %+v, want %+v", spec, team)
+	}
+}
+
+func TestOrgsService_CreateTeam(t *testing.T) {
+	setup()
+	defer teardown()
+
+	org := OrgSpec{Org: "o"}
+	want := &Team{Org: org, Slug: "t", Name: "T"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.OrgTeamsCreate, org.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Org":{"Org":"","UID":0},"Slug":"","Name":"T"}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	team, _, err := client.Orgs.CreateTeam(org, &Team{Name: "T"})
+	if err != nil {
+		t.Errorf("Orgs.CreateTeam returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(team, want) {
+		t.Errorf("Orgs.CreateTeam returned %+v, want %+v", team, want)
+	}
+}
+
+func TestOrgsService_AddAndRemoveTeamMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	team := TeamSpec{Org: OrgSpec{Org: "o"}, Slug: "t"}
+	user := UserSpec{Login: "alice"}
+	vars := teamMemberRouteVars(team, user)
+
+	var methods []string
+	mux.HandleFunc(urlPath(t, router.OrgTeamMemberAdd, vars), func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+	})
+
+	if _, err := client.Orgs.AddTeamMember(team, user); err != nil {
+		t.Errorf("Orgs.AddTeamMember returned error: %v", err)
+	}
+	if _, err := client.Orgs.RemoveTeamMember(team, user); err != nil {
+		t.Errorf("Orgs.RemoveTeamMember returned error: %v", err)
+	}
+
+	if want := []string{"PUT", "DELETE"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("got methods %v, want %v", methods, want)
+	}
+}
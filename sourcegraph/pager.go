@@ -0,0 +1,145 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A PageFunc fetches a page of a list (as specified by opt). items must
+// be a slice. Most list methods can be wrapped in a PageFunc by
+// setting their options' ListOptions:
+//
+//	func(lo ListOptions) (interface{}, Response, error) {
+//		opt.ListOptions = lo
+//		return client.PullRequests.ListByRepo(repo, opt)
+//	}
+type PageFunc func(opt ListOptions) (items interface{}, resp Response, err error)
+
+// A Pager iterates over all of the items in a paginated list, fetching
+// pages as needed:
+//
+//	p := NewPager(opt.ListOptions, func(lo ListOptions) (interface{}, Response, error) {
+//		opt.ListOptions = lo
+//		return client.PullRequests.ListByRepo(repo, opt)
+//	})
+//	var pull *PullRequest
+//	for p.Next(&pull) {
+//		...
+//	}
+//	if err := p.Err(); err != nil { ... }
+//
+// If a response has a Link header, the Pager follows its rel="next"
+// link (and stops when there is none). Otherwise, it stops after the
+// first page with fewer than PerPage items, or after it has returned
+// as many items as the total count reported by the server.
+type Pager struct {
+	// ItemID, if set, returns a value that uniquely identifies an item
+	// (such as its ID). It is used to check the pages with a
+	// PaginationGuard; if they are inconsistent, Err returns
+	// ErrInconsistentPagination.
+	ItemID func(item interface{}) string
+
+	fetch PageFunc
+	opt   ListOptions
+	resp  Response
+	err   error
+	done  bool
+	guard PaginationGuard
+
+	items reflect.Value // current page
+	i     int           // index in items of the next item
+	n     int           // number of items fetched so far
+}
+
+// NewPager returns a Pager that calls fetch to fetch each page of a
+// list, starting at the page specified by opt.
+func NewPager(opt ListOptions, fetch PageFunc) *Pager {
+	opt.Page = opt.PageOrDefault()
+	return &Pager{fetch: fetch, opt: opt}
+}
+
+// NewListPager returns a Pager for a list method whose options are
+// opt, starting at the page specified by opt. Before each page is
+// fetched, opt's ListOptions are set to specify the page, so fetch
+// need only call the list method with opt:
+//
+//	opt := &PullRequestListOptions{State: "open"}
+//	p := NewListPager(opt, func() (interface{}, Response, error) {
+//		return client.PullRequests.ListByRepo(repo, opt)
+//	})
+func NewListPager(opt Pageable, fetch func() (interface{}, Response, error)) *Pager {
+	lo := opt.Paging()
+	return NewPager(*lo, func(page ListOptions) (interface{}, Response, error) {
+		*lo = page
+		return fetch()
+	})
+}
+
+// Next stores the next item in the list in the value pointed to by v
+// (whose type must be the list's element type) and returns true, or it
+// returns false if there are no more items or an error occurred (see
+// Err).
+func (p *Pager) Next(v interface{}) bool {
+	for p.err == nil {
+		if p.items.IsValid() && p.i < p.items.Len() {
+			rv := reflect.ValueOf(v)
+			if rv.Kind() != reflect.Ptr || rv.Elem().Type() != p.items.Type().Elem() {
+				p.err = fmt.Errorf("Pager.Next: argument must be a %s, not %T", reflect.PtrTo(p.items.Type().Elem()), v)
+				return false
+			}
+			rv.Elem().Set(p.items.Index(p.i))
+			p.i++
+			return true
+		}
+		if p.done {
+			if p.ItemID != nil {
+				p.err = p.guard.Done()
+			}
+			return false
+		}
+		p.fetchPage()
+	}
+	return false
+}
+
+// Err returns the error, if any, that occurred while fetching pages.
+func (p *Pager) Err() error { return p.err }
+
+// Response returns the response for the most recently fetched page.
+func (p *Pager) Response() Response { return p.resp }
+
+func (p *Pager) fetchPage() {
+	items, resp, err := p.fetch(p.opt)
+	p.resp = resp
+	if err != nil {
+		p.err = err
+		return
+	}
+	p.items, p.i = reflect.ValueOf(items), 0
+	if p.items.Kind() != reflect.Slice {
+		p.err = fmt.Errorf("Pager: page must be a slice, not %T", items)
+		return
+	}
+	n := p.items.Len()
+	p.n += n
+
+	if p.ItemID != nil {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = p.ItemID(p.items.Index(i).Interface())
+		}
+		if p.err = p.guard.Add(resp, ids); p.err != nil {
+			return
+		}
+	}
+
+	if resp != nil {
+		if pg := resp.Pagination(); pg.Linked {
+			p.done = pg.NextPage == 0
+			p.opt.Page = pg.NextPage
+			return
+		}
+	}
+	p.done = n == 0 || n < p.opt.PerPageOrDefault() || (resp != nil && resp.TotalCount() != -1 && p.n >= resp.TotalCount())
+	p.opt.Page++
+}
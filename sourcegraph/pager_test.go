@@ -0,0 +1,143 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"github.com/sourcegraph/go-github/github"
+)
+
+func TestPager(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+	var pages []string
+	mux.HandleFunc(urlPath(t, router.RepoIssues, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.FormValue("Page"))
+		pages = append(pages, r.FormValue("Page"))
+		var issues []*Issue
+		for i := (page - 1) * 2; i < page*2 && i < 5; i++ {
+			issues = append(issues, &Issue{Issue: github.Issue{Number: github.Int(i)}})
+		}
+		writeJSON(w, issues)
+	})
+
+	opt := &IssueListOptions{ListOptions: ListOptions{PerPage: 2}}
+	p := NewPager(opt.ListOptions, func(lo ListOptions) (interface{}, Response, error) {
+		opt.ListOptions = lo
+		return client.Issues.ListByRepo(repo, opt)
+	})
+	var numbers []int
+	var issue *Issue
+	for p.Next(&issue) {
+		numbers = append(numbers, *issue.Number)
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(numbers, want) {
+		t.Errorf("got issues %v, want %v", numbers, want)
+	}
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("got pages %v, want %v", pages, want)
+	}
+}
+
+func TestNewListPager(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+	mux.HandleFunc(urlPath(t, router.RepoIssues, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"State": "open", "PerPage": "2", "Page": r.FormValue("Page")})
+		page, _ := strconv.Atoi(r.FormValue("Page"))
+		var issues []*Issue
+		for i := (page - 1) * 2; i < page*2 && i < 3; i++ {
+			issues = append(issues, &Issue{Issue: github.Issue{Number: github.Int(i)}})
+		}
+		writeJSON(w, issues)
+	})
+
+	opt := &IssueListOptions{State: "open", ListOptions: ListOptions{PerPage: 2}}
+	p := NewListPager(opt, func() (interface{}, Response, error) {
+		return client.Issues.ListByRepo(repo, opt)
+	})
+	var numbers []int
+	var issue *Issue
+	for p.Next(&issue) {
+		numbers = append(numbers, *issue.Number)
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{0, 1, 2}; !reflect.DeepEqual(numbers, want) {
+		t.Errorf("got issues %v, want %v", numbers, want)
+	}
+}
+
+func TestPager_linkHeader(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var pages []string
+	mux.HandleFunc(urlPath(t, router.Repos, nil), func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.FormValue("Page"))
+		switch r.FormValue("Page") {
+		case "1":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos?Page=3>; rel="next", <%s/repos?Page=3>; rel="last"`, server.URL, server.URL))
+			writeJSON(w, []*Repo{{URI: "a"}})
+		case "3":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos?Page=1>; rel="first"`, server.URL))
+			writeJSON(w, []*Repo{{URI: "b"}})
+		}
+	})
+
+	opt := &RepoListOptions{ListOptions: ListOptions{PerPage: 1}}
+	p := NewPager(opt.ListOptions, func(lo ListOptions) (interface{}, Response, error) {
+		opt.ListOptions = lo
+		return client.Repos.List(opt)
+	})
+	var uris []string
+	var repo *Repo
+	for p.Next(&repo) {
+		uris = append(uris, repo.URI)
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"a", "b"}; !reflect.DeepEqual(uris, want) {
+		t.Errorf("got repos %v, want %v", uris, want)
+	}
+	if want := []string{"1", "3"}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("got pages %v, want %v", pages, want)
+	}
+}
+
+func TestPager_errors(t *testing.T) {
+	pageOf := func(items ...string) PageFunc {
+		return func(ListOptions) (interface{}, Response, error) { return items, nil, nil }
+	}
+
+	p := NewPager(ListOptions{PerPage: 1}, pageOf("a"))
+	p.ItemID = func(item interface{}) string { return item.(string) }
+	var s string
+	for p.Next(&s) {
+	}
+	if err := p.Err(); err != ErrInconsistentPagination {
+		t.Errorf("got error %v, want %v", err, ErrInconsistentPagination)
+	}
+
+	p = NewPager(ListOptions{}, pageOf("a"))
+	var n int
+	if p.Next(&n) || p.Err() == nil {
+		t.Error("got no error for argument of wrong type, want error")
+	}
+}
@@ -0,0 +1,151 @@
+package sourcegraph
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInconsistentPagination is returned by PaginationGuard when the
+// pages of a list are inconsistent with each other, which usually
+// means that the list was modified on the server while it was being
+// paged through.
+var ErrInconsistentPagination = errors.New("inconsistent pagination: list changed while it was being paged through")
+
+// A PaginationGuard detects duplicates and gaps in paginated list
+// results (caused, e.g., by items being added or removed on the
+// server between page fetches). Record each page with Add, and call
+// Done after fetching the last page:
+//
+//	var g PaginationGuard
+//	for opt.Page = 1; ; opt.Page++ {
+//		repos, resp, err := client.Repos.List(opt)
+//		if err != nil { ... }
+//		ids := make([]string, len(repos))
+//		for i, repo := range repos {
+//			ids[i] = repo.URI
+//		}
+//		if err := g.Add(resp, ids); err != nil { ... }
+//		if len(repos) < opt.PerPageOrDefault() {
+//			break
+//		}
+//	}
+//	if err := g.Done(); err != nil { ... }
+//
+// The zero value is ready to use.
+type PaginationGuard struct {
+	// Duplicate is the ID of the item that appeared more than once,
+	// if Add returned ErrInconsistentPagination for that reason.
+	Duplicate string
+
+	seen       map[string]struct{}
+	totalCount int // total count reported by the first page's response (-1 if none)
+	pages      int
+}
+
+// Add records the item IDs (or any other values that uniquely
+// identify items, such as ETags) on a page of results, along with
+// the page's response. It returns ErrInconsistentPagination if an
+// item appeared on a previous page or if the total count reported by
+// the server changed.
+func (g *PaginationGuard) Add(resp Response, ids []string) error {
+	tc := -1
+	if resp != nil {
+		tc = resp.TotalCount()
+	}
+	if g.pages == 0 {
+		g.seen = make(map[string]struct{}, len(ids))
+		g.totalCount = tc
+	} else if tc != -1 && g.totalCount != -1 && tc != g.totalCount {
+		return ErrInconsistentPagination
+	}
+	g.pages++
+
+	for _, id := range ids {
+		if _, seen := g.seen[id]; seen {
+			g.Duplicate = id
+			return ErrInconsistentPagination
+		}
+		g.seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// Done checks that the number of items seen on all pages equals the
+// total count reported by the server (if any). It returns
+// ErrInconsistentPagination if items were skipped.
+func (g *PaginationGuard) Done() error {
+	if g.pages > 0 && g.totalCount != -1 && len(g.seen) != g.totalCount {
+		return ErrInconsistentPagination
+	}
+	return nil
+}
+
+// Pagination describes where a page of a paginated list is in the
+// list, as reported by the server in the response's Link and
+// X-Total-Count headers.
+type Pagination struct {
+	// NextPage, PrevPage, and LastPage are the page numbers of the
+	// response's rel="next", rel="prev", and rel="last" links, or 0 if
+	// the response has no such link.
+	NextPage, PrevPage, LastPage int
+
+	// TotalCount is the total number of items in the list, or -1 if
+	// the server didn't report it (see Response.TotalCount).
+	TotalCount int
+
+	// Linked is whether the response has a Link header. If it does, a
+	// zero NextPage means that the page is the last page.
+	Linked bool
+}
+
+// Pageable is implemented by the options of list methods that are
+// paginated by page number (which embed ListOptions). It is used by
+// NewListPager.
+type Pageable interface {
+	// Paging returns a pointer to the options' ListOptions.
+	Paging() *ListOptions
+}
+
+// Paging implements Pageable.
+func (o *ListOptions) Paging() *ListOptions { return o }
+
+// parseLinkHeader returns the pagination links in a Link header (as
+// defined in RFC 5988), such as
+// `<https://example.com/repos?Page=3>; rel="next"`. The page number
+// of each link is the value of its URL's Page query parameter.
+func parseLinkHeader(header string) Pagination {
+	p := Pagination{TotalCount: -1, Linked: header != ""}
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+		if err != nil {
+			continue
+		}
+		page, err := strconv.Atoi(u.Query().Get("Page"))
+		if err != nil {
+			continue
+		}
+		for _, param := range parts[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) != 2 || kv[0] != "rel" {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(kv[1], `"`)) {
+				switch rel {
+				case "next":
+					p.NextPage = page
+				case "prev":
+					p.PrevPage = page
+				case "last":
+					p.LastPage = page
+				}
+			}
+		}
+	}
+	return p
+}
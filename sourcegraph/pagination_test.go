@@ -0,0 +1,110 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func totalCountResponse(n int) Response {
+	h := http.Header{}
+	if n != -1 {
+		h.Set("x-total-count", strconv.Itoa(n))
+	}
+	return &HTTPResponse{Response: &http.Response{Header: h}}
+}
+
+func TestPaginationGuard(t *testing.T) {
+	tests := map[string]struct {
+		totalCounts []int
+		pages       [][]string
+		wantErr     bool
+		wantDup     string
+	}{
+		"consistent": {
+			totalCounts: []int{3, 3},
+			pages:       [][]string{{"a", "b"}, {"c"}},
+		},
+		"no total count": {
+			totalCounts: []int{-1, -1},
+			pages:       [][]string{{"a", "b"}, {"c"}},
+		},
+		"duplicate": {
+			totalCounts: []int{-1, -1},
+			pages:       [][]string{{"a", "b"}, {"b", "c"}},
+			wantErr:     true,
+			wantDup:     "b",
+		},
+		"total count changed": {
+			totalCounts: []int{3, 4},
+			pages:       [][]string{{"a", "b"}, {"c", "d"}},
+			wantErr:     true,
+		},
+		"gap": {
+			totalCounts: []int{4, 4},
+			pages:       [][]string{{"a", "b"}, {"d"}},
+			wantErr:     true,
+		},
+	}
+	for label, test := range tests {
+		var g PaginationGuard
+		var err error
+		for i, ids := range test.pages {
+			if err = g.Add(totalCountResponse(test.totalCounts[i]), ids); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = g.Done()
+		}
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%s: got error %v, want error: %v", label, err, test.wantErr)
+		}
+		if err != nil && err != ErrInconsistentPagination {
+			t.Errorf("%s: got error %v, want ErrInconsistentPagination", label, err)
+		}
+		if g.Duplicate != test.wantDup {
+			t.Errorf("%s: got Duplicate %q, want %q", label, g.Duplicate, test.wantDup)
+		}
+	}
+}
+
+func TestHTTPResponse_Pagination(t *testing.T) {
+	tests := []struct {
+		header http.Header
+		want   Pagination
+	}{
+		{
+			header: http.Header{},
+			want:   Pagination{TotalCount: -1},
+		},
+		{
+			header: http.Header{
+				"Link":          {`<https://x.com/repos?Page=3&PerPage=2>; rel="next", <https://x.com/repos?Page=1>; rel="prev first", <https://x.com/repos?Page=9>; rel="last"`},
+				"X-Total-Count": {"17"},
+			},
+			want: Pagination{NextPage: 3, PrevPage: 1, LastPage: 9, TotalCount: 17, Linked: true},
+		},
+		{
+			// The last page has no rel="next" link.
+			header: http.Header{"Link": {`<https://x.com/repos?Page=1>; rel="first", <bad>; rel="prev"`}},
+			want:   Pagination{TotalCount: -1, Linked: true},
+		},
+	}
+	for i, test := range tests {
+		resp := &HTTPResponse{Response: &http.Response{Header: test.header}}
+		if got := resp.Pagination(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("#%d: got %+v, want %+v", i, got, test.want)
+		}
+	}
+}
+
+func TestPageable(t *testing.T) {
+	opt := &RepoListOptions{ListOptions: ListOptions{PerPage: 5}}
+	var p Pageable = opt
+	p.Paging().Page = 2
+	if opt.Page != 2 {
+		t.Errorf("got Page %d, want 2", opt.Page)
+	}
+}
@@ -0,0 +1,64 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// PanicError is returned in place of a panic that occurred while the
+// client was encoding request options or a request body, or decoding
+// (and validating) a response. Such panics are usually caused by
+// custom marshalers or Validate methods that can't handle unexpected
+// data; converting them into errors prevents a single malformed
+// response from crashing a long-running process.
+type PanicError struct {
+	Op string // the operation that panicked (e.g., "decoding response")
+
+	// Route and RouteVars are the name and variables of the API route
+	// that the request was for, if known. The route variables identify
+	// the request's subject (e.g., the RepoSpec and Rev).
+	Route     string
+	RouteVars map[string]string
+
+	Method string // the HTTP request method, if known
+	URL    string // the request URL, if known
+
+	Value interface{} // the value passed to panic
+	Stack []byte      // the stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	var req string
+	if e.Method != "" || e.URL != "" {
+		req = fmt.Sprintf(" for %s %s", e.Method, e.URL)
+	}
+	return fmt.Sprintf("panic while %s (route %q, route vars %v)%s: %v", e.Op, e.Route, e.RouteVars, req, e.Value)
+}
+
+// IsPanic returns true if err is a *PanicError.
+func IsPanic(err error) bool {
+	_, ok := err.(*PanicError)
+	return ok
+}
+
+// newPanicError returns a *PanicError for a panic with value v that
+// occurred during op while handling req (which may be nil).
+func (c *Client) newPanicError(op string, req *http.Request, v interface{}) *PanicError {
+	e := &PanicError{Op: op, Value: v, Stack: debug.Stack()}
+	if req != nil {
+		e.Method = req.Method
+		e.URL = req.URL.RequestURI()
+		e.Route, e.RouteVars = c.matchRoute(req)
+	}
+	return e
+}
+
+// reportError calls the Client's OnError hook (if any) with err and
+// returns err.
+func (c *Client) reportError(req *http.Request, err error) error {
+	if err != nil && c.OnError != nil {
+		c.OnError(req, err)
+	}
+	return err
+}
@@ -5,17 +5,47 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fossas/go-sourcegraph/router"
+	"sourcegraph.com/sourcegraph/go-nnz/nnz"
 )
 
 // PeopleService communicates with the people-related endpoints in the
 // Sourcegraph API.
+//
+// To list the people who contributed to or use a repository or def,
+// use ReposService.ListAuthors and ListClients or DefsService.ListAuthors
+// and ListClients.
 type PeopleService interface {
 	// Get gets a person. If an email is provided and it resolves to a
 	// registered user, information about that user is
 	// returned. Otherwise a transient person is created and returned.
 	Get(person PersonSpec) (*Person, Response, error)
+
+	// GetStats gets statistics about a person's contributions (commits,
+	// defs authored, refs to their code, and active repositories) over
+	// the time range specified in opt.
+	GetStats(person PersonSpec, opt *PersonGetStatsOptions) (*PersonContributionStats, Response, error)
+
+	// ListCollaborators lists the people who committed to the
+	// repositories that person committed to, with statistics about
+	// their contributions to those repositories.
+	ListCollaborators(person PersonSpec, opt *PersonListCollaboratorsOptions) ([]*AugmentedPersonCollaborator, Response, error)
+
+	// Invite invites a person (by email) to create an account on the
+	// server. It requires admin access.
+	Invite(email string, opt *PersonInviteOptions) (*PersonInvite, Response, error)
+
+	// Deactivate deactivates a registered user's account, so that the
+	// user can no longer sign in or use the API (e.g., when they leave
+	// an organization). Their contributions are kept. It requires
+	// admin access.
+	Deactivate(person PersonSpec) (Response, error)
+
+	// Reactivate reactivates a deactivated account. It requires admin
+	// access.
+	Reactivate(person PersonSpec) (Response, error)
 }
 
 // peopleService implements PeopleService.
@@ -40,6 +70,10 @@ type Person struct {
 
 	// AvatarURL is the URL to the user's avatar image.
 	AvatarURL string
+
+	// Deactivated is whether the user's account has been deactivated
+	// (see PeopleService.Deactivate).
+	Deactivated bool `json:",omitempty"`
 }
 
 // ShortName returns the person's Login if nonempty and otherwise
@@ -115,23 +149,165 @@ func ParsePersonSpec(pathComponent string) (PersonSpec, error) {
 }
 
 func (s *peopleService) Get(spec PersonSpec) (*Person, Response, error) {
-	url, err := s.client.URL(router.Person, spec.RouteVars(), nil)
+	var person *Person
+	resp, err := s.client.getCached(specCachePeople, router.Person, spec.RouteVars(), nil, &person)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
+	return person, resp, nil
+}
+
+// PersonGetStatsOptions specifies options for PeopleService.GetStats.
+type PersonGetStatsOptions struct {
+	// TimeRangeOptions restricts the statistics to contributions made
+	// within the time range. If Since or Until is nil, the range is
+	// unbounded on that side.
+	TimeRangeOptions
+}
+
+// PersonContributionStats summarizes a person's contributions over a
+// time range.
+type PersonContributionStats struct {
+	// Commits is the number of commits authored by the person.
+	Commits int
+
+	// DefsAuthored is the number of defs that the person authored (in
+	// whole or in part).
+	DefsAuthored int
+
+	// RefsToAuthoredDefs is the number of refs (from any repository) to
+	// defs that the person authored.
+	RefsToAuthoredDefs int
+
+	// ActiveRepos is the list of repository URIs that the person
+	// committed to.
+	ActiveRepos []string
+}
+
+func (s *peopleService) GetStats(spec PersonSpec, opt *PersonGetStatsOptions) (*PersonContributionStats, Response, error) {
+	var stats *PersonContributionStats
+	resp, err := s.client.DoGet(router.PersonStats, spec.RouteVars(), opt, &stats)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	var person *Person
-	resp, err := s.client.Do(req, &person)
+	return stats, resp, nil
+}
+
+// PersonCollaborator describes a person's contributions to the
+// repositories that another person also contributed to.
+type PersonCollaborator struct {
+	UID   nnz.Int
+	Email nnz.String
+
+	// AuthorshipInfo describes the collaborator's most recent commit
+	// to a shared repository.
+	AuthorshipInfo
+
+	// CommitCount is the number of commits that the collaborator made
+	// to the shared repositories.
+	CommitCount int
+
+	// BytesProportion is the proportion of the code in the shared
+	// repositories that the collaborator authored.
+	BytesProportion float64
+
+	// Repos is the list of URIs of the shared repositories.
+	Repos []string
+}
+
+// AugmentedPersonCollaborator is a PersonCollaborator with the full
+// Person struct embedded.
+type AugmentedPersonCollaborator struct {
+	Person *Person
+	*PersonCollaborator
+}
+
+// PersonListCollaboratorsOptions specifies options for
+// PeopleService.ListCollaborators.
+type PersonListCollaboratorsOptions struct {
+	// Repo, if set, restricts the list to collaborators on the
+	// repository with this URI.
+	Repo string `url:",omitempty" json:",omitempty"`
+
+	// TimeRangeOptions, if set, restricts the list to collaborators
+	// who committed within the time range.
+	TimeRangeOptions
+
+	SortOptions
+	ListOptions
+}
+
+func (s *peopleService) ListCollaborators(spec PersonSpec, opt *PersonListCollaboratorsOptions) ([]*AugmentedPersonCollaborator, Response, error) {
+	var collaborators []*AugmentedPersonCollaborator
+	resp, err := s.client.DoList(router.PersonCollaborators, spec.RouteVars(), opt, &collaborators)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return person, resp, nil
+	return collaborators, resp, nil
+}
+
+// PersonInviteOptions specifies options for PeopleService.Invite.
+type PersonInviteOptions struct {
+	// Message, if set, is a personal note included in the invitation
+	// email.
+	Message string `json:",omitempty"`
+
+	// Orgs are the logins of the organizations that the person is added
+	// to when they accept the invitation.
+	Orgs []string `json:",omitempty"`
+}
+
+// A PersonInvite is an invitation to create an account.
+type PersonInvite struct {
+	Email string
+
+	// Orgs are the organizations that the person is added to when they
+	// accept the invitation.
+	Orgs []string `json:",omitempty"`
+
+	// InvitedBy is the user who sent the invitation.
+	InvitedBy UserSpec
+
+	Created time.Time
+
+	// Expires is when the invitation expires, if ever.
+	Expires time.Time `json:",omitempty"`
+}
+
+// personInvite is the request body of PeopleService.Invite.
+type personInvite struct {
+	Email string
+	PersonInviteOptions
+}
+
+func (s *peopleService) Invite(email string, opt *PersonInviteOptions) (*PersonInvite, Response, error) {
+	if !strings.Contains(strings.TrimSpace(email), "@") {
+		return nil, nil, &ValidationError{Field: "Email", Problems: []string{fmt.Sprintf("invalid email address %q", email)}}
+	}
+
+	invite := personInvite{Email: strings.TrimSpace(email)}
+	if opt != nil {
+		invite.PersonInviteOptions = *opt
+	}
+
+	var created PersonInvite
+	resp, err := s.client.DoCreate(router.PeopleInvite, nil, &invite, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &created, resp, nil
+}
+
+func (s *peopleService) Deactivate(person PersonSpec) (Response, error) {
+	return s.client.DoUpdate(router.PersonDeactivate, person.RouteVars(), nil, nil)
+}
+
+func (s *peopleService) Reactivate(person PersonSpec) (Response, error) {
+	return s.client.DoDelete(router.PersonReactivate, person.RouteVars())
 }
 
 type PersonStatType string
@@ -1,7 +1,79 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 type MockPeopleService struct {
-	Get_ func(person PersonSpec) (*Person, Response, error)
+	Get_               func(person PersonSpec) (*Person, Response, error)
+	GetStats_          func(person PersonSpec, opt *PersonGetStatsOptions) (*PersonContributionStats, Response, error)
+	ListCollaborators_ func(person PersonSpec, opt *PersonListCollaboratorsOptions) ([]*AugmentedPersonCollaborator, Response, error)
+	Invite_            func(email string, opt *PersonInviteOptions) (*PersonInvite, Response, error)
+	Deactivate_        func(person PersonSpec) (Response, error)
+	Reactivate_        func(person PersonSpec) (Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockPeopleService returns a new MockPeopleService
+// that records calls to its methods.
+func NewMockPeopleService() *MockPeopleService {
+	return &MockPeopleService{Calls: &MockCalls{}}
+}
+
+func (s MockPeopleService) Get(person PersonSpec) (*Person, Response, error) {
+	s.Calls.record("Get", person)
+	if s.Get_ == nil {
+		var r0 *Person
+		var r1 Response
+		return r0, r1, mockNotImplemented("PeopleService.Get")
+	}
+	return s.Get_(person)
+}
+
+func (s MockPeopleService) GetStats(person PersonSpec, opt *PersonGetStatsOptions) (*PersonContributionStats, Response, error) {
+	s.Calls.record("GetStats", person, opt)
+	if s.GetStats_ == nil {
+		var r0 *PersonContributionStats
+		var r1 Response
+		return r0, r1, mockNotImplemented("PeopleService.GetStats")
+	}
+	return s.GetStats_(person, opt)
 }
 
-func (s MockPeopleService) Get(person PersonSpec) (*Person, Response, error) { return s.Get_(person) }
+func (s MockPeopleService) ListCollaborators(person PersonSpec, opt *PersonListCollaboratorsOptions) ([]*AugmentedPersonCollaborator, Response, error) {
+	s.Calls.record("ListCollaborators", person, opt)
+	if s.ListCollaborators_ == nil {
+		var r0 []*AugmentedPersonCollaborator
+		var r1 Response
+		return r0, r1, mockNotImplemented("PeopleService.ListCollaborators")
+	}
+	return s.ListCollaborators_(person, opt)
+}
+
+func (s MockPeopleService) Invite(email string, opt *PersonInviteOptions) (*PersonInvite, Response, error) {
+	s.Calls.record("Invite", email, opt)
+	if s.Invite_ == nil {
+		var r0 *PersonInvite
+		var r1 Response
+		return r0, r1, mockNotImplemented("PeopleService.Invite")
+	}
+	return s.Invite_(email, opt)
+}
+
+func (s MockPeopleService) Deactivate(person PersonSpec) (Response, error) {
+	s.Calls.record("Deactivate", person)
+	if s.Deactivate_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("PeopleService.Deactivate")
+	}
+	return s.Deactivate_(person)
+}
+
+func (s MockPeopleService) Reactivate(person PersonSpec) (Response, error) {
+	s.Calls.record("Reactivate", person)
+	if s.Reactivate_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("PeopleService.Reactivate")
+	}
+	return s.Reactivate_(person)
+}
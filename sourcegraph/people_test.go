@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/fossas/go-sourcegraph/router"
 )
@@ -98,3 +99,147 @@ func TestPeopleService_Get(t *testing.T) {
 		t.Errorf("People.Get returned %+v, want %+v", person_, want)
 	}
 }
+
+func TestPeopleService_GetStats(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &PersonContributionStats{Commits: 3, DefsAuthored: 2, RefsToAuthoredDefs: 5, ActiveRepos: []string{"r"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.PersonStats, map[string]string{"PersonSpec": "a"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Since": "2014-01-01T00:00:00Z"})
+
+		writeJSON(w, want)
+	})
+
+	since := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats, _, err := client.People.GetStats(PersonSpec{Login: "a"}, &PersonGetStatsOptions{TimeRangeOptions: TimeRangeOptions{Since: &since}})
+	if err != nil {
+		t.Errorf("People.GetStats returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("People.GetStats returned %+v, want %+v", stats, want)
+	}
+}
+
+func TestPeopleService_ListCollaborators(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*AugmentedPersonCollaborator{
+		{
+			Person: &Person{PersonSpec: PersonSpec{Login: "b", UID: 2}},
+			PersonCollaborator: &PersonCollaborator{
+				UID:             2,
+				AuthorshipInfo:  AuthorshipInfo{AuthorEmail: "b@example.com", LastCommitDate: time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC), LastCommitID: "c"},
+				CommitCount:     7,
+				BytesProportion: 0.25,
+				Repos:           []string{"r"},
+			},
+		},
+	}
+
+	since := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.PersonCollaborators, map[string]string{"PersonSpec": "a"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Repo": "r", "Since": "2014-01-01T00:00:00Z", "Sort": "commits", "PerPage": "1", "Page": "2"})
+
+		writeJSON(w, want)
+	})
+
+	collaborators, _, err := client.People.ListCollaborators(PersonSpec{Login: "a"}, &PersonListCollaboratorsOptions{
+		Repo:             "r",
+		TimeRangeOptions: TimeRangeOptions{Since: &since},
+		SortOptions:      SortOptions{Sort: "commits"},
+		ListOptions:      ListOptions{PerPage: 1, Page: 2},
+	})
+	if err != nil {
+		t.Errorf("People.ListCollaborators returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(collaborators, want) {
+		t.Errorf("People.ListCollaborators returned %+v, want %+v", collaborators, want)
+	}
+}
+
+func TestPeopleService_Invite(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &PersonInvite{Email: "a@example.com", Orgs: []string{"o"}, InvitedBy: UserSpec{Login: "admin"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.PeopleInvite, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Email":"a@example.com","Message":"hi","Orgs":["o"]}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	invite, _, err := client.People.Invite(" a@example.com", &PersonInviteOptions{Message: "hi", Orgs: []string{"o"}})
+	if err != nil {
+		t.Errorf("People.Invite returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	normalizeTime(&invite.Created)
+	normalizeTime(&want.Created)
+	normalizeTime(&invite.Expires)
+	normalizeTime(&want.Expires)
+	if !reflect.DeepEqual(invite, want) {
+		t.Errorf("People.Invite returned %+v, want %+v", invite, want)
+	}
+
+	if _, _, err := NewClient(nil).People.Invite("a", nil); err == nil {
+		t.Error("People.Invite returned no error for invalid email")
+	}
+}
+
+func TestPeopleService_Deactivate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	person := PersonSpec{Login: "a"}
+
+	var deactivated, reactivated bool
+	mux.HandleFunc(urlPath(t, router.PersonDeactivate, person.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			deactivated = true
+		case "DELETE":
+			reactivated = true
+		default:
+			t.Errorf("got method %s, want PUT or DELETE", r.Method)
+		}
+	})
+
+	if _, err := client.People.Deactivate(person); err != nil {
+		t.Errorf("People.Deactivate returned error: %v", err)
+	}
+	if _, err := client.People.Reactivate(person); err != nil {
+		t.Errorf("People.Reactivate returned error: %v", err)
+	}
+
+	if !deactivated || !reactivated {
+		t.Fatal("!called")
+	}
+}
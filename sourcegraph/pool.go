@@ -0,0 +1,183 @@
+package sourcegraph
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// A ClientPool creates Clients that share one HTTP transport (and so
+// one pool of connections), response cache, and rate limiter. It is
+// intended for multi-tenant servers that make many API calls on behalf
+// of many users, each with their own credentials:
+//
+//	pool := sourcegraph.NewClientPool(nil)
+//	pool.Limiter = sourcegraph.NewRateLimiter(1000, 100)
+//	...
+//	c := pool.Client(func(t http.RoundTripper) http.RoundTripper {
+//		return &auth.TokenTransport{Source: userTokenSource, Transport: t}
+//	})
+//
+// A ClientPool's exported fields must not be modified after the first
+// call to Client. Its methods are safe for concurrent use.
+type ClientPool struct {
+	// Metrics (see Stats). They are first in the struct so that they
+	// are 64-bit aligned for atomic access on 32-bit platforms.
+	dials, openConns, inflight, requests, cacheHits, throttled int64
+
+	// BaseURL is the base URL of the Clients' API requests. If nil,
+	// the NewClient default is used.
+	BaseURL *url.URL
+
+	// UserAgent is the Clients' user agent. If empty, the NewClient
+	// default is used.
+	UserAgent string
+
+	// Limiter, if set, limits the rate of requests sent by all of the
+	// pool's Clients combined. Responses served from Cache don't count
+	// toward the limit.
+	Limiter *RateLimiter
+
+	// Cache, if set, caches JSON GET responses that have a
+	// Cache-Control max-age or an ETag (see Client.Cache). Downloads,
+	// streams, and responses larger than 1 MB aren't cached. Responses
+	// are cached per credential (keyed on the request's Authorization
+	// header), so users never see each other's responses.
+	Cache Cache
+
+	transport http.RoundTripper
+	features  *featureCache
+}
+
+// NewClientPool returns a ClientPool whose Clients send requests using
+// transport. If transport is nil, a new *http.Transport tuned for many
+// concurrent requests to the same host is used.
+func NewClientPool(transport http.RoundTripper) *ClientPool {
+	p := &ClientPool{features: &featureCache{}}
+	if transport == nil {
+		transport = &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			Dial:                p.dial,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConnsPerHost: 256,
+		}
+	}
+	p.transport = transport
+	return p
+}
+
+// Client returns a new Client that sends requests using the pool's
+// shared transport. If wrap is non-nil, it is called with the shared
+// transport and returns the transport (typically one that adds
+// credentials, such as auth.TokenTransport) that the Client uses.
+func (p *ClientPool) Client(wrap func(http.RoundTripper) http.RoundTripper) *Client {
+	var t http.RoundTripper = &poolTransport{p}
+	if wrap != nil {
+		t = wrap(t)
+	}
+	c := NewClient(&http.Client{Transport: t})
+	c.features = p.features
+	if p.BaseURL != nil {
+		u := *p.BaseURL
+		c.BaseURL = &u
+	}
+	if p.UserAgent != "" {
+		c.UserAgent = p.UserAgent
+	}
+	return c
+}
+
+// PoolStats describes the activity of a ClientPool's Clients.
+type PoolStats struct {
+	// Dials is the number of connections opened by the pool's
+	// transport, and OpenConns is the number that are currently open.
+	// Both are zero if the pool was created with a caller-provided
+	// transport.
+	Dials, OpenConns int64
+
+	// Inflight is the number of requests currently awaiting a
+	// response.
+	Inflight int64
+
+	// Requests is the total number of requests sent (including those
+	// served from the cache).
+	Requests int64
+
+	// CacheHits is the number of requests served from the cache.
+	CacheHits int64
+
+	// Throttled is the number of requests that were delayed by the
+	// rate limiter.
+	Throttled int64
+}
+
+// Stats returns a snapshot of the pool's metrics.
+func (p *ClientPool) Stats() PoolStats {
+	return PoolStats{
+		Dials:     atomic.LoadInt64(&p.dials),
+		OpenConns: atomic.LoadInt64(&p.openConns),
+		Inflight:  atomic.LoadInt64(&p.inflight),
+		Requests:  atomic.LoadInt64(&p.requests),
+		CacheHits: atomic.LoadInt64(&p.cacheHits),
+		Throttled: atomic.LoadInt64(&p.throttled),
+	}
+}
+
+// dial opens a network connection, counting it in the pool's metrics.
+func (p *ClientPool) dial(network, addr string) (net.Conn, error) {
+	conn, err := (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&p.dials, 1)
+	atomic.AddInt64(&p.openConns, 1)
+	return &poolConn{Conn: conn, pool: p}, nil
+}
+
+// poolConn is a net.Conn that updates its pool's metrics when it is
+// closed.
+type poolConn struct {
+	net.Conn
+	pool   *ClientPool
+	closed int32
+}
+
+func (c *poolConn) Close() error {
+	if atomic.CompareAndSwapInt32(&c.closed, 0, 1) {
+		atomic.AddInt64(&c.pool.openConns, -1)
+	}
+	return c.Conn.Close()
+}
+
+// poolTransport is the HTTP transport shared by a pool's Clients. It
+// serves responses from the pool's cache, applies its rate limit, and
+// records its metrics.
+type poolTransport struct{ pool *ClientPool }
+
+// RoundTrip implements http.RoundTripper.
+func (t *poolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	p := t.pool
+	atomic.AddInt64(&p.requests, 1)
+	atomic.AddInt64(&p.inflight, 1)
+	defer atomic.AddInt64(&p.inflight, -1)
+
+	send := func(req *http.Request) (*http.Response, error) {
+		if p.Limiter != nil {
+			if p.Limiter.Wait() > 0 {
+				atomic.AddInt64(&p.throttled, 1)
+			}
+		}
+		return p.transport.RoundTrip(req)
+	}
+
+	if p.Cache == nil || !isCacheable(req) {
+		return send(req)
+	}
+	resp, hit, err := cacheRoundTrip(p.Cache, req, send)
+	if hit {
+		atomic.AddInt64(&p.cacheHits, 1)
+	}
+	return resp, err
+}
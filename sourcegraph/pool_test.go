@@ -0,0 +1,118 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientPool(t *testing.T) {
+	var mu sync.Mutex
+	hits := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.Header.Get("Authorization")]++
+		mu.Unlock()
+		w.Header().Set("Cache-Control", "max-age=60")
+		writeJSON(w, &Repo{URI: "r/" + r.Header.Get("Authorization")})
+	}))
+	defer server.Close()
+
+	pool := NewClientPool(nil)
+	pool.BaseURL, _ = url.Parse(server.URL + "/")
+	pool.Cache = NewMemoryCache(10)
+
+	clientFor := func(user string) *Client {
+		return pool.Client(func(t http.RoundTripper) http.RoundTripper {
+			return &headerTransport{"Authorization", user, t}
+		})
+	}
+	alice, bob := clientFor("alice"), clientFor("bob")
+
+	for i := 0; i < 2; i++ {
+		for _, c := range []*Client{alice, bob} {
+			repo, _, err := c.Repos.Get(RepoSpec{URI: "a/r"}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "r/" + c.httpClient.Transport.(*headerTransport).value; repo.URI != want {
+				t.Errorf("got repo %q, want %q (responses must not be shared across credentials)", repo.URI, want)
+			}
+		}
+	}
+
+	if want := (map[string]int{"alice": 1, "bob": 1}); hits["alice"] != 1 || hits["bob"] != 1 {
+		t.Errorf("got server hits %v, want %v", hits, want)
+	}
+	stats := pool.Stats()
+	if stats.Requests != 4 || stats.CacheHits != 2 || stats.Inflight != 0 {
+		t.Errorf("got stats %+v, want 4 requests, 2 cache hits, 0 inflight", stats)
+	}
+	if stats.Dials == 0 || stats.Dials > 2 {
+		t.Errorf("got %d dials, want 1 or 2 (connections should be shared)", stats.Dials)
+	}
+}
+
+func TestClientPool_doesNotCacheDownloads(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	pool := NewClientPool(nil)
+	pool.Cache = NewMemoryCache(10)
+	c := pool.Client(nil)
+
+	for i := 0; i < 2; i++ {
+		req, err := c.NewRequest("GET", server.URL+"/download", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body []byte
+		if _, err := c.Do(req, &body); err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != "data" {
+			t.Errorf("got body %q, want %q", body, "data")
+		}
+	}
+	if hits != 2 {
+		t.Errorf("got %d server hits, want 2 (downloads must not be cached)", hits)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter(100, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		l.Wait()
+	}
+	// The first 2 requests are allowed immediately (burst), and each
+	// subsequent one waits 10ms.
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("4 requests took %s, want >= 20ms", elapsed)
+	}
+}
+
+// headerTransport sets a header on each request.
+type headerTransport struct {
+	name, value string
+	transport   http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req2 := *req
+	req2.Header = http.Header{}
+	for k, v := range req.Header {
+		req2.Header[k] = v
+	}
+	req2.Header.Set(t.name, t.value)
+	return t.transport.RoundTrip(&req2)
+}
@@ -0,0 +1,82 @@
+// Package prommetrics exports metrics about the requests sent by
+// sourcegraph.Clients to Prometheus:
+//
+//	c := prommetrics.NewCollector("myapp")
+//	prometheus.MustRegister(c)
+//	client.Metrics = c
+//
+// The metrics are a counter of requests
+// (<namespace>_sourcegraph_client_requests_total) and a histogram of
+// their durations in seconds
+// (<namespace>_sourcegraph_client_request_duration_seconds), both
+// labeled with the request's API route name ("route"), HTTP method
+// ("method"), and status class ("status", such as "2xx" or "error").
+//
+// This package requires github.com/prometheus/client_golang v0.9.2,
+// the last release that supports Go 1.8 (see .travis.yml, which pins
+// it and its dependencies).
+package prommetrics
+
+import (
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// labels are the labels of the exported metrics.
+var labels = []string{"route", "method", "status"}
+
+// A Collector is a sourcegraph.MetricsCollector that records request
+// metrics, and a prometheus.Collector that exports them. It is safe
+// for concurrent use and may be shared by multiple Clients.
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+var _ sourcegraph.MetricsCollector = (*Collector)(nil)
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector returns a new Collector whose metric names are prefixed
+// with namespace (which may be empty). It must be registered with
+// Prometheus (e.g., using prometheus.MustRegister) for its metrics to
+// be exported.
+func NewCollector(namespace string) *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "sourcegraph_client",
+			Name:      "requests_total",
+			Help:      "Number of requests sent to the Sourcegraph API.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "sourcegraph_client",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of requests sent to the Sourcegraph API.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+	}
+}
+
+// ObserveRequest implements sourcegraph.MetricsCollector.
+func (c *Collector) ObserveRequest(m sourcegraph.RequestMetrics) {
+	route := m.Route
+	if route == "" {
+		route = "unknown"
+	}
+	values := []string{route, m.Method, m.StatusClass()}
+	c.requests.WithLabelValues(values...).Inc()
+	c.duration.WithLabelValues(values...).Observe(m.Duration.Seconds())
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+}
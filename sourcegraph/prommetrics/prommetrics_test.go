@@ -0,0 +1,60 @@
+package prommetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector("app")
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatal(err)
+	}
+
+	c.ObserveRequest(sourcegraph.RequestMetrics{Route: router.Repo, Method: "GET", StatusCode: 200, Duration: time.Second})
+	c.ObserveRequest(sourcegraph.RequestMetrics{Route: router.Repo, Method: "GET", StatusCode: 204, Duration: time.Second})
+	c.ObserveRequest(sourcegraph.RequestMetrics{Method: "POST"})
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]uint64{} // "<metric>{route,method,status}" -> count
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			key := f.GetName() + "{"
+			for i, l := range m.GetLabel() {
+				if i > 0 {
+					key += ","
+				}
+				key += l.GetName() + "=" + l.GetValue()
+			}
+			key += "}"
+			if cm := m.GetCounter(); cm != nil {
+				counts[key] = uint64(cm.GetValue())
+			} else if h := m.GetHistogram(); h != nil {
+				counts[key] = h.GetSampleCount()
+			}
+		}
+	}
+
+	want := map[string]uint64{
+		"app_sourcegraph_client_requests_total{method=GET,route=repo,status=2xx}":                 2,
+		"app_sourcegraph_client_requests_total{method=POST,route=unknown,status=error}":           1,
+		"app_sourcegraph_client_request_duration_seconds{method=GET,route=repo,status=2xx}":       2,
+		"app_sourcegraph_client_request_duration_seconds{method=POST,route=unknown,status=error}": 1,
+	}
+	if len(counts) != len(want) {
+		t.Errorf("got metrics %v, want %v", counts, want)
+	}
+	for k, v := range want {
+		if counts[k] != v {
+			t.Errorf("%s: got %d, want %d", k, counts[k], v)
+		}
+	}
+}
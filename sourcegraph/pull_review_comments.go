@@ -0,0 +1,355 @@
+package sourcegraph
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// PullRequestReviewCommentsService communicates with the Sourcegraph
+// API endpoints for a pull request's review comments: the inline
+// comments on lines of its diff. (Comments in a pull request's main
+// discussion are comments on its issue; see IssuesService.)
+//
+// Review comments form threads: a reply's InReplyTo is the ID of the
+// comment it replies to. Use GroupReviewThreads to reconstruct the
+// threads from a list of comments.
+type PullRequestReviewCommentsService interface {
+	// List lists the review comments on a pull request (by default,
+	// oldest first).
+	List(pull PullRequestSpec, opt *PullRequestReviewCommentListOptions) ([]*PullRequestReviewComment, Response, error)
+
+	// Get fetches a review comment.
+	Get(comment PullRequestReviewCommentSpec) (*PullRequestReviewComment, Response, error)
+
+	// Create creates a review comment on a line of a pull request's
+	// diff (or, if comment.InReplyTo is set, a reply to an existing
+	// review comment). The comment body is normalized and validated as
+	// in PullRequestsService.CreateComment.
+	Create(pull PullRequestSpec, comment *PullRequestReviewCommentRequest) (*PullRequestReviewComment, Response, error)
+
+	// Reply creates a reply to a review comment, in the comment's
+	// thread.
+	Reply(comment PullRequestReviewCommentSpec, body string) (*PullRequestReviewComment, Response, error)
+
+	// Edit updates the body of a review comment.
+	Edit(comment PullRequestReviewCommentSpec, body string) (*PullRequestReviewComment, Response, error)
+
+	// Delete deletes a review comment.
+	Delete(comment PullRequestReviewCommentSpec) (Response, error)
+}
+
+// pullRequestReviewCommentsService implements
+// PullRequestReviewCommentsService.
+type pullRequestReviewCommentsService struct {
+	client *Client
+}
+
+var _ PullRequestReviewCommentsService = &pullRequestReviewCommentsService{}
+
+// A PullRequestReviewComment is an inline comment on a line of a pull
+// request's diff.
+type PullRequestReviewComment struct {
+	ID int
+
+	// Review is the ID of the review that the comment is part of (or 0,
+	// if it was not made as part of a review).
+	Review int `json:",omitempty"`
+
+	// InReplyTo is the ID of the comment that this comment replies to
+	// (or 0, if it begins a thread).
+	InReplyTo int `json:",omitempty"`
+
+	Author UserSpec
+
+	// Body is the comment body (in raw markdown).
+	Body string
+
+	// RenderedBody is the comment body rendered as HTML.
+	RenderedBody string `json:",omitempty"`
+
+	// Path is the path of the commented-on file.
+	Path string
+
+	// CommitID and Position are the commit and the line position in the
+	// diff (of that commit, against the pull request's base) that the
+	// comment currently refers to. Position is 0 if the commented-on
+	// line is no longer in the diff (i.e., the comment is outdated).
+	CommitID string
+	Position int `json:",omitempty"`
+
+	// OriginalCommitID and OriginalPosition are the commit and the line
+	// position in the diff that the comment referred to when it was
+	// created.
+	OriginalCommitID string
+	OriginalPosition int
+
+	// DiffHunk is the diff hunk that ends at the commented-on line.
+	DiffHunk string `json:",omitempty"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Outdated reports whether the line that c comments on is no longer in
+// the pull request's diff.
+func (c *PullRequestReviewComment) Outdated() bool { return c.Position == 0 }
+
+// PullRequestReviewCommentSpec specifies a review comment on a pull
+// request.
+type PullRequestReviewCommentSpec struct {
+	Pull    PullRequestSpec
+	Comment int // the comment's ID
+}
+
+// RouteVars returns the route variables for generating review comment
+// URLs.
+func (s PullRequestReviewCommentSpec) RouteVars() map[string]string {
+	rv := s.Pull.RouteVars()
+	rv["CommentID"] = strconv.Itoa(s.Comment)
+	return rv
+}
+
+// UnmarshalPullRequestReviewCommentSpec parses route variables (a map
+// returned by (PullRequestReviewCommentSpec).RouteVars()) to construct
+// a PullRequestReviewCommentSpec.
+func UnmarshalPullRequestReviewCommentSpec(v map[string]string) (PullRequestReviewCommentSpec, error) {
+	pull, err := UnmarshalPullRequestSpec(v)
+	if err != nil {
+		return PullRequestReviewCommentSpec{}, err
+	}
+	commentID, err := strconv.Atoi(v["CommentID"])
+	if err != nil {
+		return PullRequestReviewCommentSpec{}, err
+	}
+	return PullRequestReviewCommentSpec{Pull: pull, Comment: commentID}, nil
+}
+
+// PullRequestReviewCommentListOptions specifies options for
+// PullRequestReviewCommentsService.List.
+type PullRequestReviewCommentListOptions struct {
+	// Path, if set, restricts the comments listed to those on the file
+	// with this path.
+	Path string `url:",omitempty"`
+
+	SortOptions
+	ListOptions
+}
+
+// PullRequestReviewCommentRequest specifies a review comment to create
+// with PullRequestReviewCommentsService.Create.
+type PullRequestReviewCommentRequest struct {
+	Body string
+
+	// InReplyTo is the ID of the comment to reply to. If it is set, the
+	// reply is added to that comment's thread, and Path, CommitID, and
+	// Position must not be set.
+	InReplyTo int `json:",omitempty"`
+
+	// Path and Position are the file and the line position in the diff
+	// to comment on, and CommitID is the commit whose diff Position
+	// refers to (or empty for the pull request's head commit). They
+	// are required unless InReplyTo is set.
+	Path     string `json:",omitempty"`
+	CommitID string `json:",omitempty"`
+	Position int    `json:",omitempty"`
+}
+
+// validate checks that r is either a reply or specifies the line to
+// comment on.
+func (r *PullRequestReviewCommentRequest) validate() error {
+	if r.InReplyTo != 0 {
+		if r.Path != "" || r.CommitID != "" || r.Position != 0 {
+			return &ValidationError{Field: "InReplyTo", Problems: []string{"a reply must not specify Path, CommitID, or Position"}}
+		}
+		return nil
+	}
+	if r.Path == "" {
+		return &ValidationError{Field: "Path", Problems: []string{"required"}}
+	}
+	if r.Position <= 0 {
+		return &ValidationError{Field: "Position", Problems: []string{"must be positive"}}
+	}
+	return nil
+}
+
+func (s *pullRequestReviewCommentsService) List(pull PullRequestSpec, opt *PullRequestReviewCommentListOptions) ([]*PullRequestReviewComment, Response, error) {
+	var comments []*PullRequestReviewComment
+	resp, err := s.client.DoList(router.RepoPullRequestReviewComments, pull.RouteVars(), opt, &comments)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return comments, resp, nil
+}
+
+func (s *pullRequestReviewCommentsService) Get(comment PullRequestReviewCommentSpec) (*PullRequestReviewComment, Response, error) {
+	var comment_ *PullRequestReviewComment
+	resp, err := s.client.DoGet(router.RepoPullRequestReviewComment, comment.RouteVars(), nil, &comment_)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return comment_, resp, nil
+}
+
+func (s *pullRequestReviewCommentsService) Create(pull PullRequestSpec, comment *PullRequestReviewCommentRequest) (*PullRequestReviewComment, Response, error) {
+	if err := comment.validate(); err != nil {
+		return nil, nil, err
+	}
+	body, err := prepareCommentBody(&comment.Body, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	normalized := *comment
+	normalized.Body = *body
+
+	var created PullRequestReviewComment
+	resp, err := s.client.DoCreate(router.RepoPullRequestReviewCommentsCreate, pull.RouteVars(), &normalized, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &created, resp, nil
+}
+
+func (s *pullRequestReviewCommentsService) Reply(comment PullRequestReviewCommentSpec, body string) (*PullRequestReviewComment, Response, error) {
+	return s.Create(comment.Pull, &PullRequestReviewCommentRequest{Body: body, InReplyTo: comment.Comment})
+}
+
+// pullRequestReviewCommentEdit is the body of a
+// RepoPullRequestReviewCommentEdit request.
+type pullRequestReviewCommentEdit struct {
+	Body string
+}
+
+func (s *pullRequestReviewCommentsService) Edit(comment PullRequestReviewCommentSpec, body string) (*PullRequestReviewComment, Response, error) {
+	body_, err := prepareCommentBody(&body, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	url, err := s.client.URL(router.RepoPullRequestReviewCommentEdit, comment.RouteVars(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("PATCH", url.String(), &pullRequestReviewCommentEdit{Body: *body_})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updated PullRequestReviewComment
+	resp, err := s.client.Do(req, &updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &updated, resp, nil
+}
+
+func (s *pullRequestReviewCommentsService) Delete(comment PullRequestReviewCommentSpec) (Response, error) {
+	resp, err := s.client.DoDelete(router.RepoPullRequestReviewCommentDelete, comment.RouteVars())
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// A PullRequestReviewThread is a review comment and its replies.
+type PullRequestReviewThread struct {
+	// Comments are the comments in the thread, oldest first. The first
+	// comment is the one that began the thread.
+	Comments []*PullRequestReviewComment
+}
+
+// Root returns the comment that began the thread.
+func (t *PullRequestReviewThread) Root() *PullRequestReviewComment { return t.Comments[0] }
+
+// Path returns the path of the file that the thread comments on.
+func (t *PullRequestReviewThread) Path() string { return t.Root().Path }
+
+// Outdated reports whether the line that the thread comments on is no
+// longer in the pull request's diff.
+func (t *PullRequestReviewThread) Outdated() bool { return t.Root().Outdated() }
+
+// GroupReviewThreads groups review comments into threads. Each reply
+// is added to the thread of the comment it (directly or indirectly)
+// replies to; a reply to a comment that is not in comments begins its
+// own thread (so a partial list of comments still yields a thread for
+// every comment).
+//
+// The threads are sorted by file path and then by the original
+// position of their first comments, and each thread's comments are
+// sorted oldest first.
+func GroupReviewThreads(comments []*PullRequestReviewComment) []*PullRequestReviewThread {
+	byID := make(map[int]*PullRequestReviewComment, len(comments))
+	for _, c := range comments {
+		byID[c.ID] = c
+	}
+
+	// root returns the ID of the comment that began c's thread,
+	// following the InReplyTo chain (and stopping at a cycle).
+	root := func(c *PullRequestReviewComment) int {
+		seen := map[int]bool{c.ID: true}
+		for c.InReplyTo != 0 {
+			parent, ok := byID[c.InReplyTo]
+			if !ok || seen[parent.ID] {
+				break
+			}
+			seen[parent.ID] = true
+			c = parent
+		}
+		return c.ID
+	}
+
+	threadsByRoot := map[int]*PullRequestReviewThread{}
+	var threads []*PullRequestReviewThread
+	for _, c := range comments {
+		id := root(c)
+		t, ok := threadsByRoot[id]
+		if !ok {
+			t = &PullRequestReviewThread{}
+			threadsByRoot[id] = t
+			threads = append(threads, t)
+		}
+		t.Comments = append(t.Comments, c)
+	}
+
+	for _, t := range threads {
+		sort.Sort(reviewCommentsByCreated(t.Comments))
+	}
+	sort.Sort(reviewThreadsByLocation(threads))
+	return threads
+}
+
+type reviewCommentsByCreated []*PullRequestReviewComment
+
+func (v reviewCommentsByCreated) Len() int      { return len(v) }
+func (v reviewCommentsByCreated) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+func (v reviewCommentsByCreated) Less(i, j int) bool {
+	if !v[i].CreatedAt.Equal(v[j].CreatedAt) {
+		return v[i].CreatedAt.Before(v[j].CreatedAt)
+	}
+	return v[i].ID < v[j].ID
+}
+
+type reviewThreadsByLocation []*PullRequestReviewThread
+
+func (v reviewThreadsByLocation) Len() int      { return len(v) }
+func (v reviewThreadsByLocation) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+func (v reviewThreadsByLocation) Less(i, j int) bool {
+	a, b := v[i].Root(), v[j].Root()
+	if a.Path != b.Path {
+		return a.Path < b.Path
+	}
+	if a.OriginalPosition != b.OriginalPosition {
+		return a.OriginalPosition < b.OriginalPosition
+	}
+	return reviewCommentsByCreated{a, b}.Less(0, 1)
+}
+
+var _ PullRequestReviewCommentsService = &MockPullRequestReviewCommentsService{}
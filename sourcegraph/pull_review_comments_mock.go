@@ -0,0 +1,80 @@
+// generated by gen-mocks; DO NOT EDIT
+
+package sourcegraph
+
+type MockPullRequestReviewCommentsService struct {
+	List_   func(pull PullRequestSpec, opt *PullRequestReviewCommentListOptions) ([]*PullRequestReviewComment, Response, error)
+	Get_    func(comment PullRequestReviewCommentSpec) (*PullRequestReviewComment, Response, error)
+	Create_ func(pull PullRequestSpec, comment *PullRequestReviewCommentRequest) (*PullRequestReviewComment, Response, error)
+	Reply_  func(comment PullRequestReviewCommentSpec, body string) (*PullRequestReviewComment, Response, error)
+	Edit_   func(comment PullRequestReviewCommentSpec, body string) (*PullRequestReviewComment, Response, error)
+	Delete_ func(comment PullRequestReviewCommentSpec) (Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockPullRequestReviewCommentsService returns a new MockPullRequestReviewCommentsService
+// that records calls to its methods.
+func NewMockPullRequestReviewCommentsService() *MockPullRequestReviewCommentsService {
+	return &MockPullRequestReviewCommentsService{Calls: &MockCalls{}}
+}
+
+func (s MockPullRequestReviewCommentsService) List(pull PullRequestSpec, opt *PullRequestReviewCommentListOptions) ([]*PullRequestReviewComment, Response, error) {
+	s.Calls.record("List", pull, opt)
+	if s.List_ == nil {
+		var r0 []*PullRequestReviewComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestReviewCommentsService.List")
+	}
+	return s.List_(pull, opt)
+}
+
+func (s MockPullRequestReviewCommentsService) Get(comment PullRequestReviewCommentSpec) (*PullRequestReviewComment, Response, error) {
+	s.Calls.record("Get", comment)
+	if s.Get_ == nil {
+		var r0 *PullRequestReviewComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestReviewCommentsService.Get")
+	}
+	return s.Get_(comment)
+}
+
+func (s MockPullRequestReviewCommentsService) Create(pull PullRequestSpec, comment *PullRequestReviewCommentRequest) (*PullRequestReviewComment, Response, error) {
+	s.Calls.record("Create", pull, comment)
+	if s.Create_ == nil {
+		var r0 *PullRequestReviewComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestReviewCommentsService.Create")
+	}
+	return s.Create_(pull, comment)
+}
+
+func (s MockPullRequestReviewCommentsService) Reply(comment PullRequestReviewCommentSpec, body string) (*PullRequestReviewComment, Response, error) {
+	s.Calls.record("Reply", comment, body)
+	if s.Reply_ == nil {
+		var r0 *PullRequestReviewComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestReviewCommentsService.Reply")
+	}
+	return s.Reply_(comment, body)
+}
+
+func (s MockPullRequestReviewCommentsService) Edit(comment PullRequestReviewCommentSpec, body string) (*PullRequestReviewComment, Response, error) {
+	s.Calls.record("Edit", comment, body)
+	if s.Edit_ == nil {
+		var r0 *PullRequestReviewComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestReviewCommentsService.Edit")
+	}
+	return s.Edit_(comment, body)
+}
+
+func (s MockPullRequestReviewCommentsService) Delete(comment PullRequestReviewCommentSpec) (Response, error) {
+	s.Calls.record("Delete", comment)
+	if s.Delete_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("PullRequestReviewCommentsService.Delete")
+	}
+	return s.Delete_(comment)
+}
@@ -0,0 +1,214 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestPullRequestReviewCommentSpec(t *testing.T) {
+	spec := PullRequestReviewCommentSpec{Pull: PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, Comment: 2}
+	routeVars := spec.RouteVars()
+	if want := map[string]string{"RepoSpec": "r.com/x", "Pull": "1", "CommentID": "2"}; !reflect.DeepEqual(routeVars, want) {
+		t.Errorf("got route vars %+v, want %+v", routeVars, want)
+	}
+
+	spec2, err := UnmarshalPullRequestReviewCommentSpec(routeVars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec2 != spec {
+		t.Errorf("got spec %+v, want %+v", spec2, spec)
+	}
+}
+
+func TestPullRequestReviewCommentsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pull := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	want := []*PullRequestReviewComment{{ID: 2, Path: "f", Position: 3, OriginalPosition: 3}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviewComments, pull.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Path": "f"})
+
+		writeJSON(w, want)
+	})
+
+	comments, _, err := client.ReviewComments.List(pull, &PullRequestReviewCommentListOptions{Path: "f"})
+	if err != nil {
+		t.Errorf("ReviewComments.List returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(comments, want) {
+		t.Errorf("ReviewComments.List returned %+v, want %+v", comments, want)
+	}
+}
+
+func TestPullRequestReviewCommentsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pull := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	want := &PullRequestReviewComment{ID: 2, Body: "b", Path: "f", Position: 3}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviewCommentsCreate, pull.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Body":"b","Path":"f","Position":3}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	comment, _, err := client.ReviewComments.Create(pull, &PullRequestReviewCommentRequest{Body: "b \r\n", Path: "f", Position: 3})
+	if err != nil {
+		t.Errorf("ReviewComments.Create returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(comment, want) {
+		t.Errorf("ReviewComments.Create returned %+v, want %+v", comment, want)
+	}
+}
+
+func TestPullRequestReviewCommentsService_Create_invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pull := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviewCommentsCreate, pull.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	for _, req := range []*PullRequestReviewCommentRequest{
+		{Body: "b"},
+		{Body: "b", Path: "f"},
+		{Body: "b", InReplyTo: 2, Path: "f", Position: 3},
+		{Body: " ", Path: "f", Position: 3},
+	} {
+		if _, _, err := client.ReviewComments.Create(pull, req); err == nil {
+			t.Errorf("%+v: got nil error, want validation error", req)
+		}
+	}
+}
+
+func TestPullRequestReviewCommentsService_Reply(t *testing.T) {
+	setup()
+	defer teardown()
+
+	spec := PullRequestReviewCommentSpec{Pull: PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, Comment: 2}
+	want := &PullRequestReviewComment{ID: 3, InReplyTo: 2, Body: "b"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviewCommentsCreate, spec.Pull.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Body":"b","InReplyTo":2}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	comment, _, err := client.ReviewComments.Reply(spec, "b")
+	if err != nil {
+		t.Errorf("ReviewComments.Reply returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(comment, want) {
+		t.Errorf("ReviewComments.Reply returned %+v, want %+v", comment, want)
+	}
+}
+
+func TestPullRequestReviewCommentsService_Edit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	spec := PullRequestReviewCommentSpec{Pull: PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, Comment: 2}
+	want := &PullRequestReviewComment{ID: 2, Body: "b"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviewCommentEdit, spec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"Body":"b"}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	comment, _, err := client.ReviewComments.Edit(spec, "b")
+	if err != nil {
+		t.Errorf("ReviewComments.Edit returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(comment, want) {
+		t.Errorf("ReviewComments.Edit returned %+v, want %+v", comment, want)
+	}
+}
+
+func TestPullRequestReviewCommentsService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	spec := PullRequestReviewCommentSpec{Pull: PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, Comment: 2}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviewCommentDelete, spec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "DELETE")
+	})
+
+	if _, err := client.ReviewComments.Delete(spec); err != nil {
+		t.Errorf("ReviewComments.Delete returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestGroupReviewThreads(t *testing.T) {
+	t0 := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	var (
+		a  = &PullRequestReviewComment{ID: 1, Path: "b.go", OriginalPosition: 5, CreatedAt: t0}
+		a1 = &PullRequestReviewComment{ID: 2, InReplyTo: 1, CreatedAt: t0.Add(time.Minute)}
+		a2 = &PullRequestReviewComment{ID: 3, InReplyTo: 2, CreatedAt: t0.Add(2 * time.Minute)}
+		b  = &PullRequestReviewComment{ID: 4, Path: "a.go", OriginalPosition: 9, CreatedAt: t0}
+		c  = &PullRequestReviewComment{ID: 5, Path: "b.go", OriginalPosition: 2, CreatedAt: t0}
+		d  = &PullRequestReviewComment{ID: 6, InReplyTo: 99, Path: "c.go", CreatedAt: t0} // parent not listed
+	)
+
+	threads := GroupReviewThreads([]*PullRequestReviewComment{a2, d, a, c, b, a1})
+	want := []*PullRequestReviewThread{
+		{Comments: []*PullRequestReviewComment{b}},
+		{Comments: []*PullRequestReviewComment{c}},
+		{Comments: []*PullRequestReviewComment{a, a1, a2}},
+		{Comments: []*PullRequestReviewComment{d}},
+	}
+	if !reflect.DeepEqual(threads, want) {
+		t.Errorf("got threads %+v, want %+v", threads, want)
+	}
+	if threads[2].Root() != a || threads[2].Path() != "b.go" || !threads[2].Outdated() {
+		t.Errorf("got thread root %+v, path %q, outdated %v", threads[2].Root(), threads[2].Path(), threads[2].Outdated())
+	}
+}
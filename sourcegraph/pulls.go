@@ -1,12 +1,16 @@
 package sourcegraph
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/sourcegraph/go-github/github"
 
+	"net/url"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/fossas/go-sourcegraph/router"
 )
@@ -20,20 +24,93 @@ type PullRequestsService interface {
 	// List pull requests for a repository.
 	ListByRepo(repo RepoSpec, opt *PullRequestListOptions) ([]*PullRequest, Response, error)
 
+	// Create opens a pull request in a repository, to merge pr.Head
+	// into pr.Base. The body is rendered (see NewPullRequest.BodyData),
+	// normalized, and validated as in CreateComment before it is sent.
+	Create(repo RepoSpec, pr *NewPullRequest) (*PullRequest, Response, error)
+
+	// Edit changes a pull request's title, body, base branch, state,
+	// or draft state. Fields of edit that are not set are left
+	// unchanged.
+	Edit(pull PullRequestSpec, edit *PullRequestEditRequest) (*PullRequest, Response, error)
+
 	// ListComments lists comments on a pull request.
 	ListComments(pull PullRequestSpec, opt *PullRequestListCommentsOptions) ([]*PullRequestComment, Response, error)
 
-	// CreateComment creates a comment on a pull request.
+	// ListAllComments lists the inline comments on all of a
+	// repository's pull requests, least recently updated first by
+	// default. It is for exporting or analyzing a repository's
+	// comments without listing each pull request's comments (see
+	// ListCommentsBatch for comments on specific pull requests).
+	ListAllComments(repo RepoSpec, opt *PullRequestListAllCommentsOptions) ([]*RepoPullRequestComment, Response, error)
+
+	// ListCommentsBatch lists all comments on each of the given pull
+	// requests, fetching them concurrently. See
+	// PullRequestListCommentsBatchOptions.
+	ListCommentsBatch(pulls []PullRequestSpec, opt *PullRequestListCommentsBatchOptions) (map[PullRequestSpec][]*PullRequestComment, Response, error)
+
+	// CreateComment creates a comment on a pull request. The comment
+	// body is normalized and validated before it is sent (see
+	// NormalizeCommentBody and ValidateCommentBody); if it is invalid,
+	// a *ValidationError is returned.
 	CreateComment(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error)
 
-	// EditComment updates an existing comment on a pull request.
+	// EditComment updates an existing comment on a pull request. The
+	// comment body (if set) is normalized and validated as in
+	// CreateComment.
 	EditComment(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error)
 
 	// DeleteComment deletes a comment on a pull request.
 	DeleteComment(pull PullRequestSpec, commentID int) (Response, error)
 
-	// Merge merges a pull request
+	// Merge merges a pull request (using the merge method specified in
+	// mergeRequest). The result's SHA is the commit ID of the merge
+	// (or squash or rebased head) commit.
 	Merge(pull PullRequestSpec, mergeRequest *PullRequestMergeRequest) (*PullRequestMergeResult, Response, error)
+
+	// ListAffectedDefs lists the defs that were added, changed, or
+	// deleted by a pull request, along with the number of external refs
+	// to each def (so reviewers can gauge the API impact of the
+	// change).
+	ListAffectedDefs(pull PullRequestSpec, opt *PullRequestListAffectedDefsOptions) ([]*PullRequestAffectedDef, Response, error)
+
+	// ListReviews lists the reviews of a pull request.
+	ListReviews(pull PullRequestSpec, opt *PullRequestListReviewsOptions) ([]*PullRequestReview, Response, error)
+
+	// GetReview fetches a review of a pull request.
+	GetReview(review PullRequestReviewSpec) (*PullRequestReview, Response, error)
+
+	// CreateReview creates a review of a pull request. If
+	// review.Event is empty, the review is pending (and its comments
+	// are visible only to its author) until it is submitted with
+	// SubmitReview.
+	CreateReview(pull PullRequestSpec, review *PullRequestReviewRequest) (*PullRequestReview, Response, error)
+
+	// SubmitReview submits a pending review.
+	SubmitReview(review PullRequestReviewSpec, opt *PullRequestReviewSubmitOptions) (*PullRequestReview, Response, error)
+
+	// DismissReview dismisses a submitted review, so that it no longer
+	// counts toward (or blocks) the pull request's approval.
+	DismissReview(review PullRequestReviewSpec, opt *PullRequestReviewDismissOptions) (*PullRequestReview, Response, error)
+
+	// ListFiles lists the files changed by a pull request, with their
+	// diffs (between the pull request's base and head revisions).
+	ListFiles(pull PullRequestSpec, opt *PullRequestListFilesOptions) ([]*FileDiff, Response, error)
+
+	// GetDiff fetches the diff of a pull request (between its base and
+	// head revisions) in unified diff format.
+	GetDiff(pull PullRequestSpec) (string, Response, error)
+
+	// Export fetches a self-contained archive of a pull request (with
+	// all of its comments, reviews, events, and its diff), for
+	// archival or migration.
+	Export(pull PullRequestSpec) (*PullRequestArchive, Response, error)
+
+	// ListEvents lists the timeline of a pull request: the commits
+	// pushed to it, its reviews, its comments (in the main discussion),
+	// and other events such as label changes and its being merged, in
+	// the order in which they occurred.
+	ListEvents(pull PullRequestSpec, opt *PullRequestListEventsOptions) ([]*IssueEvent, Response, error)
 }
 
 // pullRequestsService implements PullRequestsService.
@@ -80,17 +157,60 @@ func UnmarshalPullRequestSpec(v map[string]string) (PullRequestSpec, error) {
 type PullRequest struct {
 	github.PullRequest
 
+	// BaseRepo is the base repository of the pull request. If the
+	// server doesn't set it, the PullRequestsService methods set it to
+	// the repository that the pull request was requested from.
+	BaseRepo RepoSpec
+
 	// Checklist is a summary of all the checkboxes in the pull request (number of checked and unchecked).
 	Checklist *Checklist `json:",omitempty"`
+
+	// Draft is whether the pull request is a draft (which can't be
+	// merged until it is marked as ready for review).
+	Draft bool `json:",omitempty"`
 }
 
 // Spec returns the PullRequestSpec that specifies r.
+//
+// If r's BaseRepo is not set (as in pull requests from archives
+// created by older servers), the repository is derived from r's
+// HTMLURL (e.g., "https://github.com/foo/bar/pull/1" yields the
+// repository "github.com/foo/bar"). If neither is set, the returned
+// spec's Repo is empty.
 func (r *PullRequest) Spec() PullRequestSpec {
-	// Extract the URI from the HTMLURL field.
-	uri := strings.Join(strings.Split(strings.TrimPrefix(*r.HTMLURL, "https://"), "/")[0:3], "/")
-	return PullRequestSpec{
-		Repo:   RepoSpec{URI: uri},
-		Number: *r.Number,
+	spec := PullRequestSpec{Repo: r.BaseRepo}
+	if r.Number != nil {
+		spec.Number = *r.Number
+	}
+	if spec.Repo == (RepoSpec{}) && r.HTMLURL != nil {
+		spec.Repo = repoFromHTMLURL(*r.HTMLURL, "/pull/")
+	}
+	return spec
+}
+
+// repoFromHTMLURL returns the repository of the pull request or issue
+// whose HTML URL is htmlURL, which is of the form
+// "scheme://host/path/to/repo" + sep + "number". It returns an empty
+// RepoSpec if htmlURL is not of that form.
+func repoFromHTMLURL(htmlURL, sep string) RepoSpec {
+	u, err := url.Parse(htmlURL)
+	if err != nil || u.Host == "" {
+		return RepoSpec{}
+	}
+	i := strings.LastIndex(u.Path, sep)
+	if i <= 0 {
+		return RepoSpec{}
+	}
+	return RepoSpec{URI: u.Host + u.Path[:i]}
+}
+
+// setBaseRepo sets the BaseRepo of each pull request in pulls that
+// doesn't have one to repo.
+func setBaseRepo(repo RepoSpec, pulls ...*PullRequest) {
+	for _, pull := range pulls {
+		if pull != nil && pull.BaseRepo == (RepoSpec{}) {
+			pull.BaseRepo = repo
+		}
 	}
 }
 
@@ -100,51 +220,184 @@ type PullRequestGetOptions struct {
 }
 
 func (s *pullRequestsService) Get(pull PullRequestSpec, opt *PullRequestGetOptions) (*PullRequest, Response, error) {
-	url, err := s.client.URL(router.RepoPullRequest, pull.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var pull_ *PullRequest
-	resp, err := s.client.Do(req, &pull_)
+	resp, err := s.client.DoGet(router.RepoPullRequest, pull.RouteVars(), opt, &pull_)
 	if err != nil {
 		return nil, resp, err
 	}
+	setBaseRepo(pull.Repo, pull_)
 
 	return pull_, resp, nil
 }
 
 type PullRequestListOptions struct {
 	State string `url:",omitempty"` // "open", "closed", or "all"
+	TimeRangeOptions
+	SortOptions
 	ListOptions
 }
 
 func (s *pullRequestsService) ListByRepo(repo RepoSpec, opt *PullRequestListOptions) ([]*PullRequest, Response, error) {
-	url, err := s.client.URL(router.RepoPullRequests, repo.RouteVars(), opt)
+	var pulls []*PullRequest
+	resp, err := s.client.DoList(router.RepoPullRequests, repo.RouteVars(), opt, &pulls)
+	if err != nil {
+		return nil, resp, err
+	}
+	setBaseRepo(repo, pulls...)
+
+	return pulls, resp, nil
+}
+
+// A NewPullRequest specifies a pull request to create with
+// PullRequestsService.Create.
+type NewPullRequest struct {
+	Title string
+
+	// Body is the pull request's description (in markdown). If
+	// BodyData is set, Body is a text/template template that is
+	// executed with BodyData to produce the description.
+	Body string `json:",omitempty"`
+
+	// BodyData is the data that the Body template is executed with.
+	// It is not sent to the server.
+	BodyData interface{} `json:"-"`
+
+	// Head is the branch that contains the changes, and Base is the
+	// branch to merge them into. Head may be in a fork, in the form
+	// "owner:branch".
+	Head string
+	Base string
+
+	// Draft is whether to open the pull request as a draft.
+	Draft bool `json:",omitempty"`
+}
+
+// A PullRequestEditRequest specifies the fields of a pull request to
+// change with PullRequestsService.Edit. Nil and empty fields are left
+// unchanged.
+type PullRequestEditRequest struct {
+	Title *string `json:",omitempty"`
+
+	// Body is the new description. It is a template if BodyData is set
+	// (see NewPullRequest.Body).
+	Body     *string     `json:",omitempty"`
+	BodyData interface{} `json:"-"`
+
+	// Base is the branch to merge the pull request into. (The head
+	// branch of an existing pull request can't be changed.)
+	Base *string `json:",omitempty"`
+
+	// State is the pull request's state (IssueStateOpen or
+	// IssueStateClosed).
+	State string `json:",omitempty"`
+
+	// Draft, if set, converts the pull request to a draft (true) or
+	// marks it as ready for review (false).
+	Draft *bool `json:",omitempty"`
+}
+
+// preparePullRequestBody renders body (a template, if data is
+// non-nil) and normalizes and validates the result as in
+// prepareCommentBody. Unlike comment bodies, pull request bodies may be
+// empty.
+func preparePullRequestBody(body *string, data interface{}) (*string, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	rendered := *body
+	if data != nil {
+		tmpl, err := template.New("body").Option("missingkey=error").Parse(rendered)
+		if err != nil {
+			return nil, &ValidationError{Field: "Body", Problems: []string{err.Error()}}
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, &ValidationError{Field: "Body", Problems: []string{err.Error()}}
+		}
+		rendered = buf.String()
+	}
+
+	if strings.TrimSpace(rendered) == "" {
+		return new(string), nil
+	}
+	return prepareCommentBody(&rendered, true)
+}
+
+func (s *pullRequestsService) Create(repo RepoSpec, pr *NewPullRequest) (*PullRequest, Response, error) {
+	if strings.TrimSpace(pr.Title) == "" {
+		return nil, nil, &ValidationError{Field: "Title", Problems: []string{"must not be empty"}}
+	}
+	if pr.Head == "" {
+		return nil, nil, &ValidationError{Field: "Head", Problems: []string{"must be set"}}
+	}
+	if pr.Base == "" {
+		return nil, nil, &ValidationError{Field: "Base", Problems: []string{"must be set"}}
+	}
+	if pr.Head == pr.Base {
+		return nil, nil, &ValidationError{Field: "Head", Problems: []string{"must differ from Base"}}
+	}
+
+	body, err := preparePullRequestBody(&pr.Body, pr.BodyData)
+	if err != nil {
+		return nil, nil, err
+	}
+	normalized := *pr
+	normalized.Body = *body
+
+	var created PullRequest
+	resp, err := s.client.DoCreate(router.RepoPullRequestsCreate, repo.RouteVars(), &normalized, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+	setBaseRepo(repo, &created)
+
+	return &created, resp, nil
+}
+
+func (s *pullRequestsService) Edit(pull PullRequestSpec, edit *PullRequestEditRequest) (*PullRequest, Response, error) {
+	if edit.Title != nil && strings.TrimSpace(*edit.Title) == "" {
+		return nil, nil, &ValidationError{Field: "Title", Problems: []string{"must not be empty"}}
+	}
+	if edit.Base != nil && *edit.Base == "" {
+		return nil, nil, &ValidationError{Field: "Base", Problems: []string{"must not be empty"}}
+	}
+	switch edit.State {
+	case "", IssueStateOpen, IssueStateClosed:
+	default:
+		return nil, nil, &ValidationError{Field: "State", Problems: []string{fmt.Sprintf("unrecognized pull request state %q", edit.State)}}
+	}
+
+	body, err := preparePullRequestBody(edit.Body, edit.BodyData)
 	if err != nil {
 		return nil, nil, err
 	}
+	normalized := *edit
+	normalized.Body = body
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
+	url, err := s.client.URL(router.RepoPullRequestEdit, pull.RouteVars(), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var pulls []*PullRequest
-	resp, err := s.client.Do(req, &pulls)
+	req, err := s.client.NewRequest("PATCH", url.String(), &normalized)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updated PullRequest
+	resp, err := s.client.Do(req, &updated)
 	if err != nil {
 		return nil, resp, err
 	}
+	setBaseRepo(pull.Repo, &updated)
 
-	return pulls, resp, nil
+	return &updated, resp, nil
 }
 
 type PullRequestListCommentsOptions struct {
+	TimeRangeOptions
+	SortOptions
 	ListOptions
 }
 
@@ -187,18 +440,38 @@ func (c PullRequestCommentSpec) RouteVars() map[string]string {
 }
 
 func (s *pullRequestsService) ListComments(pull PullRequestSpec, opt *PullRequestListCommentsOptions) ([]*PullRequestComment, Response, error) {
-	url, err := s.client.URL(router.RepoPullRequestComments, pull.RouteVars(), opt)
+	var comments []*PullRequestComment
+	resp, err := s.client.DoList(router.RepoPullRequestComments, pull.RouteVars(), opt, &comments)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	return comments, resp, nil
+}
 
-	var comments []*PullRequestComment
-	resp, err := s.client.Do(req, &comments)
+// A RepoPullRequestComment is a comment on one of a repository's pull
+// requests, as listed by PullRequestsService.ListAllComments.
+type RepoPullRequestComment struct {
+	// Number is the number of the pull request that the comment is on.
+	Number int
+
+	*PullRequestComment
+}
+
+// PullRequestListAllCommentsOptions specifies options for
+// PullRequestsService.ListAllComments.
+type PullRequestListAllCommentsOptions struct {
+	// TimeRangeOptions restricts the comments listed to those updated
+	// in the time range.
+	TimeRangeOptions
+
+	SortOptions
+	ListOptions
+}
+
+func (s *pullRequestsService) ListAllComments(repo RepoSpec, opt *PullRequestListAllCommentsOptions) ([]*RepoPullRequestComment, Response, error) {
+	var comments []*RepoPullRequestComment
+	resp, err := s.client.DoList(router.RepoPullRequestsComments, repo.RouteVars(), opt, &comments)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -206,19 +479,81 @@ func (s *pullRequestsService) ListComments(pull PullRequestSpec, opt *PullReques
 	return comments, resp, nil
 }
 
-func (s *pullRequestsService) CreateComment(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error) {
-	url, err := s.client.URL(router.RepoPullRequestCommentsCreate, pull.RouteVars(), nil)
+// DefaultBatchConcurrency is the number of concurrent requests made by
+// batch methods (such as PullRequestsService.ListCommentsBatch) if no
+// concurrency is specified.
+const DefaultBatchConcurrency = 4
+
+// PullRequestListCommentsBatchOptions specifies options for
+// PullRequestsService.ListCommentsBatch.
+type PullRequestListCommentsBatchOptions struct {
+	// Concurrency is the maximum number of requests in flight at once
+	// (or DefaultBatchConcurrency, if zero).
+	Concurrency int
+
+	// PerPage is the number of comments to fetch per request (or
+	// DefaultPerPage, if zero). All pages of comments are fetched for
+	// each pull request.
+	PerPage int
+
+	// TimeRangeOptions restricts the comments fetched to those updated
+	// in the time range.
+	TimeRangeOptions
+}
+
+// ListCommentsBatch fetches all pages of comments for each pull
+// request, with at most opt.Concurrency requests in flight. The
+// returned map has an entry for each pull request (with a nil value if
+// it has no comments). If any request fails, no further requests are
+// made, and its error and response are returned.
+func (s *pullRequestsService) ListCommentsBatch(pulls []PullRequestSpec, opt *PullRequestListCommentsBatchOptions) (map[PullRequestSpec][]*PullRequestComment, Response, error) {
+	if opt == nil {
+		opt = &PullRequestListCommentsBatchOptions{}
+	}
+
+	comments := make(map[PullRequestSpec][]*PullRequestComment, len(pulls))
+	var unique []PullRequestSpec
+	for _, pull := range pulls {
+		if _, seen := comments[pull]; !seen {
+			comments[pull] = nil
+			unique = append(unique, pull)
+		}
+	}
+
+	results := make([][]*PullRequestComment, len(unique))
+	resp, err := fanOut(len(unique), opt.Concurrency, func(i int) (Response, error) {
+		listOpt := &PullRequestListCommentsOptions{TimeRangeOptions: opt.TimeRangeOptions, ListOptions: ListOptions{PerPage: opt.PerPage}}
+		for listOpt.Page = 1; ; listOpt.Page++ {
+			page, resp, err := s.ListComments(unique[i], listOpt)
+			if err != nil {
+				return resp, err
+			}
+			results[i] = append(results[i], page...)
+			if len(page) < listOpt.PerPageOrDefault() {
+				return resp, nil
+			}
+		}
+	})
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
+	}
+
+	for i, pull := range unique {
+		comments[pull] = results[i]
 	}
+	return comments, nil, nil
+}
 
-	req, err := s.client.NewRequest("POST", url.String(), comment)
+func (s *pullRequestsService) CreateComment(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error) {
+	body, err := prepareCommentBody(comment.Body, true)
 	if err != nil {
 		return nil, nil, err
 	}
+	normalized := *comment
+	normalized.Body = body
 
 	var createdComment PullRequestComment
-	resp, err := s.client.Do(req, &createdComment)
+	resp, err := s.client.DoCreate(router.RepoPullRequestCommentsCreate, pull.RouteVars(), &normalized, &createdComment)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -231,12 +566,19 @@ func (s *pullRequestsService) EditComment(pull PullRequestSpec, comment *PullReq
 		return nil, nil, fmt.Errorf("comment ID not specified")
 	}
 
+	body, err := prepareCommentBody(comment.Body, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	normalized := *comment
+	normalized.Body = body
+
 	url, err := s.client.URL(router.RepoPullRequestCommentsEdit, PullRequestCommentSpec{Pull: pull, Comment: *comment.ID}.RouteVars(), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest("PATCH", url.String(), comment)
+	req, err := s.client.NewRequest("PATCH", url.String(), &normalized)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -251,17 +593,7 @@ func (s *pullRequestsService) EditComment(pull PullRequestSpec, comment *PullReq
 }
 
 func (s *pullRequestsService) DeleteComment(pull PullRequestSpec, commentID int) (Response, error) {
-	url, err := s.client.URL(router.RepoPullRequestCommentsDelete, PullRequestCommentSpec{Pull: pull, Comment: commentID}.RouteVars(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest("DELETE", url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.DoDelete(router.RepoPullRequestCommentsDelete, PullRequestCommentSpec{Pull: pull, Comment: commentID}.RouteVars())
 	if err != nil {
 		return nil, err
 	}
@@ -273,28 +605,337 @@ type PullRequestMergeResult struct {
 	github.PullRequestMergeResult
 }
 
+// A PullRequestMergeMethod is a way of merging a pull request.
+type PullRequestMergeMethod string
+
+const (
+	// MergeMethodMerge merges the pull request's commits into the base
+	// branch with a merge commit.
+	MergeMethodMerge PullRequestMergeMethod = "merge"
+
+	// MergeMethodSquash squashes the pull request's commits into a
+	// single commit on the base branch.
+	MergeMethodSquash PullRequestMergeMethod = "squash"
+
+	// MergeMethodRebase rebases the pull request's commits onto the
+	// base branch (without a merge commit).
+	MergeMethodRebase PullRequestMergeMethod = "rebase"
+)
+
 type PullRequestMergeRequest struct {
 	CommitMessage string
+
+	// CommitTitle, if set, is the title (first line) of the merge or
+	// squash commit. If empty, the server chooses a title.
+	CommitTitle string `json:",omitempty"`
+
+	// Method is how to merge the pull request. If empty, it is merged
+	// with MergeMethodMerge.
+	Method PullRequestMergeMethod `json:",omitempty"`
+
+	// SHA, if set, is the commit ID that the pull request's head must
+	// be at for the merge to proceed. It prevents merging commits
+	// that were pushed after the pull request was reviewed.
+	SHA string `json:",omitempty"`
 }
 
 func (s *pullRequestsService) Merge(pull PullRequestSpec, mergeRequest *PullRequestMergeRequest) (*PullRequestMergeResult, Response, error) {
-	url, err := s.client.URL(router.RepoPullRequestMerge, pull.RouteVars(), nil)
+	if mergeRequest == nil {
+		mergeRequest = &PullRequestMergeRequest{}
+	}
+	switch mergeRequest.Method {
+	case "", MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+	default:
+		return nil, nil, &ValidationError{Field: "Method", Problems: []string{fmt.Sprintf("unrecognized pull request merge method %q", mergeRequest.Method)}}
+	}
+
+	var result PullRequestMergeResult
+	resp, err := s.client.DoUpdate(router.RepoPullRequestMerge, pull.RouteVars(), mergeRequest, &result)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("PUT", url.String(), mergeRequest)
+	return &result, resp, nil
+}
+
+type PullRequestListAffectedDefsOptions struct {
+	SortOptions
+	ListOptions
+}
+
+// A PullRequestAffectedDef is a def that was added, changed, or
+// deleted by a pull request.
+type PullRequestAffectedDef struct {
+	DefDelta
+
+	// ExternalRefs is the number of refs to the def from other
+	// repositories (in the base revision, or in the head revision if
+	// the def was added).
+	ExternalRefs int
+}
+
+func (s *pullRequestsService) ListAffectedDefs(pull PullRequestSpec, opt *PullRequestListAffectedDefsOptions) ([]*PullRequestAffectedDef, Response, error) {
+	var defs []*PullRequestAffectedDef
+	resp, err := s.client.DoList(router.RepoPullRequestAffectedDefs, pull.RouteVars(), opt, &defs)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	var result PullRequestMergeResult
-	resp, err := s.client.Do(req, &result)
+	return defs, resp, nil
+}
+
+// A PullRequestReview is a review of a pull request.
+type PullRequestReview struct {
+	ID int
+
+	// Reviewer is the user who created the review.
+	Reviewer UserSpec
+
+	// State is "pending", "approved", "changes_requested",
+	// "commented", or "dismissed".
+	State string
+
+	// Body is the review's summary comment (in raw markdown). The
+	// review's inline comments are PullRequestComments.
+	Body string `json:",omitempty"`
+
+	// CommitID is the head commit of the pull request when the review
+	// was created.
+	CommitID string `json:",omitempty"`
+
+	// Submitted is when the review was submitted (or the zero time, if
+	// it is pending).
+	Submitted time.Time
+}
+
+// PullRequestReviewSpec specifies a review of a pull request.
+type PullRequestReviewSpec struct {
+	Pull   PullRequestSpec
+	Review int // the review's ID
+}
+
+// RouteVars returns the route variables for generating pull request
+// review URLs.
+func (s PullRequestReviewSpec) RouteVars() map[string]string {
+	rv := s.Pull.RouteVars()
+	rv["ReviewID"] = strconv.Itoa(s.Review)
+	return rv
+}
+
+// UnmarshalPullRequestReviewSpec parses route variables (a map
+// returned by (PullRequestReviewSpec).RouteVars()) to construct a
+// PullRequestReviewSpec.
+func UnmarshalPullRequestReviewSpec(v map[string]string) (PullRequestReviewSpec, error) {
+	pull, err := UnmarshalPullRequestSpec(v)
 	if err != nil {
-		return nil, nil, err
+		return PullRequestReviewSpec{}, err
+	}
+	reviewID, err := strconv.Atoi(v["ReviewID"])
+	if err != nil {
+		return PullRequestReviewSpec{}, err
 	}
+	return PullRequestReviewSpec{Pull: pull, Review: reviewID}, nil
+}
 
-	return &result, resp, nil
+// A PullRequestReviewEvent is the action taken when a review is
+// submitted.
+type PullRequestReviewEvent string
+
+const (
+	ReviewApprove        PullRequestReviewEvent = "APPROVE"
+	ReviewRequestChanges PullRequestReviewEvent = "REQUEST_CHANGES"
+	ReviewComment        PullRequestReviewEvent = "COMMENT"
+)
+
+type PullRequestListReviewsOptions struct {
+	SortOptions
+	ListOptions
+}
+
+func (s *pullRequestsService) ListReviews(pull PullRequestSpec, opt *PullRequestListReviewsOptions) ([]*PullRequestReview, Response, error) {
+	var reviews []*PullRequestReview
+	resp, err := s.client.DoList(router.RepoPullRequestReviews, pull.RouteVars(), opt, &reviews)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return reviews, resp, nil
+}
+
+func (s *pullRequestsService) GetReview(review PullRequestReviewSpec) (*PullRequestReview, Response, error) {
+	var review_ *PullRequestReview
+	resp, err := s.client.DoGet(router.RepoPullRequestReview, review.RouteVars(), nil, &review_)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return review_, resp, nil
+}
+
+// PullRequestReviewRequest specifies a review to create with
+// PullRequestsService.CreateReview.
+type PullRequestReviewRequest struct {
+	// CommitID is the commit to review. If empty, the pull request's
+	// current head commit is reviewed.
+	CommitID string `json:",omitempty"`
+
+	// Body is the review's summary comment (in raw markdown).
+	Body string `json:",omitempty"`
+
+	// Event, if set, submits the review when it is created. If empty,
+	// the review is pending.
+	Event PullRequestReviewEvent `json:",omitempty"`
+
+	// Comments are inline comments on the pull request's diff to
+	// create as part of the review.
+	Comments []*PullRequestComment `json:",omitempty"`
+}
+
+func (s *pullRequestsService) CreateReview(pull PullRequestSpec, review *PullRequestReviewRequest) (*PullRequestReview, Response, error) {
+	var created PullRequestReview
+	resp, err := s.client.DoCreate(router.RepoPullRequestReviewsCreate, pull.RouteVars(), review, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &created, resp, nil
+}
+
+// PullRequestReviewSubmitOptions specifies options for
+// PullRequestsService.SubmitReview.
+type PullRequestReviewSubmitOptions struct {
+	Event PullRequestReviewEvent
+
+	// Body, if set, replaces the review's summary comment.
+	Body string `json:",omitempty"`
+}
+
+func (s *pullRequestsService) SubmitReview(review PullRequestReviewSpec, opt *PullRequestReviewSubmitOptions) (*PullRequestReview, Response, error) {
+	var submitted PullRequestReview
+	resp, err := s.client.DoCreate(router.RepoPullRequestReviewSubmit, review.RouteVars(), opt, &submitted)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &submitted, resp, nil
+}
+
+// PullRequestReviewDismissOptions specifies options for
+// PullRequestsService.DismissReview.
+type PullRequestReviewDismissOptions struct {
+	// Message is the reason the review was dismissed, which is shown
+	// to the reviewer.
+	Message string
+}
+
+func (s *pullRequestsService) DismissReview(review PullRequestReviewSpec, opt *PullRequestReviewDismissOptions) (*PullRequestReview, Response, error) {
+	var dismissed PullRequestReview
+	resp, err := s.client.DoUpdate(router.RepoPullRequestReviewDismiss, review.RouteVars(), opt, &dismissed)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &dismissed, resp, nil
+}
+
+// PullRequestListFilesOptions specifies options for
+// PullRequestsService.ListFiles.
+type PullRequestListFilesOptions struct {
+	// Filter filters the list of returned files to those whose name
+	// matches Filter.
+	Filter string `url:",omitempty"`
+
+	SortOptions
+	ListOptions
+}
+
+func (s *pullRequestsService) ListFiles(pull PullRequestSpec, opt *PullRequestListFilesOptions) ([]*FileDiff, Response, error) {
+	var files []*FileDiff
+	resp, err := s.client.DoList(router.RepoPullRequestFiles, pull.RouteVars(), opt, &files)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return files, resp, nil
+}
+
+func (s *pullRequestsService) GetDiff(pull PullRequestSpec) (string, Response, error) {
+	var diff []byte
+	resp, err := s.client.DoGet(router.RepoPullRequestDiff, pull.RouteVars(), nil, &diff)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return string(diff), resp, nil
+}
+
+// A PullRequestArchive is a self-contained document describing a pull
+// request and all of its activity, as returned by
+// PullRequestsService.Export. Because it does not refer to other API
+// resources, it can be stored and read back without a server (e.g.,
+// to migrate pull requests between servers).
+type PullRequestArchive struct {
+	// Version is the archive format version (ArchiveVersion, for
+	// archives created by the current server).
+	Version int
+
+	// Exported is when the archive was created.
+	Exported time.Time
+
+	PullRequest *PullRequest
+
+	// Comments are the inline comments on the pull request's diff.
+	Comments []*PullRequestComment `json:",omitempty"`
+
+	// IssueComments are the comments in the pull request's main
+	// discussion (which are comments on the associated issue; see
+	// PullRequestSpec.IssueSpec).
+	IssueComments []*IssueComment `json:",omitempty"`
+
+	Reviews []*PullRequestReview `json:",omitempty"`
+
+	Events []*IssueEvent `json:",omitempty"`
+
+	// Files is the diff between the pull request's base and head
+	// revisions.
+	Files *DeltaFiles `json:",omitempty"`
+}
+
+func (s *pullRequestsService) Export(pull PullRequestSpec) (*PullRequestArchive, Response, error) {
+	var archive *PullRequestArchive
+	resp, err := s.client.DoGet(router.RepoPullRequestExport, pull.RouteVars(), nil, &archive)
+	if err != nil {
+		return nil, resp, err
+	}
+	if archive != nil {
+		setBaseRepo(pull.Repo, archive.PullRequest)
+	}
+
+	return archive, resp, nil
+}
+
+type PullRequestListEventsOptions struct {
+	// Types, if set, restricts the events listed to those of these
+	// types (see the IssueEvent* constants).
+	Types []string `url:",comma,omitempty"`
+
+	// IncludeVerification is whether to verify the signature of the
+	// commit in each "committed" event (see Commit.Verification).
+	IncludeVerification bool `url:",omitempty"`
+
+	TimeRangeOptions
+	SortOptions
+	ListOptions
+}
+
+func (s *pullRequestsService) ListEvents(pull PullRequestSpec, opt *PullRequestListEventsOptions) ([]*IssueEvent, Response, error) {
+	var events []*IssueEvent
+	resp, err := s.client.DoList(router.RepoPullRequestEvents, pull.RouteVars(), opt, &events)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return events, resp, nil
 }
 
 var _ PullRequestsService = &MockPullRequestsService{}
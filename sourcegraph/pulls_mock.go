@@ -1,39 +1,245 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 type MockPullRequestsService struct {
-	Get_           func(pull PullRequestSpec, opt *PullRequestGetOptions) (*PullRequest, Response, error)
-	ListByRepo_    func(repo RepoSpec, opt *PullRequestListOptions) ([]*PullRequest, Response, error)
-	ListComments_  func(pull PullRequestSpec, opt *PullRequestListCommentsOptions) ([]*PullRequestComment, Response, error)
-	CreateComment_ func(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error)
-	EditComment_   func(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error)
-	DeleteComment_ func(pull PullRequestSpec, commentID int) (Response, error)
-	Merge_         func(pull PullRequestSpec, mergeRequest *PullRequestMergeRequest) (*PullRequestMergeResult, Response, error)
+	Get_               func(pull PullRequestSpec, opt *PullRequestGetOptions) (*PullRequest, Response, error)
+	ListByRepo_        func(repo RepoSpec, opt *PullRequestListOptions) ([]*PullRequest, Response, error)
+	Create_            func(repo RepoSpec, pr *NewPullRequest) (*PullRequest, Response, error)
+	Edit_              func(pull PullRequestSpec, edit *PullRequestEditRequest) (*PullRequest, Response, error)
+	ListComments_      func(pull PullRequestSpec, opt *PullRequestListCommentsOptions) ([]*PullRequestComment, Response, error)
+	ListAllComments_   func(repo RepoSpec, opt *PullRequestListAllCommentsOptions) ([]*RepoPullRequestComment, Response, error)
+	ListCommentsBatch_ func(pulls []PullRequestSpec, opt *PullRequestListCommentsBatchOptions) (map[PullRequestSpec][]*PullRequestComment, Response, error)
+	CreateComment_     func(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error)
+	EditComment_       func(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error)
+	DeleteComment_     func(pull PullRequestSpec, commentID int) (Response, error)
+	Merge_             func(pull PullRequestSpec, mergeRequest *PullRequestMergeRequest) (*PullRequestMergeResult, Response, error)
+	ListAffectedDefs_  func(pull PullRequestSpec, opt *PullRequestListAffectedDefsOptions) ([]*PullRequestAffectedDef, Response, error)
+	ListReviews_       func(pull PullRequestSpec, opt *PullRequestListReviewsOptions) ([]*PullRequestReview, Response, error)
+	GetReview_         func(review PullRequestReviewSpec) (*PullRequestReview, Response, error)
+	CreateReview_      func(pull PullRequestSpec, review *PullRequestReviewRequest) (*PullRequestReview, Response, error)
+	SubmitReview_      func(review PullRequestReviewSpec, opt *PullRequestReviewSubmitOptions) (*PullRequestReview, Response, error)
+	DismissReview_     func(review PullRequestReviewSpec, opt *PullRequestReviewDismissOptions) (*PullRequestReview, Response, error)
+	ListFiles_         func(pull PullRequestSpec, opt *PullRequestListFilesOptions) ([]*FileDiff, Response, error)
+	GetDiff_           func(pull PullRequestSpec) (string, Response, error)
+	Export_            func(pull PullRequestSpec) (*PullRequestArchive, Response, error)
+	ListEvents_        func(pull PullRequestSpec, opt *PullRequestListEventsOptions) ([]*IssueEvent, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockPullRequestsService returns a new MockPullRequestsService
+// that records calls to its methods.
+func NewMockPullRequestsService() *MockPullRequestsService {
+	return &MockPullRequestsService{Calls: &MockCalls{}}
 }
 
 func (s MockPullRequestsService) Get(pull PullRequestSpec, opt *PullRequestGetOptions) (*PullRequest, Response, error) {
+	s.Calls.record("Get", pull, opt)
+	if s.Get_ == nil {
+		var r0 *PullRequest
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.Get")
+	}
 	return s.Get_(pull, opt)
 }
 
 func (s MockPullRequestsService) ListByRepo(repo RepoSpec, opt *PullRequestListOptions) ([]*PullRequest, Response, error) {
+	s.Calls.record("ListByRepo", repo, opt)
+	if s.ListByRepo_ == nil {
+		var r0 []*PullRequest
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.ListByRepo")
+	}
 	return s.ListByRepo_(repo, opt)
 }
 
+func (s MockPullRequestsService) Create(repo RepoSpec, pr *NewPullRequest) (*PullRequest, Response, error) {
+	s.Calls.record("Create", repo, pr)
+	if s.Create_ == nil {
+		var r0 *PullRequest
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.Create")
+	}
+	return s.Create_(repo, pr)
+}
+
+func (s MockPullRequestsService) Edit(pull PullRequestSpec, edit *PullRequestEditRequest) (*PullRequest, Response, error) {
+	s.Calls.record("Edit", pull, edit)
+	if s.Edit_ == nil {
+		var r0 *PullRequest
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.Edit")
+	}
+	return s.Edit_(pull, edit)
+}
+
 func (s MockPullRequestsService) ListComments(pull PullRequestSpec, opt *PullRequestListCommentsOptions) ([]*PullRequestComment, Response, error) {
+	s.Calls.record("ListComments", pull, opt)
+	if s.ListComments_ == nil {
+		var r0 []*PullRequestComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.ListComments")
+	}
 	return s.ListComments_(pull, opt)
 }
 
+func (s MockPullRequestsService) ListAllComments(repo RepoSpec, opt *PullRequestListAllCommentsOptions) ([]*RepoPullRequestComment, Response, error) {
+	s.Calls.record("ListAllComments", repo, opt)
+	if s.ListAllComments_ == nil {
+		var r0 []*RepoPullRequestComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.ListAllComments")
+	}
+	return s.ListAllComments_(repo, opt)
+}
+
+func (s MockPullRequestsService) ListCommentsBatch(pulls []PullRequestSpec, opt *PullRequestListCommentsBatchOptions) (map[PullRequestSpec][]*PullRequestComment, Response, error) {
+	s.Calls.record("ListCommentsBatch", pulls, opt)
+	if s.ListCommentsBatch_ == nil {
+		var r0 map[PullRequestSpec][]*PullRequestComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.ListCommentsBatch")
+	}
+	return s.ListCommentsBatch_(pulls, opt)
+}
+
 func (s MockPullRequestsService) CreateComment(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error) {
+	s.Calls.record("CreateComment", pull, comment)
+	if s.CreateComment_ == nil {
+		var r0 *PullRequestComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.CreateComment")
+	}
 	return s.CreateComment_(pull, comment)
 }
 
 func (s MockPullRequestsService) EditComment(pull PullRequestSpec, comment *PullRequestComment) (*PullRequestComment, Response, error) {
+	s.Calls.record("EditComment", pull, comment)
+	if s.EditComment_ == nil {
+		var r0 *PullRequestComment
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.EditComment")
+	}
 	return s.EditComment_(pull, comment)
 }
 
 func (s MockPullRequestsService) DeleteComment(pull PullRequestSpec, commentID int) (Response, error) {
+	s.Calls.record("DeleteComment", pull, commentID)
+	if s.DeleteComment_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("PullRequestsService.DeleteComment")
+	}
 	return s.DeleteComment_(pull, commentID)
 }
 
 func (s MockPullRequestsService) Merge(pull PullRequestSpec, mergeRequest *PullRequestMergeRequest) (*PullRequestMergeResult, Response, error) {
+	s.Calls.record("Merge", pull, mergeRequest)
+	if s.Merge_ == nil {
+		var r0 *PullRequestMergeResult
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.Merge")
+	}
 	return s.Merge_(pull, mergeRequest)
 }
+
+func (s MockPullRequestsService) ListAffectedDefs(pull PullRequestSpec, opt *PullRequestListAffectedDefsOptions) ([]*PullRequestAffectedDef, Response, error) {
+	s.Calls.record("ListAffectedDefs", pull, opt)
+	if s.ListAffectedDefs_ == nil {
+		var r0 []*PullRequestAffectedDef
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.ListAffectedDefs")
+	}
+	return s.ListAffectedDefs_(pull, opt)
+}
+
+func (s MockPullRequestsService) ListReviews(pull PullRequestSpec, opt *PullRequestListReviewsOptions) ([]*PullRequestReview, Response, error) {
+	s.Calls.record("ListReviews", pull, opt)
+	if s.ListReviews_ == nil {
+		var r0 []*PullRequestReview
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.ListReviews")
+	}
+	return s.ListReviews_(pull, opt)
+}
+
+func (s MockPullRequestsService) GetReview(review PullRequestReviewSpec) (*PullRequestReview, Response, error) {
+	s.Calls.record("GetReview", review)
+	if s.GetReview_ == nil {
+		var r0 *PullRequestReview
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.GetReview")
+	}
+	return s.GetReview_(review)
+}
+
+func (s MockPullRequestsService) CreateReview(pull PullRequestSpec, review *PullRequestReviewRequest) (*PullRequestReview, Response, error) {
+	s.Calls.record("CreateReview", pull, review)
+	if s.CreateReview_ == nil {
+		var r0 *PullRequestReview
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.CreateReview")
+	}
+	return s.CreateReview_(pull, review)
+}
+
+func (s MockPullRequestsService) SubmitReview(review PullRequestReviewSpec, opt *PullRequestReviewSubmitOptions) (*PullRequestReview, Response, error) {
+	s.Calls.record("SubmitReview", review, opt)
+	if s.SubmitReview_ == nil {
+		var r0 *PullRequestReview
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.SubmitReview")
+	}
+	return s.SubmitReview_(review, opt)
+}
+
+func (s MockPullRequestsService) DismissReview(review PullRequestReviewSpec, opt *PullRequestReviewDismissOptions) (*PullRequestReview, Response, error) {
+	s.Calls.record("DismissReview", review, opt)
+	if s.DismissReview_ == nil {
+		var r0 *PullRequestReview
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.DismissReview")
+	}
+	return s.DismissReview_(review, opt)
+}
+
+func (s MockPullRequestsService) ListFiles(pull PullRequestSpec, opt *PullRequestListFilesOptions) ([]*FileDiff, Response, error) {
+	s.Calls.record("ListFiles", pull, opt)
+	if s.ListFiles_ == nil {
+		var r0 []*FileDiff
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.ListFiles")
+	}
+	return s.ListFiles_(pull, opt)
+}
+
+func (s MockPullRequestsService) GetDiff(pull PullRequestSpec) (string, Response, error) {
+	s.Calls.record("GetDiff", pull)
+	if s.GetDiff_ == nil {
+		var r0 string
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.GetDiff")
+	}
+	return s.GetDiff_(pull)
+}
+
+func (s MockPullRequestsService) Export(pull PullRequestSpec) (*PullRequestArchive, Response, error) {
+	s.Calls.record("Export", pull)
+	if s.Export_ == nil {
+		var r0 *PullRequestArchive
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.Export")
+	}
+	return s.Export_(pull)
+}
+
+func (s MockPullRequestsService) ListEvents(pull PullRequestSpec, opt *PullRequestListEventsOptions) ([]*IssueEvent, Response, error) {
+	s.Calls.record("ListEvents", pull, opt)
+	if s.ListEvents_ == nil {
+		var r0 []*IssueEvent
+		var r1 Response
+		return r0, r1, mockNotImplemented("PullRequestsService.ListEvents")
+	}
+	return s.ListEvents_(pull, opt)
+}
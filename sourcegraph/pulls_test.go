@@ -6,20 +6,52 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/abec/srclib/graph"
 	"github.com/gorilla/schema"
 	"github.com/kr/pretty"
 	"github.com/sourcegraph/go-github/github"
 	"github.com/fossas/go-sourcegraph/router"
+	"sourcegraph.com/sourcegraph/go-diff/diff"
 )
 
+func TestPullRequest_Spec(t *testing.T) {
+	tests := []struct {
+		pull *PullRequest
+		want PullRequestSpec
+	}{
+		{
+			pull: &PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}, BaseRepo: RepoSpec{URI: "a.com/b"}},
+			want: PullRequestSpec{Repo: RepoSpec{URI: "a.com/b"}, Number: 1},
+		},
+		{
+			pull: &PullRequest{PullRequest: github.PullRequest{Number: github.Int(2), HTMLURL: github.String("https://github.com/x/y/pull/2")}},
+			want: PullRequestSpec{Repo: RepoSpec{URI: "github.com/x/y"}, Number: 2},
+		},
+		{
+			pull: &PullRequest{PullRequest: github.PullRequest{Number: github.Int(3), HTMLURL: github.String("http://git.example.com/a/b/c/pull/3")}},
+			want: PullRequestSpec{Repo: RepoSpec{URI: "git.example.com/a/b/c"}, Number: 3},
+		},
+		{
+			pull: &PullRequest{},
+			want: PullRequestSpec{},
+		},
+	}
+	for _, test := range tests {
+		if got := test.pull.Spec(); got != test.want {
+			t.Errorf("%+v: got spec %+v, want %+v", test.pull, got, test.want)
+		}
+	}
+}
+
 func TestPullRequestsService_Get(t *testing.T) {
 	setup()
 	defer teardown()
 
-	want := &PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}}
+	want := &PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}, BaseRepo: RepoSpec{URI: "r.com/x"}}
 	opts := &PullRequestGetOptions{Checklist: true}
 
 	var called bool
@@ -33,7 +65,8 @@ func TestPullRequestsService_Get(t *testing.T) {
 			t.Errorf("got requested opts %+v, but got %+v", &gotOpts, opts)
 		}
 
-		writeJSON(w, want)
+		// Omit BaseRepo, which the client should fill in.
+		writeJSON(w, &PullRequest{PullRequest: want.PullRequest})
 	})
 
 	pull, _, err := client.PullRequests.Get(PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, opts)
@@ -54,7 +87,7 @@ func TestPullRequestsService_ListByRepo(t *testing.T) {
 	setup()
 	defer teardown()
 
-	want := []*PullRequest{&PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}}}
+	want := []*PullRequest{&PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}, BaseRepo: RepoSpec{URI: "x.com/r"}}}
 	repoSpec := RepoSpec{URI: "x.com/r"}
 
 	var called bool
@@ -88,6 +121,127 @@ func TestPullRequestsService_ListByRepo(t *testing.T) {
 	}
 }
 
+func TestPullRequestsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}, BaseRepo: RepoSpec{URI: "r.com/x"}, Draft: true}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestsCreate, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Title":"t","Body":"Bumps dep to v2.","Head":"bump","Base":"master","Draft":true}`+"\n")
+
+		writeJSON(w, &PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}, Draft: true})
+	})
+
+	pr, _, err := client.PullRequests.Create(RepoSpec{URI: "r.com/x"}, &NewPullRequest{
+		Title:    "t",
+		Body:     "Bumps {{.Dep}} to {{.Version}}.  \r\n",
+		BodyData: map[string]string{"Dep": "dep", "Version": "v2"},
+		Head:     "bump",
+		Base:     "master",
+		Draft:    true,
+	})
+	if err != nil {
+		t.Errorf("PullRequests.Create returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(pr, want) {
+		t.Errorf("PullRequests.Create returned %+v, want %+v", pr, want)
+	}
+}
+
+func TestPullRequestsService_Create_invalid(t *testing.T) {
+	client := NewClient(nil)
+
+	tests := map[string]*NewPullRequest{
+		"Title": {Title: " ", Head: "h", Base: "b"},
+		"Base":  {Title: "t", Head: "h"},
+		"Head":  {Title: "t", Head: "b", Base: "b"},
+		"Body":  {Title: "t", Head: "h", Base: "b", Body: "{{.Missing}}", BodyData: map[string]string{}},
+	}
+	for field, pr := range tests {
+		_, _, err := client.PullRequests.Create(RepoSpec{URI: "r.com/x"}, pr)
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("%s: got error %v, want ValidationError for field %s", field, err, field)
+		}
+	}
+}
+
+func TestPullRequestsService_Edit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}, BaseRepo: RepoSpec{URI: "r.com/x"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestEdit, map[string]string{"RepoSpec": "r.com/x", "Pull": "1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"Body":"","Base":"release","Draft":false}`+"\n")
+
+		writeJSON(w, &PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}})
+	})
+
+	pr, _, err := client.PullRequests.Edit(PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, &PullRequestEditRequest{
+		Body:  github.String(" "),
+		Base:  github.String("release"),
+		Draft: github.Bool(false),
+	})
+	if err != nil {
+		t.Errorf("PullRequests.Edit returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(pr, want) {
+		t.Errorf("PullRequests.Edit returned %+v, want %+v", pr, want)
+	}
+}
+
+func TestPullRequestsService_ListAllComments(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*RepoPullRequestComment{{Number: 2, PullRequestComment: &PullRequestComment{PullRequestComment: github.PullRequestComment{ID: github.Int(1)}}}}
+	repo := RepoSpec{URI: "r.com/x"}
+	since := time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestsComments, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Since": "2015-01-02T03:04:05Z", "Sort": "updated", "PerPage": "100"})
+
+		writeJSON(w, want)
+	})
+
+	comments, _, err := client.PullRequests.ListAllComments(repo, &PullRequestListAllCommentsOptions{
+		TimeRangeOptions: TimeRangeOptions{Since: &since},
+		SortOptions:      SortOptions{Sort: "updated"},
+		ListOptions:      ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		t.Errorf("PullRequests.ListAllComments returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(comments, want) {
+		t.Errorf("PullRequests.ListAllComments returned %+v, want %+v", comments, want)
+	}
+}
+
 func TestPullRequestsService_ListComments(t *testing.T) {
 	setup()
 	defer teardown()
@@ -126,6 +280,72 @@ func TestPullRequestsService_ListComments(t *testing.T) {
 	}
 }
 
+func TestPullRequestsService_ListCommentsBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pull1 := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	pull2 := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 2}
+	comment := func(id int) *PullRequestComment {
+		return &PullRequestComment{PullRequestComment: github.PullRequestComment{ID: github.Int(id)}}
+	}
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestComments, pull1.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls["1:"+r.FormValue("Page")]++
+		mu.Unlock()
+		testMethod(t, r, "GET")
+		switch r.FormValue("Page") {
+		case "1":
+			writeJSON(w, []*PullRequestComment{comment(1)})
+		case "2":
+			writeJSON(w, []*PullRequestComment{})
+		}
+	})
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestComments, pull2.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls["2:"+r.FormValue("Page")]++
+		mu.Unlock()
+		writeJSON(w, []*PullRequestComment{})
+	})
+
+	comments, _, err := client.PullRequests.ListCommentsBatch([]PullRequestSpec{pull1, pull2, pull1}, &PullRequestListCommentsBatchOptions{PerPage: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[PullRequestSpec][]*PullRequestComment{pull1: {comment(1)}, pull2: nil}
+	if !reflect.DeepEqual(comments, want) {
+		t.Errorf("got comments %+v, want %+v", comments, want)
+	}
+	if wantCalls := map[string]int{"1:1": 1, "1:2": 1, "2:1": 1}; !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("got calls %v, want %v", calls, wantCalls)
+	}
+}
+
+func TestPullRequestsService_ListCommentsBatch_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pull := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestComments, pull.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "x", http.StatusInternalServerError)
+	})
+
+	comments, resp, err := client.PullRequests.ListCommentsBatch([]PullRequestSpec{pull}, nil)
+	if err == nil {
+		t.Fatal("got nil error, want error")
+	}
+	if comments != nil {
+		t.Errorf("got comments %+v, want nil", comments)
+	}
+	if resp == nil || resp.(*HTTPResponse).StatusCode != http.StatusInternalServerError {
+		t.Errorf("got response %+v, want the failed request's response", resp)
+	}
+}
+
 func TestPullRequestsService_CreateComment(t *testing.T) {
 	setup()
 	defer teardown()
@@ -176,6 +396,21 @@ func TestPullRequestsService_CreateComment(t *testing.T) {
 	}
 }
 
+func TestPullRequestsService_CreateComment_invalidBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	_, _, err := client.PullRequests.CreateComment(pullSpec, &PullRequestComment{PullRequestComment: github.PullRequestComment{Body: github.String(" \r\n ")}})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("got error %v, want *ValidationError", err)
+	}
+}
+
 func TestPullRequestsService_EditComment(t *testing.T) {
 	setup()
 	defer teardown()
@@ -326,3 +561,371 @@ func TestPullRequestsService_Merge(t *testing.T) {
 		t.Errorf("got %+v, want %+v", mergeResult, wantMergeResult)
 	}
 }
+
+func TestPullRequestsService_Merge_method(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/foo"}, Number: 22}
+
+	called := false
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestMerge, pullSpec.RouteVars()), func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		testBody(t, req, `{"CommitMessage":"m","Method":"squash","SHA":"c"}`+"\n")
+
+		writeJSON(w, &PullRequestMergeResult{})
+	})
+
+	if _, _, err := client.PullRequests.Merge(pullSpec, &PullRequestMergeRequest{CommitMessage: "m", Method: MergeMethodSquash, SHA: "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if _, _, err := client.PullRequests.Merge(pullSpec, &PullRequestMergeRequest{Method: "octopus"}); err == nil {
+		t.Error("got nil error for unrecognized merge method, want error")
+	} else if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("got error %T, want *ValidationError", err)
+	}
+}
+
+func TestPullRequestsService_Merge_nilRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/foo"}, Number: 22}
+
+	called := false
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestMerge, pullSpec.RouteVars()), func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		testMethod(t, req, "PUT")
+		testBody(t, req, `{"CommitMessage":""}`+"\n")
+
+		writeJSON(w, &PullRequestMergeResult{})
+	})
+
+	if _, _, err := client.PullRequests.Merge(pullSpec, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestPullRequestsService_ListAffectedDefs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*PullRequestAffectedDef{
+		{DefDelta: DefDelta{Head: &Def{Def: graph.Def{DefKey: graph.DefKey{Path: "p"}}}}, ExternalRefs: 2},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestAffectedDefs, map[string]string{"RepoSpec": "r.com/x", "Pull": "1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	defs, _, err := client.PullRequests.ListAffectedDefs(PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, nil)
+	if err != nil {
+		t.Errorf("PullRequests.ListAffectedDefs returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(defs, want) {
+		t.Errorf("PullRequests.ListAffectedDefs returned %+v, want %+v", defs, want)
+	}
+}
+
+func TestPullRequestsService_Export(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &PullRequestArchive{
+		Version:       ArchiveVersion,
+		PullRequest:   &PullRequest{PullRequest: github.PullRequest{Number: github.Int(1)}, BaseRepo: RepoSpec{URI: "r.com/x"}},
+		Comments:      []*PullRequestComment{{PullRequestComment: github.PullRequestComment{ID: github.Int(2)}}},
+		IssueComments: []*IssueComment{{IssueComment: github.IssueComment{ID: github.Int(3)}}},
+		Reviews:       []*PullRequestReview{{ID: 4, Reviewer: UserSpec{Login: "u"}, State: "approved"}},
+		Events:        []*IssueEvent{{ID: 5, Actor: UserSpec{Login: "u"}, Event: "merged"}},
+	}
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestExport, pullSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	archive, _, err := client.PullRequests.Export(pullSpec)
+	if err != nil {
+		t.Errorf("PullRequests.Export returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(archive, want) {
+		t.Errorf("PullRequests.Export returned %+v, want %+v with diff: %s", archive, want, strings.Join(pretty.Diff(want, archive), "\n"))
+	}
+}
+
+func TestPullRequestsService_ListReviews(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*PullRequestReview{{ID: 1, State: "approved"}}
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviews, pullSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"PerPage": "1"})
+
+		writeJSON(w, want)
+	})
+
+	reviews, _, err := client.PullRequests.ListReviews(pullSpec, &PullRequestListReviewsOptions{ListOptions: ListOptions{PerPage: 1}})
+	if err != nil {
+		t.Errorf("PullRequests.ListReviews returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(reviews, want) {
+		t.Errorf("PullRequests.ListReviews returned %+v, want %+v", reviews, want)
+	}
+}
+
+func TestPullRequestsService_ListEvents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*IssueEvent{
+		{ID: 1, Event: IssueEventReviewed, Actor: UserSpec{Login: "a"}, Review: &PullRequestReview{ID: 1, State: "approved"}},
+		{ID: 2, Event: IssueEventLabeled, Actor: UserSpec{Login: "b"}, Label: "bug"},
+		{ID: 3, Event: IssueEventMerged, Actor: UserSpec{Login: "b"}, CommitID: "c"},
+	}
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestEvents, pullSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Types": "reviewed,labeled,merged", "Until": "2015-01-02T00:00:00Z", "PerPage": "3"})
+
+		writeJSON(w, want)
+	})
+
+	until := time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)
+	events, _, err := client.PullRequests.ListEvents(pullSpec, &PullRequestListEventsOptions{
+		Types:            []string{IssueEventReviewed, IssueEventLabeled, IssueEventMerged},
+		TimeRangeOptions: TimeRangeOptions{Until: &until},
+		ListOptions:      ListOptions{PerPage: 3},
+	})
+	if err != nil {
+		t.Errorf("PullRequests.ListEvents returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("PullRequests.ListEvents returned %+v, want %+v", events, want)
+	}
+}
+
+func TestPullRequestsService_GetReview(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &PullRequestReview{ID: 2, State: "pending"}
+	reviewSpec := PullRequestReviewSpec{Pull: PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, Review: 2}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReview, reviewSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	review, _, err := client.PullRequests.GetReview(reviewSpec)
+	if err != nil {
+		t.Errorf("PullRequests.GetReview returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(review, want) {
+		t.Errorf("PullRequests.GetReview returned %+v, want %+v", review, want)
+	}
+}
+
+func TestPullRequestsService_CreateReview(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &PullRequestReview{ID: 2, State: "pending", Body: "b"}
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviewsCreate, pullSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Body":"b"}`+"\n")
+		if r.Header.Get(IdempotencyKeyHeader) == "" {
+			t.Error("no idempotency key")
+		}
+
+		writeJSON(w, want)
+	})
+
+	review, _, err := client.PullRequests.CreateReview(pullSpec, &PullRequestReviewRequest{Body: "b"})
+	if err != nil {
+		t.Errorf("PullRequests.CreateReview returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(review, want) {
+		t.Errorf("PullRequests.CreateReview returned %+v, want %+v", review, want)
+	}
+}
+
+func TestPullRequestsService_SubmitReview(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &PullRequestReview{ID: 2, State: "approved"}
+	reviewSpec := PullRequestReviewSpec{Pull: PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, Review: 2}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviewSubmit, reviewSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Event":"APPROVE"}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	review, _, err := client.PullRequests.SubmitReview(reviewSpec, &PullRequestReviewSubmitOptions{Event: ReviewApprove})
+	if err != nil {
+		t.Errorf("PullRequests.SubmitReview returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(review, want) {
+		t.Errorf("PullRequests.SubmitReview returned %+v, want %+v", review, want)
+	}
+}
+
+func TestPullRequestsService_DismissReview(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &PullRequestReview{ID: 2, State: "dismissed"}
+	reviewSpec := PullRequestReviewSpec{Pull: PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, Review: 2}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestReviewDismiss, reviewSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"Message":"stale"}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	review, _, err := client.PullRequests.DismissReview(reviewSpec, &PullRequestReviewDismissOptions{Message: "stale"})
+	if err != nil {
+		t.Errorf("PullRequests.DismissReview returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(review, want) {
+		t.Errorf("PullRequests.DismissReview returned %+v, want %+v", review, want)
+	}
+}
+
+func TestPullRequestsService_ListFiles(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*FileDiff{{FileDiff: &diff.FileDiff{OrigName: "a", NewName: "a"}}}
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestFiles, pullSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Filter": "a", "Page": "2"})
+
+		writeJSON(w, want)
+	})
+
+	files, _, err := client.PullRequests.ListFiles(pullSpec, &PullRequestListFilesOptions{Filter: "a", ListOptions: ListOptions{Page: 2}})
+	if err != nil {
+		t.Errorf("PullRequests.ListFiles returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("PullRequests.ListFiles returned %+v, want %+v", files, want)
+	}
+}
+
+func TestPullRequestsService_GetDiff(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := "--- a\n+++ a\n@@ -1 +1 @@\n-x\n+y\n"
+	pullSpec := PullRequestSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoPullRequestDiff, pullSpec.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		w.Header().Set("Content-Type", "text/x-diff")
+		w.Write([]byte(want))
+	})
+
+	diff, _, err := client.PullRequests.GetDiff(pullSpec)
+	if err != nil {
+		t.Errorf("PullRequests.GetDiff returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if diff != want {
+		t.Errorf("PullRequests.GetDiff returned %q, want %q", diff, want)
+	}
+}
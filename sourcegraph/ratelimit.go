@@ -0,0 +1,113 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// A RateLimiter limits the rate of requests using a token bucket. It
+// is safe for concurrent use, so one RateLimiter can be shared by many
+// clients (see ClientPool).
+type RateLimiter struct {
+	interval time.Duration // time to accumulate one token
+	burst    float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that allows perSecond requests
+// per second on average, with bursts of up to burst requests.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait
+// before using it.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// Wait blocks until a request is allowed and returns how long it
+// waited.
+func (l *RateLimiter) Wait() time.Duration {
+	d := l.reserve()
+	if d > 0 {
+		time.Sleep(d)
+	}
+	return d
+}
+
+// Rate is the server's rate limit for the client, as reported in the
+// X-RateLimit-* headers of a response.
+type Rate struct {
+	Limit     int       // the number of requests allowed per period
+	Remaining int       // the number of requests remaining in the current period
+	Reset     time.Time // the time at which the current period ends
+}
+
+// parseRate returns the rate limit reported by the X-RateLimit-*
+// headers in h, or nil if h has no (valid) rate limit headers.
+func parseRate(h http.Header) *Rate {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return nil
+	}
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return nil
+	}
+	rate := &Rate{Limit: limit, Remaining: remaining}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rate.Reset = time.Unix(reset, 0)
+	}
+	return rate
+}
+
+// A RateLimitError is returned when a request is rejected because the
+// client has exhausted its rate limit (with an HTTP 429 response, or a
+// 403 response reporting that no requests remain). Callers should wait
+// until Rate.Reset before making more requests.
+type RateLimitError struct {
+	*ErrorResponse
+	Rate Rate // the rate limit (Rate.Reset is zero if the server didn't report it)
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Rate.Reset.IsZero() {
+		return fmt.Sprintf("%s (rate limit exceeded)", e.ErrorResponse.Error())
+	}
+	return fmt.Sprintf("%s (rate limit exceeded; resets at %s)", e.ErrorResponse.Error(), e.Rate.Reset.Format(time.RFC3339))
+}
+
+// IsRateLimited reports whether err is a *RateLimitError.
+func IsRateLimited(err error) bool {
+	_, ok := err.(*RateLimitError)
+	return ok
+}
@@ -0,0 +1,99 @@
+package sourcegraph
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph/internal/issueref"
+)
+
+// References are the mentions and cross-references found in a comment
+// or description body by ParseReferences.
+type References struct {
+	// Mentions are the people @mentioned (by login).
+	Mentions []PersonSpec
+
+	// Issues are the issues referred to as #123, owner/repo#123, or by
+	// the URL of the issue. Issues and pull requests share numbers, so
+	// a #123 reference may refer to a pull request (see
+	// PullRequestSpec.IssueSpec).
+	Issues []IssueSpec
+
+	// PullRequests are the pull requests referred to by URL.
+	PullRequests []PullRequestSpec
+
+	// Commits are the commit IDs (abbreviated or full SHAs) referred to.
+	Commits []string
+}
+
+var (
+	mentionPattern = regexp.MustCompile(`@([A-Za-z0-9][A-Za-z0-9-]*)`)
+	commitPattern  = regexp.MustCompile(`\b[0-9a-f]{7,40}\b`)
+)
+
+// maxLoginLength is the maximum length of a login that ParseReferences
+// recognizes in an @mention.
+const maxLoginLength = 39
+
+// ParseReferences returns the @mentions, issue and pull request
+// references, and commit IDs in body, which is a comment or
+// description (in markdown) in repo. "#123" references are to issues in
+// repo (and are ignored if repo is empty), and cross-repository
+// references (owner/repo#123) are resolved relative to repo's host.
+// References in code spans and code blocks are ignored. Each reference
+// appears only once in the result, in the order in which it first
+// appears in body. To find where issue and pull request references
+// occur in body (e.g., to link them), use the references subpackage.
+func ParseReferences(repo RepoSpec, body string) *References {
+	var refs References
+
+	seen := map[string]struct{}{}
+	add := func(key string) bool {
+		if _, dup := seen[key]; dup {
+			return false
+		}
+		seen[key] = struct{}{}
+		return true
+	}
+
+	issueRefs, body := issueref.Find(repo.URI, body)
+	for _, ref := range issueRefs {
+		refRepo := RepoSpec{URI: ref.Repo}
+		if ref.Repo == "" {
+			if repo == (RepoSpec{}) {
+				continue
+			}
+			refRepo = repo
+		}
+		n := strconv.Itoa(ref.Number)
+		if ref.Pull {
+			if add("pull:" + refRepo.URI + "#" + n) {
+				refs.PullRequests = append(refs.PullRequests, PullRequestSpec{Repo: refRepo, Number: ref.Number})
+			}
+		} else if add("issue:" + refRepo.URI + "#" + n) {
+			refs.Issues = append(refs.Issues, IssueSpec{Repo: refRepo, Number: ref.Number})
+		}
+	}
+
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(body, -1) {
+		login := body[m[2]:m[3]]
+		if !issueref.BoundaryBefore(body, m[0], "@.`") || (m[1] < len(body) && body[m[1]] == '/') || len(login) > maxLoginLength {
+			continue
+		}
+		if add("mention:" + strings.ToLower(login)) {
+			refs.Mentions = append(refs.Mentions, PersonSpec{Login: login})
+		}
+	}
+
+	for _, sha := range commitPattern.FindAllString(body, -1) {
+		if strings.Trim(sha, "0123456789") == "" {
+			continue // all digits; probably a number, not a commit ID
+		}
+		if add("commit:" + sha) {
+			refs.Commits = append(refs.Commits, sha)
+		}
+	}
+
+	return &refs
+}
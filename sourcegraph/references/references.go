@@ -0,0 +1,139 @@
+// Package references finds references to issues and pull requests in
+// free text (such as comment bodies) and formats them, for bots and
+// renderers that link them.
+//
+// It recognizes the same forms as sourcegraph.ParseReferences ("#123",
+// "owner/repo#123", and issue and pull request URLs), but reports where
+// each occurrence is in the text instead of collecting the distinct
+// references:
+//
+//	linked := references.ReplaceAll(repo, body, func(ref references.Ref, text string) string {
+//		return fmt.Sprintf("[%s](%s)", text, ref.URL())
+//	})
+package references
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+	"github.com/fossas/go-sourcegraph/sourcegraph/internal/issueref"
+)
+
+// A Ref is a reference to an issue or pull request in text. Exactly
+// one of Issue and Pull is set.
+type Ref struct {
+	// Issue is set for "#123" and "owner/repo#123" references and for
+	// issue URLs. Issues and pull requests share numbers, so it may
+	// refer to a pull request (see sourcegraph.PullRequestSpec.IssueSpec).
+	Issue *sourcegraph.IssueSpec
+
+	// Pull is set for pull request URLs.
+	Pull *sourcegraph.PullRequestSpec
+
+	// Start and End are the byte offsets of the reference in the text
+	// it was found in (so text[Start:End] is the reference).
+	Start, End int
+}
+
+// Repo returns the repository of the issue or pull request.
+func (r Ref) Repo() sourcegraph.RepoSpec {
+	if r.Pull != nil {
+		return r.Pull.Repo
+	}
+	return r.Issue.Repo
+}
+
+// Number returns the number of the issue or pull request.
+func (r Ref) Number() int {
+	if r.Pull != nil {
+		return r.Pull.Number
+	}
+	return r.Issue.Number
+}
+
+// Format returns the shortest form of r that Parse resolves to the
+// same issue or pull request in text in base: "#123" for references
+// to base, "owner/repo#123" for references to other repositories on
+// the same host, and a URL (see URL) otherwise. Because the short
+// forms refer to issues, references to pull requests in repositories
+// on other hosts are formatted as URLs, and references to other pull
+// requests are formatted as issue references.
+func (r Ref) Format(base sourcegraph.RepoSpec) string {
+	repo, n := r.Repo(), strconv.Itoa(r.Number())
+	switch {
+	case repo.URI == base.URI:
+		return "#" + n
+	case issueref.RepoHost(repo.URI) != "" && issueref.RepoHost(repo.URI) == issueref.RepoHost(base.URI) && strings.Count(repo.URI, "/") == 2:
+		return strings.TrimPrefix(repo.URI, issueref.RepoHost(repo.URI)) + "#" + n
+	default:
+		return r.URL()
+	}
+}
+
+// URL returns the URL of the issue or pull request on its repository's
+// host (such as "https://github.com/o/r/pull/1").
+func (r Ref) URL() string {
+	kind := "issues"
+	if r.Pull != nil {
+		kind = "pull"
+	}
+	return fmt.Sprintf("https://%s/%s/%d", r.Repo().URI, kind, r.Number())
+}
+
+// Parse returns the references to issues and pull requests in text (in
+// markdown), in the order in which they appear. A reference that occurs
+// more than once is returned for each occurrence. "#123" references are
+// to issues in base (and are ignored if base is empty), and
+// "owner/repo#123" references are resolved relative to base's host.
+// References in code spans and code blocks are ignored.
+//
+// For URLs, Start and End span the whole URL (such as
+// "https://github.com/o/r/pull/1/files"), except for trailing
+// punctuation.
+func Parse(base sourcegraph.RepoSpec, text string) []Ref {
+	found, _ := issueref.Find(base.URI, text)
+	refs := make([]Ref, 0, len(found))
+	for _, f := range found {
+		repo := sourcegraph.RepoSpec{URI: f.Repo}
+		if f.Repo == "" {
+			if base == (sourcegraph.RepoSpec{}) {
+				continue
+			}
+			repo = base
+		}
+		ref := Ref{Start: f.Start, End: f.End}
+		if f.Pull {
+			ref.Pull = &sourcegraph.PullRequestSpec{Repo: repo, Number: f.Number}
+		} else {
+			ref.Issue = &sourcegraph.IssueSpec{Repo: repo, Number: f.Number}
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// ParseRef parses s, which must consist of a single reference (in any
+// form that Parse recognizes, such as one returned by Format).
+func ParseRef(base sourcegraph.RepoSpec, s string) (Ref, error) {
+	refs := Parse(base, s)
+	if len(refs) != 1 || refs[0].Start != 0 || refs[0].End != len(s) {
+		return Ref{}, fmt.Errorf("invalid issue or pull request reference %q", s)
+	}
+	return refs[0], nil
+}
+
+// ReplaceAll returns a copy of text in which each reference found by
+// Parse is replaced by the result of repl, which is called with the
+// reference and its text.
+func ReplaceAll(base sourcegraph.RepoSpec, text string, repl func(ref Ref, text string) string) string {
+	var buf []byte
+	last := 0
+	for _, ref := range Parse(base, text) {
+		buf = append(buf, text[last:ref.Start]...)
+		buf = append(buf, repl(ref, text[ref.Start:ref.End])...)
+		last = ref.End
+	}
+	return string(append(buf, text[last:]...))
+}
@@ -0,0 +1,91 @@
+package references
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+var base = sourcegraph.RepoSpec{URI: "github.com/o/r"}
+
+func issue(uri string, n int) *sourcegraph.IssueSpec {
+	return &sourcegraph.IssueSpec{Repo: sourcegraph.RepoSpec{URI: uri}, Number: n}
+}
+
+func pull(uri string, n int) *sourcegraph.PullRequestSpec {
+	return &sourcegraph.PullRequestSpec{Repo: sourcegraph.RepoSpec{URI: uri}, Number: n}
+}
+
+func TestParse(t *testing.T) {
+	text := "Fixes #1 and o2/r2#2 (see https://github.com/o/r/pull/3/files.) `#4`\n" +
+		"```\n#5\n```\na#6 &#7; x/y/z#8 #1"
+	want := []Ref{
+		{Issue: issue("github.com/o/r", 1), Start: 6, End: 8},
+		{Issue: issue("github.com/o2/r2", 2), Start: 13, End: 20},
+		{Pull: pull("github.com/o/r", 3), Start: 26, End: 61},
+		{Issue: issue("github.com/o/r", 1), Start: len(text) - 2, End: len(text)},
+	}
+
+	refs := Parse(base, text)
+	if !reflect.DeepEqual(refs, want) {
+		t.Fatalf("got %+v, want %+v", refs, want)
+	}
+	if got := text[refs[2].Start:refs[2].End]; got != "https://github.com/o/r/pull/3/files" {
+		t.Errorf("got URL reference text %q", got)
+	}
+}
+
+func TestParse_noBase(t *testing.T) {
+	refs := Parse(sourcegraph.RepoSpec{}, "#1 https://h.com/o/r/issues/2")
+	if want := []Ref{{Issue: issue("h.com/o/r", 2), Start: 3, End: 29}}; !reflect.DeepEqual(refs, want) {
+		t.Errorf("got %+v, want %+v", refs, want)
+	}
+}
+
+func TestRef_Format(t *testing.T) {
+	tests := []struct {
+		ref  Ref
+		want string
+	}{
+		{Ref{Issue: issue("github.com/o/r", 1)}, "#1"},
+		{Ref{Pull: pull("github.com/o/r", 1)}, "#1"},
+		{Ref{Issue: issue("github.com/o2/r2", 2)}, "o2/r2#2"},
+		{Ref{Issue: issue("gitlab.com/o/r", 3)}, "https://gitlab.com/o/r/issues/3"},
+		{Ref{Pull: pull("gitlab.com/o/r", 4)}, "https://gitlab.com/o/r/pull/4"},
+	}
+	for _, test := range tests {
+		s := test.ref.Format(base)
+		if s != test.want {
+			t.Errorf("%+v: got %q, want %q", test.ref, s, test.want)
+			continue
+		}
+
+		ref, err := ParseRef(base, s)
+		if err != nil {
+			t.Errorf("%q: %s", s, err)
+			continue
+		}
+		if ref.Repo() != test.ref.Repo() || ref.Number() != test.ref.Number() {
+			t.Errorf("%q: parsed %+v, want %+v", s, ref, test.ref)
+		}
+	}
+}
+
+func TestParseRef_invalid(t *testing.T) {
+	for _, s := range []string{"", "#", "#1 #2", "see #1", "x#1"} {
+		if _, err := ParseRef(base, s); err == nil {
+			t.Errorf("%q: err == nil", s)
+		}
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	got := ReplaceAll(base, "Fixes #1, o2/r2#2.", func(ref Ref, text string) string {
+		return "[" + text + "](" + ref.URL() + ")"
+	})
+	want := "Fixes [#1](https://github.com/o/r/issues/1), [o2/r2#2](https://github.com/o2/r2/issues/2)."
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
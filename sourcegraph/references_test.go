@@ -0,0 +1,55 @@
+package sourcegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReferences(t *testing.T) {
+	repo := RepoSpec{URI: "github.com/o/r"}
+	tests := map[string]*References{
+		"": {},
+
+		"cc @alice, @bob and @Alice. email a@b.com, team @o/team": {
+			Mentions: []PersonSpec{{Login: "alice"}, {Login: "bob"}},
+		},
+
+		"fixes #12 and x/y#3 (not &#38; or #4a); see #12": {
+			Issues: []IssueSpec{
+				{Repo: repo, Number: 12},
+				{Repo: RepoSpec{URI: "github.com/x/y"}, Number: 3},
+			},
+		},
+
+		"see https://github.com/x/y/pull/5#issuecomment-1 and https://github.com/x/y/issues/6 and https://example.com/@carol": {
+			Issues:       []IssueSpec{{Repo: RepoSpec{URI: "github.com/x/y"}, Number: 6}},
+			PullRequests: []PullRequestSpec{{Repo: RepoSpec{URI: "github.com/x/y"}, Number: 5}},
+		},
+
+		"reverts 54be461 (and 54be46135e45be9bd3318b8fd39a456ff1e2895e), not 1234567 or 54be461z": {
+			Commits: []string{"54be461", "54be46135e45be9bd3318b8fd39a456ff1e2895e"},
+		},
+
+		"ignore `@dave #7` and\n```\n@erin #8 54be461\n```\nbut not @frank": {
+			Mentions: []PersonSpec{{Login: "frank"}},
+		},
+	}
+	for body, want := range tests {
+		if got := ParseReferences(repo, body); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %+v, want %+v", body, got, want)
+		}
+	}
+}
+
+func TestParseReferences_emptyRepo(t *testing.T) {
+	// As in the references package, "#123" references are ignored when
+	// there is no repository to resolve them in.
+	got := ParseReferences(RepoSpec{}, "#1 x/y#2 https://h.com/o/r/issues/3")
+	want := &References{Issues: []IssueSpec{
+		{Repo: RepoSpec{URI: "x/y"}, Number: 2},
+		{Repo: RepoSpec{URI: "h.com/o/r"}, Number: 3},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
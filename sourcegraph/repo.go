@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
@@ -236,8 +237,10 @@ func IsNotPresent(err error) bool {
 	return err == ErrNotExist || err == ErrNotPersisted
 }
 
+// IsForbidden returns whether err is ErrForbidden or an API error with
+// HTTP status 403 Forbidden.
 func IsForbidden(err error) bool {
-	return err == ErrForbidden
+	return err == ErrForbidden || IsHTTPErrorCode(err, http.StatusForbidden)
 }
 
 // ErrNoScheme is an error indicating that a clone URL contained no scheme
@@ -0,0 +1,59 @@
+package sourcegraph
+
+import "github.com/fossas/go-sourcegraph/router"
+
+// RepoBadgesService communicates with the Sourcegraph API endpoints
+// for a repository's badges (images that show information about the
+// repository, such as the number of cross-references to it) and
+// counters (images that show how many times the repository was
+// viewed).
+type RepoBadgesService interface {
+	// ListBadges lists the available badges for a repository.
+	ListBadges(repo RepoSpec) ([]*Badge, Response, error)
+
+	// ListCounters lists the available counters for a repository.
+	ListCounters(repo RepoSpec) ([]*Counter, Response, error)
+
+	// RecordHit records a view of a repository for the named counter,
+	// as fetching the counter's (counted) ImageURL does. It is for
+	// tools that display the count without embedding the image.
+	RecordHit(repo RepoSpec, counter string) (Response, error)
+}
+
+// repoBadgesService implements RepoBadgesService.
+type repoBadgesService struct {
+	client *Client
+}
+
+var _ RepoBadgesService = &repoBadgesService{}
+
+func (s *repoBadgesService) ListBadges(repo RepoSpec) ([]*Badge, Response, error) {
+	var badges []*Badge
+	resp, err := s.client.DoList(router.RepoBadges, repo.RouteVars(), nil, &badges)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return badges, resp, nil
+}
+
+func (s *repoBadgesService) ListCounters(repo RepoSpec) ([]*Counter, Response, error) {
+	var counters []*Counter
+	resp, err := s.client.DoList(router.RepoCounters, repo.RouteVars(), nil, &counters)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return counters, resp, nil
+}
+
+func (s *repoBadgesService) RecordHit(repo RepoSpec, counter string) (Response, error) {
+	if counter == "" {
+		return nil, &ValidationError{Field: "Counter", Problems: []string{"required"}}
+	}
+	routeVars := repo.RouteVars()
+	routeVars["Counter"] = counter
+	return s.client.DoCreate(router.RepoCounterRecordHit, routeVars, nil, nil)
+}
+
+var _ RepoBadgesService = &MockRepoBadgesService{}
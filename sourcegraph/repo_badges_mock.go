@@ -0,0 +1,47 @@
+// generated by gen-mocks; DO NOT EDIT
+
+package sourcegraph
+
+type MockRepoBadgesService struct {
+	ListBadges_   func(repo RepoSpec) ([]*Badge, Response, error)
+	ListCounters_ func(repo RepoSpec) ([]*Counter, Response, error)
+	RecordHit_    func(repo RepoSpec, counter string) (Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockRepoBadgesService returns a new MockRepoBadgesService
+// that records calls to its methods.
+func NewMockRepoBadgesService() *MockRepoBadgesService {
+	return &MockRepoBadgesService{Calls: &MockCalls{}}
+}
+
+func (s MockRepoBadgesService) ListBadges(repo RepoSpec) ([]*Badge, Response, error) {
+	s.Calls.record("ListBadges", repo)
+	if s.ListBadges_ == nil {
+		var r0 []*Badge
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoBadgesService.ListBadges")
+	}
+	return s.ListBadges_(repo)
+}
+
+func (s MockRepoBadgesService) ListCounters(repo RepoSpec) ([]*Counter, Response, error) {
+	s.Calls.record("ListCounters", repo)
+	if s.ListCounters_ == nil {
+		var r0 []*Counter
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoBadgesService.ListCounters")
+	}
+	return s.ListCounters_(repo)
+}
+
+func (s MockRepoBadgesService) RecordHit(repo RepoSpec, counter string) (Response, error) {
+	s.Calls.record("RecordHit", repo, counter)
+	if s.RecordHit_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("RepoBadgesService.RecordHit")
+	}
+	return s.RecordHit_(repo, counter)
+}
@@ -0,0 +1,91 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestRepoBadgesService_ListBadges(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Badge{{Name: "b", ImageURL: "https://example.com/b.png"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoBadges, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	badges, _, err := client.RepoBadges.ListBadges(RepoSpec{URI: "r.com/x"})
+	if err != nil {
+		t.Errorf("RepoBadges.ListBadges returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(badges, want) {
+		t.Errorf("RepoBadges.ListBadges returned %+v, want %+v", badges, want)
+	}
+}
+
+func TestRepoBadgesService_ListCounters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Counter{{Name: "views"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCounters, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	counters, _, err := client.RepoBadges.ListCounters(RepoSpec{URI: "r.com/x"})
+	if err != nil {
+		t.Errorf("RepoBadges.ListCounters returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(counters, want) {
+		t.Errorf("RepoBadges.ListCounters returned %+v, want %+v", counters, want)
+	}
+}
+
+func TestRepoBadgesService_RecordHit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCounterRecordHit, map[string]string{"RepoSpec": "r.com/x", "Counter": "views"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		if r.Header.Get(IdempotencyKeyHeader) == "" {
+			t.Error("no idempotency key")
+		}
+	})
+
+	if _, err := client.RepoBadges.RecordHit(RepoSpec{URI: "r.com/x"}, "views"); err != nil {
+		t.Errorf("RepoBadges.RecordHit returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if _, err := client.RepoBadges.RecordHit(RepoSpec{URI: "r.com/x"}, ""); err == nil {
+		t.Error("RecordHit with empty counter: got nil error")
+	}
+}
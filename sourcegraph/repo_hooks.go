@@ -0,0 +1,192 @@
+package sourcegraph
+
+import (
+	"fmt"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// RepoHooksService manages a repository's webhooks, so that
+// integrations can register the webhooks they receive events from
+// (see the webhooks package). A webhook is a notification destination
+// of type NotificationWebhook; RepoHooksService is a simpler interface
+// to those destinations than NotificationsService.
+type RepoHooksService interface {
+	// List lists a repository's webhooks. Other types of notification
+	// destinations (such as Slack channels) are not listed.
+	List(repo RepoSpec, opt *RepoHookListOptions) ([]*RepoHook, Response, error)
+
+	// Create adds a webhook to a repository. The ID field of hook is
+	// ignored.
+	Create(repo RepoSpec, hook *RepoHook) (*RepoHook, Response, error)
+
+	// Edit replaces the configuration of a webhook.
+	//
+	// Edit, Delete, and Ping return an error (without changing
+	// anything) if hook specifies a notification destination that
+	// isn't a webhook.
+	Edit(hook RepoHookSpec, config *RepoHook) (*RepoHook, Response, error)
+
+	// Delete removes a webhook.
+	Delete(hook RepoHookSpec) (Response, error)
+
+	// Ping sends a test event to a webhook, so that its configuration
+	// (and the receiver) can be checked.
+	Ping(hook RepoHookSpec) (Response, error)
+}
+
+// repoHooksService implements RepoHooksService.
+type repoHooksService struct {
+	client *Client
+}
+
+var _ RepoHooksService = &repoHooksService{}
+
+// RepoHookSpec specifies a repository webhook.
+type RepoHookSpec struct {
+	Repo RepoSpec
+	ID   int
+}
+
+func (s RepoHookSpec) RouteVars() map[string]string {
+	return NotificationDestinationSpec{Repo: s.Repo, ID: s.ID}.RouteVars()
+}
+
+// A RepoHook is a URL that the server POSTs repository and build
+// events to.
+type RepoHook struct {
+	ID int `json:",omitempty"`
+
+	URL string
+
+	// Secret, if set, is used to sign each event payload. It is never
+	// returned by the server (see WebhookDestination).
+	Secret string `json:",omitempty"`
+
+	// Events is the list of events to send. If empty, all events are
+	// sent.
+	Events []NotificationEvent `json:",omitempty"`
+
+	// Disabled is whether sending events to the webhook is
+	// (temporarily) turned off.
+	Disabled bool `json:",omitempty"`
+}
+
+// destination returns the notification destination that h is.
+func (h *RepoHook) destination() *NotificationDestination {
+	return &NotificationDestination{
+		ID:       h.ID,
+		Type:     NotificationWebhook,
+		Webhook:  &WebhookDestination{URL: h.URL, Secret: h.Secret},
+		Events:   h.Events,
+		Disabled: h.Disabled,
+	}
+}
+
+// repoHook returns the webhook that d is. It returns an error if d
+// isn't a webhook destination.
+func repoHook(d *NotificationDestination) (*RepoHook, error) {
+	if d.Type != NotificationWebhook || d.Webhook == nil {
+		return nil, fmt.Errorf("notification destination %d is not a webhook (type %q)", d.ID, d.Type)
+	}
+	return &RepoHook{
+		ID:       d.ID,
+		URL:      d.Webhook.URL,
+		Secret:   d.Webhook.Secret,
+		Events:   d.Events,
+		Disabled: d.Disabled,
+	}, nil
+}
+
+// RepoHookListOptions specifies options for RepoHooksService.List.
+type RepoHookListOptions struct {
+	SortOptions
+	ListOptions
+}
+
+func (s *repoHooksService) List(repo RepoSpec, opt *RepoHookListOptions) ([]*RepoHook, Response, error) {
+	// The server filters by type before paginating, so that each page
+	// has PerPage webhooks (unless it is the last).
+	listOpt := &NotificationDestinationListOptions{Type: NotificationWebhook}
+	if opt != nil {
+		listOpt.SortOptions = opt.SortOptions
+		listOpt.ListOptions = opt.ListOptions
+	}
+
+	var dests []*NotificationDestination
+	resp, err := s.client.DoList(router.RepoNotificationDestinations, repo.RouteVars(), listOpt, &dests)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	hooks := make([]*RepoHook, len(dests))
+	for i, d := range dests {
+		hook, err := repoHook(d)
+		if err != nil {
+			return nil, resp, err
+		}
+		hooks[i] = hook
+	}
+	return hooks, resp, nil
+}
+
+// checkWebhook returns an error if the notification destination that
+// hook specifies isn't a webhook.
+func (s *repoHooksService) checkWebhook(hook RepoHookSpec) (Response, error) {
+	var dest NotificationDestination
+	resp, err := s.client.DoGet(router.RepoNotificationDestination, hook.RouteVars(), nil, &dest)
+	if err != nil {
+		return resp, err
+	}
+	_, err = repoHook(&dest)
+	return resp, err
+}
+
+func (s *repoHooksService) Create(repo RepoSpec, hook *RepoHook) (*RepoHook, Response, error) {
+	if hook.URL == "" {
+		return nil, nil, &ValidationError{Field: "URL", Problems: []string{"must be set"}}
+	}
+
+	var created NotificationDestination
+	resp, err := s.client.DoCreate(router.RepoNotificationDestinationsCreate, repo.RouteVars(), hook.destination(), &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	h, err := repoHook(&created)
+	return h, resp, err
+}
+
+func (s *repoHooksService) Edit(hook RepoHookSpec, config *RepoHook) (*RepoHook, Response, error) {
+	if config.URL == "" {
+		return nil, nil, &ValidationError{Field: "URL", Problems: []string{"must be set"}}
+	}
+	if resp, err := s.checkWebhook(hook); err != nil {
+		return nil, resp, err
+	}
+
+	var updated NotificationDestination
+	resp, err := s.client.DoUpdate(router.RepoNotificationDestinationUpdate, hook.RouteVars(), config.destination(), &updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	h, err := repoHook(&updated)
+	return h, resp, err
+}
+
+func (s *repoHooksService) Delete(hook RepoHookSpec) (Response, error) {
+	if resp, err := s.checkWebhook(hook); err != nil {
+		return resp, err
+	}
+	return s.client.DoDelete(router.RepoNotificationDestinationDelete, hook.RouteVars())
+}
+
+func (s *repoHooksService) Ping(hook RepoHookSpec) (Response, error) {
+	if resp, err := s.checkWebhook(hook); err != nil {
+		return resp, err
+	}
+	return s.client.DoCreate(router.RepoNotificationDestinationTest, hook.RouteVars(), nil, nil)
+}
+
+var _ RepoHooksService = &MockRepoHooksService{}
@@ -0,0 +1,68 @@
+// generated by gen-mocks; DO NOT EDIT
+
+package sourcegraph
+
+type MockRepoHooksService struct {
+	List_   func(repo RepoSpec, opt *RepoHookListOptions) ([]*RepoHook, Response, error)
+	Create_ func(repo RepoSpec, hook *RepoHook) (*RepoHook, Response, error)
+	Edit_   func(hook RepoHookSpec, config *RepoHook) (*RepoHook, Response, error)
+	Delete_ func(hook RepoHookSpec) (Response, error)
+	Ping_   func(hook RepoHookSpec) (Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockRepoHooksService returns a new MockRepoHooksService
+// that records calls to its methods.
+func NewMockRepoHooksService() *MockRepoHooksService {
+	return &MockRepoHooksService{Calls: &MockCalls{}}
+}
+
+func (s MockRepoHooksService) List(repo RepoSpec, opt *RepoHookListOptions) ([]*RepoHook, Response, error) {
+	s.Calls.record("List", repo, opt)
+	if s.List_ == nil {
+		var r0 []*RepoHook
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoHooksService.List")
+	}
+	return s.List_(repo, opt)
+}
+
+func (s MockRepoHooksService) Create(repo RepoSpec, hook *RepoHook) (*RepoHook, Response, error) {
+	s.Calls.record("Create", repo, hook)
+	if s.Create_ == nil {
+		var r0 *RepoHook
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoHooksService.Create")
+	}
+	return s.Create_(repo, hook)
+}
+
+func (s MockRepoHooksService) Edit(hook RepoHookSpec, config *RepoHook) (*RepoHook, Response, error) {
+	s.Calls.record("Edit", hook, config)
+	if s.Edit_ == nil {
+		var r0 *RepoHook
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoHooksService.Edit")
+	}
+	return s.Edit_(hook, config)
+}
+
+func (s MockRepoHooksService) Delete(hook RepoHookSpec) (Response, error) {
+	s.Calls.record("Delete", hook)
+	if s.Delete_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("RepoHooksService.Delete")
+	}
+	return s.Delete_(hook)
+}
+
+func (s MockRepoHooksService) Ping(hook RepoHookSpec) (Response, error) {
+	s.Calls.record("Ping", hook)
+	if s.Ping_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("RepoHooksService.Ping")
+	}
+	return s.Ping_(hook)
+}
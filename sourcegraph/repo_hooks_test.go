@@ -0,0 +1,185 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestRepoHooksService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestinations, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Type": NotificationWebhook, "Sort": "id", "Direction": "desc", "PerPage": "10"})
+
+		writeJSON(w, []*NotificationDestination{
+			{ID: 1, Type: NotificationWebhook, Webhook: &WebhookDestination{URL: "https://example.com/hook"}, Events: []NotificationEvent{EventRepoPush}},
+		})
+	})
+
+	hooks, _, err := client.RepoHooks.List(repo, &RepoHookListOptions{
+		SortOptions: SortOptions{Sort: "id", Direction: Descending},
+		ListOptions: ListOptions{PerPage: 10},
+	})
+	if err != nil {
+		t.Errorf("RepoHooks.List returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	want := []*RepoHook{{ID: 1, URL: "https://example.com/hook", Events: []NotificationEvent{EventRepoPush}}}
+	if !reflect.DeepEqual(hooks, want) {
+		t.Errorf("RepoHooks.List returned %+v, want %+v", hooks, want)
+	}
+}
+
+func TestRepoHooksService_List_notWebhook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestinations, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []*NotificationDestination{
+			{ID: 2, Type: NotificationSlack, Slack: &SlackDestination{WebhookURL: "https://hooks.example.com/x"}},
+		})
+	})
+
+	if _, _, err := client.RepoHooks.List(repo, nil); err == nil {
+		t.Error("got nil error for a non-webhook destination, want error")
+	}
+}
+
+func TestRepoHooksService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	repo := RepoSpec{URI: "r.com/x"}
+	hook := &RepoHook{URL: "https://example.com/hook", Secret: "s", Events: []NotificationEvent{EventBuildFailed}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestinationsCreate, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Type":"webhook","Webhook":{"URL":"https://example.com/hook","Secret":"s"},"Events":["build.failed"]}`+"\n")
+
+		writeJSON(w, &NotificationDestination{ID: 3, Type: NotificationWebhook, Webhook: &WebhookDestination{URL: "https://example.com/hook"}, Events: []NotificationEvent{EventBuildFailed}})
+	})
+
+	created, _, err := client.RepoHooks.Create(repo, hook)
+	if err != nil {
+		t.Errorf("RepoHooks.Create returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	want := &RepoHook{ID: 3, URL: "https://example.com/hook", Events: []NotificationEvent{EventBuildFailed}}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("RepoHooks.Create returned %+v, want %+v", created, want)
+	}
+}
+
+func TestRepoHooksService_Create_noURL(t *testing.T) {
+	_, _, err := NewClient(nil).RepoHooks.Create(RepoSpec{URI: "r.com/x"}, &RepoHook{})
+	if e, ok := err.(*ValidationError); !ok || e.Field != "URL" {
+		t.Errorf("got error %v, want a ValidationError for URL", err)
+	}
+}
+
+func TestRepoHooksService_Edit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hook := RepoHookSpec{Repo: RepoSpec{URI: "r.com/x"}, ID: 3}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestinationUpdate, hook.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			writeJSON(w, &NotificationDestination{ID: 3, Type: NotificationWebhook, Webhook: &WebhookDestination{URL: "https://example.com/hook"}})
+			return
+		}
+		called = true
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"ID":3,"Type":"webhook","Webhook":{"URL":"https://example.com/hook2"},"Disabled":true}`+"\n")
+
+		writeJSON(w, &NotificationDestination{ID: 3, Type: NotificationWebhook, Webhook: &WebhookDestination{URL: "https://example.com/hook2"}, Disabled: true})
+	})
+
+	updated, _, err := client.RepoHooks.Edit(hook, &RepoHook{ID: 3, URL: "https://example.com/hook2", Disabled: true})
+	if err != nil {
+		t.Errorf("RepoHooks.Edit returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	want := &RepoHook{ID: 3, URL: "https://example.com/hook2", Disabled: true}
+	if !reflect.DeepEqual(updated, want) {
+		t.Errorf("RepoHooks.Edit returned %+v, want %+v", updated, want)
+	}
+}
+
+func TestRepoHooksService_Ping(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hook := RepoHookSpec{Repo: RepoSpec{URI: "r.com/x"}, ID: 3}
+
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestination, hook.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		writeJSON(w, &NotificationDestination{ID: 3, Type: NotificationWebhook, Webhook: &WebhookDestination{URL: "https://example.com/hook"}})
+	})
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestinationTest, hook.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+	})
+
+	_, err := client.RepoHooks.Ping(hook)
+	if err != nil {
+		t.Errorf("RepoHooks.Ping returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestRepoHooksService_notWebhook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hook := RepoHookSpec{Repo: RepoSpec{URI: "r.com/x"}, ID: 2}
+
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestination, hook.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("got %s request for a non-webhook destination, want only GET", r.Method)
+		}
+		writeJSON(w, &NotificationDestination{ID: 2, Type: NotificationSlack, Slack: &SlackDestination{WebhookURL: "https://hooks.example.com/x"}})
+	})
+	mux.HandleFunc(urlPath(t, router.RepoNotificationDestinationTest, hook.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		t.Error("got ping for a non-webhook destination")
+	})
+
+	if _, _, err := client.RepoHooks.Edit(hook, &RepoHook{URL: "https://example.com/hook"}); err == nil {
+		t.Error("Edit: got nil error, want error")
+	}
+	if _, err := client.RepoHooks.Delete(hook); err == nil {
+		t.Error("Delete: got nil error, want error")
+	}
+	if _, err := client.RepoHooks.Ping(hook); err == nil {
+		t.Error("Ping: got nil error, want error")
+	}
+}
@@ -0,0 +1,238 @@
+package sourcegraph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// RepoResolveImportPathOptions specifies options for
+// ReposService.ResolveImportPath.
+type RepoResolveImportPathOptions struct {
+	// Lang is the language whose import path syntax ImportPath uses
+	// (e.g., "go", "java", or "javascript").
+	Lang string `url:",omitempty" json:",omitempty"`
+
+	// ImportPath is the language-specific import path to resolve
+	// (e.g., a Go import path, a Java package name, or an npm package
+	// name).
+	ImportPath string `url:",omitempty" json:",omitempty"`
+}
+
+// A ResolvedImportPath describes the repository (and the directory
+// within it) that an import path refers to.
+type ResolvedImportPath struct {
+	// Repo is the repository that contains the package.
+	Repo RepoSpec
+
+	// ImportPathRoot is the prefix of the import path that corresponds
+	// to the root of Repo.
+	ImportPathRoot string
+
+	// Dir is the directory in Repo that contains the package, relative
+	// to the repository root ("" or "." for the root itself).
+	Dir string `json:",omitempty"`
+}
+
+func (s *repositoriesService) ResolveImportPath(opt *RepoResolveImportPathOptions) (*ResolvedImportPath, Response, error) {
+	var resolved *ResolvedImportPath
+	resp, err := s.client.DoGet(router.ReposResolveImportPath, nil, opt, &resolved)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return resolved, resp, nil
+}
+
+// Package registries recognized by ParsePackageCoordinates.
+const (
+	RegistryNPM   = "npm"
+	RegistryPyPI  = "pypi"
+	RegistryMaven = "maven"
+)
+
+// PackageCoordinates identify a package (and optionally a version of
+// it) in a package registry.
+type PackageCoordinates struct {
+	// Registry is the package registry (e.g., "npm", "pypi", or
+	// "maven").
+	Registry string `url:",omitempty" json:",omitempty"`
+
+	// Name is the package name in the registry. For Maven packages, it
+	// is "groupId:artifactId".
+	Name string `url:",omitempty" json:",omitempty"`
+
+	// Version is the package version. If empty, all versions match.
+	Version string `url:",omitempty" json:",omitempty"`
+}
+
+// String returns the coordinates in the registry's conventional
+// notation (the inverse of ParsePackageCoordinates).
+func (c PackageCoordinates) String() string {
+	if c.Version == "" {
+		return c.Name
+	}
+	switch c.Registry {
+	case RegistryPyPI:
+		return c.Name + "==" + c.Version
+	case RegistryMaven:
+		return c.Name + ":" + c.Version
+	default:
+		return c.Name + "@" + c.Version
+	}
+}
+
+// ParsePackageCoordinates parses coordinates written in the
+// registry's conventional notation: "name@version" (or
+// "@scope/name@version") for npm, "name==version" for PyPI, and
+// "groupId:artifactId:version" for Maven. The version is optional.
+func ParsePackageCoordinates(registry, s string) (PackageCoordinates, error) {
+	c := PackageCoordinates{Registry: registry}
+	switch registry {
+	case RegistryNPM:
+		// Skip a leading '@' (which denotes a scoped package name).
+		if i := strings.LastIndex(s, "@"); i > 0 {
+			c.Name, c.Version = s[:i], s[i+1:]
+		} else {
+			c.Name = s
+		}
+	case RegistryPyPI:
+		if i := strings.Index(s, "=="); i != -1 {
+			c.Name, c.Version = s[:i], s[i+len("=="):]
+		} else {
+			c.Name = s
+		}
+	case RegistryMaven:
+		parts := strings.Split(s, ":")
+		switch len(parts) {
+		case 2:
+			c.Name = s
+		case 3:
+			c.Name, c.Version = parts[0]+":"+parts[1], parts[2]
+		default:
+			return c, fmt.Errorf("invalid Maven coordinates %q (want groupId:artifactId[:version])", s)
+		}
+	default:
+		return c, fmt.Errorf("unrecognized package registry %q", registry)
+	}
+	if c.Name == "" {
+		return c, fmt.Errorf("invalid %s package coordinates %q: empty name", registry, s)
+	}
+	return c, nil
+}
+
+// RepoResolvePackageOptions specifies options for
+// ReposService.ResolvePackage.
+type RepoResolvePackageOptions struct {
+	PackageCoordinates
+}
+
+// A ResolvedPackage is a repository (and revision) that a package's
+// registry coordinates refer to.
+type ResolvedPackage struct {
+	// Repo is the repository that the package is built from.
+	Repo RepoSpec
+
+	// Rev is the tag in Repo that corresponds to the package version,
+	// if a version was specified and a matching tag was found.
+	Rev string `json:",omitempty"`
+
+	// CommitID is the commit ID that Rev refers to.
+	CommitID string `json:",omitempty"`
+}
+
+func (s *repositoriesService) ResolvePackage(opt *RepoResolvePackageOptions) ([]*ResolvedPackage, Response, error) {
+	var pkgs []*ResolvedPackage
+	resp, err := s.client.DoList(router.ReposResolvePackage, nil, opt, &pkgs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return pkgs, resp, nil
+}
+
+// A GoImport is a parsed go-import meta tag, which maps a Go import
+// path prefix to the VCS repository that contains it. See `go help
+// importpath` for more information.
+type GoImport struct {
+	Prefix, VCS, RepoRoot string
+}
+
+// RepoSpec returns the RepoSpec for the repository at the go-import
+// meta tag's repo root URL (e.g., "https://github.com/a/b.git"
+// yields a RepoSpec with URI "github.com/a/b").
+func (g GoImport) RepoSpec() RepoSpec {
+	uri := g.RepoRoot
+	if i := strings.Index(uri, "://"); i != -1 {
+		uri = uri[i+len("://"):]
+	}
+	uri = strings.TrimSuffix(strings.TrimSuffix(uri, "/"), "."+g.VCS)
+	return RepoSpec{URI: uri}
+}
+
+// ParseGoImportMeta parses the go-import meta tags in the HTML
+// document read from r (such as the response to a "?go-get=1"
+// request). Parsing stops at the end of the document's <head>.
+func ParseGoImportMeta(r io.Reader) ([]GoImport, error) {
+	d := xml.NewDecoder(r)
+	d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// Only the ASCII meta tags matter, so pass all charsets
+		// through unchanged.
+		return input, nil
+	}
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	var imports []GoImport
+	for {
+		t, err := d.RawToken()
+		if err != nil {
+			if err == io.EOF || len(imports) > 0 {
+				return imports, nil
+			}
+			return nil, err
+		}
+		if e, ok := t.(xml.StartElement); ok && strings.EqualFold(e.Name.Local, "body") {
+			return imports, nil
+		}
+		if e, ok := t.(xml.EndElement); ok && strings.EqualFold(e.Name.Local, "head") {
+			return imports, nil
+		}
+		e, ok := t.(xml.StartElement)
+		if !ok || !strings.EqualFold(e.Name.Local, "meta") {
+			continue
+		}
+		if attrValue(e.Attr, "name") != "go-import" {
+			continue
+		}
+		if f := strings.Fields(attrValue(e.Attr, "content")); len(f) == 3 {
+			imports = append(imports, GoImport{Prefix: f[0], VCS: f[1], RepoRoot: f[2]})
+		}
+	}
+}
+
+// MatchGoImport returns the go-import entry whose prefix matches
+// importPath, or false if there is none.
+func MatchGoImport(imports []GoImport, importPath string) (GoImport, bool) {
+	for _, g := range imports {
+		if importPath == g.Prefix || strings.HasPrefix(importPath, g.Prefix+"/") {
+			return g, true
+		}
+	}
+	return GoImport{}, false
+}
+
+// attrValue returns the value of the named attribute in attrs, or ""
+// if there is no such attribute.
+func attrValue(attrs []xml.Attr, name string) string {
+	for _, a := range attrs {
+		if strings.EqualFold(a.Name.Local, name) {
+			return a.Value
+		}
+	}
+	return ""
+}
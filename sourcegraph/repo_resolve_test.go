@@ -0,0 +1,141 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestReposService_ResolveImportPath(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &ResolvedImportPath{Repo: RepoSpec{URI: "github.com/a/b"}, ImportPathRoot: "example.com/b", Dir: "c"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.ReposResolveImportPath, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Lang": "go", "ImportPath": "example.com/b/c"})
+
+		writeJSON(w, want)
+	})
+
+	resolved, _, err := client.Repos.ResolveImportPath(&RepoResolveImportPathOptions{Lang: "go", ImportPath: "example.com/b/c"})
+	if err != nil {
+		t.Errorf("Repos.ResolveImportPath returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(resolved, want) {
+		t.Errorf("Repos.ResolveImportPath returned %+v, want %+v", resolved, want)
+	}
+}
+
+func TestReposService_ResolvePackage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*ResolvedPackage{{Repo: RepoSpec{URI: "github.com/a/b"}, Rev: "v1.2.3", CommitID: "c"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.ReposResolvePackage, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Registry": "npm", "Name": "b", "Version": "1.2.3"})
+
+		writeJSON(w, want)
+	})
+
+	pkgs, _, err := client.Repos.ResolvePackage(&RepoResolvePackageOptions{PackageCoordinates{Registry: "npm", Name: "b", Version: "1.2.3"}})
+	if err != nil {
+		t.Errorf("Repos.ResolvePackage returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(pkgs, want) {
+		t.Errorf("Repos.ResolvePackage returned %+v, want %+v", pkgs, want)
+	}
+}
+
+func TestParsePackageCoordinates(t *testing.T) {
+	tests := []struct {
+		registry, str string
+		want          PackageCoordinates
+		wantErr       bool
+	}{
+		{registry: "npm", str: "a", want: PackageCoordinates{Registry: "npm", Name: "a"}},
+		{registry: "npm", str: "a@1.0", want: PackageCoordinates{Registry: "npm", Name: "a", Version: "1.0"}},
+		{registry: "npm", str: "@s/a@1.0", want: PackageCoordinates{Registry: "npm", Name: "@s/a", Version: "1.0"}},
+		{registry: "npm", str: "@s/a", want: PackageCoordinates{Registry: "npm", Name: "@s/a"}},
+		{registry: "pypi", str: "a==1.0", want: PackageCoordinates{Registry: "pypi", Name: "a", Version: "1.0"}},
+		{registry: "maven", str: "g:a:1.0", want: PackageCoordinates{Registry: "maven", Name: "g:a", Version: "1.0"}},
+		{registry: "maven", str: "g:a", want: PackageCoordinates{Registry: "maven", Name: "g:a"}},
+		{registry: "maven", str: "g", wantErr: true},
+		{registry: "pypi", str: "==1.0", wantErr: true},
+		{registry: "x", str: "a", wantErr: true},
+	}
+	for _, test := range tests {
+		c, err := ParsePackageCoordinates(test.registry, test.str)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s %q: got nil error, want error", test.registry, test.str)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %q: ParsePackageCoordinates failed: %s", test.registry, test.str, err)
+			continue
+		}
+		if c != test.want {
+			t.Errorf("%s %q: got %+v, want %+v", test.registry, test.str, c, test.want)
+		}
+		if str := c.String(); str != test.str {
+			t.Errorf("%+v: got String() %q, want %q", c, str, test.str)
+		}
+	}
+}
+
+func TestParseGoImportMeta(t *testing.T) {
+	const html = `<!DOCTYPE html>
+<html>
+<head>
+<meta http-equiv="Content-Type" content="text/html; charset=utf-8">
+<meta name="go-import" content="example.com/b git https://github.com/a/b.git">
+<meta name="go-source" content="example.com/b _ _ _">
+</head>
+<body>
+<meta name="go-import" content="example.com/ignored git https://github.com/a/ignored">
+</body>
+</html>`
+
+	imports, err := ParseGoImportMeta(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []GoImport{{Prefix: "example.com/b", VCS: "git", RepoRoot: "https://github.com/a/b.git"}}
+	if !reflect.DeepEqual(imports, want) {
+		t.Fatalf("got imports %+v, want %+v", imports, want)
+	}
+
+	g, ok := MatchGoImport(imports, "example.com/b/c")
+	if !ok {
+		t.Fatal("MatchGoImport: no match")
+	}
+	if spec := g.RepoSpec(); spec != (RepoSpec{URI: "github.com/a/b"}) {
+		t.Errorf("got RepoSpec %+v, want %+v", spec, RepoSpec{URI: "github.com/a/b"})
+	}
+
+	if _, ok := MatchGoImport(imports, "example.com/bb"); ok {
+		t.Error("MatchGoImport: got match for example.com/bb, want no match")
+	}
+}
@@ -1,31 +1,78 @@
 package sourcegraph
 
 import (
+	"fmt"
+
 	"github.com/sourcegraph/go-github/github"
 	"github.com/fossas/go-sourcegraph/router"
 )
 
+// RepoStatusesService communicates with the Sourcegraph API endpoints
+// that report and read the statuses of a repository's commits (such
+// as the results of CI builds).
+type RepoStatusesService interface {
+	// GetCombined fetches the combined status of a commit, which
+	// summarizes the latest status for each context.
+	GetCombined(rev RepoRevSpec) (*CombinedStatus, Response, error)
+
+	// Create creates a status for a commit. The status's State must be
+	// one of the StatusState constants.
+	Create(rev RepoRevSpec, status *RepoStatus) (*RepoStatus, Response, error)
+}
+
+// repoStatusesService implements RepoStatusesService.
+type repoStatusesService struct {
+	client *Client
+}
+
+var _ RepoStatusesService = &repoStatusesService{}
+
+// Commit status states (see RepoStatus).
+const (
+	StatusStatePending = "pending"
+	StatusStateSuccess = "success"
+	StatusStateFailure = "failure"
+	StatusStateError   = "error"
+)
+
+// A RepoStatus is the status of a commit reported by an external
+// system (such as a CI server), identified by its Context (such as
+// "ci/build"). Its TargetURL links to details about the status.
 type RepoStatus struct {
 	github.RepoStatus
 }
 
+// A CombinedStatus is the combined state of a commit's statuses (and
+// the latest status for each context). Its state is "failure" if any
+// status is failed or errored, "pending" if any status is pending (or
+// there are no statuses), and "success" otherwise.
 type CombinedStatus struct {
 	github.CombinedStatus
 }
 
 func (s *repositoriesService) GetCombinedStatus(spec RepoRevSpec) (*CombinedStatus, Response, error) {
-	url, err := s.client.URL(router.RepoCombinedStatus, spec.RouteVars(), nil)
+	var status CombinedStatus
+	resp, err := s.client.DoGet(router.RepoCombinedStatus, spec.RouteVars(), nil, &status)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
+	return &status, resp, nil
+}
+
+func (s *repositoriesService) CreateStatus(spec RepoRevSpec, st RepoStatus) (*RepoStatus, Response, error) {
+	var created RepoStatus
+	resp, err := s.client.DoCreate(router.RepoStatusCreate, spec.RouteVars(), st, &created)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
+	return &created, resp, nil
+}
+
+func (s *repoStatusesService) GetCombined(rev RepoRevSpec) (*CombinedStatus, Response, error) {
 	var status CombinedStatus
-	resp, err := s.client.Do(req, &status)
+	resp, err := s.client.DoGet(router.RepoCombinedStatus, rev.RouteVars(), nil, &status)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -33,22 +80,24 @@ func (s *repositoriesService) GetCombinedStatus(spec RepoRevSpec) (*CombinedStat
 	return &status, resp, nil
 }
 
-func (s *repositoriesService) CreateStatus(spec RepoRevSpec, st RepoStatus) (*RepoStatus, Response, error) {
-	url, err := s.client.URL(router.RepoStatusCreate, spec.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
+func (s *repoStatusesService) Create(rev RepoRevSpec, status *RepoStatus) (*RepoStatus, Response, error) {
+	var state string
+	if status.State != nil {
+		state = *status.State
 	}
-
-	req, err := s.client.NewRequest("POST", url.String(), st)
-	if err != nil {
-		return nil, nil, err
+	switch state {
+	case StatusStatePending, StatusStateSuccess, StatusStateFailure, StatusStateError:
+	default:
+		return nil, nil, &ValidationError{Field: "State", Problems: []string{fmt.Sprintf("unrecognized status state %q", state)}}
 	}
 
 	var created RepoStatus
-	resp, err := s.client.Do(req, &created)
+	resp, err := s.client.DoCreate(router.RepoStatusCreate, rev.RouteVars(), status, &created)
 	if err != nil {
 		return nil, resp, err
 	}
 
 	return &created, resp, nil
 }
+
+var _ RepoStatusesService = &MockRepoStatusesService{}
@@ -0,0 +1,37 @@
+// generated by gen-mocks; DO NOT EDIT
+
+package sourcegraph
+
+type MockRepoStatusesService struct {
+	GetCombined_ func(rev RepoRevSpec) (*CombinedStatus, Response, error)
+	Create_      func(rev RepoRevSpec, status *RepoStatus) (*RepoStatus, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockRepoStatusesService returns a new MockRepoStatusesService
+// that records calls to its methods.
+func NewMockRepoStatusesService() *MockRepoStatusesService {
+	return &MockRepoStatusesService{Calls: &MockCalls{}}
+}
+
+func (s MockRepoStatusesService) GetCombined(rev RepoRevSpec) (*CombinedStatus, Response, error) {
+	s.Calls.record("GetCombined", rev)
+	if s.GetCombined_ == nil {
+		var r0 *CombinedStatus
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoStatusesService.GetCombined")
+	}
+	return s.GetCombined_(rev)
+}
+
+func (s MockRepoStatusesService) Create(rev RepoRevSpec, status *RepoStatus) (*RepoStatus, Response, error) {
+	s.Calls.record("Create", rev, status)
+	if s.Create_ == nil {
+		var r0 *RepoStatus
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoStatusesService.Create")
+	}
+	return s.Create_(rev, status)
+}
@@ -73,3 +73,81 @@ func TestReposService_CreateStatus(t *testing.T) {
 		t.Errorf("Repos.CreateStatus returned %+v, want %+v", s, &want)
 	}
 }
+
+func TestRepoStatusesService_GetCombined(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &CombinedStatus{CombinedStatus: github.CombinedStatus{State: github.String(StatusStateSuccess)}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCombinedStatus, map[string]string{"RepoSpec": "r.com/x", "Rev": "r"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	cs, _, err := client.RepoStatuses.GetCombined(RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "r"})
+	if err != nil {
+		t.Errorf("RepoStatuses.GetCombined returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(cs, want) {
+		t.Errorf("RepoStatuses.GetCombined returned %+v, want %+v", cs, want)
+	}
+}
+
+func TestRepoStatusesService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &RepoStatus{RepoStatus: github.RepoStatus{
+		State:     github.String(StatusStatePending),
+		TargetURL: github.String("https://ci.example.com/1"),
+		Context:   github.String("ci/build"),
+	}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoStatusCreate, map[string]string{"RepoSpec": "r.com/x", "Rev": "r"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+
+		var st RepoStatus
+		if err := json.NewDecoder(r.Body).Decode(&st); err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(&st, want) {
+			t.Errorf("got status %+v, want %+v", st, want)
+		}
+
+		writeJSON(w, want)
+	})
+
+	s, _, err := client.RepoStatuses.Create(RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "r"}, want)
+	if err != nil {
+		t.Errorf("RepoStatuses.Create returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("RepoStatuses.Create returned %+v, want %+v", s, want)
+	}
+}
+
+func TestRepoStatusesService_Create_invalidState(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, _, err := client.RepoStatuses.Create(RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "r"}, &RepoStatus{RepoStatus: github.RepoStatus{State: github.String("done")}})
+	if verr, ok := err.(*ValidationError); !ok || verr.Field != "State" {
+		t.Errorf("got error %v, want ValidationError for field State", err)
+	}
+}
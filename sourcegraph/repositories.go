@@ -3,12 +3,15 @@ package sourcegraph
 import (
 	"errors"
 	"fmt"
+	"io"
+	"path"
 	"text/template"
 
 	"sourcegraph.com/sourcegraph/go-nnz/nnz"
 	"sourcegraph.com/sourcegraph/go-vcs/vcs"
 	"sourcegraph.com/sourcegraph/vcsstore/vcsclient"
 
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -21,6 +24,14 @@ type ReposService interface {
 	// Get fetches a repository.
 	Get(repo RepoSpec, opt *RepoGetOptions) (*Repo, Response, error)
 
+	// GetMulti fetches multiple repositories in a single request. The
+	// returned slice has an element for each element of repos (which
+	// is nil if the repository doesn't exist). If the server doesn't
+	// support batch requests, the repositories are fetched with
+	// concurrent Get requests (at most DefaultBatchConcurrency at
+	// once).
+	GetMulti(repos []RepoSpec, opt *RepoGetOptions) ([]*Repo, Response, error)
+
 	// GetStats gets statistics about a repository at a specific
 	// commit. Some statistics are per-commit and some are global to
 	// the repository. If you only care about global repository
@@ -28,7 +39,15 @@ type ReposService interface {
 	// resolved to the repository's default branch).
 	GetStats(repo RepoRevSpec) (RepoStats, Response, error)
 
+	// GetStatsSummary gets a summary of a repository at a specific
+	// commit: its statistics (as returned by GetStats) along with its
+	// language breakdown, ref and contributor counts, and latest
+	// build, for dashboards that would otherwise need several
+	// requests per repository.
+	GetStatsSummary(repo RepoRevSpec) (*RepoStatsSummary, Response, error)
+
 	// CreateStatus creates a repository status for the given commit.
+	// (See also RepoStatusesService, which validates the status.)
 	CreateStatus(spec RepoRevSpec, st RepoStatus) (*RepoStatus, Response, error)
 
 	// GetCombinedStatus fetches the combined repository status for
@@ -45,29 +64,42 @@ type ReposService interface {
 	GetSettings(repo RepoSpec) (*RepoSettings, Response, error)
 
 	// UpdateSettings updates a repository's configuration settings.
+	// Only the settings that are non-nil in settings are changed.
 	UpdateSettings(repo RepoSpec, settings RepoSettings) (Response, error)
 
+	// Enable enables a repository for use on Sourcegraph (so that it
+	// is indexed and built). It is equivalent to calling
+	// UpdateSettings with Enabled set to true.
+	Enable(repo RepoSpec) (Response, error)
+
+	// Disable disables a repository. It is equivalent to calling
+	// UpdateSettings with Enabled set to false.
+	Disable(repo RepoSpec) (Response, error)
+
 	// RefreshProfile updates the repository metadata for a repository, fetching
 	// it from an external host if the host is recognized (such as GitHub).
 	//
 	// This operation is performed asynchronously on the server side (after
-	// receiving the request) and the API currently has no way of notifying
-	// callers when the operation completes.
+	// receiving the request). If the server tracks the operation, it
+	// responds with 202 Accepted; pass the response to
+	// (*Client).Operation to wait for the operation to complete.
 	RefreshProfile(repo RepoSpec) (Response, error)
 
 	// RefreshVCSData updates the repository VCS (git/hg) data, fetching all new
 	// commits, branches, tags, and blobs.
 	//
 	// This operation is performed asynchronously on the server side (after
-	// receiving the request) and the API currently has no way of notifying
-	// callers when the operation completes.
+	// receiving the request). If the server tracks the operation, it
+	// responds with 202 Accepted; pass the response to
+	// (*Client).Operation to wait for the operation to complete.
 	RefreshVCSData(repo RepoSpec) (Response, error)
 
 	// ComputeStats updates the statistics about a repository.
 	//
 	// This operation is performed asynchronously on the server side (after
-	// receiving the request) and the API currently has no way of notifying
-	// callers when the operation completes.
+	// receiving the request). If the server tracks the operation, it
+	// responds with 202 Accepted; pass the response to
+	// (*Client).Operation to wait for the operation to complete.
 	ComputeStats(repo RepoRevSpec) (Response, error)
 
 	// GetBuild gets the build for a specific revspec. It returns
@@ -80,30 +112,46 @@ type ReposService interface {
 	// the repository that can be inferred from the URL (or, for GitHub
 	// repositories, fetched from the GitHub API). If a repository with the
 	// specified clone URL, or the same URI, already exists, it is returned.
+	//
+	// If newRepoSpec has no clone URL, a new repository hosted on
+	// Sourcegraph is created with the specified URI instead. A
+	// mirror's VCS data is fetched asynchronously after it is created
+	// (and may be refreshed later with RefreshVCSData).
 	Create(newRepoSpec NewRepoSpec) (*Repo, Response, error)
 
 	// GetReadme fetches the formatted README file for a repository.
 	GetReadme(repo RepoRevSpec) (*vcsclient.TreeEntry, Response, error)
 
+	// GetArchive downloads an archive of a repository's files at a
+	// revision, in the given format. Large archives may be downloaded
+	// directly from object storage (see SignedURL). Callers must close
+	// the returned reader.
+	GetArchive(rev RepoRevSpec, format ArchiveFormat, opt *RepoGetArchiveOptions) (io.ReadCloser, Response, error)
+
 	// List repositories.
 	List(opt *RepoListOptions) ([]*Repo, Response, error)
 
 	// List commits.
 	ListCommits(repo RepoSpec, opt *RepoListCommitsOptions) ([]*Commit, Response, error)
 
-	// GetCommit gets a commit.
+	// GetCommit gets a commit. (See also RepoCommitsService, which
+	// can also compare commits.)
 	GetCommit(rev RepoRevSpec, opt *RepoGetCommitOptions) (*Commit, Response, error)
 
-	// ListBranches lists a repository's branches.
+	// ListBranches lists a repository's branches. The branches' head
+	// commits and their behind/ahead counts relative to the default
+	// branch are included if requested in opt.
 	ListBranches(repo RepoSpec, opt *RepoListBranchesOptions) ([]*vcs.Branch, Response, error)
 
-	// ListTags lists a repository's tags.
-	ListTags(repo RepoSpec, opt *RepoListTagsOptions) ([]*vcs.Tag, Response, error)
+	// ListTags lists a repository's tags. The commits that the tags
+	// point to are included if requested in opt.
+	ListTags(repo RepoSpec, opt *RepoListTagsOptions) ([]*Tag, Response, error)
 
 	// ListBadges lists the available badges for repo.
 	ListBadges(repo RepoSpec) ([]*Badge, Response, error)
 
-	// ListCounters lists the available counters for repo.
+	// ListCounters lists the available counters for repo. (See also
+	// RepoBadgesService, which can also record counter hits.)
 	ListCounters(repo RepoSpec) ([]*Counter, Response, error)
 
 	// ListAuthors lists people who have contributed (i.e., committed) code to
@@ -120,6 +168,11 @@ type ReposService interface {
 	// ListDependents lists repositories that reference defs defined in repo.
 	ListDependents(repo RepoSpec, opt *RepoListDependentsOptions) ([]*AugmentedRepoDependent, Response, error)
 
+	// ListByOwner lists the repositories owned by a user or an
+	// organization, filtered on the server (see
+	// RepoListByOwnerOptions).
+	ListByOwner(owner RepoOwnerSpec, opt *RepoListByOwnerOptions) ([]*Repo, Response, error)
+
 	// ListByContributor lists repositories that user has contributed (i.e.,
 	// committed) code to.
 	ListByContributor(user UserSpec, opt *RepoListByContributorOptions) ([]*AugmentedRepoContribution, Response, error)
@@ -131,6 +184,40 @@ type ReposService interface {
 	// ListByRefdAuthor lists repositories that reference code authored by
 	// user.
 	ListByRefdAuthor(user UserSpec, opt *RepoListByRefdAuthorOptions) ([]*AugmentedRepoUsageOfAuthor, Response, error)
+
+	// ResolveImportPath resolves a language-specific import path (such
+	// as a Go import path, Java package name, or npm package name) to
+	// the repository known to the server that contains it. For Go
+	// import paths, go-import meta tags are consulted (see
+	// ParseGoImportMeta).
+	ResolveImportPath(opt *RepoResolveImportPathOptions) (*ResolvedImportPath, Response, error)
+
+	// ResolvePackage lists the repositories (and, if a version is
+	// given, the revisions matched via tags) that correspond to a
+	// package's registry coordinates (npm name@version, PyPI
+	// name==version, or Maven groupId:artifactId:version).
+	ResolvePackage(opt *RepoResolvePackageOptions) ([]*ResolvedPackage, Response, error)
+
+	// ListCollaborators lists the people who have been granted access
+	// to a repository, and their permissions. Only repository admins
+	// may list collaborators.
+	ListCollaborators(repo RepoSpec, opt *RepoListCollaboratorsOptions) ([]*RepoCollaborator, Response, error)
+
+	// AddCollaborator grants a person the given permission on a
+	// repository. If the person is already a collaborator, their
+	// permission is changed to perm. Only repository admins may add
+	// collaborators.
+	AddCollaborator(repo RepoSpec, person PersonSpec, perm Permission) (Response, error)
+
+	// RemoveCollaborator revokes a collaborator's access to a
+	// repository. Access that the person has for other reasons (e.g.,
+	// because the repository is public) is not affected.
+	RemoveCollaborator(repo RepoSpec, person PersonSpec) (Response, error)
+
+	// GetPermission returns a person's effective permission on a
+	// repository (including access that isn't granted by being a
+	// collaborator, such as read access to a public repository).
+	GetPermission(repo RepoSpec, person PersonSpec) (Permission, Response, error)
 }
 
 // repositoriesService implements ReposService.
@@ -146,17 +233,24 @@ type RepoSpec struct {
 	RID int
 }
 
+// repoSpecReservedChars are the characters that are escaped in a
+// RepoSpec's path component because they are not permitted by
+// router.RepoSpecPathPattern.
+const repoSpecReservedChars = "@"
+
 // PathComponent returns the URL path component that specifies the
-// repository.
+// repository. Characters in the URI that are not permitted in the
+// repository route pattern (such as "@") are escaped; ParseRepoSpec
+// unescapes them.
 func (s RepoSpec) PathComponent() string {
 	if s.RID > 0 {
 		return "R$" + strconv.Itoa(s.RID)
 	}
 	if s.URI != "" {
 		if strings.HasPrefix("sourcegraph.com/", s.URI) {
-			return s.URI[len("sourcegraph.com/"):]
+			return escapeRouteVar(s.URI[len("sourcegraph.com/"):], repoSpecReservedChars)
 		} else {
-			return s.URI
+			return escapeRouteVar(s.URI, repoSpecReservedChars)
 		}
 	}
 	panic("empty RepoSpec")
@@ -180,11 +274,12 @@ func ParseRepoSpec(pathComponent string) (RepoSpec, error) {
 		return RepoSpec{RID: rid}, err
 	}
 
-	var uri string
-	if strings.HasPrefix(pathComponent, "sourcegraph/") {
-		uri = "sourcegraph.com/" + pathComponent
-	} else {
-		uri = pathComponent
+	uri, err := unescapeRouteVar(pathComponent)
+	if err != nil {
+		return RepoSpec{}, err
+	}
+	if strings.HasPrefix(uri, "sourcegraph/") {
+		uri = "sourcegraph.com/" + uri
 	}
 
 	return RepoSpec{URI: uri}, nil
@@ -239,6 +334,12 @@ type RepoRevSpec struct {
 
 const repoRevSpecCommitSep = "==="
 
+// revReservedChars are the characters that are escaped in the Rev and
+// CommitID of a RepoRevSpec's path component, because they would be
+// confused with repoRevSpecCommitSep or with the separator between
+// the repository and revision of a cross-repository DeltaSpec.
+const revReservedChars = "=:"
+
 // RouteVars returns route variables for constructing routes to a
 // repository commit.
 func (s RepoRevSpec) RouteVars() map[string]string {
@@ -250,15 +351,17 @@ func (s RepoRevSpec) RouteVars() map[string]string {
 // RevPathComponent encodes the revision and commit ID for use in a
 // URL path. If CommitID is set, the path component is
 // "Rev===CommitID"; otherwise, it is just "Rev". See the docstring
-// for RepoRevSpec for an explanation why.
+// for RepoRevSpec for an explanation why. Characters in Rev and
+// CommitID that would make the path component ambiguous (such as "="
+// and ":") are escaped; UnmarshalRepoRevSpec unescapes them.
 func (s RepoRevSpec) RevPathComponent() string {
 	if s.Rev == "" && s.CommitID != "" {
 		panic("invalid empty Rev but non-empty CommitID (" + s.CommitID + ")")
 	}
 	if s.CommitID != "" {
-		return s.Rev + repoRevSpecCommitSep + s.CommitID
+		return escapeRouteVar(s.Rev, revReservedChars) + repoRevSpecCommitSep + escapeRouteVar(s.CommitID, revReservedChars)
 	}
-	return s.Rev
+	return escapeRouteVar(s.Rev, revReservedChars)
 }
 
 // UnmarshalRepoRevSpec marshals a map containing route variables
@@ -273,10 +376,15 @@ func UnmarshalRepoRevSpec(routeVars map[string]string) (RepoRevSpec, error) {
 	repoRevSpec := RepoRevSpec{RepoSpec: repoSpec}
 	revStr := routeVars["Rev"]
 	if i := strings.Index(revStr, repoRevSpecCommitSep); i == -1 {
-		repoRevSpec.Rev = revStr
+		repoRevSpec.Rev, err = unescapeRouteVar(revStr)
 	} else {
-		repoRevSpec.Rev = revStr[:i]
-		repoRevSpec.CommitID = revStr[i+len(repoRevSpecCommitSep):]
+		repoRevSpec.Rev, err = unescapeRouteVar(revStr[:i])
+		if err == nil {
+			repoRevSpec.CommitID, err = unescapeRouteVar(revStr[i+len(repoRevSpecCommitSep):])
+		}
+	}
+	if err != nil {
+		return RepoRevSpec{}, err
 	}
 
 	if repoRevSpec.Rev == "" && repoRevSpec.CommitID != "" {
@@ -292,18 +400,8 @@ type RepoGetOptions struct {
 }
 
 func (s *repositoriesService) Get(repo RepoSpec, opt *RepoGetOptions) (*Repo, Response, error) {
-	url, err := s.client.URL(router.Repo, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var repo_ *Repo
-	resp, err := s.client.Do(req, &repo_)
+	resp, err := s.client.getCached(specCacheRepos, router.Repo, repo.RouteVars(), opt, &repo_)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -311,19 +409,47 @@ func (s *repositoriesService) Get(repo RepoSpec, opt *RepoGetOptions) (*Repo, Re
 	return repo_, resp, nil
 }
 
-func (s *repositoriesService) GetStats(repoRev RepoRevSpec) (RepoStats, Response, error) {
-	url, err := s.client.URL(router.RepoStats, repoRev.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
+// reposBatchRequest is the body of a ReposBatch request.
+type reposBatchRequest struct {
+	Repos   []RepoSpec
+	Options *RepoGetOptions `json:",omitempty"`
+}
+
+func (s *repositoriesService) GetMulti(repos []RepoSpec, opt *RepoGetOptions) ([]*Repo, Response, error) {
+	if len(repos) == 0 {
+		return nil, nil, nil
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
+	var repos_ []*Repo
+	resp, err := s.client.DoCreate(router.ReposBatch, nil, &reposBatchRequest{Repos: repos, Options: opt}, &repos_)
+	if err == nil {
+		if len(repos_) != len(repos) {
+			return nil, resp, fmt.Errorf("ReposService.GetMulti: got %d repos, want %d", len(repos_), len(repos))
+		}
+		return repos_, resp, nil
+	}
+	if !IsNotSupported(err) {
+		return nil, resp, err
+	}
+
+	repos_ = make([]*Repo, len(repos))
+	resp, err = fanOut(len(repos), 0, func(i int) (Response, error) {
+		repo, resp, err := s.Get(repos[i], opt)
+		if IsNotFound(err) {
+			return resp, nil
+		}
+		repos_[i] = repo
+		return resp, err
+	})
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
+	return repos_, nil, nil
+}
 
+func (s *repositoriesService) GetStats(repoRev RepoRevSpec) (RepoStats, Response, error) {
 	var stats RepoStats
-	resp, err := s.client.Do(req, &stats)
+	resp, err := s.client.DoGet(router.RepoStats, repoRev.RouteVars(), nil, &stats)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -331,6 +457,38 @@ func (s *repositoriesService) GetStats(repoRev RepoRevSpec) (RepoStats, Response
 	return stats, resp, nil
 }
 
+// RepoStatsSummary summarizes a repository at a commit (see
+// ReposService.GetStatsSummary).
+type RepoStatsSummary struct {
+	// Languages is the number of bytes of source code in each language
+	// (e.g., "Go").
+	Languages map[string]int64 `json:",omitempty"`
+
+	// Stats are the repository's statistics, including its def counts
+	// (RepoStatDefs and RepoStatExportedDefs).
+	Stats RepoStats `json:",omitempty"`
+
+	// Refs is the number of refs in the repository at the commit.
+	Refs int
+
+	// Contributors is the number of people who authored commits in the
+	// repository's history (up to the commit).
+	Contributors int
+
+	// LastBuild is the most recent build of the commit, if any.
+	LastBuild *Build `json:",omitempty"`
+}
+
+func (s *repositoriesService) GetStatsSummary(repoRev RepoRevSpec) (*RepoStatsSummary, Response, error) {
+	var summary *RepoStatsSummary
+	resp, err := s.client.DoGet(router.RepoStatsSummary, repoRev.RouteVars(), nil, &summary)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return summary, resp, nil
+}
+
 func (s *repositoriesService) GetOrCreate(repo_ RepoSpec, opt *RepoGetOptions) (*Repo, Response, error) {
 	url, err := s.client.URL(router.ReposGetOrCreate, repo_.RouteVars(), opt)
 	if err != nil {
@@ -389,21 +547,15 @@ type RepoSettings struct {
 	// authorized key. It is only necessary for private repositories
 	// and for write operations on public repositories.
 	UseSSHPrivateKey *bool `db:"use_ssh_private_key" json:",omitempty"`
+
+	// DefaultBranch, if set, overrides the repository's default branch
+	// (which is otherwise the default branch of its origin).
+	DefaultBranch *string `db:"default_branch" json:",omitempty"`
 }
 
 func (s *repositoriesService) GetSettings(repo RepoSpec) (*RepoSettings, Response, error) {
-	url, err := s.client.URL(router.RepoSettings, repo.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var settings *RepoSettings
-	resp, err := s.client.Do(req, &settings)
+	resp, err := s.client.DoGet(router.RepoSettings, repo.RouteVars(), nil, &settings)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -412,17 +564,7 @@ func (s *repositoriesService) GetSettings(repo RepoSpec) (*RepoSettings, Respons
 }
 
 func (s *repositoriesService) UpdateSettings(repo RepoSpec, settings RepoSettings) (Response, error) {
-	url, err := s.client.URL(router.RepoSettingsUpdate, repo.RouteVars(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest("PUT", url.String(), settings)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.DoUpdate(router.RepoSettingsUpdate, repo.RouteVars(), settings, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -430,18 +572,18 @@ func (s *repositoriesService) UpdateSettings(repo RepoSpec, settings RepoSetting
 	return resp, nil
 }
 
-func (s *repositoriesService) RefreshProfile(repo RepoSpec) (Response, error) {
-	url, err := s.client.URL(router.RepoRefreshProfile, repo.RouteVars(), nil)
-	if err != nil {
-		return nil, err
-	}
+func (s *repositoriesService) Enable(repo RepoSpec) (Response, error) {
+	enabled := true
+	return s.UpdateSettings(repo, RepoSettings{Enabled: &enabled})
+}
 
-	req, err := s.client.NewRequest("PUT", url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+func (s *repositoriesService) Disable(repo RepoSpec) (Response, error) {
+	enabled := false
+	return s.UpdateSettings(repo, RepoSettings{Enabled: &enabled})
+}
 
-	resp, err := s.client.Do(req, nil)
+func (s *repositoriesService) RefreshProfile(repo RepoSpec) (Response, error) {
+	resp, err := s.client.DoUpdate(router.RepoRefreshProfile, repo.RouteVars(), nil, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -450,17 +592,7 @@ func (s *repositoriesService) RefreshProfile(repo RepoSpec) (Response, error) {
 }
 
 func (s *repositoriesService) RefreshVCSData(repo RepoSpec) (Response, error) {
-	url, err := s.client.URL(router.RepoRefreshVCSData, repo.RouteVars(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest("PUT", url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.DoUpdate(router.RepoRefreshVCSData, repo.RouteVars(), nil, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -469,17 +601,7 @@ func (s *repositoriesService) RefreshVCSData(repo RepoSpec) (Response, error) {
 }
 
 func (s *repositoriesService) ComputeStats(repo RepoRevSpec) (Response, error) {
-	url, err := s.client.URL(router.RepoComputeStats, repo.RouteVars(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest("PUT", url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.DoUpdate(router.RepoComputeStats, repo.RouteVars(), nil, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -522,18 +644,8 @@ type RepoBuildInfo struct {
 }
 
 func (s *repositoriesService) GetBuild(repo RepoRevSpec, opt *RepoGetBuildOptions) (*RepoBuildInfo, Response, error) {
-	url, err := s.client.URL(router.RepoBuild, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var info *RepoBuildInfo
-	resp, err := s.client.Do(req, &info)
+	resp, err := s.client.DoGet(router.RepoBuild, repo.RouteVars(), opt, &info)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -541,24 +653,56 @@ func (s *repositoriesService) GetBuild(repo RepoRevSpec, opt *RepoGetBuildOption
 	return info, resp, nil
 }
 
+// NewRepoSpec specifies a repository to create with
+// ReposService.Create: either a mirror of an external repository (if
+// CloneURLStr is set) or a repository hosted on Sourcegraph.
 type NewRepoSpec struct {
 	Type        string
 	CloneURLStr string `json:"CloneURL"`
+
+	// URI is the URI of the new repository (such as
+	// "sourcegraph.com/o/r"). It is required for hosted repositories;
+	// for mirrors, it is inferred from the clone URL if empty.
+	URI string `json:",omitempty"`
+
+	Description string `json:",omitempty"`
+
+	// Private is whether the repository is only visible to its owners
+	// (and site admins).
+	Private bool `json:",omitempty"`
+
+	// DefaultBranch is the default branch of a new hosted repository.
+	// If empty, "master" is used.
+	DefaultBranch string `json:",omitempty"`
 }
 
-func (s *repositoriesService) Create(newRepoSpec NewRepoSpec) (*Repo, Response, error) {
-	url, err := s.client.URL(router.ReposCreate, nil, nil)
-	if err != nil {
-		return nil, nil, err
+// validate returns a *ValidationError if s doesn't specify a clone
+// URL or URI, or its clone URL is not an absolute URL.
+func (s NewRepoSpec) validate() error {
+	if s.CloneURLStr == "" {
+		if s.URI == "" {
+			return &ValidationError{Field: "URI", Problems: []string{"a URI is required for hosted repositories (those with no clone URL)"}}
+		}
+		return nil
+	}
+	// Allow scp-like SSH clone URLs (user@host:path), which aren't
+	// valid URLs.
+	if strings.Contains(s.CloneURLStr, "@") && !strings.Contains(s.CloneURLStr, "://") {
+		return nil
+	}
+	if u, err := url.Parse(s.CloneURLStr); err != nil || !u.IsAbs() || u.Host == "" {
+		return &ValidationError{Field: "CloneURL", Problems: []string{fmt.Sprintf("%q is not an absolute URL", s.CloneURLStr)}}
 	}
+	return nil
+}
 
-	req, err := s.client.NewRequest("POST", url.String(), newRepoSpec)
-	if err != nil {
+func (s *repositoriesService) Create(newRepoSpec NewRepoSpec) (*Repo, Response, error) {
+	if err := newRepoSpec.validate(); err != nil {
 		return nil, nil, err
 	}
 
 	var repo_ *Repo
-	resp, err := s.client.Do(req, &repo_)
+	resp, err := s.client.DoCreate(router.ReposCreate, nil, newRepoSpec, &repo_)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -567,23 +711,53 @@ func (s *repositoriesService) Create(newRepoSpec NewRepoSpec) (*Repo, Response,
 }
 
 func (s *repositoriesService) GetReadme(repo RepoRevSpec) (*vcsclient.TreeEntry, Response, error) {
-	url, err := s.client.URL(router.RepoReadme, repo.RouteVars(), nil)
+	var readme *vcsclient.TreeEntry
+	resp, err := s.client.DoGet(router.RepoReadme, repo.RouteVars(), nil, &readme)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
+	return readme, resp, nil
+}
+
+// An ArchiveFormat is a format of repository archive (see
+// ReposService.GetArchive).
+type ArchiveFormat string
+
+const (
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+	ArchiveZip   ArchiveFormat = "zip"
+)
+
+type RepoGetArchiveOptions struct {
+	// Path, if set, restricts the archive to the files in this
+	// directory (relative to the repository root).
+	Path string `url:",omitempty"`
+}
+
+func (s *repositoriesService) GetArchive(rev RepoRevSpec, format ArchiveFormat, opt *RepoGetArchiveOptions) (io.ReadCloser, Response, error) {
+	switch format {
+	case ArchiveTarGz, ArchiveZip:
+	default:
+		return nil, nil, &ValidationError{Field: "Format", Problems: []string{fmt.Sprintf("unrecognized archive format %q", format)}}
+	}
+	if opt != nil && path.IsAbs(opt.Path) {
+		return nil, nil, &ValidationError{Field: "Path", Problems: []string{"must be relative to the repository root"}}
+	}
+
+	v := rev.RouteVars()
+	v["Format"] = string(format)
+	url, err := s.client.URL(router.RepoArchive, v, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var readme *vcsclient.TreeEntry
-	resp, err := s.client.Do(req, &readme)
+	req, err := s.client.NewRequest("GET", url.String(), nil)
 	if err != nil {
-		return nil, resp, err
+		return nil, nil, err
 	}
 
-	return readme, resp, nil
+	return s.client.download(req, false)
 }
 
 type RepoListOptions struct {
@@ -609,22 +783,16 @@ type RepoListOptions struct {
 
 	Stats bool `url:",omitempty" json:",omitempty"` // whether to fetch and include stats in the returned repositories
 
+	// TimeRangeOptions restricts the list to repositories updated in
+	// the time range. To sync incrementally, also sort by "updated".
+	TimeRangeOptions
+
 	ListOptions
 }
 
 func (s *repositoriesService) List(opt *RepoListOptions) ([]*Repo, Response, error) {
-	url, err := s.client.URL(router.Repos, nil, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var repos []*Repo
-	resp, err := s.client.Do(req, &repos)
+	resp, err := s.client.DoList(router.Repos, nil, opt, &repos)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -634,27 +802,56 @@ func (s *repositoriesService) List(opt *RepoListOptions) ([]*Repo, Response, err
 
 type Commit struct {
 	*vcs.Commit
+
+	// Verification is the result of verifying the commit's signature.
+	// It is only set if verification was requested (e.g., with
+	// RepoListCommitsOptions.IncludeVerification).
+	Verification *Verification `json:",omitempty"`
+}
+
+// Reasons for the result of verifying a commit's signature (see
+// Verification.Reason).
+const (
+	VerificationValid           = "valid"
+	VerificationUnsigned        = "unsigned"
+	VerificationUnknownKey      = "unknown_key"
+	VerificationBadSignature    = "bad_signature"
+	VerificationExpiredKey      = "expired_key"
+	VerificationUnverifiedEmail = "unverified_email"
+)
+
+// A Verification is the result of verifying a commit's (GPG or S/MIME)
+// signature.
+type Verification struct {
+	// Verified is whether the commit is signed and the signature is
+	// valid and made by a key that belongs to the commit's author.
+	Verified bool
+
+	// Reason explains the result (e.g., VerificationValid or
+	// VerificationUnknownKey).
+	Reason string
+
+	// Signer is the identity of the key that made the signature (such
+	// as "Alice <alice@example.com>"), if known.
+	Signer string `json:",omitempty"`
 }
 
 type RepoListCommitsOptions struct {
 	Head string `url:",omitempty" json:",omitempty"`
 	Base string `url:",omitempty" json:",omitempty"`
+
+	// IncludeVerification is whether to verify each commit's signature
+	// (and include the result in its Verification field).
+	IncludeVerification bool `url:",omitempty" json:",omitempty"`
+
+	TimeRangeOptions
+	SortOptions
 	ListOptions
 }
 
 func (s *repositoriesService) ListCommits(repo RepoSpec, opt *RepoListCommitsOptions) ([]*Commit, Response, error) {
-	url, err := s.client.URL(router.RepoCommits, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var commits []*Commit
-	resp, err := s.client.Do(req, &commits)
+	resp, err := s.client.DoList(router.RepoCommits, repo.RouteVars(), opt, &commits)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -663,21 +860,14 @@ func (s *repositoriesService) ListCommits(repo RepoSpec, opt *RepoListCommitsOpt
 }
 
 type RepoGetCommitOptions struct {
+	// IncludeVerification is whether to verify the commit's signature
+	// (and include the result in its Verification field).
+	IncludeVerification bool `url:",omitempty" json:",omitempty"`
 }
 
 func (s *repositoriesService) GetCommit(rev RepoRevSpec, opt *RepoGetCommitOptions) (*Commit, Response, error) {
-	url, err := s.client.URL(router.RepoCommit, rev.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var commit *Commit
-	resp, err := s.client.Do(req, &commit)
+	resp, err := s.client.DoGet(router.RepoCommit, rev.RouteVars(), opt, &commit)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -685,23 +875,34 @@ func (s *repositoriesService) GetCommit(rev RepoRevSpec, opt *RepoGetCommitOptio
 	return commit, resp, nil
 }
 
+// Values for RepoListBranchesOptions.MergeState.
+const (
+	BranchMerged   = "merged"
+	BranchUnmerged = "unmerged"
+)
+
 type RepoListBranchesOptions struct {
+	// IncludeCommit is whether to include each branch's head commit
+	// (in its Commit field).
+	IncludeCommit bool `url:",omitempty" json:",omitempty"`
+
+	// IncludeCounts is whether to include the number of commits that
+	// each branch is behind and ahead of the repository's default
+	// branch (in its Counts field).
+	IncludeCounts bool `url:",omitempty" json:",omitempty"`
+
+	// MergeState, if set, restricts the list to branches that have
+	// (BranchMerged) or have not (BranchUnmerged) been merged into the
+	// repository's default branch.
+	MergeState string `url:",omitempty" json:",omitempty"`
+
+	SortOptions
 	ListOptions
 }
 
 func (s *repositoriesService) ListBranches(repo RepoSpec, opt *RepoListBranchesOptions) ([]*vcs.Branch, Response, error) {
-	url, err := s.client.URL(router.RepoBranches, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var branches []*vcs.Branch
-	resp, err := s.client.Do(req, &branches)
+	resp, err := s.client.DoList(router.RepoBranches, repo.RouteVars(), opt, &branches)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -709,23 +910,27 @@ func (s *repositoriesService) ListBranches(repo RepoSpec, opt *RepoListBranchesO
 	return branches, resp, nil
 }
 
-type RepoListTagsOptions struct {
-	ListOptions
+// A Tag is a repository tag.
+type Tag struct {
+	vcs.Tag
+
+	// Commit is the commit that the tag points to. It is only set if
+	// RepoListTagsOptions.IncludeCommit is true.
+	Commit *vcs.Commit `json:",omitempty"`
 }
 
-func (s *repositoriesService) ListTags(repo RepoSpec, opt *RepoListTagsOptions) ([]*vcs.Tag, Response, error) {
-	url, err := s.client.URL(router.RepoTags, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
+type RepoListTagsOptions struct {
+	// IncludeCommit is whether to include the commit that each tag
+	// points to (in its Commit field).
+	IncludeCommit bool `url:",omitempty" json:",omitempty"`
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	SortOptions
+	ListOptions
+}
 
-	var tags []*vcs.Tag
-	resp, err := s.client.Do(req, &tags)
+func (s *repositoriesService) ListTags(repo RepoSpec, opt *RepoListTagsOptions) ([]*Tag, Response, error) {
+	var tags []*Tag
+	resp, err := s.client.DoList(router.RepoTags, repo.RouteVars(), opt, &tags)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -746,18 +951,8 @@ func (b *Badge) HTML() string {
 }
 
 func (s *repositoriesService) ListBadges(repo RepoSpec) ([]*Badge, Response, error) {
-	url, err := s.client.URL(router.RepoBadges, repo.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var badges []*Badge
-	resp, err := s.client.Do(req, &badges)
+	resp, err := s.client.DoList(router.RepoBadges, repo.RouteVars(), nil, &badges)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -778,18 +973,8 @@ func (c *Counter) HTML() string {
 }
 
 func (s *repositoriesService) ListCounters(repo RepoSpec) ([]*Counter, Response, error) {
-	url, err := s.client.URL(router.RepoCounters, repo.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var counters []*Counter
-	resp, err := s.client.Do(req, &counters)
+	resp, err := s.client.DoList(router.RepoCounters, repo.RouteVars(), nil, &counters)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -811,22 +996,13 @@ type AugmentedRepoAuthor struct {
 }
 
 type RepoListAuthorsOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *repositoriesService) ListAuthors(repo RepoRevSpec, opt *RepoListAuthorsOptions) ([]*AugmentedRepoAuthor, Response, error) {
-	url, err := s.client.URL(router.RepoAuthors, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var authors []*AugmentedRepoAuthor
-	resp, err := s.client.Do(req, &authors)
+	resp, err := s.client.DoList(router.RepoAuthors, repo.RouteVars(), opt, &authors)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -867,22 +1043,13 @@ type AugmentedRepoClient struct {
 }
 
 type RepoListClientsOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *repositoriesService) ListClients(repo RepoSpec, opt *RepoListClientsOptions) ([]*AugmentedRepoClient, Response, error) {
-	url, err := s.client.URL(router.RepoClients, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var clients []*AugmentedRepoClient
-	resp, err := s.client.Do(req, &clients)
+	resp, err := s.client.DoList(router.RepoClients, repo.RouteVars(), opt, &clients)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -900,22 +1067,13 @@ type AugmentedRepoDependency struct {
 }
 
 type RepoListDependenciesOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *repositoriesService) ListDependencies(repo RepoRevSpec, opt *RepoListDependenciesOptions) ([]*AugmentedRepoDependency, Response, error) {
-	url, err := s.client.URL(router.RepoDependencies, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var dependencies []*AugmentedRepoDependency
-	resp, err := s.client.Do(req, &dependencies)
+	resp, err := s.client.DoList(router.RepoDependencies, repo.RouteVars(), opt, &dependencies)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -932,21 +1090,14 @@ type AugmentedRepoDependent struct {
 	*RepoDependent
 }
 
-type RepoListDependentsOptions struct{ ListOptions }
+type RepoListDependentsOptions struct {
+	SortOptions
+	ListOptions
+}
 
 func (s *repositoriesService) ListDependents(repo RepoSpec, opt *RepoListDependentsOptions) ([]*AugmentedRepoDependent, Response, error) {
-	url, err := s.client.URL(router.RepoDependents, repo.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var dependents []*AugmentedRepoDependent
-	resp, err := s.client.Do(req, &dependents)
+	resp, err := s.client.DoList(router.RepoDependents, repo.RouteVars(), opt, &dependents)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -983,24 +1134,69 @@ type AugmentedRepoContribution struct {
 	*RepoContribution
 }
 
-type RepoListByContributorOptions struct {
-	NoFork bool
+// RepoOwnerSpec specifies the owner of repositories (see
+// ReposService.ListByOwner). Exactly one of Person and Org must be set.
+type RepoOwnerSpec struct {
+	Person *PersonSpec `json:",omitempty"`
+	Org    *OrgSpec    `json:",omitempty"`
+}
+
+// errInvalidRepoOwnerSpec is returned for a RepoOwnerSpec that doesn't
+// have exactly one field set.
+var errInvalidRepoOwnerSpec = errors.New("repository owner spec must specify exactly one of Person and Org")
+
+// route returns the name of the route that lists the owner's
+// repositories, and the route's variables.
+func (s RepoOwnerSpec) route() (string, map[string]string, error) {
+	switch {
+	case s.Person != nil && s.Org == nil:
+		return router.PersonRepos, s.Person.RouteVars(), nil
+	case s.Org != nil && s.Person == nil:
+		return router.OrgRepos, s.Org.RouteVars(), nil
+	}
+	return "", nil, errInvalidRepoOwnerSpec
+}
+
+type RepoListByOwnerOptions struct {
+	// Fork, Mirror, and Private, if set, restrict the list to
+	// repositories that are (if true) or are not (if false) forks,
+	// mirrors, or private.
+	Fork    *bool `url:",omitempty" json:",omitempty"`
+	Mirror  *bool `url:",omitempty" json:",omitempty"`
+	Private *bool `url:",omitempty" json:",omitempty"`
+
+	// Language, if set, restricts the list to repositories whose
+	// primary language is Language (e.g., "Go").
+	Language string `url:",omitempty" json:",omitempty"`
+
+	SortOptions
 	ListOptions
 }
 
-func (s *repositoriesService) ListByContributor(user UserSpec, opt *RepoListByContributorOptions) ([]*AugmentedRepoContribution, Response, error) {
-	url, err := s.client.URL(router.UserRepoContributions, user.RouteVars(), opt)
+func (s *repositoriesService) ListByOwner(owner RepoOwnerSpec, opt *RepoListByOwnerOptions) ([]*Repo, Response, error) {
+	route, routeVars, err := owner.route()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
+	var repos []*Repo
+	resp, err := s.client.DoList(route, routeVars, opt, &repos)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
+	return repos, resp, nil
+}
+
+type RepoListByContributorOptions struct {
+	NoFork bool
+	SortOptions
+	ListOptions
+}
+
+func (s *repositoriesService) ListByContributor(user UserSpec, opt *RepoListByContributorOptions) ([]*AugmentedRepoContribution, Response, error) {
 	var repos []*AugmentedRepoContribution
-	resp, err := s.client.Do(req, &repos)
+	resp, err := s.client.DoList(router.UserRepoContributions, user.RouteVars(), opt, &repos)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -1030,22 +1226,13 @@ type AugmentedRepoUsageByClient struct {
 }
 
 type RepoListByClientOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *repositoriesService) ListByClient(user UserSpec, opt *RepoListByClientOptions) ([]*AugmentedRepoUsageByClient, Response, error) {
-	url, err := s.client.URL(router.UserRepoDependencies, user.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var repos []*AugmentedRepoUsageByClient
-	resp, err := s.client.Do(req, &repos)
+	resp, err := s.client.DoList(router.UserRepoDependencies, user.RouteVars(), opt, &repos)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -1069,27 +1256,131 @@ type AugmentedRepoUsageOfAuthor struct {
 }
 
 type RepoListByRefdAuthorOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *repositoriesService) ListByRefdAuthor(user UserSpec, opt *RepoListByRefdAuthorOptions) ([]*AugmentedRepoUsageOfAuthor, Response, error) {
-	url, err := s.client.URL(router.UserRepoDependents, user.RouteVars(), opt)
+	var repos []*AugmentedRepoUsageOfAuthor
+	resp, err := s.client.DoList(router.UserRepoDependents, user.RouteVars(), opt, &repos)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
+	return repos, resp, nil
+}
+
+// A Permission is a level of access to a repository. Each level
+// includes the access granted by the levels below it.
+type Permission string
+
+const (
+	// PermissionNone grants no access to a repository.
+	PermissionNone Permission = "none"
+
+	// PermissionRead grants access to read a repository and to comment
+	// on its issues and pull requests.
+	PermissionRead Permission = "read"
+
+	// PermissionWrite additionally grants access to push to a
+	// repository and to manage its issues and pull requests.
+	PermissionWrite Permission = "write"
+
+	// PermissionAdmin additionally grants access to change a
+	// repository's settings and collaborators.
+	PermissionAdmin Permission = "admin"
+)
+
+// permissionLevels orders the permissions from least to most access.
+var permissionLevels = map[Permission]int{
+	PermissionNone:  0,
+	PermissionRead:  1,
+	PermissionWrite: 2,
+	PermissionAdmin: 3,
+}
+
+// Includes reports whether p grants at least the access that q
+// grants. Unknown permissions include only PermissionNone.
+func (p Permission) Includes(q Permission) bool {
+	return permissionLevels[p] >= permissionLevels[q]
+}
+
+// RepoPermissions returns the RepoPermissions that p grants.
+func (p Permission) RepoPermissions() RepoPermissions {
+	return RepoPermissions{
+		Read:  p.Includes(PermissionRead),
+		Write: p.Includes(PermissionWrite),
+		Admin: p.Includes(PermissionAdmin),
 	}
+}
 
-	var repos []*AugmentedRepoUsageOfAuthor
-	resp, err := s.client.Do(req, &repos)
+// A RepoCollaborator is a person who has been granted access to a
+// repository.
+type RepoCollaborator struct {
+	Person     *Person
+	Permission Permission
+}
+
+// RepoListCollaboratorsOptions specifies options for
+// ReposService.ListCollaborators.
+type RepoListCollaboratorsOptions struct {
+	// Permission, if set, restricts the results to collaborators with
+	// exactly this permission.
+	Permission Permission `url:",omitempty"`
+
+	SortOptions
+	ListOptions
+}
+
+// repoCollaboratorRouteVars returns the route variables that specify a
+// collaborator on a repository.
+func repoCollaboratorRouteVars(repo RepoSpec, person PersonSpec) map[string]string {
+	m := repo.RouteVars()
+	m["PersonSpec"] = person.PathComponent()
+	return m
+}
+
+func (s *repositoriesService) ListCollaborators(repo RepoSpec, opt *RepoListCollaboratorsOptions) ([]*RepoCollaborator, Response, error) {
+	var collaborators []*RepoCollaborator
+	resp, err := s.client.DoList(router.RepoCollaborators, repo.RouteVars(), opt, &collaborators)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return repos, resp, nil
+	return collaborators, resp, nil
+}
+
+func (s *repositoriesService) AddCollaborator(repo RepoSpec, person PersonSpec, perm Permission) (Response, error) {
+	if perm != PermissionRead && perm != PermissionWrite && perm != PermissionAdmin {
+		return nil, &ValidationError{Field: "Permission", Problems: []string{fmt.Sprintf("must be %q, %q, or %q, not %q", PermissionRead, PermissionWrite, PermissionAdmin, perm)}}
+	}
+
+	body := struct{ Permission Permission }{perm}
+	resp, err := s.client.DoUpdate(router.RepoCollaboratorAdd, repoCollaboratorRouteVars(repo, person), body, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func (s *repositoriesService) RemoveCollaborator(repo RepoSpec, person PersonSpec) (Response, error) {
+	resp, err := s.client.DoDelete(router.RepoCollaboratorRemove, repoCollaboratorRouteVars(repo, person))
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func (s *repositoriesService) GetPermission(repo RepoSpec, person PersonSpec) (Permission, Response, error) {
+	var out struct{ Permission Permission }
+	resp, err := s.client.DoGet(router.RepoCollaboratorPermission, repoCollaboratorRouteVars(repo, person), nil, &out)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return out.Permission, resp, nil
 }
 
 var _ ReposService = &MockReposService{}
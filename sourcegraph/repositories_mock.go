@@ -1,142 +1,443 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 import (
+	"io"
+
 	"sourcegraph.com/sourcegraph/go-vcs/vcs"
 	"sourcegraph.com/sourcegraph/vcsstore/vcsclient"
 )
 
 type MockReposService struct {
-	Get_               func(repo RepoSpec, opt *RepoGetOptions) (*Repo, Response, error)
-	GetStats_          func(repo RepoRevSpec) (RepoStats, Response, error)
-	GetCombinedStatus_ func(spec RepoRevSpec) (*CombinedStatus, Response, error)
-	CreateStatus_      func(spec RepoRevSpec, st RepoStatus) (*RepoStatus, Response, error)
-	GetOrCreate_       func(repo RepoSpec, opt *RepoGetOptions) (*Repo, Response, error)
-	GetSettings_       func(repo RepoSpec) (*RepoSettings, Response, error)
-	UpdateSettings_    func(repo RepoSpec, settings RepoSettings) (Response, error)
-	RefreshProfile_    func(repo RepoSpec) (Response, error)
-	RefreshVCSData_    func(repo RepoSpec) (Response, error)
-	ComputeStats_      func(repo RepoRevSpec) (Response, error)
-	GetBuild_          func(repo RepoRevSpec, opt *RepoGetBuildOptions) (*RepoBuildInfo, Response, error)
-	Create_            func(newRepoSpec NewRepoSpec) (*Repo, Response, error)
-	GetReadme_         func(repo RepoRevSpec) (*vcsclient.TreeEntry, Response, error)
-	List_              func(opt *RepoListOptions) ([]*Repo, Response, error)
-	ListCommits_       func(repo RepoSpec, opt *RepoListCommitsOptions) ([]*Commit, Response, error)
-	GetCommit_         func(rev RepoRevSpec, opt *RepoGetCommitOptions) (*Commit, Response, error)
-	ListBranches_      func(repo RepoSpec, opt *RepoListBranchesOptions) ([]*vcs.Branch, Response, error)
-	ListTags_          func(repo RepoSpec, opt *RepoListTagsOptions) ([]*vcs.Tag, Response, error)
-	ListBadges_        func(repo RepoSpec) ([]*Badge, Response, error)
-	ListCounters_      func(repo RepoSpec) ([]*Counter, Response, error)
-	ListAuthors_       func(repo RepoRevSpec, opt *RepoListAuthorsOptions) ([]*AugmentedRepoAuthor, Response, error)
-	ListClients_       func(repo RepoSpec, opt *RepoListClientsOptions) ([]*AugmentedRepoClient, Response, error)
-	ListDependencies_  func(repo RepoRevSpec, opt *RepoListDependenciesOptions) ([]*AugmentedRepoDependency, Response, error)
-	ListDependents_    func(repo RepoSpec, opt *RepoListDependentsOptions) ([]*AugmentedRepoDependent, Response, error)
-	ListByContributor_ func(user UserSpec, opt *RepoListByContributorOptions) ([]*AugmentedRepoContribution, Response, error)
-	ListByClient_      func(user UserSpec, opt *RepoListByClientOptions) ([]*AugmentedRepoUsageByClient, Response, error)
-	ListByRefdAuthor_  func(user UserSpec, opt *RepoListByRefdAuthorOptions) ([]*AugmentedRepoUsageOfAuthor, Response, error)
+	Get_                func(repo RepoSpec, opt *RepoGetOptions) (*Repo, Response, error)
+	GetMulti_           func(repos []RepoSpec, opt *RepoGetOptions) ([]*Repo, Response, error)
+	GetStats_           func(repo RepoRevSpec) (RepoStats, Response, error)
+	GetStatsSummary_    func(repo RepoRevSpec) (*RepoStatsSummary, Response, error)
+	CreateStatus_       func(spec RepoRevSpec, st RepoStatus) (*RepoStatus, Response, error)
+	GetCombinedStatus_  func(spec RepoRevSpec) (*CombinedStatus, Response, error)
+	GetOrCreate_        func(repo RepoSpec, opt *RepoGetOptions) (*Repo, Response, error)
+	GetSettings_        func(repo RepoSpec) (*RepoSettings, Response, error)
+	UpdateSettings_     func(repo RepoSpec, settings RepoSettings) (Response, error)
+	Enable_             func(repo RepoSpec) (Response, error)
+	Disable_            func(repo RepoSpec) (Response, error)
+	RefreshProfile_     func(repo RepoSpec) (Response, error)
+	RefreshVCSData_     func(repo RepoSpec) (Response, error)
+	ComputeStats_       func(repo RepoRevSpec) (Response, error)
+	GetBuild_           func(repo RepoRevSpec, opt *RepoGetBuildOptions) (*RepoBuildInfo, Response, error)
+	Create_             func(newRepoSpec NewRepoSpec) (*Repo, Response, error)
+	GetReadme_          func(repo RepoRevSpec) (*vcsclient.TreeEntry, Response, error)
+	GetArchive_         func(rev RepoRevSpec, format ArchiveFormat, opt *RepoGetArchiveOptions) (io.ReadCloser, Response, error)
+	List_               func(opt *RepoListOptions) ([]*Repo, Response, error)
+	ListCommits_        func(repo RepoSpec, opt *RepoListCommitsOptions) ([]*Commit, Response, error)
+	GetCommit_          func(rev RepoRevSpec, opt *RepoGetCommitOptions) (*Commit, Response, error)
+	ListBranches_       func(repo RepoSpec, opt *RepoListBranchesOptions) ([]*vcs.Branch, Response, error)
+	ListTags_           func(repo RepoSpec, opt *RepoListTagsOptions) ([]*Tag, Response, error)
+	ListBadges_         func(repo RepoSpec) ([]*Badge, Response, error)
+	ListCounters_       func(repo RepoSpec) ([]*Counter, Response, error)
+	ListAuthors_        func(repo RepoRevSpec, opt *RepoListAuthorsOptions) ([]*AugmentedRepoAuthor, Response, error)
+	ListClients_        func(repo RepoSpec, opt *RepoListClientsOptions) ([]*AugmentedRepoClient, Response, error)
+	ListDependencies_   func(repo RepoRevSpec, opt *RepoListDependenciesOptions) ([]*AugmentedRepoDependency, Response, error)
+	ListDependents_     func(repo RepoSpec, opt *RepoListDependentsOptions) ([]*AugmentedRepoDependent, Response, error)
+	ListByOwner_        func(owner RepoOwnerSpec, opt *RepoListByOwnerOptions) ([]*Repo, Response, error)
+	ListByContributor_  func(user UserSpec, opt *RepoListByContributorOptions) ([]*AugmentedRepoContribution, Response, error)
+	ListByClient_       func(user UserSpec, opt *RepoListByClientOptions) ([]*AugmentedRepoUsageByClient, Response, error)
+	ListByRefdAuthor_   func(user UserSpec, opt *RepoListByRefdAuthorOptions) ([]*AugmentedRepoUsageOfAuthor, Response, error)
+	ResolveImportPath_  func(opt *RepoResolveImportPathOptions) (*ResolvedImportPath, Response, error)
+	ResolvePackage_     func(opt *RepoResolvePackageOptions) ([]*ResolvedPackage, Response, error)
+	ListCollaborators_  func(repo RepoSpec, opt *RepoListCollaboratorsOptions) ([]*RepoCollaborator, Response, error)
+	AddCollaborator_    func(repo RepoSpec, person PersonSpec, perm Permission) (Response, error)
+	RemoveCollaborator_ func(repo RepoSpec, person PersonSpec) (Response, error)
+	GetPermission_      func(repo RepoSpec, person PersonSpec) (Permission, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockReposService returns a new MockReposService
+// that records calls to its methods.
+func NewMockReposService() *MockReposService {
+	return &MockReposService{Calls: &MockCalls{}}
 }
 
 func (s MockReposService) Get(repo RepoSpec, opt *RepoGetOptions) (*Repo, Response, error) {
+	s.Calls.record("Get", repo, opt)
+	if s.Get_ == nil {
+		var r0 *Repo
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.Get")
+	}
 	return s.Get_(repo, opt)
 }
 
+func (s MockReposService) GetMulti(repos []RepoSpec, opt *RepoGetOptions) ([]*Repo, Response, error) {
+	s.Calls.record("GetMulti", repos, opt)
+	if s.GetMulti_ == nil {
+		var r0 []*Repo
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetMulti")
+	}
+	return s.GetMulti_(repos, opt)
+}
+
 func (s MockReposService) GetStats(repo RepoRevSpec) (RepoStats, Response, error) {
+	s.Calls.record("GetStats", repo)
+	if s.GetStats_ == nil {
+		var r0 RepoStats
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetStats")
+	}
 	return s.GetStats_(repo)
 }
 
-func (s MockReposService) GetCombinedStatus(spec RepoRevSpec) (*CombinedStatus, Response, error) {
-	return s.GetCombinedStatus_(spec)
+func (s MockReposService) GetStatsSummary(repo RepoRevSpec) (*RepoStatsSummary, Response, error) {
+	s.Calls.record("GetStatsSummary", repo)
+	if s.GetStatsSummary_ == nil {
+		var r0 *RepoStatsSummary
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetStatsSummary")
+	}
+	return s.GetStatsSummary_(repo)
 }
 
 func (s MockReposService) CreateStatus(spec RepoRevSpec, st RepoStatus) (*RepoStatus, Response, error) {
+	s.Calls.record("CreateStatus", spec, st)
+	if s.CreateStatus_ == nil {
+		var r0 *RepoStatus
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.CreateStatus")
+	}
 	return s.CreateStatus_(spec, st)
 }
 
+func (s MockReposService) GetCombinedStatus(spec RepoRevSpec) (*CombinedStatus, Response, error) {
+	s.Calls.record("GetCombinedStatus", spec)
+	if s.GetCombinedStatus_ == nil {
+		var r0 *CombinedStatus
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetCombinedStatus")
+	}
+	return s.GetCombinedStatus_(spec)
+}
+
 func (s MockReposService) GetOrCreate(repo RepoSpec, opt *RepoGetOptions) (*Repo, Response, error) {
+	s.Calls.record("GetOrCreate", repo, opt)
+	if s.GetOrCreate_ == nil {
+		var r0 *Repo
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetOrCreate")
+	}
 	return s.GetOrCreate_(repo, opt)
 }
 
 func (s MockReposService) GetSettings(repo RepoSpec) (*RepoSettings, Response, error) {
+	s.Calls.record("GetSettings", repo)
+	if s.GetSettings_ == nil {
+		var r0 *RepoSettings
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetSettings")
+	}
 	return s.GetSettings_(repo)
 }
 
 func (s MockReposService) UpdateSettings(repo RepoSpec, settings RepoSettings) (Response, error) {
+	s.Calls.record("UpdateSettings", repo, settings)
+	if s.UpdateSettings_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("ReposService.UpdateSettings")
+	}
 	return s.UpdateSettings_(repo, settings)
 }
 
+func (s MockReposService) Enable(repo RepoSpec) (Response, error) {
+	s.Calls.record("Enable", repo)
+	if s.Enable_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("ReposService.Enable")
+	}
+	return s.Enable_(repo)
+}
+
+func (s MockReposService) Disable(repo RepoSpec) (Response, error) {
+	s.Calls.record("Disable", repo)
+	if s.Disable_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("ReposService.Disable")
+	}
+	return s.Disable_(repo)
+}
+
 func (s MockReposService) RefreshProfile(repo RepoSpec) (Response, error) {
+	s.Calls.record("RefreshProfile", repo)
+	if s.RefreshProfile_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("ReposService.RefreshProfile")
+	}
 	return s.RefreshProfile_(repo)
 }
 
 func (s MockReposService) RefreshVCSData(repo RepoSpec) (Response, error) {
+	s.Calls.record("RefreshVCSData", repo)
+	if s.RefreshVCSData_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("ReposService.RefreshVCSData")
+	}
 	return s.RefreshVCSData_(repo)
 }
 
 func (s MockReposService) ComputeStats(repo RepoRevSpec) (Response, error) {
+	s.Calls.record("ComputeStats", repo)
+	if s.ComputeStats_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("ReposService.ComputeStats")
+	}
 	return s.ComputeStats_(repo)
 }
 
 func (s MockReposService) GetBuild(repo RepoRevSpec, opt *RepoGetBuildOptions) (*RepoBuildInfo, Response, error) {
+	s.Calls.record("GetBuild", repo, opt)
+	if s.GetBuild_ == nil {
+		var r0 *RepoBuildInfo
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetBuild")
+	}
 	return s.GetBuild_(repo, opt)
 }
 
 func (s MockReposService) Create(newRepoSpec NewRepoSpec) (*Repo, Response, error) {
+	s.Calls.record("Create", newRepoSpec)
+	if s.Create_ == nil {
+		var r0 *Repo
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.Create")
+	}
 	return s.Create_(newRepoSpec)
 }
 
 func (s MockReposService) GetReadme(repo RepoRevSpec) (*vcsclient.TreeEntry, Response, error) {
+	s.Calls.record("GetReadme", repo)
+	if s.GetReadme_ == nil {
+		var r0 *vcsclient.TreeEntry
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetReadme")
+	}
 	return s.GetReadme_(repo)
 }
 
-func (s MockReposService) List(opt *RepoListOptions) ([]*Repo, Response, error) { return s.List_(opt) }
+func (s MockReposService) GetArchive(rev RepoRevSpec, format ArchiveFormat, opt *RepoGetArchiveOptions) (io.ReadCloser, Response, error) {
+	s.Calls.record("GetArchive", rev, format, opt)
+	if s.GetArchive_ == nil {
+		var r0 io.ReadCloser
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetArchive")
+	}
+	return s.GetArchive_(rev, format, opt)
+}
+
+func (s MockReposService) List(opt *RepoListOptions) ([]*Repo, Response, error) {
+	s.Calls.record("List", opt)
+	if s.List_ == nil {
+		var r0 []*Repo
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.List")
+	}
+	return s.List_(opt)
+}
 
 func (s MockReposService) ListCommits(repo RepoSpec, opt *RepoListCommitsOptions) ([]*Commit, Response, error) {
+	s.Calls.record("ListCommits", repo, opt)
+	if s.ListCommits_ == nil {
+		var r0 []*Commit
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListCommits")
+	}
 	return s.ListCommits_(repo, opt)
 }
 
 func (s MockReposService) GetCommit(rev RepoRevSpec, opt *RepoGetCommitOptions) (*Commit, Response, error) {
+	s.Calls.record("GetCommit", rev, opt)
+	if s.GetCommit_ == nil {
+		var r0 *Commit
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetCommit")
+	}
 	return s.GetCommit_(rev, opt)
 }
 
 func (s MockReposService) ListBranches(repo RepoSpec, opt *RepoListBranchesOptions) ([]*vcs.Branch, Response, error) {
+	s.Calls.record("ListBranches", repo, opt)
+	if s.ListBranches_ == nil {
+		var r0 []*vcs.Branch
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListBranches")
+	}
 	return s.ListBranches_(repo, opt)
 }
 
-func (s MockReposService) ListTags(repo RepoSpec, opt *RepoListTagsOptions) ([]*vcs.Tag, Response, error) {
+func (s MockReposService) ListTags(repo RepoSpec, opt *RepoListTagsOptions) ([]*Tag, Response, error) {
+	s.Calls.record("ListTags", repo, opt)
+	if s.ListTags_ == nil {
+		var r0 []*Tag
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListTags")
+	}
 	return s.ListTags_(repo, opt)
 }
 
 func (s MockReposService) ListBadges(repo RepoSpec) ([]*Badge, Response, error) {
+	s.Calls.record("ListBadges", repo)
+	if s.ListBadges_ == nil {
+		var r0 []*Badge
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListBadges")
+	}
 	return s.ListBadges_(repo)
 }
 
 func (s MockReposService) ListCounters(repo RepoSpec) ([]*Counter, Response, error) {
+	s.Calls.record("ListCounters", repo)
+	if s.ListCounters_ == nil {
+		var r0 []*Counter
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListCounters")
+	}
 	return s.ListCounters_(repo)
 }
 
 func (s MockReposService) ListAuthors(repo RepoRevSpec, opt *RepoListAuthorsOptions) ([]*AugmentedRepoAuthor, Response, error) {
+	s.Calls.record("ListAuthors", repo, opt)
+	if s.ListAuthors_ == nil {
+		var r0 []*AugmentedRepoAuthor
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListAuthors")
+	}
 	return s.ListAuthors_(repo, opt)
 }
 
 func (s MockReposService) ListClients(repo RepoSpec, opt *RepoListClientsOptions) ([]*AugmentedRepoClient, Response, error) {
+	s.Calls.record("ListClients", repo, opt)
+	if s.ListClients_ == nil {
+		var r0 []*AugmentedRepoClient
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListClients")
+	}
 	return s.ListClients_(repo, opt)
 }
 
 func (s MockReposService) ListDependencies(repo RepoRevSpec, opt *RepoListDependenciesOptions) ([]*AugmentedRepoDependency, Response, error) {
+	s.Calls.record("ListDependencies", repo, opt)
+	if s.ListDependencies_ == nil {
+		var r0 []*AugmentedRepoDependency
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListDependencies")
+	}
 	return s.ListDependencies_(repo, opt)
 }
 
 func (s MockReposService) ListDependents(repo RepoSpec, opt *RepoListDependentsOptions) ([]*AugmentedRepoDependent, Response, error) {
+	s.Calls.record("ListDependents", repo, opt)
+	if s.ListDependents_ == nil {
+		var r0 []*AugmentedRepoDependent
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListDependents")
+	}
 	return s.ListDependents_(repo, opt)
 }
 
+func (s MockReposService) ListByOwner(owner RepoOwnerSpec, opt *RepoListByOwnerOptions) ([]*Repo, Response, error) {
+	s.Calls.record("ListByOwner", owner, opt)
+	if s.ListByOwner_ == nil {
+		var r0 []*Repo
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListByOwner")
+	}
+	return s.ListByOwner_(owner, opt)
+}
+
 func (s MockReposService) ListByContributor(user UserSpec, opt *RepoListByContributorOptions) ([]*AugmentedRepoContribution, Response, error) {
+	s.Calls.record("ListByContributor", user, opt)
+	if s.ListByContributor_ == nil {
+		var r0 []*AugmentedRepoContribution
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListByContributor")
+	}
 	return s.ListByContributor_(user, opt)
 }
 
 func (s MockReposService) ListByClient(user UserSpec, opt *RepoListByClientOptions) ([]*AugmentedRepoUsageByClient, Response, error) {
+	s.Calls.record("ListByClient", user, opt)
+	if s.ListByClient_ == nil {
+		var r0 []*AugmentedRepoUsageByClient
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListByClient")
+	}
 	return s.ListByClient_(user, opt)
 }
 
 func (s MockReposService) ListByRefdAuthor(user UserSpec, opt *RepoListByRefdAuthorOptions) ([]*AugmentedRepoUsageOfAuthor, Response, error) {
+	s.Calls.record("ListByRefdAuthor", user, opt)
+	if s.ListByRefdAuthor_ == nil {
+		var r0 []*AugmentedRepoUsageOfAuthor
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListByRefdAuthor")
+	}
 	return s.ListByRefdAuthor_(user, opt)
 }
+
+func (s MockReposService) ResolveImportPath(opt *RepoResolveImportPathOptions) (*ResolvedImportPath, Response, error) {
+	s.Calls.record("ResolveImportPath", opt)
+	if s.ResolveImportPath_ == nil {
+		var r0 *ResolvedImportPath
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ResolveImportPath")
+	}
+	return s.ResolveImportPath_(opt)
+}
+
+func (s MockReposService) ResolvePackage(opt *RepoResolvePackageOptions) ([]*ResolvedPackage, Response, error) {
+	s.Calls.record("ResolvePackage", opt)
+	if s.ResolvePackage_ == nil {
+		var r0 []*ResolvedPackage
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ResolvePackage")
+	}
+	return s.ResolvePackage_(opt)
+}
+
+func (s MockReposService) ListCollaborators(repo RepoSpec, opt *RepoListCollaboratorsOptions) ([]*RepoCollaborator, Response, error) {
+	s.Calls.record("ListCollaborators", repo, opt)
+	if s.ListCollaborators_ == nil {
+		var r0 []*RepoCollaborator
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.ListCollaborators")
+	}
+	return s.ListCollaborators_(repo, opt)
+}
+
+func (s MockReposService) AddCollaborator(repo RepoSpec, person PersonSpec, perm Permission) (Response, error) {
+	s.Calls.record("AddCollaborator", repo, person, perm)
+	if s.AddCollaborator_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("ReposService.AddCollaborator")
+	}
+	return s.AddCollaborator_(repo, person, perm)
+}
+
+func (s MockReposService) RemoveCollaborator(repo RepoSpec, person PersonSpec) (Response, error) {
+	s.Calls.record("RemoveCollaborator", repo, person)
+	if s.RemoveCollaborator_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("ReposService.RemoveCollaborator")
+	}
+	return s.RemoveCollaborator_(repo, person)
+}
+
+func (s MockReposService) GetPermission(repo RepoSpec, person PersonSpec) (Permission, Response, error) {
+	s.Calls.record("GetPermission", repo, person)
+	if s.GetPermission_ == nil {
+		var r0 Permission
+		var r1 Response
+		return r0, r1, mockNotImplemented("ReposService.GetPermission")
+	}
+	return s.GetPermission_(repo, person)
+}
@@ -1,8 +1,10 @@
 package sourcegraph
 
 import (
+	"io/ioutil"
 	"net/http"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +20,9 @@ func TestRepoSpec(t *testing.T) {
 	}{
 		{"a.com/x", RepoSpec{URI: "a.com/x"}},
 		{"R$1", RepoSpec{RID: 1}},
+		{"a.com/x y#z?", RepoSpec{URI: "a.com/x y#z?"}},
+		{"a.com/%40x/%2Ey%25", RepoSpec{URI: "a.com/@x/.y%"}},
+		{"a.com/日本", RepoSpec{URI: "a.com/日本"}},
 	}
 
 	for _, test := range tests {
@@ -57,6 +62,8 @@ func TestRepoRevSpec(t *testing.T) {
 		{RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/x"}, Rev: "r"}, map[string]string{"RepoSpec": "a.com/x", "Rev": "r"}},
 		{RepoRevSpec{RepoSpec: RepoSpec{RID: 123}, Rev: "r"}, map[string]string{"RepoSpec": "R$123", "Rev": "r"}},
 		{RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/x"}, Rev: "r", CommitID: "c"}, map[string]string{"RepoSpec": "a.com/x", "Rev": "r===c"}},
+		{RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/x"}, Rev: "a/.b=c:d", CommitID: "c"}, map[string]string{"RepoSpec": "a.com/x", "Rev": "a/%2Eb%3Dc%3Ad===c"}},
+		{RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/x"}, Rev: "my branch#1?日本"}, map[string]string{"RepoSpec": "a.com/x", "Rev": "my branch#1?日本"}},
 	}
 
 	for _, test := range tests {
@@ -132,6 +139,42 @@ func TestReposService_GetStats(t *testing.T) {
 	}
 }
 
+func TestReposService_GetStatsSummary(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &RepoStatsSummary{
+		Languages:    map[string]int64{"Go": 1000, "Shell": 20},
+		Stats:        RepoStats{RepoStatDefs: 10, RepoStatXRefs: 3},
+		Refs:         40,
+		Contributors: 2,
+		LastBuild:    &Build{BID: 1, Success: true},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoStatsSummary, map[string]string{"RepoSpec": "r.com/x", "Rev": "c"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	summary, _, err := client.Repos.GetStatsSummary(RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "c"})
+	if err != nil {
+		t.Errorf("Repos.GetStatsSummary returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	normalizeBuildTime(summary.LastBuild)
+	normalizeBuildTime(want.LastBuild)
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("Repos.GetStatsSummary returned %+v, want %+v", summary, want)
+	}
+}
+
 func TestReposService_GetOrCreate(t *testing.T) {
 	setup()
 	defer teardown()
@@ -214,6 +257,48 @@ func TestReposService_UpdateSettings(t *testing.T) {
 	}
 }
 
+func TestReposService_Enable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoSettings, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"Enabled":true}`+"\n")
+	})
+
+	_, err := client.Repos.Enable(RepoSpec{URI: "r.com/x"})
+	if err != nil {
+		t.Errorf("Repos.Enable returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestReposService_Disable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoSettings, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"Enabled":false}`+"\n")
+	})
+
+	_, err := client.Repos.Disable(RepoSpec{URI: "r.com/x"})
+	if err != nil {
+		t.Errorf("Repos.Disable returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
 func TestReposService_RefreshProfile(t *testing.T) {
 	setup()
 	defer teardown()
@@ -313,6 +398,70 @@ func TestReposService_GetBuild(t *testing.T) {
 	}
 }
 
+func TestReposService_GetMulti(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Repo{{RID: 1}, {RID: 2}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.ReposBatch, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Repos":[{"URI":"r.com/x","RID":0},{"URI":"r.com/y","RID":0}],"Options":{"Stats":true}}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	repos, _, err := client.Repos.GetMulti([]RepoSpec{{URI: "r.com/x"}, {URI: "r.com/y"}}, &RepoGetOptions{Stats: true})
+	if err != nil {
+		t.Errorf("Repos.GetMulti returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	for _, repo := range want {
+		normRepo(repo)
+	}
+	if !reflect.DeepEqual(repos, want) {
+		t.Errorf("Repos.GetMulti returned %+v, want %+v", repos, want)
+	}
+}
+
+func TestReposService_GetMulti_fanOut(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	calls := 0
+	for i, uri := range []string{"r.com/x", "r.com/y", "r.com/z"} {
+		rid := i + 1
+		mux.HandleFunc(urlPath(t, router.Repo, map[string]string{"RepoSpec": uri}), func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+			testMethod(t, r, "GET")
+			writeJSON(w, &Repo{RID: rid})
+		})
+	}
+
+	repos, _, err := client.Repos.GetMulti([]RepoSpec{{URI: "r.com/x"}, {URI: "r.com/y"}, {URI: "r.com/z"}}, nil)
+	if err != nil {
+		t.Fatalf("Repos.GetMulti returned error: %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("got %d Get requests, want 3", calls)
+	}
+	for i, repo := range repos {
+		if repo == nil || repo.RID != i+1 {
+			t.Errorf("repos[%d] = %+v, want repo with RID %d", i, repo, i+1)
+		}
+	}
+}
+
 func TestReposService_Create(t *testing.T) {
 	setup()
 	defer teardown()
@@ -344,6 +493,54 @@ func TestReposService_Create(t *testing.T) {
 	}
 }
 
+func TestReposService_Create_hosted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	newRepo := NewRepoSpec{Type: "git", URI: "r.com/x", Private: true}
+	want := &Repo{RID: 1}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.ReposCreate, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Type":"git","CloneURL":"","URI":"r.com/x","Private":true}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	repo_, _, err := client.Repos.Create(newRepo)
+	if err != nil {
+		t.Errorf("Repos.Create returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	normRepo(want)
+	if !reflect.DeepEqual(repo_, want) {
+		t.Errorf("Repos.Create returned %+v, want %+v", repo_, want)
+	}
+}
+
+func TestReposService_Create_invalid(t *testing.T) {
+	tests := map[string]NewRepoSpec{
+		"URI":      {Type: "git"},
+		"CloneURL": {Type: "git", CloneURLStr: "r.com/x"},
+	}
+	for field, newRepo := range tests {
+		_, _, err := NewClient(nil).Repos.Create(newRepo)
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("%s: got error %v, want ValidationError for field %s", field, err, field)
+		}
+	}
+
+	if err := (NewRepoSpec{Type: "git", CloneURLStr: "git@github.com:o/r.git"}).validate(); err != nil {
+		t.Errorf("got error %v for scp-like clone URL, want nil", err)
+	}
+}
+
 func TestReposService_GetReadme(t *testing.T) {
 	setup()
 	defer teardown()
@@ -373,6 +570,59 @@ func TestReposService_GetReadme(t *testing.T) {
 	}
 }
 
+func TestReposService_GetArchive(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := "archive data"
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoArchive, map[string]string{"RepoSpec": "r.com/x", "Rev": "v1", "Format": "zip"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Path": "cmd/x"})
+
+		w.Write([]byte(want))
+	})
+
+	rc, _, err := client.Repos.GetArchive(RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "v1"}, ArchiveZip, &RepoGetArchiveOptions{Path: "cmd/x"})
+	if err != nil {
+		t.Fatalf("Repos.GetArchive returned error: %v", err)
+	}
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if string(data) != want {
+		t.Errorf("Repos.GetArchive returned %q, want %q", data, want)
+	}
+}
+
+func TestReposService_GetArchive_invalid(t *testing.T) {
+	client := NewClient(nil)
+	rev := RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}}
+
+	tests := map[string]struct {
+		format ArchiveFormat
+		opt    *RepoGetArchiveOptions
+	}{
+		"Format": {format: "rar"},
+		"Path":   {format: ArchiveTarGz, opt: &RepoGetArchiveOptions{Path: "/etc"}},
+	}
+	for field, test := range tests {
+		_, _, err := client.Repos.GetArchive(rev, test.format, test.opt)
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("%s: got error %v, want ValidationError for field %s", field, err, field)
+		}
+	}
+}
+
 func TestReposService_List(t *testing.T) {
 	setup()
 	defer teardown()
@@ -507,21 +757,61 @@ func TestReposService_ListBranches(t *testing.T) {
 	}
 }
 
+func TestReposService_ListBranches_options(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*vcs.Branch{{Name: "b", Head: "c", Commit: &vcs.Commit{ID: "c"}, Counts: &vcs.BehindAhead{Behind: 1, Ahead: 2}}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoBranches, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"IncludeCommit": "true",
+			"IncludeCounts": "true",
+			"MergeState":    "unmerged",
+			"PerPage":       "2",
+		})
+
+		writeJSON(w, want)
+	})
+
+	branches, _, err := client.Repos.ListBranches(RepoSpec{URI: "r.com/x"}, &RepoListBranchesOptions{
+		IncludeCommit: true,
+		IncludeCounts: true,
+		MergeState:    BranchUnmerged,
+		ListOptions:   ListOptions{PerPage: 2},
+	})
+	if err != nil {
+		t.Errorf("Repos.ListBranches returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(branches, want) {
+		t.Errorf("Repos.ListBranches returned %+v, want %+v", branches, want)
+	}
+}
+
 func TestReposService_ListTags(t *testing.T) {
 	setup()
 	defer teardown()
 
-	want := []*vcs.Tag{{Name: "t", CommitID: "c"}}
+	want := []*Tag{{Tag: vcs.Tag{Name: "t", CommitID: "c"}, Commit: &vcs.Commit{ID: "c", Message: "m"}}}
 
 	var called bool
 	mux.HandleFunc(urlPath(t, router.RepoTags, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
 		called = true
 		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"IncludeCommit": "true"})
 
 		writeJSON(w, want)
 	})
 
-	tags, _, err := client.Repos.ListTags(RepoSpec{URI: "r.com/x"}, nil)
+	tags, _, err := client.Repos.ListTags(RepoSpec{URI: "r.com/x"}, &RepoListTagsOptions{IncludeCommit: true})
 	if err != nil {
 		t.Errorf("Repos.ListTags returned error: %v", err)
 	}
@@ -705,6 +995,43 @@ func TestReposService_ListDependencies(t *testing.T) {
 	}
 }
 
+func TestReposService_ListByOwner(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*Repo{{URI: "r.com/o/x", Language: "Go"}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.OrgRepos, map[string]string{"OrgSpec": "o"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Fork": "false", "Language": "Go", "Sort": "name"})
+
+		writeJSON(w, want)
+	})
+
+	fork := false
+	repos, _, err := client.Repos.ListByOwner(RepoOwnerSpec{Org: &OrgSpec{Org: "o"}}, &RepoListByOwnerOptions{Fork: &fork, Language: "Go", SortOptions: SortOptions{Sort: "name"}})
+	if err != nil {
+		t.Errorf("Repos.ListByOwner returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	normRepo(want...)
+	if !reflect.DeepEqual(repos, want) {
+		t.Errorf("Repos.ListByOwner returned %+v, want %+v", repos, want)
+	}
+
+	for _, owner := range []RepoOwnerSpec{{}, {Person: &PersonSpec{Login: "a"}, Org: &OrgSpec{Org: "o"}}} {
+		if _, _, err := client.Repos.ListByOwner(owner, nil); err != errInvalidRepoOwnerSpec {
+			t.Errorf("%+v: got error %v, want %v", owner, err, errInvalidRepoOwnerSpec)
+		}
+	}
+}
+
 func TestReposService_ListByContributor(t *testing.T) {
 	setup()
 	defer teardown()
@@ -793,6 +1120,126 @@ func TestReposService_ListByRefdAuthor(t *testing.T) {
 	}
 }
 
+func TestReposService_ListCollaborators(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*RepoCollaborator{{Person: &Person{PersonSpec: PersonSpec{Login: "a"}}, Permission: PermissionWrite}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCollaborators, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Permission": "write", "PerPage": "50"})
+
+		writeJSON(w, want)
+	})
+
+	collaborators, _, err := client.Repos.ListCollaborators(RepoSpec{URI: "r.com/x"}, &RepoListCollaboratorsOptions{Permission: PermissionWrite, ListOptions: ListOptions{PerPage: 50}})
+	if err != nil {
+		t.Errorf("Repos.ListCollaborators returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(collaborators, want) {
+		t.Errorf("Repos.ListCollaborators returned %+v, want %+v", collaborators, want)
+	}
+}
+
+func TestReposService_AddCollaborator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCollaboratorAdd, map[string]string{"RepoSpec": "r.com/x", "PersonSpec": "a"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"Permission":"admin"}`+"\n")
+	})
+
+	_, err := client.Repos.AddCollaborator(RepoSpec{URI: "r.com/x"}, PersonSpec{Login: "a"}, PermissionAdmin)
+	if err != nil {
+		t.Errorf("Repos.AddCollaborator returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestReposService_AddCollaborator_invalidPermission(t *testing.T) {
+	for _, perm := range []Permission{"", PermissionNone, "owner"} {
+		_, err := NewClient(nil).Repos.AddCollaborator(RepoSpec{URI: "r.com/x"}, PersonSpec{Login: "a"}, perm)
+		if e, ok := err.(*ValidationError); !ok || e.Field != "Permission" {
+			t.Errorf("%q: got error %v, want a ValidationError for Permission", perm, err)
+		}
+	}
+}
+
+func TestReposService_RemoveCollaborator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCollaboratorRemove, map[string]string{"RepoSpec": "r.com/x", "PersonSpec": "a@b.com"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "DELETE")
+	})
+
+	_, err := client.Repos.RemoveCollaborator(RepoSpec{URI: "r.com/x"}, PersonSpec{Email: "a@b.com"})
+	if err != nil {
+		t.Errorf("Repos.RemoveCollaborator returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestReposService_GetPermission(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoCollaboratorPermission, map[string]string{"RepoSpec": "r.com/x", "PersonSpec": "a"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, map[string]Permission{"Permission": PermissionRead})
+	})
+
+	perm, _, err := client.Repos.GetPermission(RepoSpec{URI: "r.com/x"}, PersonSpec{Login: "a"})
+	if err != nil {
+		t.Errorf("Repos.GetPermission returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if perm != PermissionRead {
+		t.Errorf("Repos.GetPermission returned %q, want %q", perm, PermissionRead)
+	}
+}
+
+func TestPermission_RepoPermissions(t *testing.T) {
+	tests := map[Permission]RepoPermissions{
+		PermissionNone:  {},
+		"unknown":       {},
+		PermissionRead:  {Read: true},
+		PermissionWrite: {Read: true, Write: true},
+		PermissionAdmin: {Read: true, Write: true, Admin: true},
+	}
+	for perm, want := range tests {
+		if got := perm.RepoPermissions(); got != want {
+			t.Errorf("%q: got %+v, want %+v", perm, got, want)
+		}
+	}
+}
+
 func normTime(c *Commit) {
 	c.Author.Date = c.Author.Date.In(time.UTC)
 	if c.Committer != nil {
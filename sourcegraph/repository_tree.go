@@ -12,8 +12,19 @@ import (
 // RepoTreeService communicates with the Sourcegraph API endpoints that
 // fetch file and directory entries in repositories.
 type RepoTreeService interface {
+	// Get fetches a file (with its contents) or a directory (with its
+	// entries) at a revision. For files, opt may select a range of
+	// lines or bytes and request formatted (syntax-highlighted and
+	// def-linked) or tokenized output. An invalid range is reported
+	// as a *ValidationError without sending a request.
 	Get(entry TreeEntrySpec, opt *RepoTreeGetOptions) (*TreeEntry, Response, error)
-	Search(RepoRevSpec, *RepoTreeSearchOptions) ([]*vcs.SearchResult, Response, error)
+	Search(rev RepoRevSpec, opt *RepoTreeSearchOptions) ([]*vcs.SearchResult, Response, error)
+
+	// SearchFile searches the contents of a single file at a
+	// revision and returns the ranges that match the query. It is
+	// intended to back find-in-file features for files too large to
+	// search on the client.
+	SearchFile(entry TreeEntrySpec, opt *RepoTreeSearchFileOptions) ([]*FileMatch, Response, error)
 }
 
 type repoTreeService struct {
@@ -33,6 +44,21 @@ func (s *TreeEntrySpec) RouteVars() map[string]string {
 	return m
 }
 
+// UnmarshalTreeEntrySpec marshals a map containing route variables
+// generated by (*TreeEntrySpec).RouteVars() and returns the equivalent
+// TreeEntrySpec struct.
+//
+// The Path route variable is not escaped by RouteVars, because the
+// tree entry route pattern accepts any path; characters such as
+// spaces, '#', and '?' are escaped when the URL is generated.
+func UnmarshalTreeEntrySpec(routeVars map[string]string) (TreeEntrySpec, error) {
+	repoRev, err := UnmarshalRepoRevSpec(routeVars)
+	if err != nil {
+		return TreeEntrySpec{}, err
+	}
+	return TreeEntrySpec{RepoRev: repoRev, Path: routeVars["Path"]}, nil
+}
+
 func (s TreeEntrySpec) String() string {
 	return fmt.Sprintf("%v: %s (rev %q)", s.RepoRev, s.Path, s.RepoRev.Rev)
 }
@@ -144,18 +170,14 @@ type RepoTreeGetOptions struct {
 }
 
 func (s *repoTreeService) Get(entry TreeEntrySpec, opt *RepoTreeGetOptions) (*TreeEntry, Response, error) {
-	url, err := s.client.URL(router.RepoTreeEntry, entry.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
+	if opt != nil {
+		if err := validateFileRange(opt.FileRange); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	var entry_ *TreeEntry
-	resp, err := s.client.Do(req, &entry_)
+	resp, err := s.client.DoGet(router.RepoTreeEntry, entry.RouteVars(), opt, &entry_)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -163,29 +185,85 @@ func (s *repoTreeService) Get(entry TreeEntrySpec, opt *RepoTreeGetOptions) (*Tr
 	return entry_, resp, nil
 }
 
+// validateFileRange returns a *ValidationError if r is not a valid
+// range of lines or bytes: if it has negative bounds, ends before it
+// starts, or specifies both lines and bytes. Lines are 1-indexed and
+// inclusive; bytes are 0-indexed and exclusive of EndByte. A zero End
+// bound means the end of the file.
+func validateFileRange(r vcsclient.FileRange) error {
+	var problems []string
+	if r.StartLine < 0 || r.EndLine < 0 || r.StartByte < 0 || r.EndByte < 0 {
+		problems = append(problems, "range bounds may not be negative")
+	}
+	if r.EndLine != 0 && r.EndLine < r.StartLine {
+		problems = append(problems, fmt.Sprintf("EndLine %d is before StartLine %d", r.EndLine, r.StartLine))
+	}
+	if r.EndByte != 0 && r.EndByte < r.StartByte {
+		problems = append(problems, fmt.Sprintf("EndByte %d is before StartByte %d", r.EndByte, r.StartByte))
+	}
+	if (r.StartLine != 0 || r.EndLine != 0) && (r.StartByte != 0 || r.EndByte != 0) {
+		problems = append(problems, "a range may specify lines or bytes, not both")
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Field: "FileRange", Problems: problems}
+	}
+	return nil
+}
+
 type RepoTreeSearchOptions struct {
 	vcs.SearchOptions
 	Formatted bool
 }
 
 func (s *repoTreeService) Search(repoRev RepoRevSpec, opt *RepoTreeSearchOptions) ([]*vcs.SearchResult, Response, error) {
-	url, err := s.client.URL(router.RepoTreeSearch, repoRev.RouteVars(), opt)
+	var res []*vcs.SearchResult
+	resp, err := s.client.DoList(router.RepoTreeSearch, repoRev.RouteVars(), opt, &res)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	return res, resp, nil
+}
 
-	var res []*vcs.SearchResult
-	resp, err := s.client.Do(req, &res)
+// RepoTreeSearchFileOptions specifies options for
+// (RepoTreeService).SearchFile.
+type RepoTreeSearchFileOptions struct {
+	// Query is the string or regular expression to search for.
+	Query string
+
+	// Regexp is whether Query is a regular expression (in RE2 syntax).
+	// If false, Query is matched as a fixed string.
+	Regexp bool `url:",omitempty" json:",omitempty"`
+
+	// IgnoreCase is whether to match case-insensitively.
+	IgnoreCase bool `url:",omitempty" json:",omitempty"`
+
+	// N is the maximum number of matches to return (if zero, a
+	// server-defined default is used).
+	N int `url:",omitempty" json:",omitempty"`
+}
+
+// A FileMatch is a range in a file that matches a search query.
+type FileMatch struct {
+	// StartByte and EndByte are the byte offsets of the match in the
+	// file.
+	StartByte int
+	EndByte   int
+
+	// StartLine and EndLine are the (1-indexed) lines that the match
+	// begins and ends on.
+	StartLine int
+	EndLine   int
+}
+
+func (s *repoTreeService) SearchFile(entry TreeEntrySpec, opt *RepoTreeSearchFileOptions) ([]*FileMatch, Response, error) {
+	var matches []*FileMatch
+	resp, err := s.client.DoList(router.RepoFileSearch, entry.RouteVars(), opt, &matches)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return res, resp, nil
+	return matches, resp, nil
 }
 
 var _ RepoTreeService = &MockRepoTreeService{}
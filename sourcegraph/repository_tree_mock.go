@@ -1,16 +1,50 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 import "sourcegraph.com/sourcegraph/go-vcs/vcs"
 
 type MockRepoTreeService struct {
-	Get_    func(entry TreeEntrySpec, opt *RepoTreeGetOptions) (*TreeEntry, Response, error)
-	Search_ func(RepoRevSpec, *RepoTreeSearchOptions) ([]*vcs.SearchResult, Response, error)
+	Get_        func(entry TreeEntrySpec, opt *RepoTreeGetOptions) (*TreeEntry, Response, error)
+	Search_     func(rev RepoRevSpec, opt *RepoTreeSearchOptions) ([]*vcs.SearchResult, Response, error)
+	SearchFile_ func(entry TreeEntrySpec, opt *RepoTreeSearchFileOptions) ([]*FileMatch, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockRepoTreeService returns a new MockRepoTreeService
+// that records calls to its methods.
+func NewMockRepoTreeService() *MockRepoTreeService {
+	return &MockRepoTreeService{Calls: &MockCalls{}}
 }
 
 func (s MockRepoTreeService) Get(entry TreeEntrySpec, opt *RepoTreeGetOptions) (*TreeEntry, Response, error) {
+	s.Calls.record("Get", entry, opt)
+	if s.Get_ == nil {
+		var r0 *TreeEntry
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoTreeService.Get")
+	}
 	return s.Get_(entry, opt)
 }
 
 func (s MockRepoTreeService) Search(rev RepoRevSpec, opt *RepoTreeSearchOptions) ([]*vcs.SearchResult, Response, error) {
+	s.Calls.record("Search", rev, opt)
+	if s.Search_ == nil {
+		var r0 []*vcs.SearchResult
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoTreeService.Search")
+	}
 	return s.Search_(rev, opt)
 }
+
+func (s MockRepoTreeService) SearchFile(entry TreeEntrySpec, opt *RepoTreeSearchFileOptions) ([]*FileMatch, Response, error) {
+	s.Calls.record("SearchFile", entry, opt)
+	if s.SearchFile_ == nil {
+		var r0 []*FileMatch
+		var r1 Response
+		return r0, r1, mockNotImplemented("RepoTreeService.SearchFile")
+	}
+	return s.SearchFile_(entry, opt)
+}
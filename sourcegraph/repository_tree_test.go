@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/fossas/go-sourcegraph/router"
+	muxpkg "github.com/fossas/mux"
 	"sourcegraph.com/sourcegraph/go-vcs/vcs"
 	"sourcegraph.com/sourcegraph/vcsstore/vcsclient"
 )
@@ -63,6 +64,55 @@ func TestRepoTreeService_Get(t *testing.T) {
 	}
 }
 
+func TestRepoTreeService_Get_invalidRange(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(urlPath(t, router.RepoTreeEntry, map[string]string{"RepoSpec": "r.com/x", "Rev": "v", "Path": "p"}), func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent for invalid range")
+	})
+
+	entry := TreeEntrySpec{RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "v"}, Path: "p"}
+	ranges := []vcsclient.FileRange{
+		{StartLine: 5, EndLine: 3},
+		{StartByte: 10, EndByte: 2},
+		{StartLine: -1},
+		{StartLine: 1, EndByte: 10},
+	}
+	for _, r := range ranges {
+		opt := &RepoTreeGetOptions{GetFileOptions: vcsclient.GetFileOptions{FileRange: r}}
+		if _, _, err := client.RepoTree.Get(entry, opt); err == nil {
+			t.Errorf("%+v: got nil error, want error", r)
+		} else if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("%+v: got error %v (%T), want *ValidationError", r, err, err)
+		}
+	}
+}
+
+func TestRepoTreeService_Get_root(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc("/repos/r.com/x@v/.tree/", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		writeJSON(w, &TreeEntry{TreeEntry: &vcsclient.TreeEntry{Type: vcsclient.DirEntry}})
+	})
+
+	_, _, err := client.RepoTree.Get(TreeEntrySpec{
+		RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "v"},
+		Path:    "",
+	}, nil)
+	if err != nil {
+		t.Errorf("RepoTree.Get returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
 func TestRepoTreeService_Search(t *testing.T) {
 	setup()
 	defer teardown()
@@ -109,3 +159,77 @@ func TestRepoTreeService_Search(t *testing.T) {
 		t.Errorf("RepoTree.Search returned %+v, want %+v", data, want)
 	}
 }
+
+func TestRepoTreeService_SearchFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*FileMatch{{StartByte: 3, EndByte: 6, StartLine: 1, EndLine: 1}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.RepoFileSearch, map[string]string{"RepoSpec": "r.com/x", "Rev": "v", "Path": "p"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"Query":      "a.c",
+			"Regexp":     "true",
+			"IgnoreCase": "true",
+		})
+
+		writeJSON(w, want)
+	})
+
+	opt := &RepoTreeSearchFileOptions{Query: "a.c", Regexp: true, IgnoreCase: true}
+	matches, _, err := client.RepoTree.SearchFile(TreeEntrySpec{RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "v"}, Path: "p"}, opt)
+	if err != nil {
+		t.Errorf("RepoTree.SearchFile returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("RepoTree.SearchFile returned %+v, want %+v", matches, want)
+	}
+}
+
+func TestTreeEntrySpec_routeRoundTrip(t *testing.T) {
+	repoRev := func(uri, rev, commitID string) RepoRevSpec {
+		return RepoRevSpec{RepoSpec: RepoSpec{URI: uri}, Rev: rev, CommitID: commitID}
+	}
+	tests := []TreeEntrySpec{
+		{RepoRev: repoRev("a.com/x", "master", ""), Path: "f"},
+		{RepoRev: repoRev("a.com/x y", "my branch", ""), Path: "d/my file"},
+		{RepoRev: repoRev("a.com/x#y", "b#1", "c"), Path: "d#1/f?q=1"},
+		{RepoRev: repoRev("a.com/@x/.y", "a/.b@c", ""), Path: ".d/%41"},
+		{RepoRev: repoRev("a.com/日本", "ブランチ", ""), Path: "日本/語.go"},
+	}
+	for _, spec := range tests {
+		u, err := URL(router.RepoTreeEntry, spec.RouteVars(), nil)
+		if err != nil {
+			t.Errorf("%+v: URL: %s", spec, err)
+			continue
+		}
+
+		req, err := http.NewRequest("GET", u.String(), nil)
+		if err != nil {
+			t.Errorf("%+v: NewRequest: %s", spec, err)
+			continue
+		}
+		var m muxpkg.RouteMatch
+		if !Router.Match(req, &m) || m.Route.GetName() != router.RepoTreeEntry {
+			t.Errorf("%+v: URL %q did not match route %q", spec, u, router.RepoTreeEntry)
+			continue
+		}
+
+		spec2, err := UnmarshalTreeEntrySpec(m.Vars)
+		if err != nil {
+			t.Errorf("%+v: UnmarshalTreeEntrySpec: %s", spec, err)
+			continue
+		}
+		if spec2 != spec {
+			t.Errorf("got spec %+v, want %+v (URL %q)", spec2, spec, u)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// The Do* methods make a request to a named API route and decode the
+// response, which is what most service methods do. They are exported
+// so that packages that add services for other API routes can
+// implement them the same way:
+//
+//	func (s *widgetsService) Get(widget WidgetSpec) (*Widget, Response, error) {
+//		var w *Widget
+//		resp, err := s.client.DoGet(routeWidget, widget.RouteVars(), nil, &w)
+//		return w, resp, err
+//	}
+//
+// (This package predates type parameters, so the result is decoded into
+// v, as in Do, instead of being returned.)
+
+// DoGet sends a GET request to the named API route (with opt encoded
+// in the query string) and decodes the response body into v.
+func (c *Client) DoGet(route string, routeVars map[string]string, opt interface{}, v interface{}) (Response, error) {
+	return c.doRoute("GET", route, routeVars, opt, nil, v)
+}
+
+// DoList is like DoGet, but v must be a pointer to a slice. It is used
+// for routes that return a list of items.
+func (c *Client) DoList(route string, routeVars map[string]string, opt interface{}, v interface{}) (Response, error) {
+	if rv := reflect.ValueOf(v); rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return nil, c.reportError(nil, fmt.Errorf("DoList %s: result must be a pointer to a slice, not %T", route, v))
+	}
+	return c.doRoute("GET", route, routeVars, opt, nil, v)
+}
+
+// DoCreate sends a POST request with the JSON-encoded body to the named
+// API route and decodes the response body into v (if non-nil). If the
+// route is IdempotentWithKey, the request is sent with a new
+// idempotency key.
+func (c *Client) DoCreate(route string, routeVars map[string]string, body interface{}, v interface{}) (Response, error) {
+	return c.doRoute("POST", route, routeVars, nil, body, v)
+}
+
+// DoUpdate sends a PUT request with the JSON-encoded body to the named
+// API route and decodes the response body into v (if non-nil).
+func (c *Client) DoUpdate(route string, routeVars map[string]string, body interface{}, v interface{}) (Response, error) {
+	return c.doRoute("PUT", route, routeVars, nil, body, v)
+}
+
+// DoDelete sends a DELETE request to the named API route.
+func (c *Client) DoDelete(route string, routeVars map[string]string) (Response, error) {
+	return c.doRoute("DELETE", route, routeVars, nil, nil, nil)
+}
+
+// doRoute makes a request to the named API route and decodes the
+// response body into v (if non-nil).
+func (c *Client) doRoute(method, route string, routeVars map[string]string, opt, body, v interface{}) (Response, error) {
+	url, err := c.URL(route, routeVars, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(method, url.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if method == "POST" {
+		setIdempotencyKey(req, route)
+	}
+
+	return c.Do(req, v)
+}
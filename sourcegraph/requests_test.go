@@ -0,0 +1,129 @@
+package sourcegraph
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestClient_DoGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	repo := RepoSpec{URI: "r.com/x"}
+	mux.HandleFunc(urlPath(t, router.Repo, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"Foo": "bar"})
+		writeJSON(w, &Repo{URI: "r.com/x"})
+	})
+
+	opt := &struct{ Foo string }{Foo: "bar"}
+	var got *Repo
+	if _, err := client.DoGet(router.Repo, repo.RouteVars(), opt, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+	if want := (&Repo{URI: "r.com/x"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_DoList_requiresSlice(t *testing.T) {
+	var repo *Repo
+	if _, err := NewClient(nil).DoList(router.Repos, nil, nil, &repo); err == nil {
+		t.Error("got nil error for non-slice result, want error")
+	}
+}
+
+func TestClient_DoCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	repo := RepoSpec{URI: "r.com/x"}
+	mux.HandleFunc(urlPath(t, router.RepoIssuesCreate, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		if key := r.Header.Get(IdempotencyKeyHeader); len(key) != 32 {
+			t.Errorf("got idempotency key %q, want a 32-char key", key)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if want := map[string]string{"Title": "t"}; !reflect.DeepEqual(body, want) {
+			t.Errorf("got body %v, want %v", body, want)
+		}
+		writeJSON(w, map[string]int{"Number": 1})
+	})
+
+	var got map[string]int
+	if _, err := client.DoCreate(router.RepoIssuesCreate, repo.RouteVars(), map[string]string{"Title": "t"}, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+	if want := map[string]int{"Number": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestClient_DoUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	repo := RepoSpec{URI: "r.com/x"}
+	mux.HandleFunc(urlPath(t, router.RepoSettingsUpdate, repo.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+		if key := r.Header.Get(IdempotencyKeyHeader); key != "" {
+			t.Errorf("got idempotency key %q on PUT, want none", key)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if want := map[string]string{"A": "b"}; !reflect.DeepEqual(body, want) {
+			t.Errorf("got body %v, want %v", body, want)
+		}
+	})
+
+	if _, err := client.DoUpdate(router.RepoSettingsUpdate, repo.RouteVars(), map[string]string{"A": "b"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestClient_DoDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	comment := IssueCommentSpec{Issue: IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}, Comment: 2}
+	mux.HandleFunc(urlPath(t, router.RepoIssueCommentsDelete, comment.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "DELETE")
+	})
+
+	if _, err := client.DoDelete(router.RepoIssueCommentsDelete, comment.RouteVars()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
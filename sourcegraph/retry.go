@@ -0,0 +1,321 @@
+package sourcegraph
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultRetryableStatusCodes are the HTTP status codes of responses
+// that are retried if RetryPolicy.RetryableStatusCodes is nil.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// A RetryPolicy configures the automatic retrying of failed requests
+// (see Client.Retry). Only retry-safe requests (see IsRetrySafe) are
+// retried, and only after a network error or a response with one of
+// the RetryableStatusCodes.
+//
+// If a retryable response has a Retry-After header, the client waits
+// for the duration it specifies (instead of the backoff delay) before
+// retrying. If that would take longer than the time remaining until
+// the deadline of the client's context, the response is returned
+// without retrying.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent,
+	// including the first attempt. If it is less than 2, requests are
+	// not retried.
+	MaxAttempts int
+
+	// Budget, if set, limits the number of retries relative to the
+	// number of requests, so that retries can't amplify the load on
+	// the server during an outage. It may be shared by multiple
+	// clients.
+	Budget *RetryBudget
+
+	// SplitDeadline is whether to divide the time remaining until the
+	// deadline of the client's context (see WithContext) evenly among
+	// the remaining attempts of a request. Without it, an attempt that
+	// hangs uses up all of the time that could have been used for
+	// retries. It has no effect on streaming downloads, whose bodies
+	// are read after the request returns.
+	SplitDeadline bool
+
+	// MinBackoff is the delay before the first retry. Each subsequent
+	// retry waits twice as long as the previous one (up to MaxBackoff).
+	// Each delay is randomly reduced by up to half so that clients
+	// whose requests failed at the same time don't retry in lockstep.
+	// If MinBackoff is zero, requests are retried immediately.
+	MinBackoff time.Duration
+
+	// MaxBackoff, if nonzero, is the maximum backoff delay. It does not
+	// limit delays requested by a Retry-After header.
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes are the HTTP status codes of responses that
+	// are retried. If nil, DefaultRetryableStatusCodes is used.
+	RetryableStatusCodes []int
+}
+
+// NewRetryPolicy returns a RetryPolicy that sends each request at most
+// maxAttempts times, with exponential backoff starting at 100ms and
+// capped at 10s between attempts.
+func NewRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+	}
+}
+
+// backoff returns the delay before the given retry (1 for the first
+// retry).
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := p.MinBackoff
+	if d <= 0 {
+		return 0
+	}
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d - time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// isRetryable reports whether a request that resulted in resp and err
+// should be retried (if it is retry-safe).
+func (p *RetryPolicy) isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	codes := p.RetryableStatusCodes
+	if codes == nil {
+		codes = DefaultRetryableStatusCodes
+	}
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// A RetryBudget limits retries to a fraction of requests. Each request
+// deposits ratio tokens into the budget, and each retry withdraws one
+// token; a retry is not attempted if less than one token is available.
+// To allow retries by clients that make few requests, minPerSecond
+// tokens are also deposited per second.
+//
+// To limit the number of retries after a long period without failures,
+// at most 10 seconds' worth of minPerSecond deposits plus 100
+// requests' worth of ratio deposits are kept.
+type RetryBudget struct {
+	ratio, minPerSecond, max float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time // time of the last deposit of minPerSecond tokens
+}
+
+// NewRetryBudget returns a new retry budget that allows retries of
+// about ratio of all requests (e.g., 0.1 for 10%), plus minPerSecond
+// retries per second.
+func NewRetryBudget(ratio, minPerSecond float64) *RetryBudget {
+	max := 10*minPerSecond + 100*ratio
+	if max < 1 {
+		max = 1
+	}
+	return &RetryBudget{
+		ratio:        ratio,
+		minPerSecond: minPerSecond,
+		max:          max,
+		tokens:       minPerSecond,
+		last:         time.Now(),
+	}
+}
+
+// deposit records a request (that is not a retry).
+func (b *RetryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.tokens += b.ratio
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+}
+
+// withdraw reports whether a retry is allowed, and if so, records it.
+func (b *RetryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (b *RetryBudget) refill() {
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.minPerSecond
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+	b.last = now
+}
+
+// send sends req, retrying it according to c.Retry. If stream is
+// false, the caller must call done after reading the response body
+// (to release the per-attempt deadline, if any).
+func (c *Client) send(req *http.Request, stream bool) (resp *http.Response, done func(), err error) {
+	p := c.Retry
+	attempts := 1
+	if p != nil && p.MaxAttempts > 1 && c.isRetrySafe(req) && (req.Body == nil || req.GetBody != nil) {
+		attempts = p.MaxAttempts
+	}
+	if p != nil && p.Budget != nil {
+		p.Budget.deposit()
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.ctx != nil {
+			if err := c.ctx.Err(); err != nil {
+				return nil, nil, err
+			}
+		}
+		if attempt > 1 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, nil, err
+			}
+			req.Body = body
+		}
+
+		done = c.setAttemptCancel(req, attempts-attempt+1, stream)
+		resp, err = c.roundTrip(req, stream)
+		if err != nil && c.ctx != nil && c.ctx.Err() != nil {
+			done()
+			return resp, nil, c.ctx.Err()
+		}
+
+		if attempt == attempts || !p.isRetryable(resp, err) {
+			return resp, done, err
+		}
+		wait := p.backoff(attempt)
+		if d, ok := retryAfter(resp); ok {
+			if c.ctx != nil {
+				if deadline, ok := c.ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+					return resp, done, err
+				}
+			}
+			wait = d
+		}
+		if p.Budget != nil && !p.Budget.withdraw() {
+			return resp, done, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		done()
+
+		if err := c.sleep(wait); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// sleep waits for d, or until c's context is done (in which case it
+// returns the context's error).
+func (c *Client) sleep(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	var ctxDone <-chan struct{}
+	if c.ctx != nil {
+		ctxDone = c.ctx.Done()
+	}
+	select {
+	case <-t.C:
+		return nil
+	case <-ctxDone:
+		return c.ctx.Err()
+	}
+}
+
+// retryAfter returns the delay specified by resp's Retry-After header
+// (either a number of seconds or an HTTP date), and whether resp has a
+// valid Retry-After header.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(time.Now()); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// roundTrip sends req using c's HTTP client, or serves it from c.Cache
+// (if set and the request is a cacheable non-streaming request).
+func (c *Client) roundTrip(req *http.Request, stream bool) (*http.Response, error) {
+	if c.Cache == nil || stream || !isCacheable(req) {
+		return c.httpClient.Do(req)
+	}
+	resp, _, err := cacheRoundTrip(c.Cache, req, c.httpClient.Do)
+	return resp, err
+}
+
+// setAttemptCancel sets req.Cancel so that the request is canceled
+// when c's context is done or (if the request isn't a streaming
+// download) when c.Timeout or, if c.Retry.SplitDeadline is set, the
+// attempt's share of the time remaining until the context's deadline
+// has elapsed. It returns a func that releases the attempt's deadline.
+func (c *Client) setAttemptCancel(req *http.Request, attemptsLeft int, stream bool) func() {
+	timeout := c.Timeout > 0 && !stream
+	if c.ctx == nil && !timeout {
+		return func() {}
+	}
+	ctx, cancel := c.ctx, context.CancelFunc(func() {})
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if deadline, ok := ctx.Deadline(); ok && c.Retry != nil && c.Retry.SplitDeadline && !stream && attemptsLeft > 1 {
+		ctx, cancel = context.WithTimeout(ctx, deadline.Sub(time.Now())/time.Duration(attemptsLeft))
+	}
+	if timeout {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, c.Timeout)
+		cancelSplit := cancel
+		cancel = func() { cancelTimeout(); cancelSplit() }
+	}
+	req.Cancel = ctx.Done()
+	return cancel
+}
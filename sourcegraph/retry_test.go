@@ -0,0 +1,269 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"golang.org/x/net/context"
+)
+
+func TestClient_Retry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	issue := IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	var attempts int
+	mux.HandleFunc(urlPath(t, router.RepoIssue, issue.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, &Issue{})
+	})
+
+	tests := []struct {
+		policy       *RetryPolicy
+		wantAttempts int
+		wantErr      bool
+	}{
+		{policy: nil, wantAttempts: 1, wantErr: true},
+		{policy: &RetryPolicy{MaxAttempts: 2}, wantAttempts: 2, wantErr: true},
+		{policy: &RetryPolicy{MaxAttempts: 3}, wantAttempts: 3},
+		{policy: &RetryPolicy{MaxAttempts: 3, Budget: NewRetryBudget(0, 0)}, wantAttempts: 1, wantErr: true},
+		{policy: &RetryPolicy{MaxAttempts: 3, Budget: NewRetryBudget(0, 1)}, wantAttempts: 2, wantErr: true},
+	}
+	for i, test := range tests {
+		attempts = 0
+		client.Retry = test.policy
+		_, _, err := client.Issues.Get(issue, nil)
+		if (err != nil) != test.wantErr {
+			t.Errorf("#%d: got error %v, want error %v", i, err, test.wantErr)
+		}
+		if attempts != test.wantAttempts {
+			t.Errorf("#%d: got %d attempts, want %d", i, attempts, test.wantAttempts)
+		}
+	}
+}
+
+func TestClient_Retry_notRetrySafe(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var attempts int
+	mux.HandleFunc(urlPath(t, router.AdminTestEmail, nil), func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	client.Retry = &RetryPolicy{MaxAttempts: 3}
+	if _, _, err := client.Admin.SendTestEmail(nil); err == nil {
+		t.Fatal("got nil error, want error")
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestClient_Retry_idempotentPOST(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var attempts int
+	mux.HandleFunc(urlPath(t, router.Markdown, nil), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if attempts++; attempts < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, &MarkdownData{})
+	})
+
+	client.Retry = &RetryPolicy{MaxAttempts: 3}
+	if _, _, err := client.Markdown.Render([]byte("*a*"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Errorf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestClient_Retry_splitDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	issue := IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	var attempts int32
+	mux.HandleFunc(urlPath(t, router.RepoIssue, issue.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			<-unblock // hang until the test is done
+			return
+		}
+		writeJSON(w, &Issue{})
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	c := client.WithContext(ctx)
+	c.Retry = &RetryPolicy{MaxAttempts: 2, SplitDeadline: true}
+	if _, _, err := c.Issues.Get(issue, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("got %d attempts, want 2", n)
+	}
+}
+
+func TestClient_Retry_retryAfter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	issue := IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	var attempts int
+	mux.HandleFunc(urlPath(t, router.RepoIssue, issue.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		writeJSON(w, &Issue{})
+	})
+
+	client.Retry = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Hour}
+	if _, _, err := client.Issues.Get(issue, nil); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 {
+		t.Errorf("got %d attempts, want 2", attempts)
+	}
+}
+
+func TestClient_Retry_retryAfterPastDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	issue := IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	var attempts int
+	mux.HandleFunc(urlPath(t, router.RepoIssue, issue.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "3600")
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c := client.WithContext(ctx)
+	c.Retry = &RetryPolicy{MaxAttempts: 3}
+	_, resp, err := c.Issues.Get(issue, nil)
+	if err == nil {
+		t.Fatal("got nil error, want error")
+	}
+	if resp == nil || resp.(*HTTPResponse).StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got response %v, want 503 response", resp)
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestClient_Retry_statusCodes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	issue := IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	var attempts int
+	mux.HandleFunc(urlPath(t, router.RepoIssue, issue.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	})
+
+	tests := []struct {
+		codes        []int
+		wantAttempts int
+	}{
+		{codes: nil, wantAttempts: 2},
+		{codes: []int{http.StatusServiceUnavailable}, wantAttempts: 1},
+		{codes: []int{}, wantAttempts: 1},
+	}
+	for i, test := range tests {
+		attempts = 0
+		client.Retry = &RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: test.codes}
+		client.Issues.Get(issue, nil)
+		if attempts != test.wantAttempts {
+			t.Errorf("#%d: got %d attempts, want %d", i, attempts, test.wantAttempts)
+		}
+	}
+}
+
+func TestClient_Retry_backoffCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	issue := IssueSpec{Repo: RepoSpec{URI: "r.com/x"}, Number: 1}
+	ctx, cancel := context.WithCancel(context.Background())
+	mux.HandleFunc(urlPath(t, router.RepoIssue, issue.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	c := client.WithContext(ctx)
+	c.Retry = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Hour}
+	if _, _, err := c.Issues.Get(issue, nil); err != context.Canceled {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	tests := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{retry: 1, min: 500 * time.Millisecond, max: time.Second},
+		{retry: 2, min: time.Second, max: 2 * time.Second},
+		{retry: 3, min: 2 * time.Second, max: 4 * time.Second},
+		{retry: 4, min: 2500 * time.Millisecond, max: 5 * time.Second},
+		{retry: 100, min: 2500 * time.Millisecond, max: 5 * time.Second},
+	}
+	for _, test := range tests {
+		for i := 0; i < 10; i++ {
+			if d := p.backoff(test.retry); d < test.min || d > test.max {
+				t.Errorf("retry %d: got backoff %s, want between %s and %s", test.retry, d, test.min, test.max)
+			}
+		}
+	}
+
+	if d := (&RetryPolicy{}).backoff(3); d != 0 {
+		t.Errorf("got backoff %s with zero MinBackoff, want 0", d)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		d  time.Duration
+		ok bool
+	}{
+		"":                              {0, false},
+		"120":                           {120 * time.Second, true},
+		"-1":                            {0, false},
+		"soon":                          {0, false},
+		"Wed, 21 Oct 2015 07:28:00 GMT": {0, true},
+	}
+	for header, want := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		if header != "" {
+			resp.Header.Set("Retry-After", header)
+		}
+		d, ok := retryAfter(resp)
+		if d != want.d || ok != want.ok {
+			t.Errorf("%q: got (%s, %v), want (%s, %v)", header, d, ok, want.d, want.ok)
+		}
+	}
+}
@@ -21,10 +21,16 @@ type SearchService interface {
 }
 
 type SearchResults struct {
-	Defs   []*Def                  `json:",omitempty"`
-	People []*Person               `json:",omitempty"`
-	Repos  []*Repo                 `json:",omitempty"`
-	Tree   []*RepoTreeSearchResult `json:",omitempty"`
+	Defs         []*Def                  `json:",omitempty"`
+	People       []*Person               `json:",omitempty"`
+	Repos        []*Repo                 `json:",omitempty"`
+	Tree         []*RepoTreeSearchResult `json:",omitempty"`
+	Issues       []*Issue                `json:",omitempty"`
+	PullRequests []*PullRequest          `json:",omitempty"`
+
+	// Totals are the total numbers of results of each kind (not just
+	// the number on this page).
+	Totals SearchResultTotals
 
 	// RawQuery is the raw query passed to search.
 	RawQuery RawQuery
@@ -55,7 +61,21 @@ type SearchResults struct {
 
 // Empty is whether there are no search results for any result type.
 func (r *SearchResults) Empty() bool {
-	return len(r.Defs) == 0 && len(r.People) == 0 && len(r.Repos) == 0 && len(r.Tree) == 0
+	return len(r.Defs) == 0 && len(r.People) == 0 && len(r.Repos) == 0 && len(r.Tree) == 0 && len(r.Issues) == 0 && len(r.PullRequests) == 0
+}
+
+// SearchResultTotals are the total numbers of search results of each
+// kind. The ListOptions in SearchOptions apply to each kind of result
+// separately, so one kind may have more pages than the others. To page
+// through the results of one kind, search again with only that kind
+// enabled in the SearchOptions.
+type SearchResultTotals struct {
+	Defs         int `json:",omitempty"`
+	People       int `json:",omitempty"`
+	Repos        int `json:",omitempty"`
+	Tree         int `json:",omitempty"`
+	Issues       int `json:",omitempty"`
+	PullRequests int `json:",omitempty"`
 }
 
 // A RepoTreeSearchResult is a tree search result that includes the repo
@@ -80,22 +100,17 @@ type SearchOptions struct {
 	People bool
 	Tree   bool
 
+	// Issues and PullRequests are whether to search issues and pull
+	// requests (by title and body).
+	Issues       bool `url:",omitempty"`
+	PullRequests bool `url:",omitempty"`
+
 	ListOptions
 }
 
 func (s *searchService) Search(opt *SearchOptions) (*SearchResults, Response, error) {
-	url, err := s.client.URL(router.Search, nil, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var results *SearchResults
-	resp, err := s.client.Do(req, &results)
+	resp, err := s.client.DoGet(router.Search, nil, opt, &results)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -118,18 +133,8 @@ type Completions struct {
 }
 
 func (s *searchService) Complete(q RawQuery) (*Completions, Response, error) {
-	url, err := s.client.URL(router.SearchComplete, nil, q)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var comps *Completions
-	resp, err := s.client.Do(req, &comps)
+	resp, err := s.client.DoGet(router.SearchComplete, nil, q, &comps)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -138,21 +143,13 @@ func (s *searchService) Complete(q RawQuery) (*Completions, Response, error) {
 }
 
 func (s *searchService) Suggest(q RawQuery) ([]*Suggestion, Response, error) {
-	url, err := s.client.URL(router.SearchSuggestions, nil, q)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var suggs []*Suggestion
-	resp, err := s.client.Do(req, &suggs)
+	resp, err := s.client.DoList(router.SearchSuggestions, nil, q, &suggs)
 	if err != nil {
 		return nil, resp, err
 	}
 
 	return suggs, resp, nil
 }
+
+var _ SearchService = &MockSearchService{}
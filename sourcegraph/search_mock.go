@@ -1,21 +1,48 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 type MockSearchService struct {
 	Search_   func(opt *SearchOptions) (*SearchResults, Response, error)
 	Complete_ func(q RawQuery) (*Completions, Response, error)
 	Suggest_  func(q RawQuery) ([]*Suggestion, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
 }
 
-var _ SearchService = MockSearchService{}
+// NewMockSearchService returns a new MockSearchService
+// that records calls to its methods.
+func NewMockSearchService() *MockSearchService {
+	return &MockSearchService{Calls: &MockCalls{}}
+}
 
 func (s MockSearchService) Search(opt *SearchOptions) (*SearchResults, Response, error) {
+	s.Calls.record("Search", opt)
+	if s.Search_ == nil {
+		var r0 *SearchResults
+		var r1 Response
+		return r0, r1, mockNotImplemented("SearchService.Search")
+	}
 	return s.Search_(opt)
 }
 
 func (s MockSearchService) Complete(q RawQuery) (*Completions, Response, error) {
+	s.Calls.record("Complete", q)
+	if s.Complete_ == nil {
+		var r0 *Completions
+		var r1 Response
+		return r0, r1, mockNotImplemented("SearchService.Complete")
+	}
 	return s.Complete_(q)
 }
 
 func (s MockSearchService) Suggest(q RawQuery) ([]*Suggestion, Response, error) {
+	s.Calls.record("Suggest", q)
+	if s.Suggest_ == nil {
+		var r0 []*Suggestion
+		var r1 Response
+		return r0, r1, mockNotImplemented("SearchService.Suggest")
+	}
 	return s.Suggest_(q)
 }
@@ -51,6 +51,51 @@ func TestSearchService_Search(t *testing.T) {
 	}
 }
 
+func TestSearchService_Search_issuesAndPullRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &SearchResults{
+		Issues:         []*Issue{{}},
+		PullRequests:   []*PullRequest{{}},
+		Totals:         SearchResultTotals{Issues: 3, PullRequests: 1},
+		ResolvedTokens: Tokens{},
+	}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.Search, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"q":            "q",
+			"People":       "false",
+			"Repos":        "false",
+			"Defs":         "false",
+			"Tree":         "false",
+			"Issues":       "true",
+			"PullRequests": "true",
+		})
+
+		writeJSON(w, want)
+	})
+
+	results, _, err := client.Search.Search(&SearchOptions{Query: "q", Issues: true, PullRequests: true})
+	if err != nil {
+		t.Errorf("Search.Search returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Search.Search returned %+v, want %+v", results, want)
+	}
+	if results.Empty() {
+		t.Error("Empty returned true, want false")
+	}
+}
+
 func TestSearchService_Complete(t *testing.T) {
 	setup()
 	defer teardown()
@@ -0,0 +1,174 @@
+package sourcegraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AcceptSignedURLHeader is the HTTP request header that the client
+// sends with download requests to indicate that the server may respond
+// with a pre-signed object storage URL (see SignedURL) instead of the
+// download contents.
+const AcceptSignedURLHeader = "X-Sourcegraph-Accept-Signed-URL"
+
+// SignedURLContentType is the content type of a response whose body is
+// a JSON-encoded SignedURL.
+const SignedURLContentType = "application/vnd.sourcegraph.signed-url+json"
+
+// A SignedURL is a pre-signed object storage URL that the server hands
+// back (in response to a download request) in place of large download
+// contents. The client downloads the contents directly from the URL,
+// which offloads large transfers from the API server.
+type SignedURL struct {
+	URL string
+
+	// Expires is when the URL expires. If the download is interrupted
+	// after this time, it can't be resumed.
+	Expires time.Time `json:",omitempty"`
+
+	// Size is the size of the contents in bytes, if known.
+	Size int64 `json:",omitempty"`
+
+	// Digest is the digest of the contents, in the format of the
+	// Digest header (e.g., "SHA-256=<base64-encoded digest>"), if
+	// known.
+	Digest string `json:",omitempty"`
+}
+
+// maxResumes is the maximum number of times an interrupted download
+// from a signed URL is resumed.
+const maxResumes = 3
+
+// isSignedURLResponse returns true if resp's body is a SignedURL.
+func isSignedURLResponse(resp *http.Response) bool {
+	ct := resp.Header.Get("Content-Type")
+	if i := strings.Index(ct, ";"); i != -1 {
+		ct = ct[:i]
+	}
+	return strings.TrimSpace(ct) == SignedURLContentType
+}
+
+// signedURLClient returns the HTTP client used to download from signed
+// URLs.
+func (c *Client) signedURLClient() *http.Client {
+	if c.SignedURLClient != nil {
+		return c.SignedURLClient
+	}
+	return http.DefaultClient
+}
+
+// downloadSigned starts downloading the contents at the signed URL
+// described by the JSON body of resp (which it closes). The returned
+// body resumes the download (using a Range request) if it is
+// interrupted.
+func (c *Client) downloadSigned(resp *http.Response) (io.ReadCloser, *http.Response, *SignedURL, error) {
+	var su SignedURL
+	err := json.NewDecoder(resp.Body).Decode(&su)
+	resp.Body.Close()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding signed URL: %s", err)
+	}
+	if c.MaxResponseBytes > 0 && su.Size > c.MaxResponseBytes {
+		return nil, nil, nil, ErrResponseTooLarge
+	}
+
+	req, err := http.NewRequest("GET", su.URL, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	req.Cancel = c.ctxDone()
+	oresp, err := c.signedURLClient().Do(req)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if oresp.StatusCode != http.StatusOK {
+		oresp.Body.Close()
+		return nil, oresp, nil, fmt.Errorf("downloading from signed URL: HTTP %s", oresp.Status)
+	}
+
+	var body io.ReadCloser = &resumableBody{
+		client: c.signedURLClient(),
+		url:    su.URL,
+		etag:   oresp.Header.Get("ETag"),
+		cancel: c.ctxDone(),
+		rc:     oresp.Body,
+	}
+	if c.MaxResponseBytes > 0 {
+		body = &limitedBody{rc: body, n: c.MaxResponseBytes}
+	}
+	return body, oresp, &su, nil
+}
+
+// resumableBody reads the contents of a download from a signed URL,
+// resuming it with a Range request if it is interrupted.
+type resumableBody struct {
+	client *http.Client
+	url    string
+	etag   string          // sent in If-Range to ensure the contents haven't changed
+	cancel <-chan struct{} // if closed, the download was canceled and isn't resumed
+
+	rc      io.ReadCloser
+	off     int64 // bytes read so far
+	resumes int
+}
+
+func (b *resumableBody) Read(p []byte) (int, error) {
+	n, err := b.rc.Read(p)
+	b.off += int64(n)
+	if err != nil && err != io.EOF && b.resumes < maxResumes && !b.canceled() {
+		b.resumes++
+		if rerr := b.resume(); rerr != nil {
+			return n, fmt.Errorf("%s (resuming download failed: %s)", err, rerr)
+		}
+		if n > 0 {
+			return n, nil
+		}
+		return b.Read(p)
+	}
+	return n, err
+}
+
+// canceled returns true if the download was canceled.
+func (b *resumableBody) canceled() bool {
+	select {
+	case <-b.cancel:
+		return true
+	default:
+		return false
+	}
+}
+
+// resume requests the remainder of the contents (after the bytes that
+// have already been read).
+func (b *resumableBody) resume() error {
+	b.rc.Close()
+
+	req, err := http.NewRequest("GET", b.url, nil)
+	if err != nil {
+		return err
+	}
+	req.Cancel = b.cancel
+	req.Header.Set("Range", "bytes="+strconv.FormatInt(b.off, 10)+"-")
+	if b.etag != "" {
+		req.Header.Set("If-Range", b.etag)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	wantRange := "bytes " + strconv.FormatInt(b.off, 10) + "-"
+	if resp.StatusCode != http.StatusPartialContent || !strings.HasPrefix(resp.Header.Get("Content-Range"), wantRange) {
+		resp.Body.Close()
+		return fmt.Errorf("server did not resume at byte %d (HTTP %s)", b.off, resp.Status)
+	}
+	b.rc = resp.Body
+	return nil
+}
+
+func (b *resumableBody) Close() error { return b.rc.Close() }
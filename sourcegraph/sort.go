@@ -0,0 +1,186 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Sort directions accepted in the Direction field of list options.
+const (
+	Ascending  = "asc"
+	Descending = "desc"
+)
+
+// SortOptions specifies the order in which list results are
+// returned. It is embedded in list options structs.
+//
+// If Sort is empty, the list method's default sort key is used (see
+// DefaultSort). If Direction is empty, the sort key's default
+// direction is used. Results that compare equal on the sort key are
+// always returned in a stable, server-defined order, so that
+// paginating through a list sorted by the same key yields each result
+// exactly once (as long as the list is not modified concurrently).
+type SortOptions struct {
+	Sort      string `url:",omitempty" json:",omitempty"`
+	Direction string `url:",omitempty" json:",omitempty"`
+}
+
+// A sortKey is a sort key accepted by a list method, along with the
+// direction used when no Direction is specified.
+type sortKey struct {
+	name      string
+	direction string
+}
+
+// A sortSpec describes how a list method sorts its results. The sort
+// keys that the server accepts vary by server version, so only the
+// default key is recorded; other keys are passed to the server as-is.
+type sortSpec struct {
+	// def is the sort key used when no Sort is specified.
+	def sortKey
+
+	// queryIgnoresSort is whether the server ignores the sort options
+	// when a search query is specified.
+	queryIgnoresSort bool
+}
+
+// sortSpecs maps each list options type to how its list method sorts
+// results.
+var sortSpecs = map[reflect.Type]sortSpec{
+	reflect.TypeOf(BuildListOptions{}):        {def: sortKey{"created_at", Descending}},
+	reflect.TypeOf(BuildTaskListOptions{}):    {def: sortKey{"id", Ascending}},
+	reflect.TypeOf(BuildListByQueueOptions{}): {def: sortKey{"priority", Descending}},
+
+	reflect.TypeOf(DefListOptions{}):           {def: sortKey{"key", Ascending}, queryIgnoresSort: true},
+	reflect.TypeOf(DefListRefsOptions{}):       {def: sortKey{"repo", Ascending}},
+	reflect.TypeOf(DefListExamplesOptions{}):   {def: sortKey{"relevance", Descending}},
+	reflect.TypeOf(DefListAuthorsOptions{}):    {def: sortKey{"bytes", Descending}},
+	reflect.TypeOf(DefListClientsOptions{}):    {def: sortKey{"refs", Descending}},
+	reflect.TypeOf(DefListDependentsOptions{}): {def: sortKey{"refs", Descending}},
+	reflect.TypeOf(DefListCallersOptions{}):    {def: sortKey{"key", Ascending}},
+	reflect.TypeOf(DefListCalleesOptions{}):    {def: sortKey{"key", Ascending}},
+	reflect.TypeOf(DefListVersionsOptions{}):   {def: sortKey{"commit_date", Descending}},
+
+	reflect.TypeOf(DeltaListDefsOptions{}):               {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(DeltaListAPIChangesOptions{}):         {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(DeltaListDependenciesOptions{}):       {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(DeltaListAffectedAuthorsOptions{}):    {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(DeltaListAffectedClientsOptions{}):    {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(DeltaListAffectedDependentsOptions{}): {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(DeltaListReviewersOptions{}):          {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(DeltaListIncomingOptions{}):           {def: sortKey{"repo", Ascending}},
+
+	reflect.TypeOf(IssueListOptions{}):                    {def: sortKey{"created", Descending}},
+	reflect.TypeOf(IssueListCommentsOptions{}):            {def: sortKey{"created", Ascending}},
+	reflect.TypeOf(IssueListAllCommentsOptions{}):         {def: sortKey{"updated", Ascending}},
+	reflect.TypeOf(IssueListEventsOptions{}):              {def: sortKey{"created", Ascending}},
+	reflect.TypeOf(PullRequestListOptions{}):              {def: sortKey{"created", Descending}},
+	reflect.TypeOf(PullRequestListCommentsOptions{}):      {def: sortKey{"created", Ascending}},
+	reflect.TypeOf(PullRequestListAllCommentsOptions{}):   {def: sortKey{"updated", Ascending}},
+	reflect.TypeOf(PullRequestListAffectedDefsOptions{}):  {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(PullRequestListReviewsOptions{}):       {def: sortKey{"created", Ascending}},
+	reflect.TypeOf(PullRequestListFilesOptions{}):         {def: sortKey{"path", Ascending}},
+	reflect.TypeOf(PullRequestReviewCommentListOptions{}): {def: sortKey{"created", Ascending}},
+	reflect.TypeOf(PullRequestListEventsOptions{}):        {def: sortKey{"created", Ascending}},
+
+	reflect.TypeOf(NotificationDestinationListOptions{}): {def: sortKey{"id", Ascending}},
+	reflect.TypeOf(RepoHookListOptions{}):                {def: sortKey{"id", Ascending}},
+	reflect.TypeOf(NotificationListOptions{}):            {def: sortKey{"updated", Descending}},
+
+	reflect.TypeOf(AlertListOptions{}):        {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(AlertSilenceListOptions{}): {def: sortKey{"start", Descending}},
+
+	reflect.TypeOf(OrgListMembersOptions{}): {def: sortKey{"login", Ascending}},
+	reflect.TypeOf(OrgListTeamsOptions{}):   {def: sortKey{"name", Ascending}},
+
+	reflect.TypeOf(PersonListCollaboratorsOptions{}): {def: sortKey{"commits", Descending}},
+
+	reflect.TypeOf(TrackerLinkListOptions{}): {def: sortKey{"created", Descending}},
+
+	reflect.TypeOf(RepoListOptions{}):              {def: sortKey{"uri", Ascending}, queryIgnoresSort: true},
+	reflect.TypeOf(RepoListCommitsOptions{}):       {def: sortKey{"date", Descending}},
+	reflect.TypeOf(RepoListBranchesOptions{}):      {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(RepoListTagsOptions{}):          {def: sortKey{"name", Ascending}},
+	reflect.TypeOf(RepoListAuthorsOptions{}):       {def: sortKey{"bytes", Descending}},
+	reflect.TypeOf(RepoListClientsOptions{}):       {def: sortKey{"refs", Descending}},
+	reflect.TypeOf(RepoListDependenciesOptions{}):  {def: sortKey{"uri", Ascending}},
+	reflect.TypeOf(RepoListDependentsOptions{}):    {def: sortKey{"refs", Descending}},
+	reflect.TypeOf(RepoListByContributorOptions{}): {def: sortKey{"commits", Descending}},
+	reflect.TypeOf(RepoListByClientOptions{}):      {def: sortKey{"refs", Descending}},
+	reflect.TypeOf(RepoListByRefdAuthorOptions{}):  {def: sortKey{"refs", Descending}},
+	reflect.TypeOf(RepoListByOwnerOptions{}):       {def: sortKey{"pushed", Descending}},
+	reflect.TypeOf(RepoListCollaboratorsOptions{}): {def: sortKey{"login", Ascending}},
+
+	reflect.TypeOf(UnitListOptions{}): {def: sortKey{"name", Ascending}},
+
+	reflect.TypeOf(UsersListOptions{}):     {def: sortKey{"login", Ascending}},
+	reflect.TypeOf(UsersListOrgsOptions{}): {def: sortKey{"login", Ascending}},
+	reflect.TypeOf(TokenListOptions{}):     {def: sortKey{"created", Descending}},
+}
+
+// DefaultSort returns the sort key and direction that are used for
+// the list method that accepts opt (a list options struct or a pointer
+// to one) when opt specifies no sort key. If opt's list method does
+// not support sorting, ok is false.
+func DefaultSort(opt interface{}) (sort, direction string, ok bool) {
+	spec, ok := sortSpecFor(opt)
+	if !ok {
+		return "", "", false
+	}
+	return spec.def.name, spec.def.direction, true
+}
+
+// UnsupportedSortError is returned when list options specify a sort
+// direction or combination of options that the list method does not
+// support.
+type UnsupportedSortError struct {
+	Options   string // the list options type name (e.g., "RepoListOptions")
+	Sort      string
+	Direction string
+	Reason    string
+}
+
+func (e *UnsupportedSortError) Error() string {
+	return fmt.Sprintf("unsupported sort for %s (Sort %q, Direction %q): %s", e.Options, e.Sort, e.Direction, e.Reason)
+}
+
+func sortSpecFor(opt interface{}) (sortSpec, bool) {
+	v := reflect.Indirect(reflect.ValueOf(opt))
+	if !v.IsValid() {
+		return sortSpec{}, false
+	}
+	spec, ok := sortSpecs[v.Type()]
+	return spec, ok
+}
+
+// checkSort returns an *UnsupportedSortError if the sort options in
+// opt (a list options struct or a pointer to one) are not supported
+// by opt's list method. Options types that aren't list options are
+// not checked. The sort key itself isn't checked (the server rejects
+// keys that it doesn't accept).
+func checkSort(opt interface{}) error {
+	spec, ok := sortSpecFor(opt)
+	if !ok {
+		return nil
+	}
+	v := reflect.Indirect(reflect.ValueOf(opt))
+	sort := v.FieldByName("Sort").String()
+	direction := v.FieldByName("Direction").String()
+
+	newErr := func(reason string) error {
+		return &UnsupportedSortError{Options: v.Type().Name(), Sort: sort, Direction: direction, Reason: reason}
+	}
+
+	switch direction {
+	case "", Ascending, Descending:
+	default:
+		return newErr(fmt.Sprintf("direction must be %q or %q", Ascending, Descending))
+	}
+
+	if sort != "" && spec.queryIgnoresSort {
+		if q := v.FieldByName("Query"); q.IsValid() && q.String() != "" {
+			return newErr("sort options are ignored when a search query is specified")
+		}
+	}
+	return nil
+}
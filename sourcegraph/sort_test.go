@@ -0,0 +1,131 @@
+package sourcegraph
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestCheckSort(t *testing.T) {
+	tests := map[string]struct {
+		opt     interface{}
+		wantErr bool
+	}{
+		"nil":                {opt: (*RepoListOptions)(nil)},
+		"not list options":   {opt: &RepoGetOptions{}},
+		"default":            {opt: &RepoListOptions{}},
+		"supported":          {opt: &RepoListOptions{Sort: "name", Direction: Descending}},
+		"direction only":     {opt: &RepoListOptions{Direction: Ascending}},
+		"embedded":           {opt: &IssueListOptions{SortOptions: SortOptions{Sort: "updated"}}},
+		"non-pointer":        {opt: UnitListOptions{SortOptions: SortOptions{Sort: "type"}}},
+		"unknown key":        {opt: &RepoListOptions{Sort: "stars"}},
+		"unsupported dir":    {opt: &RepoListOptions{Sort: "name", Direction: "up"}, wantErr: true},
+		"query ignores sort": {opt: &DefListOptions{Query: "q", Sort: "name"}, wantErr: true},
+		"query and sort":     {opt: &UsersListOptions{Query: "q", Sort: "name"}},
+	}
+	for label, test := range tests {
+		err := checkSort(test.opt)
+		if test.wantErr {
+			if _, ok := err.(*UnsupportedSortError); !ok {
+				t.Errorf("%s: got err %v, want *UnsupportedSortError", label, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: checkSort: %s", label, err)
+		}
+	}
+}
+
+func TestDefaultSort(t *testing.T) {
+	sort, direction, ok := DefaultSort(&BuildListOptions{})
+	if !ok || sort != "created_at" || direction != Descending {
+		t.Errorf("got DefaultSort %q, %q, %v, want %q, %q, true", sort, direction, ok, "created_at", Descending)
+	}
+
+	if _, _, ok := DefaultSort(RepoGetOptions{}); ok {
+		t.Error("got ok == true for non-list options, want false")
+	}
+}
+
+func TestClient_URL_unsupportedSort(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.URL(router.Repos, nil, &RepoListOptions{Sort: "name", Direction: "up"})
+	if _, ok := err.(*UnsupportedSortError); !ok {
+		t.Errorf("got err %v, want *UnsupportedSortError", err)
+	}
+}
+
+func TestClient_URL_unknownSortKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Sort keys that the client doesn't know about are left for the
+	// server to accept or reject.
+	u, err := client.URL(router.Repos, nil, &RepoListOptions{Sort: "stars"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Query().Get("Sort"); got != "stars" {
+		t.Errorf("got Sort %q, want %q", got, "stars")
+	}
+}
+
+// TestSortSpecs_allListOptions checks that every list options struct
+// (every struct that embeds ListOptions) has sort options and an entry
+// in sortSpecs.
+func TestSortSpecs_allListOptions(t *testing.T) {
+	// Search results are ranked by relevance to the query.
+	unsorted := map[string]bool{"SearchOptions": true}
+
+	registered := map[string]bool{}
+	for typ := range sortSpecs {
+		registered[typ.Name()] = true
+	}
+
+	fset := token.NewFileSet()
+	notTest := func(fi os.FileInfo) bool { return !strings.HasSuffix(fi.Name(), "_test.go") }
+	pkgs, err := parser.ParseDir(fset, ".", notTest, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range pkgs["sourcegraph"].Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			var hasList, hasSort bool
+			for _, field := range st.Fields.List {
+				if len(field.Names) == 0 {
+					if id, ok := field.Type.(*ast.Ident); ok {
+						hasList = hasList || id.Name == "ListOptions"
+						hasSort = hasSort || id.Name == "SortOptions"
+					}
+				}
+				for _, name := range field.Names {
+					hasSort = hasSort || name.Name == "Sort"
+				}
+			}
+			if !hasList || unsorted[spec.Name.Name] {
+				return false
+			}
+			if !hasSort {
+				t.Errorf("%s embeds ListOptions but has no sort options (embed SortOptions)", spec.Name.Name)
+			}
+			if !registered[spec.Name.Name] {
+				t.Errorf("%s embeds ListOptions but has no entry in sortSpecs", spec.Name.Name)
+			}
+			return false
+		})
+	}
+}
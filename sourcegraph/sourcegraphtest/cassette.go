@@ -0,0 +1,291 @@
+package sourcegraphtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+// A Cassette is a recording of a client's API requests and the
+// server's responses, which can be replayed in tests without a live
+// server. Cassettes are recorded with RecordClient and replayed with
+// ReplayClient (or both, with CassetteClient), and are stored as JSON
+// files.
+type Cassette struct {
+	Interactions []*Interaction
+}
+
+// An Interaction is a recorded API request and its response.
+type Interaction struct {
+	Request  RecordedRequest
+	Response RecordedResponse
+}
+
+// A RecordedRequest is a recorded API request. Its headers are not
+// recorded, and secrets in its URL and body are redacted with
+// sourcegraph.RedactSecrets (so that credentials aren't stored in
+// cassettes).
+type RecordedRequest struct {
+	Method string
+
+	// URL is the request's path and query, relative to the client's
+	// base URL (such as "repos/github.com/foo/bar?Stats=true").
+	URL string
+
+	Body string `json:",omitempty"`
+}
+
+// A RecordedResponse is a recorded API response. Set-Cookie headers
+// are not recorded, and secrets in the body (such as the token returned
+// when a token is created) are redacted with sourcegraph.RedactSecrets.
+type RecordedResponse struct {
+	StatusCode int
+	Header     http.Header `json:",omitempty"`
+	Body       string      `json:",omitempty"`
+}
+
+// LoadCassette reads the cassette file at path.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("cassette %s: %s", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes c to the cassette file at path.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// relativeURL returns the path and query of req's URL relative to
+// basePath.
+func relativeURL(req *http.Request, basePath string) string {
+	return strings.TrimPrefix(req.URL.RequestURI(), strings.TrimSuffix(basePath, "/")+"/")
+}
+
+// isStreamingResponse reports whether resp's body is a stream of
+// records (such as newline-delimited JSON or server-sent events) that
+// may stay open indefinitely.
+func isStreamingResponse(resp *http.Response) bool {
+	ct := resp.Header.Get("Content-Type")
+	return strings.HasPrefix(ct, "application/x-ndjson") || strings.HasPrefix(ct, "text/event-stream")
+}
+
+// readRequestBody returns the body of req without consuming it.
+func readRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	if req.GetBody == nil {
+		return "", fmt.Errorf("cassette: can't read body of %s %s", req.Method, req.URL)
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	b, err := ioutil.ReadAll(body)
+	return string(b), err
+}
+
+// RecordClient returns a copy of c that records its requests (sent to
+// the server by c) and their responses, and a func that saves the
+// recording to the cassette file at path. Requests that fail without a
+// response (such as network errors) are not recorded.
+//
+// Streaming responses (see isStreamingResponse), such as audit log
+// exports, are passed through unrecorded, because reading them to the
+// end would block on streams that stay open (such as exports with
+// Follow set). Tests that make such requests can't be replayed.
+func RecordClient(c *sourcegraph.Client, path string) (client *sourcegraph.Client, save func() error) {
+	var (
+		mu       sync.Mutex
+		cassette Cassette
+	)
+	basePath := c.BaseURL.Path
+
+	c2 := c.Clone()
+	c2.Use(func(next http.RoundTripper) http.RoundTripper {
+		return sourcegraph.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			reqBody, err := readRequestBody(req)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil || isStreamingResponse(resp) {
+				return resp, err
+			}
+			respBody, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+			header := http.Header{}
+			for k, v := range resp.Header {
+				if k != "Set-Cookie" {
+					header[k] = v
+				}
+			}
+			mu.Lock()
+			cassette.Interactions = append(cassette.Interactions, &Interaction{
+				Request:  RecordedRequest{Method: req.Method, URL: sourcegraph.RedactSecrets(relativeURL(req, basePath)), Body: sourcegraph.RedactSecrets(reqBody)},
+				Response: RecordedResponse{StatusCode: resp.StatusCode, Header: header, Body: sourcegraph.RedactSecrets(string(respBody))},
+			})
+			mu.Unlock()
+			return resp, nil
+		})
+	})
+
+	return c2, func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return cassette.Save(path)
+	}
+}
+
+// A Replayer is an http.RoundTripper that serves requests from the
+// interactions in a cassette, without contacting a server. A request
+// is served by the first unused interaction with the same method,
+// relative URL, and body (after redacting secrets from the URL and
+// body, as RecordClient does); identical requests are served by their
+// interactions in the order they were recorded. Requests that no
+// unused interaction matches fail with an error.
+type Replayer struct {
+	// BasePath is the path of the client's base URL, which is removed
+	// from request paths before they are matched (see
+	// RecordedRequest.URL).
+	BasePath string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer returns a Replayer that serves requests from c.
+func NewReplayer(c *Cassette, basePath string) *Replayer {
+	return &Replayer{BasePath: basePath, cassette: c, used: make([]bool, len(c.Interactions))}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	body = sourcegraph.RedactSecrets(body)
+	url := sourcegraph.RedactSecrets(relativeURL(req, r.BasePath))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request.Method != req.Method || in.Request.URL != url || in.Request.Body != body {
+			continue
+		}
+		r.used[i] = true
+		header := http.Header{}
+		for k, v := range in.Response.Header {
+			header[k] = v
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette: no recorded interaction for %s %s", req.Method, url)
+}
+
+// Unused returns the interactions that haven't been replayed. Tests may
+// check that it is empty, to detect stale cassettes.
+func (r *Replayer) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []*Interaction
+	for i, in := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, in)
+		}
+	}
+	return unused
+}
+
+// ReplayClient returns a Client whose requests are served from the
+// cassette file at path (see Replayer).
+func ReplayClient(path string) (*sourcegraph.Client, *Replayer, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	hc := &http.Client{}
+	c := sourcegraph.NewClient(hc)
+	r := NewReplayer(cassette, c.BaseURL.Path)
+	hc.Transport = r
+	return c, r, nil
+}
+
+// RecordEnv is the environment variable that makes CassetteClient
+// record new cassettes (if it is set to a non-empty value) instead of
+// replaying existing ones.
+const RecordEnv = "SOURCEGRAPHTEST_RECORD"
+
+// CassetteClient returns a Client for a test that uses the cassette
+// file at path. Normally the client replays the cassette (see
+// ReplayClient). If the RecordEnv environment variable is set, or the
+// cassette doesn't exist, the client is a recording copy of the client
+// returned by live (see RecordClient), and done saves the cassette.
+// The caller must call done when the test finishes:
+//
+//	c, done, err := sourcegraphtest.CassetteClient("testdata/repos.json", func() *sourcegraph.Client {
+//		c := sourcegraph.NewClient(nil)
+//		c.Credentials = sourcegraph.StaticToken(os.Getenv("SRC_TOKEN"))
+//		return c
+//	})
+//	if err != nil { t.Fatal(err) }
+//	defer func() {
+//		if err := done(); err != nil { t.Error(err) }
+//	}()
+//
+// When replaying, done returns an error if some interactions weren't
+// replayed.
+func CassetteClient(path string, live func() *sourcegraph.Client) (c *sourcegraph.Client, done func() error, err error) {
+	_, statErr := os.Stat(path)
+	if os.Getenv(RecordEnv) != "" || os.IsNotExist(statErr) {
+		c, save := RecordClient(live(), path)
+		return c, save, nil
+	}
+
+	c, r, err := ReplayClient(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, func() error {
+		if unused := r.Unused(); len(unused) > 0 {
+			return fmt.Errorf("cassette %s: %d interactions were not replayed (first: %s %s)", path, len(unused), unused[0].Request.Method, unused[0].Request.URL)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,174 @@
+package sourcegraphtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+func TestCassette_recordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sourcegraphtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/repos/r.com/x" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=s")
+		w.Write([]byte(`{"URI":"r.com/x","Description":"d"}`))
+	}))
+	defer server.Close()
+
+	live := func() *sourcegraph.Client {
+		c := sourcegraph.NewClient(nil)
+		c.BaseURL, _ = url.Parse(server.URL + "/api/")
+		c.Credentials = sourcegraph.StaticToken("secret")
+		return c
+	}
+
+	// Record (the cassette doesn't exist yet).
+	c, done, err := CassetteClient(path, live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _, err := c.Repos.Get(sourcegraph.RepoSpec{URI: "r.com/x"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := done(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"secret", "session=s"} {
+		if strings.Contains(string(data), s) {
+			t.Errorf("cassette contains %q:\n%s", s, data)
+		}
+	}
+
+	// Replay, without contacting the server.
+	server.Close()
+	requests = 0
+	c, done, err = CassetteClient(path, live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := done(); err == nil {
+		t.Error("done: err == nil before the recorded interaction was replayed")
+	}
+	repo, _, err := c.Repos.Get(sourcegraph.RepoSpec{URI: "r.com/x"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(repo, want) {
+		t.Errorf("replayed %+v, want %+v", repo, want)
+	}
+	if requests != 0 {
+		t.Errorf("replay sent %d requests to the server", requests)
+	}
+	if err := done(); err != nil {
+		t.Error(err)
+	}
+
+	// Each interaction is replayed once, and unrecorded requests fail.
+	if _, _, err := c.Repos.Get(sourcegraph.RepoSpec{URI: "r.com/x"}, nil); err == nil {
+		t.Error("second replay of a single interaction: err == nil")
+	}
+	if _, _, err := c.Repos.Get(sourcegraph.RepoSpec{URI: "r.com/other"}, nil); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("got error %v, want no recorded interaction", err)
+	}
+}
+
+func TestReplayer_matchesBody(t *testing.T) {
+	cassette := &Cassette{Interactions: []*Interaction{
+		{Request: RecordedRequest{Method: "POST", URL: "markdown", Body: "a"}, Response: RecordedResponse{StatusCode: 200, Body: "A"}},
+		{Request: RecordedRequest{Method: "POST", URL: "markdown", Body: "b"}, Response: RecordedResponse{StatusCode: 201, Body: "B"}},
+	}}
+	r := NewReplayer(cassette, "/api/")
+
+	req, _ := http.NewRequest("POST", "https://example.com/api/markdown", strings.NewReader("b"))
+	resp, err := r.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != 201 || string(body) != "B" {
+		t.Errorf("got %d %q, want 201 %q", resp.StatusCode, body, "B")
+	}
+	if unused := r.Unused(); len(unused) != 1 || unused[0] != cassette.Interactions[0] {
+		t.Errorf("got unused %+v, want the first interaction", unused)
+	}
+}
+
+func TestRecordClient_redactsSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sourcegraphtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/users/u/tokens":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"1","Note":"n","Token":"t0k3n"}`))
+		case "/api/audit-events/export":
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Write([]byte(`{"ID":"e0"}` + "\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := sourcegraph.NewClient(nil)
+	c.BaseURL, _ = url.Parse(server.URL + "/api/")
+	c, save := RecordClient(c, path)
+
+	tok, _, err := c.Tokens.Create(sourcegraph.UserSpec{Login: "u"}, &sourcegraph.AccessTokenCreateRequest{Note: "n", Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.Token != "t0k3n" {
+		t.Errorf("got token %q, want the live token", tok.Token)
+	}
+	stream, _, err := c.AuditLog.Export(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Close()
+	if err := save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != 1 {
+		t.Fatalf("got %d interactions, want 1 (streaming responses aren't recorded)", len(cassette.Interactions))
+	}
+	if body := cassette.Interactions[0].Response.Body; strings.Contains(body, "t0k3n") || !strings.Contains(body, `"Note":"n"`) {
+		t.Errorf("got recorded response body %q, want the token redacted", body)
+	}
+}
@@ -0,0 +1,251 @@
+// Package sourcegraphtest provides a fake Sourcegraph API server for
+// testing code that uses the sourcegraph package.
+//
+// The fake server serves the pull requests and issues that are
+// imported into it (from archives created by PullRequestsService.Export
+// and IssuesService.Export), so tests can run against realistic data
+// exported from a real server:
+//
+//	s := sourcegraphtest.NewServer()
+//	defer s.Close()
+//	if err := s.ImportPullRequest(archive); err != nil { ... }
+//	pull, _, err := s.Client().PullRequests.Get(spec, nil)
+//
+// Requests to other API routes fail with HTTP 404 Not Found.
+//
+// For tests that need other data, CassetteClient records a real
+// server's responses once and replays them (see Cassette).
+package sourcegraphtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+	"github.com/fossas/mux"
+)
+
+// A Server is a fake Sourcegraph API server.
+type Server struct {
+	// Server is the underlying HTTP test server.
+	*httptest.Server
+
+	mu     sync.Mutex
+	pulls  map[sourcegraph.PullRequestSpec]*sourcegraph.PullRequestArchive
+	issues map[sourcegraph.IssueSpec]*sourcegraph.IssueArchive
+}
+
+// NewServer starts and returns a new fake server. The caller should
+// call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		pulls:  map[sourcegraph.PullRequestSpec]*sourcegraph.PullRequestArchive{},
+		issues: map[sourcegraph.IssueSpec]*sourcegraph.IssueArchive{},
+	}
+
+	r := router.NewAPIRouter(nil)
+	r.Get(router.RepoPullRequest).HandlerFunc(s.servePullRequest)
+	r.Get(router.RepoPullRequestComments).HandlerFunc(s.servePullRequestComments)
+	r.Get(router.RepoPullRequestReviews).HandlerFunc(s.servePullRequestReviews)
+	r.Get(router.RepoPullRequestReview).HandlerFunc(s.servePullRequestReview)
+	r.Get(router.RepoPullRequestFiles).HandlerFunc(s.servePullRequestFiles)
+	r.Get(router.RepoPullRequestExport).HandlerFunc(s.servePullRequestExport)
+	r.Get(router.RepoIssue).HandlerFunc(s.serveIssue)
+	r.Get(router.RepoIssueComments).HandlerFunc(s.serveIssueComments)
+	r.Get(router.RepoIssueExport).HandlerFunc(s.serveIssueExport)
+
+	s.Server = httptest.NewServer(r)
+	return s
+}
+
+// Client returns a new API client that communicates with s.
+func (s *Server) Client() *sourcegraph.Client {
+	c := sourcegraph.NewClient(nil)
+	c.BaseURL, _ = url.Parse(s.URL)
+	return c
+}
+
+// ImportPullRequest adds the pull request in the archive to s,
+// replacing any existing pull request with the same repository and
+// number. The archive's pull request must have its Number and BaseRepo
+// (or HTMLURL) fields set (as in archives returned by
+// PullRequestsService.Export).
+func (s *Server) ImportPullRequest(archive *sourcegraph.PullRequestArchive) error {
+	if archive.Version != sourcegraph.ArchiveVersion {
+		return fmt.Errorf("unsupported archive version %d (want %d)", archive.Version, sourcegraph.ArchiveVersion)
+	}
+	if archive.PullRequest == nil {
+		return errors.New("archive has no pull request")
+	}
+	if err := archive.PullRequest.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pulls[archive.PullRequest.Spec()] = archive
+	return nil
+}
+
+// ImportIssue adds the issue in the archive to s, replacing any
+// existing issue with the same repository and number. The archive's
+// issue must have its Number and HTMLURL fields set, and the HTMLURL
+// must be of the form "https://host/path/to/repo/issues/number" (as in
+// archives returned by IssuesService.Export).
+func (s *Server) ImportIssue(archive *sourcegraph.IssueArchive) error {
+	if archive.Version != sourcegraph.ArchiveVersion {
+		return fmt.Errorf("unsupported archive version %d (want %d)", archive.Version, sourcegraph.ArchiveVersion)
+	}
+	if archive.Issue == nil {
+		return errors.New("archive has no issue")
+	}
+	if err := archive.Issue.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issues[archive.Issue.Spec()] = archive
+	return nil
+}
+
+func (s *Server) pullRequest(w http.ResponseWriter, r *http.Request) *sourcegraph.PullRequestArchive {
+	spec, err := sourcegraph.UnmarshalPullRequestSpec(mux.Vars(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	archive, ok := s.pulls[sourcegraph.PullRequestSpec{Repo: sourcegraph.RepoSpec{URI: spec.Repo.URI}, Number: spec.Number}]
+	if !ok {
+		http.Error(w, "pull request not found", http.StatusNotFound)
+		return nil
+	}
+	return archive
+}
+
+func (s *Server) servePullRequest(w http.ResponseWriter, r *http.Request) {
+	if archive := s.pullRequest(w, r); archive != nil {
+		writeJSON(w, archive.PullRequest)
+	}
+}
+
+func (s *Server) servePullRequestComments(w http.ResponseWriter, r *http.Request) {
+	if archive := s.pullRequest(w, r); archive != nil {
+		start, end := page(w, r, len(archive.Comments))
+		writeJSON(w, archive.Comments[start:end])
+	}
+}
+
+func (s *Server) servePullRequestReviews(w http.ResponseWriter, r *http.Request) {
+	if archive := s.pullRequest(w, r); archive != nil {
+		start, end := page(w, r, len(archive.Reviews))
+		writeJSON(w, archive.Reviews[start:end])
+	}
+}
+
+func (s *Server) servePullRequestReview(w http.ResponseWriter, r *http.Request) {
+	spec, err := sourcegraph.UnmarshalPullRequestReviewSpec(mux.Vars(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if archive := s.pullRequest(w, r); archive != nil {
+		for _, review := range archive.Reviews {
+			if review.ID == spec.Review {
+				writeJSON(w, review)
+				return
+			}
+		}
+		http.Error(w, "review not found", http.StatusNotFound)
+	}
+}
+
+func (s *Server) servePullRequestFiles(w http.ResponseWriter, r *http.Request) {
+	if archive := s.pullRequest(w, r); archive != nil {
+		var files []*sourcegraph.FileDiff
+		if archive.Files != nil {
+			files = archive.Files.FileDiffs
+		}
+		start, end := page(w, r, len(files))
+		writeJSON(w, files[start:end])
+	}
+}
+
+func (s *Server) servePullRequestExport(w http.ResponseWriter, r *http.Request) {
+	if archive := s.pullRequest(w, r); archive != nil {
+		writeJSON(w, archive)
+	}
+}
+
+func (s *Server) issue(w http.ResponseWriter, r *http.Request) *sourcegraph.IssueArchive {
+	spec, err := sourcegraph.UnmarshalIssueSpec(mux.Vars(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	archive, ok := s.issues[sourcegraph.IssueSpec{Repo: sourcegraph.RepoSpec{URI: spec.Repo.URI}, Number: spec.Number}]
+	if !ok {
+		http.Error(w, "issue not found", http.StatusNotFound)
+		return nil
+	}
+	return archive
+}
+
+func (s *Server) serveIssue(w http.ResponseWriter, r *http.Request) {
+	if archive := s.issue(w, r); archive != nil {
+		writeJSON(w, archive.Issue)
+	}
+}
+
+func (s *Server) serveIssueComments(w http.ResponseWriter, r *http.Request) {
+	if archive := s.issue(w, r); archive != nil {
+		start, end := page(w, r, len(archive.Comments))
+		writeJSON(w, archive.Comments[start:end])
+	}
+}
+
+func (s *Server) serveIssueExport(w http.ResponseWriter, r *http.Request) {
+	if archive := s.issue(w, r); archive != nil {
+		writeJSON(w, archive)
+	}
+}
+
+// page returns the bounds of the page of a list of n items requested
+// by r (with its Page and PerPage query parameters), and sets the
+// X-Total-Count response header.
+func page(w http.ResponseWriter, r *http.Request, n int) (start, end int) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(n))
+
+	pageNum, _ := strconv.Atoi(r.FormValue("Page"))
+	perPage, _ := strconv.Atoi(r.FormValue("PerPage"))
+	opt := sourcegraph.ListOptions{Page: pageNum, PerPage: perPage}
+	start = opt.Offset()
+	if start > n {
+		start = n
+	}
+	end = start + opt.Limit()
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,86 @@
+package sourcegraphtest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+	"github.com/sourcegraph/go-github/github"
+)
+
+func TestServer_ImportPullRequest(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	pullSpec := sourcegraph.PullRequestSpec{Repo: sourcegraph.RepoSpec{URI: "r.com/x/y"}, Number: 1}
+	archive := &sourcegraph.PullRequestArchive{
+		Version:  sourcegraph.ArchiveVersion,
+		Exported: time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC),
+		PullRequest: &sourcegraph.PullRequest{
+			PullRequest: github.PullRequest{
+				Number:  github.Int(1),
+				HTMLURL: github.String("https://r.com/x/y/pull/1"),
+			},
+			BaseRepo: sourcegraph.RepoSpec{URI: "r.com/x/y"},
+		},
+		Comments: []*sourcegraph.PullRequestComment{
+			{PullRequestComment: github.PullRequestComment{ID: github.Int(1), Body: github.String("a")}},
+			{PullRequestComment: github.PullRequestComment{ID: github.Int(2), Body: github.String("b")}},
+			{PullRequestComment: github.PullRequestComment{ID: github.Int(3), Body: github.String("c")}},
+		},
+		Reviews: []*sourcegraph.PullRequestReview{{ID: 7, State: "approved", Submitted: time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)}},
+	}
+	if err := s.ImportPullRequest(archive); err != nil {
+		t.Fatal(err)
+	}
+	c := s.Client()
+
+	exported, _, err := c.PullRequests.Export(pullSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exported, archive) {
+		t.Errorf("got exported archive %+v, want %+v", exported, archive)
+	}
+
+	comments, resp, err := c.PullRequests.ListComments(pullSpec, &sourcegraph.PullRequestListCommentsOptions{ListOptions: sourcegraph.ListOptions{PerPage: 2, Page: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := archive.Comments[2:]; !reflect.DeepEqual(comments, want) {
+		t.Errorf("got comments %+v, want %+v", comments, want)
+	}
+	if tc := resp.TotalCount(); tc != 3 {
+		t.Errorf("got total count %d, want 3", tc)
+	}
+
+	review, _, err := c.PullRequests.GetReview(sourcegraph.PullRequestReviewSpec{Pull: pullSpec, Review: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(review, archive.Reviews[0]) {
+		t.Errorf("got review %+v, want %+v", review, archive.Reviews[0])
+	}
+
+	if _, resp, err := c.PullRequests.Get(sourcegraph.PullRequestSpec{Repo: pullSpec.Repo, Number: 2}, nil); err == nil || resp.(*sourcegraph.HTTPResponse).StatusCode != 404 {
+		t.Errorf("got error %v for nonexistent pull request, want HTTP 404", err)
+	}
+}
+
+func TestServer_ImportIssue_invalid(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	tests := []*sourcegraph.IssueArchive{
+		{Version: sourcegraph.ArchiveVersion + 1, Issue: &sourcegraph.Issue{Issue: github.Issue{Number: github.Int(1), HTMLURL: github.String("https://r.com/x/y/issues/1")}}},
+		{Version: sourcegraph.ArchiveVersion},
+		{Version: sourcegraph.ArchiveVersion, Issue: &sourcegraph.Issue{Issue: github.Issue{Number: github.Int(1), HTMLURL: github.String("https://r.com")}}},
+		{Version: sourcegraph.ArchiveVersion, Issue: &sourcegraph.Issue{Issue: github.Issue{Number: github.Int(1), HTMLURL: github.String("x/1")}}},
+	}
+	for _, archive := range tests {
+		if err := s.ImportIssue(archive); err == nil {
+			t.Errorf("%+v: got nil error, want error", archive)
+		}
+	}
+}
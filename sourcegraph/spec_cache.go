@@ -0,0 +1,176 @@
+package sourcegraph
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// A SpecCache memoizes the results of getting entities by spec
+// (ReposService.Get, DefsService.Get, and PeopleService.Get) for a
+// fixed time (TTL), and de-duplicates concurrent identical requests so
+// that only one is sent to the server and its result is shared. It is
+// intended for processes (such as indexers) that fetch the same
+// entities from many goroutines:
+//
+//	client.SpecCache = &sourcegraph.SpecCache{Repos: time.Minute, Defs: time.Minute}
+//
+// Results are keyed by request URL (including options) and
+// Authorization header, like Client.Cache. Errors are not memoized.
+// The returned values are shared by all callers, so they must not be
+// modified. A SpecCache is safe for concurrent use and may be shared
+// by a Client and its copies (see Clone).
+type SpecCache struct {
+	// Repos, Defs, and People are the TTLs of the results of the
+	// corresponding services' Get methods. If zero, the service's
+	// results are not cached (and its requests aren't
+	// de-duplicated).
+	Repos, Defs, People time.Duration
+
+	mu        sync.Mutex
+	entries   map[string]*specCacheEntry
+	calls     map[string]*specCacheCall // in-flight requests
+	nextPrune int                       // size of entries at which expired entries are removed
+}
+
+type specCacheEntry struct {
+	v       interface{}
+	resp    Response
+	expires time.Time
+}
+
+// A specCacheCall is an in-flight request whose result is shared with
+// concurrent identical requests.
+type specCacheCall struct {
+	done chan struct{}
+	v    interface{}
+	resp Response
+	err  error
+}
+
+var errSpecCacheFetchPanicked = errors.New("SpecCache: concurrent identical request panicked")
+
+// specCacheKind identifies the service whose results are cached.
+type specCacheKind int
+
+const (
+	specCacheRepos specCacheKind = iota
+	specCacheDefs
+	specCachePeople
+)
+
+// ttl returns the TTL for results of kind, or 0 if c is nil.
+func (c *SpecCache) ttl(kind specCacheKind) time.Duration {
+	if c == nil {
+		return 0
+	}
+	switch kind {
+	case specCacheRepos:
+		return c.Repos
+	case specCacheDefs:
+		return c.Defs
+	case specCachePeople:
+		return c.People
+	}
+	return 0
+}
+
+// Purge removes all cached results.
+func (c *SpecCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+	c.nextPrune = 0
+}
+
+// do returns the result cached under key, if it hasn't expired. If
+// not, it calls fetch (or waits for a concurrent call with the same
+// key to finish) and caches the result for ttl.
+func (c *SpecCache) do(key string, ttl time.Duration, fetch func() (interface{}, Response, error)) (interface{}, Response, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		if time.Now().Before(e.expires) {
+			c.mu.Unlock()
+			return e.v, e.resp, nil
+		}
+		delete(c.entries, key)
+	}
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.v, call.resp, call.err
+	}
+	if c.calls == nil {
+		c.calls = map[string]*specCacheCall{}
+	}
+	call := &specCacheCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	returned := false
+	defer func() {
+		if !returned {
+			// fetch panicked; don't leave waiters with a nil result.
+			call.err = errSpecCacheFetchPanicked
+		}
+		c.mu.Lock()
+		delete(c.calls, key)
+		if call.err == nil {
+			c.set(key, &specCacheEntry{v: call.v, resp: call.resp, expires: time.Now().Add(ttl)})
+		}
+		c.mu.Unlock()
+		close(call.done)
+	}()
+	call.v, call.resp, call.err = fetch()
+	returned = true
+	return call.v, call.resp, call.err
+}
+
+// set stores e under key, first removing expired entries if the cache
+// has grown since they were last removed. c.mu must be held.
+func (c *SpecCache) set(key string, e *specCacheEntry) {
+	if c.entries == nil {
+		c.entries = map[string]*specCacheEntry{}
+	}
+	if len(c.entries) >= c.nextPrune {
+		now := time.Now()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.nextPrune = 2*len(c.entries) + 64
+	}
+	c.entries[key] = e
+}
+
+// getCached is like DoGet, but if c.SpecCache caches results of kind,
+// the result is served from (and stored in) c.SpecCache. v must be a
+// pointer.
+func (c *Client) getCached(kind specCacheKind, route string, routeVars map[string]string, opt interface{}, v interface{}) (Response, error) {
+	ttl := c.SpecCache.ttl(kind)
+	if ttl <= 0 {
+		return c.DoGet(route, routeVars, opt, v)
+	}
+
+	url, err := c.URL(route, routeVars, opt)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	val, resp, err := c.SpecCache.do(cacheKey(req), ttl, func() (interface{}, Response, error) {
+		resp, err := c.Do(req, v)
+		return rv.Interface(), resp, err
+	})
+	if err != nil {
+		return resp, err
+	}
+	rv.Set(reflect.ValueOf(val))
+	return resp, nil
+}
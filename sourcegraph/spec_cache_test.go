@@ -0,0 +1,129 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestSpecCache_ReposGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &Repo{URI: "r.com/x"}
+
+	var requests int32
+	mux.HandleFunc(urlPath(t, router.Repo, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		testMethod(t, r, "GET")
+		writeJSON(w, want)
+	})
+
+	client.SpecCache = &SpecCache{Repos: time.Hour}
+	for i := 0; i < 3; i++ {
+		repo, _, err := client.Repos.Get(RepoSpec{URI: "r.com/x"}, nil)
+		if err != nil {
+			t.Fatalf("Repos.Get returned error: %v", err)
+		}
+		if !reflect.DeepEqual(repo, want) {
+			t.Errorf("Repos.Get returned %+v, want %+v", repo, want)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+
+	// Different options are cached separately.
+	if _, _, err := client.Repos.Get(RepoSpec{URI: "r.com/x"}, &RepoGetOptions{Stats: true}); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+
+	client.SpecCache.Purge()
+	if _, _, err := client.Repos.Get(RepoSpec{URI: "r.com/x"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("after Purge, got %d requests, want 3", n)
+	}
+}
+
+func TestSpecCache_expiresAndDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int32
+	mux.HandleFunc(urlPath(t, router.Person, map[string]string{"PersonSpec": "a"}), func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		writeJSON(w, &Person{})
+	})
+	mux.HandleFunc(urlPath(t, router.Repo, map[string]string{"RepoSpec": "r.com/x"}), func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+
+	client.SpecCache = &SpecCache{People: time.Millisecond}
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.People.Get(PersonSpec{Login: "a"}); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("got %d requests after TTL elapsed, want 2", n)
+	}
+
+	// Repos caching is disabled (zero TTL), and errors are returned.
+	if _, _, err := client.Repos.Get(RepoSpec{URI: "r.com/x"}, nil); err == nil {
+		t.Error("err == nil")
+	}
+}
+
+func TestSpecCache_deduplicatesConcurrentRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int32
+	unblock := make(chan struct{})
+	mux.HandleFunc(urlPath(t, router.Def, map[string]string{"RepoSpec": "r.com/x", "UnitType": "t", "Unit": "u", "Path": "p"}), func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-unblock
+		writeJSON(w, &Def{})
+	})
+
+	client.SpecCache = &SpecCache{Defs: time.Hour}
+	def := DefSpec{Repo: "r.com/x", UnitType: "t", Unit: "u", Path: "p"}
+
+	const n = 10
+	var wg sync.WaitGroup
+	defs := make([]*Def, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			defs[i], _, err = client.Defs.Get(def, nil)
+			if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(unblock)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+	for i, d := range defs {
+		if d != defs[0] {
+			t.Errorf("defs[%d] = %p, want shared result %p", i, d, defs[0])
+		}
+	}
+}
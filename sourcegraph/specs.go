@@ -0,0 +1,227 @@
+package sourcegraph
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// This file contains parsers for human-typed identifiers of the
+// entities specified by the *Spec types (such as those given as
+// command-line arguments). ParseRepoSpec, ParseUserSpec,
+// ParsePersonSpec, and ParseOrgSpec are defined alongside their spec
+// types.
+
+// ParseRepoRevSpec parses a repository revision of the form
+// "repo@rev" or "repo@rev===commitID" (see RepoRevSpec). The "@rev"
+// suffix is optional.
+func ParseRepoRevSpec(s string) (RepoRevSpec, error) {
+	repo, rev := s, ""
+	if i := strings.Index(s, "@"); i != -1 {
+		repo, rev = s[:i], s[i+1:]
+		if rev == "" {
+			return RepoRevSpec{}, fmt.Errorf("empty revision in repository revision spec %q", s)
+		}
+	}
+	return UnmarshalRepoRevSpec(map[string]string{"RepoSpec": repo, "Rev": rev})
+}
+
+// ParseUnitSpec parses a source unit of the form
+// "repo[@rev]/.UnitType/unit", such as
+// "github.com/foo/bar/.GoPackage/github.com/foo/bar/baz".
+func ParseUnitSpec(s string) (UnitSpec, error) {
+	repoRev, unitType, unit, err := parseUnitParts(s)
+	if err != nil {
+		return UnitSpec{}, err
+	}
+	if unit == "" {
+		return UnitSpec{}, fmt.Errorf("empty source unit in unit spec %q", s)
+	}
+	return UnitSpec{RepoRevSpec: repoRev, UnitType: unitType, Unit: unit}, nil
+}
+
+// ParseDefSpec parses a def of the form
+// "repo[@commitID]/.UnitType/unit/.def/path" (the same form as the
+// def's API route path, without the leading "/repos/"). If the unit is
+// ".", the unit component is omitted (as in
+// "repo/.UnitType/.def/path").
+func ParseDefSpec(s string) (DefSpec, error) {
+	repoRev, unitType, rest, err := parseUnitParts(s)
+	if err != nil {
+		return DefSpec{}, err
+	}
+	if repoRev.URI == "" {
+		return DefSpec{}, fmt.Errorf("def spec %q must specify a repository URI", s)
+	}
+
+	var unit, path string
+	if rest == ".def" || strings.HasPrefix(rest, ".def/") {
+		unit, path = ".", strings.TrimPrefix(rest[len(".def"):], "/")
+	} else if i := strings.Index(rest+"/", "/.def/"); i != -1 {
+		unit, path = rest[:i], strings.TrimPrefix(rest[i+len("/.def"):], "/")
+	} else {
+		return DefSpec{}, fmt.Errorf("def spec %q has no \"/.def\" component", s)
+	}
+	if path == "" {
+		path = "."
+	}
+	return DefSpec{
+		Repo:     repoRev.URI,
+		CommitID: repoRev.Rev,
+		UnitType: unitType,
+		Unit:     unit,
+		Path:     path,
+	}, nil
+}
+
+// parseUnitParts splits a string of the form
+// "repo[@rev]/.UnitType/rest" into its components.
+func parseUnitParts(s string) (repoRev RepoRevSpec, unitType, rest string, err error) {
+	i := strings.Index(s, "/.")
+	if i == -1 {
+		return RepoRevSpec{}, "", "", fmt.Errorf("spec %q has no \"/.UnitType\" component", s)
+	}
+	repoRev, err = ParseRepoRevSpec(s[:i])
+	if err != nil {
+		return RepoRevSpec{}, "", "", err
+	}
+	unitType, rest = s[i+len("/."):], ""
+	if j := strings.Index(unitType, "/"); j != -1 {
+		unitType, rest = unitType[:j], unitType[j+1:]
+	}
+	if unitType == "" {
+		return RepoRevSpec{}, "", "", fmt.Errorf("empty unit type in spec %q", s)
+	}
+	return repoRev, unitType, rest, nil
+}
+
+// ParsePullRequestSpec parses a pull request of the form "repo#123".
+func ParsePullRequestSpec(s string) (PullRequestSpec, error) {
+	repo, number, err := parseRepoNumber(s)
+	if err != nil {
+		return PullRequestSpec{}, err
+	}
+	return PullRequestSpec{Repo: repo, Number: number}, nil
+}
+
+// ParseIssueSpec parses an issue of the form "repo#123".
+func ParseIssueSpec(s string) (IssueSpec, error) {
+	repo, number, err := parseRepoNumber(s)
+	if err != nil {
+		return IssueSpec{}, err
+	}
+	return IssueSpec{Repo: repo, Number: number}, nil
+}
+
+// parseRepoNumber parses a string of the form "repo#123".
+func parseRepoNumber(s string) (RepoSpec, int, error) {
+	i := strings.LastIndex(s, "#")
+	if i == -1 {
+		return RepoSpec{}, 0, fmt.Errorf("spec %q is not of the form \"repo#number\"", s)
+	}
+	repo, err := ParseRepoSpec(s[:i])
+	if err != nil {
+		return RepoSpec{}, 0, err
+	}
+	number, err := strconv.Atoi(s[i+1:])
+	if err != nil || number <= 0 {
+		return RepoSpec{}, 0, fmt.Errorf("invalid number in spec %q", s)
+	}
+	return repo, number, nil
+}
+
+// ParseDeltaSpec parses a delta of the form "repo@base..head" (for a
+// delta within a repository) or "repo@base..headRepo@head".
+func ParseDeltaSpec(s string) (DeltaSpec, error) {
+	i := strings.Index(s, "..")
+	if i == -1 {
+		return DeltaSpec{}, fmt.Errorf("delta spec %q is not of the form \"repo@base..head\"", s)
+	}
+	base, err := ParseRepoRevSpec(s[:i])
+	if err != nil {
+		return DeltaSpec{}, err
+	}
+	if base.Rev == "" {
+		return DeltaSpec{}, fmt.Errorf("delta spec %q has no base revision", s)
+	}
+
+	headStr := s[i+len(".."):]
+	var head RepoRevSpec
+	if strings.Contains(headStr, "@") {
+		head, err = ParseRepoRevSpec(headStr)
+	} else {
+		head, err = UnmarshalRepoRevSpec(map[string]string{"RepoSpec": base.RepoSpec.PathComponent(), "Rev": headStr})
+	}
+	if err != nil {
+		return DeltaSpec{}, err
+	}
+	if head.Rev == "" {
+		return DeltaSpec{}, fmt.Errorf("delta spec %q has no head revision", s)
+	}
+	return DeltaSpec{Base: base, Head: head}, nil
+}
+
+// specParsers maps the kind prefixes accepted by ParseSpec to the
+// parsers for the kinds.
+var specParsers = map[string]func(string) (interface{}, error){
+	"repo":   func(s string) (interface{}, error) { return ParseRepoSpec(s) },
+	"rev":    func(s string) (interface{}, error) { return ParseRepoRevSpec(s) },
+	"unit":   func(s string) (interface{}, error) { return ParseUnitSpec(s) },
+	"def":    func(s string) (interface{}, error) { return ParseDefSpec(s) },
+	"pull":   func(s string) (interface{}, error) { return ParsePullRequestSpec(s) },
+	"issue":  func(s string) (interface{}, error) { return ParseIssueSpec(s) },
+	"delta":  func(s string) (interface{}, error) { return ParseDeltaSpec(s) },
+	"user":   func(s string) (interface{}, error) { return ParseUserSpec(s) },
+	"person": func(s string) (interface{}, error) { return ParsePersonSpec(s) },
+	"org":    func(s string) (interface{}, error) { return ParseOrgSpec(s) },
+}
+
+// ParseSpec parses a human-typed identifier of any kind of entity,
+// returning a spec value (such as a RepoSpec or PullRequestSpec, not
+// a pointer).
+//
+// The kind may be given explicitly as a prefix, as in "issue:repo#1";
+// the prefixes are "repo", "rev", "unit", "def", "pull", "issue",
+// "delta", "user", "person", and "org". Otherwise the kind is inferred
+// from the form of s:
+//
+//	repo/.UnitType/unit/.def/path  DefSpec (see ParseDefSpec)
+//	repo/.UnitType/unit            UnitSpec
+//	repo#123                       PullRequestSpec (use "issue:" for issues)
+//	repo@base..head                DeltaSpec
+//	repo@rev                       RepoRevSpec
+//	name@example.com               PersonSpec (email)
+//	host.com/repo, R$123           RepoSpec
+//	login, $123                    UserSpec
+func ParseSpec(s string) (interface{}, error) {
+	if s == "" {
+		return nil, errors.New("empty spec")
+	}
+	if i := strings.Index(s, ":"); i != -1 {
+		if parse, ok := specParsers[s[:i]]; ok {
+			return parse(s[i+1:])
+		}
+	}
+
+	var kind string
+	switch at := strings.Index(s, "@"); {
+	case strings.Contains(s, "/.") && (strings.Contains(s, "/.def/") || strings.HasSuffix(s, "/.def")):
+		kind = "def"
+	case strings.Contains(s, "/."):
+		kind = "unit"
+	case strings.Contains(s, "#"):
+		kind = "pull"
+	case at != -1 && strings.Contains(s[at:], ".."):
+		kind = "delta"
+	case at != -1 && strings.Contains(s[:at], "/"):
+		kind = "rev"
+	case at != -1:
+		kind = "person"
+	case strings.Contains(s, "/") || strings.HasPrefix(s, "R$"):
+		kind = "repo"
+	default:
+		kind = "user"
+	}
+	return specParsers[kind](s)
+}
@@ -0,0 +1,66 @@
+package sourcegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	tests := map[string]interface{}{
+		"a.com/b":           RepoSpec{URI: "a.com/b"},
+		"R$123":             RepoSpec{RID: 123},
+		"repo:a.com/b":      RepoSpec{URI: "a.com/b"},
+		"a.com/b@feature/x": RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/b"}, Rev: "feature/x"},
+		"a.com/b@v1===abc":  RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/b"}, Rev: "v1", CommitID: "abc"},
+		"a.com/b#12":        PullRequestSpec{Repo: RepoSpec{URI: "a.com/b"}, Number: 12},
+		"issue:a.com/b#12":  IssueSpec{Repo: RepoSpec{URI: "a.com/b"}, Number: 12},
+		"a.com/b@v1..v2": DeltaSpec{
+			Base: RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/b"}, Rev: "v1"},
+			Head: RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/b"}, Rev: "v2"},
+		},
+		"a.com/b@v1..c.com/d@v2": DeltaSpec{
+			Base: RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/b"}, Rev: "v1"},
+			Head: RepoRevSpec{RepoSpec: RepoSpec{URI: "c.com/d"}, Rev: "v2"},
+		},
+		"a.com/b@v1/.GoPackage/a.com/b/c": UnitSpec{
+			RepoRevSpec: RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/b"}, Rev: "v1"},
+			UnitType:    "GoPackage",
+			Unit:        "a.com/b/c",
+		},
+		"a.com/b/.GoPackage/a.com/b/c/.def/T/M": DefSpec{Repo: "a.com/b", UnitType: "GoPackage", Unit: "a.com/b/c", Path: "T/M"},
+		"a.com/b@abc/.t/.def/p":                 DefSpec{Repo: "a.com/b", CommitID: "abc", UnitType: "t", Unit: ".", Path: "p"},
+		"a.com/b/.t/u/.def":                     DefSpec{Repo: "a.com/b", UnitType: "t", Unit: "u", Path: "."},
+		"alice":                                 UserSpec{Login: "alice"},
+		"$1":                                    UserSpec{UID: 1},
+		"alice@example.com":                     PersonSpec{Email: "alice@example.com"},
+		"org:acme":                              OrgSpec{Org: "acme"},
+	}
+	for s, want := range tests {
+		got, err := ParseSpec(s)
+		if err != nil {
+			t.Errorf("%q: %s", s, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %#v, want %#v", s, got, want)
+		}
+	}
+}
+
+func TestParseSpec_errors(t *testing.T) {
+	tests := []string{
+		"",
+		"a.com/b#",
+		"a.com/b#x",
+		"issue:a.com/b",
+		"a.com/b@",
+		"a.com/b/.",
+		"def:a.com/b/.t/u",
+		"R$123/.t/.def/p",
+	}
+	for _, s := range tests {
+		if got, err := ParseSpec(s); err == nil {
+			t.Errorf("%q: got %#v, want error", s, got)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package sourcegraph
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// WithContext returns a copy of c whose requests are canceled when ctx
+// is done. Reading the body of a streaming download (such as
+// BuildDataService.Download or AuditLogService.Export) made with the
+// copy fails with ctx.Err() once ctx is done, even if the download is
+// in progress:
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	f, _, err := client.WithContext(ctx).BuildData.Download(file, nil)
+//	...
+//	cancelButton.OnClick(cancel)
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := c.Clone()
+	c2.ctx = ctx
+	return c2
+}
+
+// WithProgress returns a copy of c that calls fn as the bodies of
+// streaming downloads made with the copy are read. It may be used to
+// display the progress of long downloads.
+func (c *Client) WithProgress(fn ProgressFunc) *Client {
+	c2 := c.Clone()
+	c2.progress = fn
+	return c2
+}
+
+// A ProgressFunc is called with the progress of a streaming download
+// each time its body is read. It is called from the goroutine that
+// reads the body.
+type ProgressFunc func(Progress)
+
+// Progress describes the progress of a streaming download.
+type Progress struct {
+	// Bytes is the number of bytes transferred so far.
+	Bytes int64
+
+	// Total is the total size of the download in bytes, or -1 if it is
+	// unknown.
+	Total int64
+
+	// Elapsed is the time since the download began.
+	Elapsed time.Duration
+
+	// Done is whether the download is complete.
+	Done bool
+}
+
+// ETA returns the estimated time remaining until the download is
+// complete (based on the average transfer rate so far), or -1 if it
+// can't be estimated.
+func (p Progress) ETA() time.Duration {
+	if p.Done {
+		return 0
+	}
+	if p.Total < 0 || p.Bytes <= 0 || p.Elapsed <= 0 {
+		return -1
+	}
+	return time.Duration(float64(p.Elapsed) * float64(p.Total-p.Bytes) / float64(p.Bytes))
+}
+
+// ctxDone returns a channel that is closed when c's context is done,
+// or nil if c has no context.
+func (c *Client) ctxDone() <-chan struct{} {
+	if c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Done()
+}
+
+// streamBody wraps the body of a streaming download (whose size is
+// total bytes, or -1 if unknown) so that reading it fails when c's
+// context is done and reports progress to c's ProgressFunc.
+func (c *Client) streamBody(rc io.ReadCloser, total int64) io.ReadCloser {
+	if c.progress != nil {
+		rc = &progressBody{rc: rc, fn: c.progress, total: total, start: time.Now()}
+	}
+	if c.ctx != nil {
+		rc = newContextBody(c.ctx, rc)
+	}
+	return rc
+}
+
+// contextBody is a body that is closed when its context is done, which
+// makes blocked and subsequent reads fail with the context's error.
+type contextBody struct {
+	rc   io.ReadCloser
+	ctx  context.Context
+	stop chan struct{}
+	once sync.Once
+}
+
+func newContextBody(ctx context.Context, rc io.ReadCloser) *contextBody {
+	b := &contextBody{rc: rc, ctx: ctx, stop: make(chan struct{})}
+	go func() {
+		select {
+		case <-ctx.Done():
+			rc.Close()
+		case <-b.stop:
+		}
+	}()
+	return b
+}
+
+func (b *contextBody) Read(p []byte) (int, error) {
+	if err := b.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := b.rc.Read(p)
+	if err != nil && err != io.EOF && b.ctx.Err() != nil {
+		err = b.ctx.Err()
+	}
+	return n, err
+}
+
+func (b *contextBody) Close() error {
+	b.once.Do(func() { close(b.stop) })
+	return b.rc.Close()
+}
+
+// progressBody is a body that reports the progress of reading it.
+type progressBody struct {
+	rc    io.ReadCloser
+	fn    ProgressFunc
+	total int64
+	n     int64
+	start time.Time
+}
+
+func (b *progressBody) Read(p []byte) (int, error) {
+	n, err := b.rc.Read(p)
+	b.n += int64(n)
+	if n > 0 || err == io.EOF {
+		b.fn(Progress{Bytes: b.n, Total: b.total, Elapsed: time.Since(b.start), Done: err == io.EOF})
+	}
+	return n, err
+}
+
+func (b *progressBody) Close() error { return b.rc.Close() }
@@ -0,0 +1,84 @@
+package sourcegraph
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+	"golang.org/x/net/context"
+)
+
+func TestClient_WithContext_cancelsDownload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	file := BuildDataFileSpec{RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "c"}, Path: "a/b"}
+	mux.HandleFunc(urlPath(t, router.RepoBuildDataEntry, file.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		<-unblock
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f, _, err := client.WithContext(ctx).BuildData.Download(file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if _, err := ioutil.ReadAll(f); err != context.Canceled {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+
+	// Requests made after the context is done fail immediately.
+	if _, _, err := client.WithContext(ctx).BuildData.Download(file, nil); err != context.Canceled {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClient_WithProgress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	file := BuildDataFileSpec{RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r.com/x"}, Rev: "c"}, Path: "a/b"}
+	mux.HandleFunc(urlPath(t, router.RepoBuildDataEntry, file.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "5")
+		w.Write([]byte("hello"))
+	})
+
+	var last Progress
+	f, _, err := client.WithProgress(func(p Progress) { last = p }).BuildData.Download(file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ioutil.ReadAll(f); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if last.Bytes != 5 || last.Total != 5 || !last.Done || last.ETA() != 0 {
+		t.Errorf("got final progress %+v, want 5 of 5 bytes, done", last)
+	}
+}
+
+func TestProgress_ETA(t *testing.T) {
+	tests := []struct {
+		p    Progress
+		want time.Duration
+	}{
+		{Progress{Bytes: 25, Total: 100, Elapsed: time.Second}, 3 * time.Second},
+		{Progress{Bytes: 25, Total: -1, Elapsed: time.Second}, -1},
+		{Progress{Bytes: 0, Total: 100, Elapsed: time.Second}, -1},
+	}
+	for _, test := range tests {
+		if got := test.p.ETA(); got != test.want {
+			t.Errorf("%+v: got ETA %s, want %s", test.p, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package sourcegraph
+
+import (
+	"time"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// TokensService communicates with the Sourcegraph API endpoints that
+// manage users' API access tokens (personal access tokens).
+type TokensService interface {
+	// List lists a user's access tokens. The secret token values are
+	// not included.
+	List(user UserSpec, opt *TokenListOptions) ([]*AccessToken, Response, error)
+
+	// Create creates an access token for a user with the given scopes.
+	// The returned AccessToken's Token field holds the secret token
+	// value, which can't be retrieved again later.
+	Create(user UserSpec, token *AccessTokenCreateRequest) (*AccessToken, Response, error)
+
+	// Revoke revokes an access token. Requests authenticated with the
+	// token fail after it is revoked.
+	Revoke(token TokenSpec) (Response, error)
+}
+
+// tokensService implements TokensService.
+type tokensService struct {
+	client *Client
+}
+
+var _ TokensService = &tokensService{}
+
+// TokenSpec specifies a user's access token.
+type TokenSpec struct {
+	User UserSpec
+	ID   string
+}
+
+func (s TokenSpec) RouteVars() map[string]string {
+	m := s.User.RouteVars()
+	m["TokenID"] = s.ID
+	return m
+}
+
+// UnmarshalTokenSpec marshals a map containing route variables
+// generated by (TokenSpec).RouteVars() and returns the equivalent
+// TokenSpec struct.
+func UnmarshalTokenSpec(routeVars map[string]string) (TokenSpec, error) {
+	user, err := ParseUserSpec(routeVars["UserSpec"])
+	if err != nil {
+		return TokenSpec{}, err
+	}
+	return TokenSpec{User: user, ID: routeVars["TokenID"]}, nil
+}
+
+// An AccessToken is an API access token that authenticates requests as
+// a user (with the permissions granted by its scopes).
+type AccessToken struct {
+	ID   string
+	User UserSpec
+
+	// Note describes what the token is used for.
+	Note string `json:",omitempty"`
+
+	// Scopes are the scopes that the token grants.
+	Scopes []string
+
+	// Token is the secret token value. It is only set in the response
+	// to TokensService.Create.
+	Token string `json:",omitempty"`
+
+	Created time.Time
+
+	// LastUsed is when the token was last used to authenticate a
+	// request, if ever.
+	LastUsed *time.Time `json:",omitempty"`
+
+	// Expires is when the token expires. If nil, it doesn't expire.
+	Expires *time.Time `json:",omitempty"`
+}
+
+// Spec returns the TokenSpec that specifies t.
+func (t *AccessToken) Spec() TokenSpec {
+	return TokenSpec{User: t.User, ID: t.ID}
+}
+
+type TokenListOptions struct {
+	SortOptions
+	ListOptions
+}
+
+func (s *tokensService) List(user UserSpec, opt *TokenListOptions) ([]*AccessToken, Response, error) {
+	var tokens []*AccessToken
+	resp, err := s.client.DoList(router.UserTokens, user.RouteVars(), opt, &tokens)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return tokens, resp, nil
+}
+
+// AccessTokenCreateRequest specifies an access token to create with
+// TokensService.Create.
+type AccessTokenCreateRequest struct {
+	// Note describes what the token is used for.
+	Note string `json:",omitempty"`
+
+	// Scopes are the scopes to grant to the token. At least one scope
+	// is required.
+	Scopes []string
+
+	// Expires is when the token expires. If nil, it doesn't expire.
+	Expires *time.Time `json:",omitempty"`
+}
+
+func (s *tokensService) Create(user UserSpec, token *AccessTokenCreateRequest) (*AccessToken, Response, error) {
+	if len(token.Scopes) == 0 {
+		return nil, nil, &ValidationError{Field: "Scopes", Problems: []string{"at least one scope is required"}}
+	}
+
+	var created AccessToken
+	resp, err := s.client.DoCreate(router.UserTokensCreate, user.RouteVars(), token, &created)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &created, resp, nil
+}
+
+func (s *tokensService) Revoke(token TokenSpec) (Response, error) {
+	resp, err := s.client.DoDelete(router.UserTokenRevoke, token.RouteVars())
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+var _ TokensService = &MockTokensService{}
@@ -0,0 +1,47 @@
+// generated by gen-mocks; DO NOT EDIT
+
+package sourcegraph
+
+type MockTokensService struct {
+	List_   func(user UserSpec, opt *TokenListOptions) ([]*AccessToken, Response, error)
+	Create_ func(user UserSpec, token *AccessTokenCreateRequest) (*AccessToken, Response, error)
+	Revoke_ func(token TokenSpec) (Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockTokensService returns a new MockTokensService
+// that records calls to its methods.
+func NewMockTokensService() *MockTokensService {
+	return &MockTokensService{Calls: &MockCalls{}}
+}
+
+func (s MockTokensService) List(user UserSpec, opt *TokenListOptions) ([]*AccessToken, Response, error) {
+	s.Calls.record("List", user, opt)
+	if s.List_ == nil {
+		var r0 []*AccessToken
+		var r1 Response
+		return r0, r1, mockNotImplemented("TokensService.List")
+	}
+	return s.List_(user, opt)
+}
+
+func (s MockTokensService) Create(user UserSpec, token *AccessTokenCreateRequest) (*AccessToken, Response, error) {
+	s.Calls.record("Create", user, token)
+	if s.Create_ == nil {
+		var r0 *AccessToken
+		var r1 Response
+		return r0, r1, mockNotImplemented("TokensService.Create")
+	}
+	return s.Create_(user, token)
+}
+
+func (s MockTokensService) Revoke(token TokenSpec) (Response, error) {
+	s.Calls.record("Revoke", token)
+	if s.Revoke_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("TokensService.Revoke")
+	}
+	return s.Revoke_(token)
+}
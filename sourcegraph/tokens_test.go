@@ -0,0 +1,106 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestTokenSpec(t *testing.T) {
+	spec := TokenSpec{User: UserSpec{Login: "u"}, ID: "t1"}
+	routeVars := spec.RouteVars()
+	if want := map[string]string{"UserSpec": "u", "TokenID": "t1"}; !reflect.DeepEqual(routeVars, want) {
+		t.Errorf("got route vars %+v, want %+v", routeVars, want)
+	}
+
+	spec2, err := UnmarshalTokenSpec(routeVars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(spec2, spec) {
+		t.Errorf("got spec %+v, want %+v", spec2, spec)
+	}
+}
+
+func TestTokensService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []*AccessToken{{ID: "t1", User: UserSpec{Login: "u"}, Scopes: []string{"read"}}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.UserTokens, map[string]string{"UserSpec": "u"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	tokens, _, err := client.Tokens.List(UserSpec{Login: "u"}, nil)
+	if err != nil {
+		t.Errorf("Tokens.List returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(tokens, want) {
+		t.Errorf("Tokens.List returned %+v, want %+v", tokens, want)
+	}
+}
+
+func TestTokensService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &AccessToken{ID: "t1", User: UserSpec{Login: "u"}, Note: "ci", Scopes: []string{"read"}, Token: "secret"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.UserTokensCreate, map[string]string{"UserSpec": "u"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Note":"ci","Scopes":["read"]}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	token, _, err := client.Tokens.Create(UserSpec{Login: "u"}, &AccessTokenCreateRequest{Note: "ci", Scopes: []string{"read"}})
+	if err != nil {
+		t.Errorf("Tokens.Create returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(token, want) {
+		t.Errorf("Tokens.Create returned %+v, want %+v", token, want)
+	}
+
+	if _, _, err := client.Tokens.Create(UserSpec{Login: "u"}, &AccessTokenCreateRequest{Note: "ci"}); err == nil {
+		t.Error("err == nil for token with no scopes")
+	}
+}
+
+func TestTokensService_Revoke(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.UserTokenRevoke, map[string]string{"UserSpec": "u", "TokenID": "t1"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "DELETE")
+	})
+
+	_, err := client.Tokens.Revoke(TokenSpec{User: UserSpec{Login: "u"}, ID: "t1"})
+	if err != nil {
+		t.Errorf("Tokens.Revoke returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
@@ -0,0 +1,174 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+// TrackerLinksService communicates with the endpoints in the
+// Sourcegraph API that associate pull requests and commits with issues
+// in external issue trackers (such as Jira).
+type TrackerLinksService interface {
+	// ListByPullRequest lists the tracker issues linked to a pull
+	// request.
+	ListByPullRequest(pull PullRequestSpec, opt *TrackerLinkListOptions) ([]*TrackerLink, Response, error)
+
+	// ListByCommit lists the tracker issues linked to a commit. The
+	// commit is specified by rev's Rev (or CommitID, if set).
+	ListByCommit(rev RepoRevSpec, opt *TrackerLinkListOptions) ([]*TrackerLink, Response, error)
+
+	// ListByIssue lists the pull requests and commits (in all
+	// repositories) that are linked to a tracker issue.
+	ListByIssue(issue TrackerIssueSpec, opt *TrackerLinkListOptions) ([]*TrackerLink, Response, error)
+
+	// Create links a pull request or commit to a tracker issue. The
+	// ID field of link is ignored.
+	Create(link *TrackerLink) (*TrackerLink, Response, error)
+
+	// Delete removes a link.
+	Delete(link TrackerLinkSpec) (Response, error)
+}
+
+// trackerLinksService implements TrackerLinksService.
+type trackerLinksService struct {
+	client *Client
+}
+
+var _ TrackerLinksService = &trackerLinksService{}
+
+// Issue trackers.
+const (
+	TrackerJira = "jira"
+)
+
+// jiraKeyPattern matches Jira issue keys (e.g., "PROJ-123").
+var jiraKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[1-9][0-9]*$`)
+
+// IsJiraKey returns true if key is a well-formed Jira issue key (e.g.,
+// "PROJ-123").
+func IsJiraKey(key string) bool {
+	return jiraKeyPattern.MatchString(key)
+}
+
+// TrackerIssueSpec specifies an issue in an external issue tracker.
+type TrackerIssueSpec struct {
+	Tracker string // the issue tracker (e.g., TrackerJira)
+	Key     string // the tracker's key for the issue (e.g., "PROJ-123")
+}
+
+func (s TrackerIssueSpec) RouteVars() map[string]string {
+	return map[string]string{"Tracker": s.Tracker, "TrackerIssueKey": s.Key}
+}
+
+// Validate returns an error if s's Key is not well-formed for its
+// Tracker.
+func (s TrackerIssueSpec) Validate() error {
+	if s.Tracker == "" || s.Key == "" {
+		return fmt.Errorf("tracker issue %+v: Tracker and Key must be set", s)
+	}
+	if s.Tracker == TrackerJira && !IsJiraKey(s.Key) {
+		return fmt.Errorf("invalid Jira issue key %q", s.Key)
+	}
+	return nil
+}
+
+// TrackerLinkSpec specifies a link between a pull request or commit
+// and a tracker issue.
+type TrackerLinkSpec struct {
+	ID int
+}
+
+func (s TrackerLinkSpec) RouteVars() map[string]string {
+	return map[string]string{"TrackerLinkID": strconv.Itoa(s.ID)}
+}
+
+// A TrackerLink associates a pull request or a commit with an issue in
+// an external issue tracker. Exactly one of PullRequest and CommitID
+// is set.
+type TrackerLink struct {
+	ID int `json:",omitempty"`
+
+	// Issue is the linked tracker issue.
+	Issue TrackerIssueSpec
+
+	// IssueURL is the URL of the issue's web page on the tracker. It
+	// is set by the server.
+	IssueURL string `json:",omitempty"`
+
+	// Repo is the URI of the repository that contains the pull request
+	// or commit.
+	Repo string
+
+	PullRequest int    `json:",omitempty"` // the linked pull request's number
+	CommitID    string `json:",omitempty"` // the linked commit's ID
+}
+
+// Spec returns the TrackerLinkSpec that specifies l.
+func (l *TrackerLink) Spec() TrackerLinkSpec {
+	return TrackerLinkSpec{ID: l.ID}
+}
+
+// TrackerLinkListOptions specifies options for listing tracker links.
+type TrackerLinkListOptions struct {
+	SortOptions
+	ListOptions
+}
+
+func (s *trackerLinksService) ListByPullRequest(pull PullRequestSpec, opt *TrackerLinkListOptions) ([]*TrackerLink, Response, error) {
+	return s.list(router.RepoPullRequestTrackerLinks, pull.RouteVars(), opt)
+}
+
+func (s *trackerLinksService) ListByCommit(rev RepoRevSpec, opt *TrackerLinkListOptions) ([]*TrackerLink, Response, error) {
+	return s.list(router.RepoCommitTrackerLinks, rev.RouteVars(), opt)
+}
+
+func (s *trackerLinksService) ListByIssue(issue TrackerIssueSpec, opt *TrackerLinkListOptions) ([]*TrackerLink, Response, error) {
+	return s.list(router.TrackerIssueLinks, issue.RouteVars(), opt)
+}
+
+func (s *trackerLinksService) list(route string, routeVars map[string]string, opt *TrackerLinkListOptions) ([]*TrackerLink, Response, error) {
+	var links []*TrackerLink
+	resp, err := s.client.DoList(route, routeVars, opt, &links)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return links, resp, nil
+}
+
+func (s *trackerLinksService) Create(link *TrackerLink) (*TrackerLink, Response, error) {
+	if err := link.Issue.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if (link.PullRequest == 0) == (link.CommitID == "") {
+		return nil, nil, fmt.Errorf("tracker link for %s: exactly one of PullRequest and CommitID must be set", link.Issue.Key)
+	}
+
+	url, err := s.client.URL(router.TrackerLinksCreate, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("POST", url.String(), link)
+	if err != nil {
+		return nil, nil, err
+	}
+	setIdempotencyKey(req, router.TrackerLinksCreate)
+
+	var created TrackerLink
+	resp, err := s.client.Do(req, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &created, resp, nil
+}
+
+func (s *trackerLinksService) Delete(link TrackerLinkSpec) (Response, error) {
+	return s.client.DoDelete(router.TrackerLinkDelete, link.RouteVars())
+}
+
+var _ TrackerLinksService = &MockTrackerLinksService{}
@@ -0,0 +1,96 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestIsJiraKey(t *testing.T) {
+	tests := map[string]bool{
+		"PROJ-1":    true,
+		"AB2_C-123": true,
+		"proj-1":    false,
+		"P-1":       false,
+		"PROJ-0":    false,
+		"PROJ":      false,
+		"PROJ-1x":   false,
+	}
+	for key, want := range tests {
+		if got := IsJiraKey(key); got != want {
+			t.Errorf("%q: got IsJiraKey == %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestTrackerLinksService_ListByIssue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	issue := TrackerIssueSpec{Tracker: TrackerJira, Key: "PROJ-1"}
+	want := []*TrackerLink{{ID: 1, Issue: issue, Repo: "r.com/x", PullRequest: 2}}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.TrackerIssueLinks, issue.RouteVars()), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "GET")
+
+		writeJSON(w, want)
+	})
+
+	links, _, err := client.TrackerLinks.ListByIssue(issue, nil)
+	if err != nil {
+		t.Errorf("TrackerLinks.ListByIssue returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("TrackerLinks.ListByIssue returned %+v, want %+v", links, want)
+	}
+}
+
+func TestTrackerLinksService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	link := &TrackerLink{Issue: TrackerIssueSpec{Tracker: TrackerJira, Key: "PROJ-1"}, Repo: "r.com/x", CommitID: "c"}
+	want := &TrackerLink{ID: 1, Issue: link.Issue, Repo: "r.com/x", CommitID: "c"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.TrackerLinksCreate, nil), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"Issue":{"Tracker":"jira","Key":"PROJ-1"},"Repo":"r.com/x","CommitID":"c"}`+"\n")
+
+		writeJSON(w, want)
+	})
+
+	created, _, err := client.TrackerLinks.Create(link)
+	if err != nil {
+		t.Errorf("TrackerLinks.Create returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("TrackerLinks.Create returned %+v, want %+v", created, want)
+	}
+
+	// Invalid links are rejected without contacting the server.
+	for _, l := range []*TrackerLink{
+		{Issue: TrackerIssueSpec{Tracker: TrackerJira, Key: "bad"}, Repo: "r.com/x", CommitID: "c"},
+		{Issue: link.Issue, Repo: "r.com/x"},
+		{Issue: link.Issue, Repo: "r.com/x", CommitID: "c", PullRequest: 1},
+	} {
+		if _, _, err := client.TrackerLinks.Create(l); err == nil {
+			t.Errorf("%+v: got nil error, want error", l)
+		}
+	}
+}
@@ -46,6 +46,17 @@ func UnmarshalUnitSpec(vars map[string]string) (UnitSpec, error) {
 	}, nil
 }
 
+// String returns the unit spec in the form parsed by ParseUnitSpec
+// ("repo[@rev]/.UnitType/unit"). It may be used to refer to units in
+// command-line arguments.
+func (s UnitSpec) String() string {
+	str := s.RepoSpec.PathComponent()
+	if s.Rev != "" {
+		str += "@" + s.RevPathComponent()
+	}
+	return str + "/." + s.UnitType + "/" + s.Unit
+}
+
 func (s UnitSpec) RouteVars() map[string]string {
 	v := s.RepoRevSpec.RouteVars()
 	v["UnitType"] = s.UnitType
@@ -78,23 +89,19 @@ type UnitListOptions struct {
 	// unit name, and unit data.
 	Query string `url:",omitempty" json:",omitempty"`
 
+	// Incomplete, if true, restricts the results to units whose
+	// analysis is incomplete (for example, because the unit failed to
+	// build).
+	Incomplete bool `url:",omitempty" json:",omitempty"`
+
 	// Paging
+	SortOptions
 	ListOptions
 }
 
 func (s *unitsService) Get(spec UnitSpec) (*unit.RepoSourceUnit, Response, error) {
-	url, err := s.client.URL(router.Unit, spec.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var u unit.RepoSourceUnit
-	resp, err := s.client.Do(req, &u)
+	resp, err := s.client.DoGet(router.Unit, spec.RouteVars(), nil, &u)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -103,18 +110,8 @@ func (s *unitsService) Get(spec UnitSpec) (*unit.RepoSourceUnit, Response, error
 }
 
 func (s *unitsService) List(opt *UnitListOptions) ([]*unit.RepoSourceUnit, Response, error) {
-	url, err := s.client.URL(router.Units, nil, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var units []*unit.RepoSourceUnit
-	resp, err := s.client.Do(req, &units)
+	resp, err := s.client.DoList(router.Units, nil, opt, &units)
 	if err != nil {
 		return nil, resp, err
 	}
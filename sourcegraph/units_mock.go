@@ -1,3 +1,5 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 import "github.com/abec/srclib/unit"
@@ -5,12 +7,33 @@ import "github.com/abec/srclib/unit"
 type MockUnitsService struct {
 	Get_  func(spec UnitSpec) (*unit.RepoSourceUnit, Response, error)
 	List_ func(opt *UnitListOptions) ([]*unit.RepoSourceUnit, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockUnitsService returns a new MockUnitsService
+// that records calls to its methods.
+func NewMockUnitsService() *MockUnitsService {
+	return &MockUnitsService{Calls: &MockCalls{}}
 }
 
 func (s MockUnitsService) Get(spec UnitSpec) (*unit.RepoSourceUnit, Response, error) {
+	s.Calls.record("Get", spec)
+	if s.Get_ == nil {
+		var r0 *unit.RepoSourceUnit
+		var r1 Response
+		return r0, r1, mockNotImplemented("UnitsService.Get")
+	}
 	return s.Get_(spec)
 }
 
 func (s MockUnitsService) List(opt *UnitListOptions) ([]*unit.RepoSourceUnit, Response, error) {
+	s.Calls.record("List", opt)
+	if s.List_ == nil {
+		var r0 []*unit.RepoSourceUnit
+		var r1 Response
+		return r0, r1, mockNotImplemented("UnitsService.List")
+	}
 	return s.List_(opt)
 }
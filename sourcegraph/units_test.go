@@ -60,9 +60,12 @@ func TestUnitsService_List(t *testing.T) {
 		called = true
 		testMethod(t, r, "GET")
 		testFormValues(t, r, values{
-			"RepoRevs": "r1@x,r2",
-			"PerPage":  "1",
-			"Page":     "2",
+			"RepoRevs":   "r1@x,r2",
+			"UnitType":   "t",
+			"NameQuery":  "q",
+			"Incomplete": "true",
+			"PerPage":    "1",
+			"Page":       "2",
 		})
 
 		writeJSON(w, want)
@@ -70,6 +73,9 @@ func TestUnitsService_List(t *testing.T) {
 
 	units, _, err := client.Units.List(&UnitListOptions{
 		RepoRevs:    []string{"r1@x", "r2"},
+		UnitType:    "t",
+		NameQuery:   "q",
+		Incomplete:  true,
 		ListOptions: ListOptions{PerPage: 1, Page: 2},
 	})
 	if err != nil {
@@ -84,3 +90,24 @@ func TestUnitsService_List(t *testing.T) {
 		t.Errorf("Units.List returned %+v, want %+v", units, want)
 	}
 }
+
+func TestUnitSpec_String(t *testing.T) {
+	tests := map[string]UnitSpec{
+		"x.com/r/.t/u":                {RepoRevSpec: RepoRevSpec{RepoSpec: RepoSpec{URI: "x.com/r"}}, UnitType: "t", Unit: "u"},
+		"x.com/r@v1/.t/a/b":           {RepoRevSpec: RepoRevSpec{RepoSpec: RepoSpec{URI: "x.com/r"}, Rev: "v1"}, UnitType: "t", Unit: "a/b"},
+		"x.com/r@v1===c/.GoPackage/.": {RepoRevSpec: RepoRevSpec{RepoSpec: RepoSpec{URI: "x.com/r"}, Rev: "v1", CommitID: "c"}, UnitType: "GoPackage", Unit: "."},
+	}
+	for str, spec := range tests {
+		if got := spec.String(); got != str {
+			t.Errorf("%+v: got String %q, want %q", spec, got, str)
+		}
+		parsed, err := ParseUnitSpec(str)
+		if err != nil {
+			t.Errorf("ParseUnitSpec(%q): %s", str, err)
+			continue
+		}
+		if !reflect.DeepEqual(parsed, spec) {
+			t.Errorf("ParseUnitSpec(%q): got %+v, want %+v", str, parsed, spec)
+		}
+	}
+}
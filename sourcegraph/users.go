@@ -29,6 +29,18 @@ type UsersService interface {
 	// ListEmails returns a list of a user's email addresses.
 	ListEmails(user UserSpec) ([]*EmailAddr, Response, error)
 
+	// AddEmail adds an email address to a user. The new address is
+	// unverified (and not primary) until the user verifies it.
+	AddEmail(user UserSpec, email string) (*EmailAddr, Response, error)
+
+	// RemoveEmail removes an email address from a user. A user's
+	// primary email address can't be removed.
+	RemoveEmail(user UserSpec, email string) (Response, error)
+
+	// SetPrimaryEmail makes one of a user's verified email addresses
+	// the user's primary email address.
+	SetPrimaryEmail(user UserSpec, email string) (Response, error)
+
 	// GetOrCreateFromGitHub creates a new user based a GitHub user.
 	GetOrCreateFromGitHub(user GitHubUserSpec, opt *UserGetOptions) (*User, Response, error)
 
@@ -223,18 +235,8 @@ type UserGetOptions struct {
 }
 
 func (s *usersService) Get(user_ UserSpec, opt *UserGetOptions) (*User, Response, error) {
-	url, err := s.client.URL(router.User, user_.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var user__ *User
-	resp, err := s.client.Do(req, &user__)
+	resp, err := s.client.DoGet(router.User, user_.RouteVars(), opt, &user__)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -256,23 +258,70 @@ type EmailAddr struct {
 }
 
 func (s *usersService) ListEmails(user UserSpec) ([]*EmailAddr, Response, error) {
-	url, err := s.client.URL(router.UserEmails, user.RouteVars(), nil)
+	var emails []*EmailAddr
+	resp, err := s.client.DoList(router.UserEmails, user.RouteVars(), nil, &emails)
 	if err != nil {
+		return nil, resp, err
+	}
+
+	return emails, resp, nil
+}
+
+// validateEmail returns a *ValidationError if email is obviously not
+// an email address.
+func validateEmail(email string) error {
+	if i := strings.Index(email, "@"); i <= 0 || i == len(email)-1 || strings.ContainsAny(email, " /") {
+		return &ValidationError{Field: "Email", Problems: []string{fmt.Sprintf("%q is not an email address", email)}}
+	}
+	return nil
+}
+
+// userEmailRouteVars returns the route variables for the given email
+// address of a user.
+func userEmailRouteVars(user UserSpec, email string) map[string]string {
+	rv := user.RouteVars()
+	rv["Email"] = email
+	return rv
+}
+
+func (s *usersService) AddEmail(user UserSpec, email string) (*EmailAddr, Response, error) {
+	if err := validateEmail(email); err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest("GET", url.String(), nil)
+	var added EmailAddr
+	resp, err := s.client.DoCreate(router.UserEmailsAdd, user.RouteVars(), &EmailAddr{Email: email}, &added)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var emails []*EmailAddr
-	resp, err := s.client.Do(req, &emails)
+	return &added, resp, nil
+}
+
+func (s *usersService) RemoveEmail(user UserSpec, email string) (Response, error) {
+	if err := validateEmail(email); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.DoDelete(router.UserEmailRemove, userEmailRouteVars(user, email))
 	if err != nil {
-		return nil, resp, err
+		return nil, err
 	}
 
-	return emails, resp, nil
+	return resp, nil
+}
+
+func (s *usersService) SetPrimaryEmail(user UserSpec, email string) (Response, error) {
+	if err := validateEmail(email); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.DoUpdate(router.UserEmailSetPrimary, userEmailRouteVars(user, email), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
 }
 
 // UserSettings describes a user's configuration settings.
@@ -289,18 +338,8 @@ type PlanSettings struct {
 }
 
 func (s *usersService) GetSettings(user UserSpec) (*UserSettings, Response, error) {
-	url, err := s.client.URL(router.UserSettings, user.RouteVars(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var settings *UserSettings
-	resp, err := s.client.Do(req, &settings)
+	resp, err := s.client.DoGet(router.UserSettings, user.RouteVars(), nil, &settings)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -309,17 +348,7 @@ func (s *usersService) GetSettings(user UserSpec) (*UserSettings, Response, erro
 }
 
 func (s *usersService) UpdateSettings(user UserSpec, settings UserSettings) (Response, error) {
-	url, err := s.client.URL(router.UserSettingsUpdate, user.RouteVars(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest("PUT", url.String(), settings)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.DoUpdate(router.UserSettingsUpdate, user.RouteVars(), settings, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -344,18 +373,8 @@ func (s GitHubUserSpec) RouteVars() map[string]string {
 }
 
 func (s *usersService) GetOrCreateFromGitHub(user GitHubUserSpec, opt *UserGetOptions) (*User, Response, error) {
-	url, err := s.client.URL(router.UserFromGitHub, user.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var user__ *User
-	resp, err := s.client.Do(req, &user__)
+	resp, err := s.client.DoGet(router.UserFromGitHub, user.RouteVars(), opt, &user__)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -364,17 +383,7 @@ func (s *usersService) GetOrCreateFromGitHub(user GitHubUserSpec, opt *UserGetOp
 }
 
 func (s *usersService) RefreshProfile(user_ UserSpec) (Response, error) {
-	url, err := s.client.URL(router.UserRefreshProfile, user_.RouteVars(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest("PUT", url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.DoUpdate(router.UserRefreshProfile, user_.RouteVars(), nil, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -383,17 +392,7 @@ func (s *usersService) RefreshProfile(user_ UserSpec) (Response, error) {
 }
 
 func (s *usersService) ComputeStats(user_ UserSpec) (Response, error) {
-	url, err := s.client.URL(router.UserComputeStats, user_.RouteVars(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest("PUT", url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.DoUpdate(router.UserComputeStats, user_.RouteVars(), nil, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -415,18 +414,8 @@ type UsersListOptions struct {
 }
 
 func (s *usersService) List(opt *UsersListOptions) ([]*User, Response, error) {
-	url, err := s.client.URL(router.Users, nil, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var users []*User
-	resp, err := s.client.Do(req, &users)
+	resp, err := s.client.DoList(router.Users, nil, opt, &users)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -450,18 +439,8 @@ type AugmentedPersonUsageByClient struct {
 type UsersListAuthorsOptions UsersListOptions
 
 func (s *usersService) ListAuthors(user UserSpec, opt *UsersListAuthorsOptions) ([]*AugmentedPersonUsageByClient, Response, error) {
-	url, err := s.client.URL(router.UserAuthors, user.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var people []*AugmentedPersonUsageByClient
-	resp, err := s.client.Do(req, &people)
+	resp, err := s.client.DoList(router.UserAuthors, user.RouteVars(), opt, &people)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -485,18 +464,8 @@ type AugmentedPersonUsageOfAuthor struct {
 type UsersListClientsOptions UsersListOptions
 
 func (s *usersService) ListClients(user UserSpec, opt *UsersListClientsOptions) ([]*AugmentedPersonUsageOfAuthor, Response, error) {
-	url, err := s.client.URL(router.UserClients, user.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var people []*AugmentedPersonUsageOfAuthor
-	resp, err := s.client.Do(req, &people)
+	resp, err := s.client.DoList(router.UserClients, user.RouteVars(), opt, &people)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -504,22 +473,13 @@ func (s *usersService) ListClients(user UserSpec, opt *UsersListClientsOptions)
 }
 
 type UsersListOrgsOptions struct {
+	SortOptions
 	ListOptions
 }
 
 func (s *usersService) ListOrgs(member UserSpec, opt *UsersListOrgsOptions) ([]*Org, Response, error) {
-	url, err := s.client.URL(router.UserOrgs, member.RouteVars(), opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var orgs []*Org
-	resp, err := s.client.Do(req, &orgs)
+	resp, err := s.client.DoList(router.UserOrgs, member.RouteVars(), opt, &orgs)
 	if err != nil {
 		return nil, resp, err
 	}
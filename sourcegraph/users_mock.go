@@ -1,3 +1,5 @@
+// generated by gen-mocks; DO NOT EDIT
+
 package sourcegraph
 
 type MockUsersService struct {
@@ -5,6 +7,9 @@ type MockUsersService struct {
 	GetSettings_           func(user UserSpec) (*UserSettings, Response, error)
 	UpdateSettings_        func(user UserSpec, settings UserSettings) (Response, error)
 	ListEmails_            func(user UserSpec) ([]*EmailAddr, Response, error)
+	AddEmail_              func(user UserSpec, email string) (*EmailAddr, Response, error)
+	RemoveEmail_           func(user UserSpec, email string) (Response, error)
+	SetPrimaryEmail_       func(user UserSpec, email string) (Response, error)
 	GetOrCreateFromGitHub_ func(user GitHubUserSpec, opt *UserGetOptions) (*User, Response, error)
 	RefreshProfile_        func(userSpec UserSpec) (Response, error)
 	ComputeStats_          func(userSpec UserSpec) (Response, error)
@@ -12,46 +17,148 @@ type MockUsersService struct {
 	ListAuthors_           func(user UserSpec, opt *UsersListAuthorsOptions) ([]*AugmentedPersonUsageByClient, Response, error)
 	ListClients_           func(user UserSpec, opt *UsersListClientsOptions) ([]*AugmentedPersonUsageOfAuthor, Response, error)
 	ListOrgs_              func(member UserSpec, opt *UsersListOrgsOptions) ([]*Org, Response, error)
+
+	// Calls, if set, records the calls to the mock's methods.
+	Calls *MockCalls
+}
+
+// NewMockUsersService returns a new MockUsersService
+// that records calls to its methods.
+func NewMockUsersService() *MockUsersService {
+	return &MockUsersService{Calls: &MockCalls{}}
 }
 
 func (s MockUsersService) Get(user UserSpec, opt *UserGetOptions) (*User, Response, error) {
+	s.Calls.record("Get", user, opt)
+	if s.Get_ == nil {
+		var r0 *User
+		var r1 Response
+		return r0, r1, mockNotImplemented("UsersService.Get")
+	}
 	return s.Get_(user, opt)
 }
 
 func (s MockUsersService) GetSettings(user UserSpec) (*UserSettings, Response, error) {
+	s.Calls.record("GetSettings", user)
+	if s.GetSettings_ == nil {
+		var r0 *UserSettings
+		var r1 Response
+		return r0, r1, mockNotImplemented("UsersService.GetSettings")
+	}
 	return s.GetSettings_(user)
 }
 
 func (s MockUsersService) UpdateSettings(user UserSpec, settings UserSettings) (Response, error) {
+	s.Calls.record("UpdateSettings", user, settings)
+	if s.UpdateSettings_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("UsersService.UpdateSettings")
+	}
 	return s.UpdateSettings_(user, settings)
 }
 
 func (s MockUsersService) ListEmails(user UserSpec) ([]*EmailAddr, Response, error) {
+	s.Calls.record("ListEmails", user)
+	if s.ListEmails_ == nil {
+		var r0 []*EmailAddr
+		var r1 Response
+		return r0, r1, mockNotImplemented("UsersService.ListEmails")
+	}
 	return s.ListEmails_(user)
 }
 
+func (s MockUsersService) AddEmail(user UserSpec, email string) (*EmailAddr, Response, error) {
+	s.Calls.record("AddEmail", user, email)
+	if s.AddEmail_ == nil {
+		var r0 *EmailAddr
+		var r1 Response
+		return r0, r1, mockNotImplemented("UsersService.AddEmail")
+	}
+	return s.AddEmail_(user, email)
+}
+
+func (s MockUsersService) RemoveEmail(user UserSpec, email string) (Response, error) {
+	s.Calls.record("RemoveEmail", user, email)
+	if s.RemoveEmail_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("UsersService.RemoveEmail")
+	}
+	return s.RemoveEmail_(user, email)
+}
+
+func (s MockUsersService) SetPrimaryEmail(user UserSpec, email string) (Response, error) {
+	s.Calls.record("SetPrimaryEmail", user, email)
+	if s.SetPrimaryEmail_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("UsersService.SetPrimaryEmail")
+	}
+	return s.SetPrimaryEmail_(user, email)
+}
+
 func (s MockUsersService) GetOrCreateFromGitHub(user GitHubUserSpec, opt *UserGetOptions) (*User, Response, error) {
+	s.Calls.record("GetOrCreateFromGitHub", user, opt)
+	if s.GetOrCreateFromGitHub_ == nil {
+		var r0 *User
+		var r1 Response
+		return r0, r1, mockNotImplemented("UsersService.GetOrCreateFromGitHub")
+	}
 	return s.GetOrCreateFromGitHub_(user, opt)
 }
 
 func (s MockUsersService) RefreshProfile(userSpec UserSpec) (Response, error) {
+	s.Calls.record("RefreshProfile", userSpec)
+	if s.RefreshProfile_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("UsersService.RefreshProfile")
+	}
 	return s.RefreshProfile_(userSpec)
 }
 
 func (s MockUsersService) ComputeStats(userSpec UserSpec) (Response, error) {
+	s.Calls.record("ComputeStats", userSpec)
+	if s.ComputeStats_ == nil {
+		var r0 Response
+		return r0, mockNotImplemented("UsersService.ComputeStats")
+	}
 	return s.ComputeStats_(userSpec)
 }
 
-func (s MockUsersService) List(opt *UsersListOptions) ([]*User, Response, error) { return s.List_(opt) }
+func (s MockUsersService) List(opt *UsersListOptions) ([]*User, Response, error) {
+	s.Calls.record("List", opt)
+	if s.List_ == nil {
+		var r0 []*User
+		var r1 Response
+		return r0, r1, mockNotImplemented("UsersService.List")
+	}
+	return s.List_(opt)
+}
 
 func (s MockUsersService) ListAuthors(user UserSpec, opt *UsersListAuthorsOptions) ([]*AugmentedPersonUsageByClient, Response, error) {
+	s.Calls.record("ListAuthors", user, opt)
+	if s.ListAuthors_ == nil {
+		var r0 []*AugmentedPersonUsageByClient
+		var r1 Response
+		return r0, r1, mockNotImplemented("UsersService.ListAuthors")
+	}
 	return s.ListAuthors_(user, opt)
 }
 
 func (s MockUsersService) ListClients(user UserSpec, opt *UsersListClientsOptions) ([]*AugmentedPersonUsageOfAuthor, Response, error) {
+	s.Calls.record("ListClients", user, opt)
+	if s.ListClients_ == nil {
+		var r0 []*AugmentedPersonUsageOfAuthor
+		var r1 Response
+		return r0, r1, mockNotImplemented("UsersService.ListClients")
+	}
 	return s.ListClients_(user, opt)
 }
 
 func (s MockUsersService) ListOrgs(member UserSpec, opt *UsersListOrgsOptions) ([]*Org, Response, error) {
+	s.Calls.record("ListOrgs", member, opt)
+	if s.ListOrgs_ == nil {
+		var r0 []*Org
+		var r1 Response
+		return r0, r1, mockNotImplemented("UsersService.ListOrgs")
+	}
 	return s.ListOrgs_(member, opt)
 }
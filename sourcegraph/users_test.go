@@ -164,6 +164,86 @@ func TestUsersService_ListEmails(t *testing.T) {
 	}
 }
 
+func TestUsersService_AddEmail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := &EmailAddr{Email: "a@a.com"}
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.UserEmailsAdd, map[string]string{"UserSpec": "a"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "POST")
+
+		var email EmailAddr
+		if err := json.NewDecoder(r.Body).Decode(&email); err != nil {
+			t.Fatal(err)
+		}
+		if email.Email != "a@a.com" {
+			t.Errorf("got email %q, want %q", email.Email, "a@a.com")
+		}
+
+		writeJSON(w, want)
+	})
+
+	email, _, err := client.Users.AddEmail(UserSpec{Login: "a"}, "a@a.com")
+	if err != nil {
+		t.Errorf("Users.AddEmail returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+
+	if !reflect.DeepEqual(email, want) {
+		t.Errorf("Users.AddEmail returned %+v, want %+v", email, want)
+	}
+
+	if _, _, err := client.Users.AddEmail(UserSpec{Login: "a"}, "a.com"); err == nil {
+		t.Error("err == nil for invalid email address")
+	}
+}
+
+func TestUsersService_RemoveEmail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.UserEmailRemove, map[string]string{"UserSpec": "a", "Email": "a@a.com"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "DELETE")
+	})
+
+	_, err := client.Users.RemoveEmail(UserSpec{Login: "a"}, "a@a.com")
+	if err != nil {
+		t.Errorf("Users.RemoveEmail returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
+func TestUsersService_SetPrimaryEmail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc(urlPath(t, router.UserEmailSetPrimary, map[string]string{"UserSpec": "a", "Email": "a@a.com"}), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		testMethod(t, r, "PUT")
+	})
+
+	_, err := client.Users.SetPrimaryEmail(UserSpec{Login: "a"}, "a@a.com")
+	if err != nil {
+		t.Errorf("Users.SetPrimaryEmail returned error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("!called")
+	}
+}
+
 func TestUsersService_GetOrCreateFromGitHub(t *testing.T) {
 	setup()
 	defer teardown()
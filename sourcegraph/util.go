@@ -1,6 +1,11 @@
 package sourcegraph
 
-import "strings"
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Bool is a helper routine that allocates a new bool value to store v
 // and returns a pointer to it.
@@ -34,3 +39,54 @@ func ParseRepoAndCommitID(repoAndCommitID string) (uri, commitID string) {
 	}
 	return repoAndCommitID, ""
 }
+
+// escapeRouteVar percent-encodes '%', the characters in reserved, and
+// any '.' that begins a slash-separated component of s. Route patterns
+// disallow those characters where they would be ambiguous (e.g., "@"
+// separates a repository from its revision, and "/." is forbidden to
+// prevent path traversal), so route variable values that contain them
+// must be escaped.
+//
+// The escaping is in addition to the standard URL path escaping
+// applied when the URL is generated (which handles spaces, '#', '?',
+// non-ASCII characters, etc.), so it survives the server's decoding
+// of the request path and must be reversed by unescapeRouteVar.
+func escapeRouteVar(s, reserved string) string {
+	if !strings.ContainsAny(s, "%."+reserved) {
+		return s
+	}
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '%' || strings.IndexByte(reserved, c) != -1 || (c == '.' && (i == 0 || s[i-1] == '/')) {
+			fmt.Fprintf(&buf, "%%%02X", c)
+		} else {
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
+
+// unescapeRouteVar reverses escapeRouteVar.
+func unescapeRouteVar(s string) (string, error) {
+	if strings.IndexByte(s, '%') == -1 {
+		return s, nil
+	}
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			buf.WriteByte(s[i])
+			continue
+		}
+		if i+2 >= len(s) {
+			return "", fmt.Errorf("invalid escape sequence in route variable %q", s)
+		}
+		c, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("invalid escape sequence in route variable %q", s)
+		}
+		buf.WriteByte(byte(c))
+		i += 2
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,144 @@
+package sourcegraph
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// A Validator is a type whose values can check their own
+// invariants. If a Client's StrictValidation field is true,
+// (*Client).Do calls Validate on each decoded response value that
+// implements Validator.
+type Validator interface {
+	// Validate returns a non-nil error describing the first
+	// invariant that the value violates, if any.
+	Validate() error
+}
+
+// An InvalidResponseError is returned by (*Client).Do (when the
+// Client's StrictValidation field is true) if a decoded response
+// value violates one of its type's invariants. It usually indicates
+// that the server's response format has changed.
+type InvalidResponseError struct {
+	Method string // HTTP method of the request
+	URL    string // request URL
+	Err    error  // the violated invariant
+}
+
+func (e *InvalidResponseError) Error() string {
+	return fmt.Sprintf("invalid response from %s %s: %s", e.Method, e.URL, e.Err)
+}
+
+// validateResponse calls Validate on v (the value that a response
+// was decoded into) if it implements Validator. If v is a slice,
+// array, or map (or a pointer to one), Validate is called on each
+// element. Nested struct fields are not traversed; a type's Validate
+// method is responsible for validating its fields.
+func validateResponse(v interface{}) error {
+	switch v.(type) {
+	case *[]byte, *RequestOptions:
+		return nil
+	}
+	return validateValue(reflect.ValueOf(v))
+}
+
+var validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+
+func validateValue(v reflect.Value) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Implements(validatorType) {
+			return v.Interface().(Validator).Validate()
+		}
+		v = v.Elem()
+	}
+	if v.Type().Implements(validatorType) {
+		return v.Interface().(Validator).Validate()
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(validatorType) {
+		return v.Addr().Interface().(Validator).Validate()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(v.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %s", i, err)
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if err := validateValue(v.MapIndex(k)); err != nil {
+				return fmt.Errorf("key %v: %s", k.Interface(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (r *Repo) Validate() error {
+	if r.URI == "" {
+		return errors.New("repo has empty URI")
+	}
+	switch r.VCS {
+	case "", Git, Hg:
+	default:
+		return fmt.Errorf("repo %s has unrecognized VCS %q", r.URI, r.VCS)
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (b *Build) Validate() error {
+	if b.Success && b.Failure {
+		return fmt.Errorf("build %s has both Success and Failure set", b.Spec().IDString())
+	}
+	if b.Killed && !b.Failure {
+		return fmt.Errorf("build %s has Killed set but not Failure", b.Spec().IDString())
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (p *PullRequest) Validate() error {
+	if p.Number == nil {
+		return errors.New("pull request has nil Number")
+	}
+	if p.Spec().Repo == (RepoSpec{}) {
+		return fmt.Errorf("pull request #%d has no BaseRepo (and no HTMLURL to derive it from)", *p.Number)
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (i *Issue) Validate() error {
+	if i.Number == nil {
+		return errors.New("issue has nil Number")
+	}
+	if i.HTMLURL == nil {
+		return fmt.Errorf("issue #%d has nil HTMLURL", *i.Number)
+	}
+	if i.Spec().Repo == (RepoSpec{}) {
+		return fmt.Errorf("issue #%d has malformed HTMLURL %q", *i.Number, *i.HTMLURL)
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c *DeltaAPIChanges) Validate() error {
+	for i, ch := range c.Changes {
+		if ch == nil {
+			return fmt.Errorf("API change %d is nil", i)
+		}
+		switch ch.Kind {
+		case APIChangeAdded, APIChangeRemoved, APIChangeSignatureChanged:
+		default:
+			return fmt.Errorf("API change has unrecognized kind %q", ch.Kind)
+		}
+	}
+	return nil
+}
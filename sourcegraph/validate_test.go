@@ -0,0 +1,53 @@
+package sourcegraph
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/router"
+)
+
+func TestClient_StrictValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(urlPath(t, router.Repos, nil), func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []*Repo{{URI: "r.com/x", VCS: "git"}, {URI: "r.com/y", VCS: "svn"}})
+	})
+
+	// Without strict validation, the unrecognized VCS is accepted.
+	if _, _, err := client.Repos.List(nil); err != nil {
+		t.Fatalf("Repos.List returned error: %v", err)
+	}
+
+	client.StrictValidation = true
+	_, _, err := client.Repos.List(nil)
+	if _, ok := err.(*InvalidResponseError); !ok {
+		t.Fatalf("got error %v (%T), want *InvalidResponseError", err, err)
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	tests := []struct {
+		v       interface{}
+		wantErr bool
+	}{
+		{v: &Repo{URI: "r"}, wantErr: false},
+		{v: &Repo{}, wantErr: true},
+		{v: &[]*Repo{{URI: "r"}, nil}, wantErr: false},
+		{v: &[]*Repo{{URI: "r"}, {URI: "r", VCS: "x"}}, wantErr: true},
+		{v: &map[string]*Repo{"a": {}}, wantErr: true},
+		{v: &[]Build{{Killed: true, Failure: true}}, wantErr: false},
+		{v: &[]Build{{Killed: true}}, wantErr: true},
+		{v: &DeltaAPIChanges{Changes: []*APIChange{{Kind: "x"}}}, wantErr: true},
+		{v: &DeltaAPIChanges{Changes: []*APIChange{{Kind: APIChangeAdded}, nil}}, wantErr: true},
+		{v: &[]byte{1, 2, 3}, wantErr: false},
+		{v: &struct{}{}, wantErr: false},
+	}
+	for _, test := range tests {
+		err := validateResponse(test.v)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%#v: got error %v, want error: %v", test.v, err, test.wantErr)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+// Package state exports the configuration of repositories on a
+// Sourcegraph server (their settings and notification destinations,
+// such as webhooks) to a declarative document, and reconciles a server
+// with a document describing its desired state.
+//
+// A typical reconciliation loop is:
+//
+//	doc, err := state.Read(f)
+//	...
+//	changes, err := state.Plan(client, doc)
+//	...
+//	for _, c := range changes {
+//		fmt.Println(c)
+//	}
+//	err = state.Apply(client, changes)
+//
+// Only repositories' settings and notification destinations are
+// managed. Documents are JSON only (no YAML), external services are
+// not covered (the client has no API for them), and repositories
+// absent from a document are never deleted (the API can't delete
+// repositories).
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+// A Document describes the configuration of a set of repositories.
+type Document struct {
+	Repos []*Repo
+}
+
+// Repo describes the configuration of a repository.
+type Repo struct {
+	URI string
+
+	// VCS and CloneURL are used to create the repository if it doesn't
+	// exist. They are ignored for existing repositories.
+	VCS      string `json:",omitempty"`
+	CloneURL string `json:",omitempty"`
+
+	// Settings are the repository's settings. Nil settings are left
+	// unchanged by Plan.
+	Settings sourcegraph.RepoSettings
+
+	// NotificationDestinations are the repository's notification
+	// destinations. Their IDs are ignored; a destination is identified
+	// by its type and target URL. Webhook secrets are never returned by
+	// the server, so a destination whose secret alone differs is not
+	// updated.
+	NotificationDestinations []*sourcegraph.NotificationDestination `json:",omitempty"`
+}
+
+// Read decodes a JSON-encoded Document from r.
+func Read(r io.Reader) (*Document, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Write encodes doc as indented JSON to w.
+func (doc *Document) Write(w io.Writer) error {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// Export returns a Document describing the current configuration of
+// the repositories listed by c.Repos.List(opt).
+func Export(c *sourcegraph.Client, opt *sourcegraph.RepoListOptions) (*Document, error) {
+	var opt2 sourcegraph.RepoListOptions
+	if opt != nil {
+		opt2 = *opt
+	}
+
+	var doc Document
+	var g sourcegraph.PaginationGuard
+	for opt2.Page = 1; ; opt2.Page++ {
+		repos, resp, err := c.Repos.List(&opt2)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, len(repos))
+		for i, repo := range repos {
+			ids[i] = repo.URI
+		}
+		if err := g.Add(resp, ids); err != nil {
+			return nil, err
+		}
+
+		for _, repo := range repos {
+			r, err := exportRepo(c, repo)
+			if err != nil {
+				return nil, fmt.Errorf("exporting repo %s: %s", repo.URI, err)
+			}
+			doc.Repos = append(doc.Repos, r)
+		}
+
+		if len(repos) < opt2.PerPageOrDefault() {
+			break
+		}
+	}
+	if err := g.Done(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func exportRepo(c *sourcegraph.Client, repo *sourcegraph.Repo) (*Repo, error) {
+	spec := sourcegraph.RepoSpec{URI: repo.URI}
+	settings, _, err := c.Repos.GetSettings(spec)
+	if err != nil {
+		return nil, err
+	}
+	dests, err := listDestinations(c, spec)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dests {
+		d.ID = 0
+	}
+	return &Repo{
+		URI:      repo.URI,
+		VCS:      repo.VCS,
+		CloneURL: repo.HTTPCloneURL,
+		Settings: *settings,
+
+		NotificationDestinations: dests,
+	}, nil
+}
+
+// listDestinations lists all of a repository's notification
+// destinations.
+func listDestinations(c *sourcegraph.Client, repo sourcegraph.RepoSpec) ([]*sourcegraph.NotificationDestination, error) {
+	var all []*sourcegraph.NotificationDestination
+	var g sourcegraph.PaginationGuard
+	opt := &sourcegraph.NotificationDestinationListOptions{}
+	for opt.Page = 1; ; opt.Page++ {
+		dests, resp, err := c.Notifications.ListDestinations(repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, len(dests))
+		for i, d := range dests {
+			ids[i] = strconv.Itoa(d.ID)
+		}
+		if err := g.Add(resp, ids); err != nil {
+			return nil, err
+		}
+		all = append(all, dests...)
+		if len(dests) < opt.PerPageOrDefault() {
+			break
+		}
+	}
+	if err := g.Done(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// An Action is a kind of change to a resource.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// A Change is a single change that Apply makes to reconcile a server
+// with a Document.
+type Change struct {
+	Action Action
+
+	// RepoURI is the URI of the repository that the change applies to.
+	RepoURI string
+
+	// Exactly one of the following fields is set, depending on the kind
+	// of resource that the change applies to.
+
+	// Repo is the repository to create (with Action Create).
+	Repo *Repo `json:",omitempty"`
+
+	// Settings are the repository settings to set (with Action
+	// Update).
+	Settings *sourcegraph.RepoSettings `json:",omitempty"`
+
+	// Destination is the notification destination to create, update
+	// (whose ID is that of the existing destination), or delete.
+	Destination *sourcegraph.NotificationDestination `json:",omitempty"`
+}
+
+func (c *Change) String() string {
+	switch {
+	case c.Repo != nil:
+		return fmt.Sprintf("%s repo %s", c.Action, c.RepoURI)
+	case c.Settings != nil:
+		return fmt.Sprintf("%s settings of repo %s", c.Action, c.RepoURI)
+	case c.Destination != nil:
+		return fmt.Sprintf("%s notification destination %s of repo %s", c.Action, destinationKey(c.Destination), c.RepoURI)
+	}
+	return fmt.Sprintf("%s (empty change) of repo %s", c.Action, c.RepoURI)
+}
+
+// Plan returns the changes that Apply must make to reconcile the
+// server that c communicates with with doc.
+//
+// Repositories that aren't in doc are left unchanged (the API doesn't
+// support deleting repositories). The notification destinations of a
+// repository in doc that aren't listed in doc are deleted.
+func Plan(c *sourcegraph.Client, doc *Document) ([]*Change, error) {
+	var changes []*Change
+	seen := map[string]struct{}{}
+	for _, want := range doc.Repos {
+		if _, dup := seen[want.URI]; dup {
+			return nil, fmt.Errorf("repo %s is listed more than once", want.URI)
+		}
+		seen[want.URI] = struct{}{}
+
+		repoChanges, err := planRepo(c, want)
+		if err != nil {
+			return nil, fmt.Errorf("planning changes to repo %s: %s", want.URI, err)
+		}
+		changes = append(changes, repoChanges...)
+	}
+	return changes, nil
+}
+
+func planRepo(c *sourcegraph.Client, want *Repo) ([]*Change, error) {
+	spec := sourcegraph.RepoSpec{URI: want.URI}
+
+	var changes []*Change
+	var settings sourcegraph.RepoSettings
+	var dests []*sourcegraph.NotificationDestination
+	if _, _, err := c.Repos.Get(spec, nil); sourcegraph.IsNotFound(err) {
+		changes = append(changes, &Change{Action: Create, RepoURI: want.URI, Repo: want})
+	} else if err != nil {
+		return nil, err
+	} else {
+		cur, _, err := c.Repos.GetSettings(spec)
+		if err != nil {
+			return nil, err
+		}
+		settings = *cur
+		if dests, err = listDestinations(c, spec); err != nil {
+			return nil, err
+		}
+	}
+
+	if !settingsSatisfied(settings, want.Settings) {
+		s := want.Settings
+		changes = append(changes, &Change{Action: Update, RepoURI: want.URI, Settings: &s})
+	}
+
+	cur := map[string]*sourcegraph.NotificationDestination{}
+	for _, d := range dests {
+		cur[destinationKey(d)] = d
+	}
+	wanted := map[string]struct{}{}
+	for _, d := range want.NotificationDestinations {
+		key := destinationKey(d)
+		if _, dup := wanted[key]; dup {
+			return nil, fmt.Errorf("notification destination %s is listed more than once", key)
+		}
+		wanted[key] = struct{}{}
+
+		if existing, ok := cur[key]; !ok {
+			changes = append(changes, &Change{Action: Create, RepoURI: want.URI, Destination: d})
+		} else if !destinationsEqual(existing, d) {
+			d2 := *d
+			d2.ID = existing.ID
+			changes = append(changes, &Change{Action: Update, RepoURI: want.URI, Destination: &d2})
+		}
+	}
+	for _, d := range dests {
+		if _, ok := wanted[destinationKey(d)]; !ok {
+			changes = append(changes, &Change{Action: Delete, RepoURI: want.URI, Destination: d})
+		}
+	}
+	return changes, nil
+}
+
+// settingsSatisfied returns true if each non-nil setting in want has
+// the same value in cur.
+func settingsSatisfied(cur, want sourcegraph.RepoSettings) bool {
+	satisfied := func(cur, want *bool) bool {
+		return want == nil || (cur != nil && *cur == *want)
+	}
+	return satisfied(cur.Enabled, want.Enabled) &&
+		satisfied(cur.BuildPushes, want.BuildPushes) &&
+		satisfied(cur.ExternalCommitStatuses, want.ExternalCommitStatuses) &&
+		satisfied(cur.UnsuccessfulExternalCommitStatuses, want.UnsuccessfulExternalCommitStatuses) &&
+		satisfied(cur.UseSSHPrivateKey, want.UseSSHPrivateKey)
+}
+
+// destinationKey returns the type and target URL of d, which identify
+// it among a repository's notification destinations.
+func destinationKey(d *sourcegraph.NotificationDestination) string {
+	switch {
+	case d.Slack != nil:
+		return d.Type + " " + d.Slack.WebhookURL + " " + d.Slack.Channel
+	case d.Webhook != nil:
+		return d.Type + " " + d.Webhook.URL
+	}
+	return d.Type
+}
+
+// destinationsEqual returns true if a and b have the same
+// configuration, ignoring their IDs and webhook secrets (which the
+// server never returns).
+func destinationsEqual(a, b *sourcegraph.NotificationDestination) bool {
+	normalize := func(d sourcegraph.NotificationDestination) sourcegraph.NotificationDestination {
+		d.ID = 0
+		if d.Webhook != nil {
+			w := *d.Webhook
+			w.Secret = ""
+			d.Webhook = &w
+		}
+		if len(d.Events) == 0 {
+			d.Events = nil
+		}
+		return d
+	}
+	return reflect.DeepEqual(normalize(*a), normalize(*b))
+}
+
+// Apply makes changes (as returned by Plan) using c, in order. It
+// stops at the first change that fails.
+func Apply(c *sourcegraph.Client, changes []*Change) error {
+	// Created repos may be assigned a different URI by the server.
+	uris := map[string]string{}
+
+	for _, ch := range changes {
+		uri := ch.RepoURI
+		if u, ok := uris[uri]; ok {
+			uri = u
+		}
+		spec := sourcegraph.RepoSpec{URI: uri}
+
+		var err error
+		switch {
+		case ch.Repo != nil && ch.Action == Create:
+			var repo *sourcegraph.Repo
+			repo, _, err = c.Repos.Create(sourcegraph.NewRepoSpec{Type: ch.Repo.VCS, CloneURLStr: ch.Repo.CloneURL})
+			if err == nil {
+				uris[ch.RepoURI] = repo.URI
+			}
+		case ch.Settings != nil && ch.Action == Update:
+			_, err = c.Repos.UpdateSettings(spec, *ch.Settings)
+		case ch.Destination != nil && ch.Action == Create:
+			_, _, err = c.Notifications.CreateDestination(spec, ch.Destination)
+		case ch.Destination != nil && ch.Action == Update:
+			_, _, err = c.Notifications.UpdateDestination(sourcegraph.NotificationDestinationSpec{Repo: spec, ID: ch.Destination.ID}, ch.Destination)
+		case ch.Destination != nil && ch.Action == Delete:
+			_, err = c.Notifications.DeleteDestination(sourcegraph.NotificationDestinationSpec{Repo: spec, ID: ch.Destination.ID})
+		default:
+			err = fmt.Errorf("unsupported change")
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %s", ch, err)
+		}
+	}
+	return nil
+}
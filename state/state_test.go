@@ -0,0 +1,199 @@
+package state
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestPlanAndApply(t *testing.T) {
+	hook := func(url string) *sourcegraph.NotificationDestination {
+		return &sourcegraph.NotificationDestination{Type: sourcegraph.NotificationWebhook, Webhook: &sourcegraph.WebhookDestination{URL: url}}
+	}
+
+	c := sourcegraph.NewMockClient()
+	c.Repos = sourcegraph.MockReposService{
+		Get_: func(repo sourcegraph.RepoSpec, opt *sourcegraph.RepoGetOptions) (*sourcegraph.Repo, sourcegraph.Response, error) {
+			if repo.URI == "new/r" {
+				return nil, nil, &sourcegraph.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+			}
+			return &sourcegraph.Repo{URI: repo.URI}, nil, nil
+		},
+		GetSettings_: func(repo sourcegraph.RepoSpec) (*sourcegraph.RepoSettings, sourcegraph.Response, error) {
+			return &sourcegraph.RepoSettings{Enabled: boolPtr(true), BuildPushes: boolPtr(false)}, nil, nil
+		},
+	}
+	c.Notifications = sourcegraph.MockNotificationsService{
+		ListDestinations_: func(repo sourcegraph.RepoSpec, opt *sourcegraph.NotificationDestinationListOptions) ([]*sourcegraph.NotificationDestination, sourcegraph.Response, error) {
+			keep, del, upd := hook("https://keep"), hook("https://delete"), hook("https://update")
+			keep.ID, del.ID, upd.ID = 1, 2, 3
+			upd.Disabled = true
+			return []*sourcegraph.NotificationDestination{keep, del, upd}, nil, nil
+		},
+	}
+
+	doc := &Document{Repos: []*Repo{
+		{
+			URI:                      "old/r",
+			Settings:                 sourcegraph.RepoSettings{Enabled: boolPtr(true), BuildPushes: boolPtr(true)},
+			NotificationDestinations: []*sourcegraph.NotificationDestination{hook("https://keep"), hook("https://update"), hook("https://create")},
+		},
+		{URI: "new/r", VCS: "git", CloneURL: "https://example.com/r.git"},
+	}}
+
+	changes, err := Plan(c, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, ch := range changes {
+		got = append(got, ch.String())
+	}
+	want := []string{
+		"update settings of repo old/r",
+		"update notification destination webhook https://update of repo old/r",
+		"create notification destination webhook https://create of repo old/r",
+		"delete notification destination webhook https://delete of repo old/r",
+		"create repo new/r",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got changes %q, want %q", got, want)
+	}
+
+	var applied []string
+	c.Repos = sourcegraph.MockReposService{
+		Create_: func(spec sourcegraph.NewRepoSpec) (*sourcegraph.Repo, sourcegraph.Response, error) {
+			applied = append(applied, "create "+spec.CloneURLStr)
+			return &sourcegraph.Repo{URI: "example.com/r"}, nil, nil
+		},
+		UpdateSettings_: func(repo sourcegraph.RepoSpec, settings sourcegraph.RepoSettings) (sourcegraph.Response, error) {
+			applied = append(applied, "settings "+repo.URI)
+			return nil, nil
+		},
+	}
+	c.Notifications = sourcegraph.MockNotificationsService{
+		CreateDestination_: func(repo sourcegraph.RepoSpec, dest *sourcegraph.NotificationDestination) (*sourcegraph.NotificationDestination, sourcegraph.Response, error) {
+			applied = append(applied, "create dest "+dest.Webhook.URL)
+			return dest, nil, nil
+		},
+		UpdateDestination_: func(dest sourcegraph.NotificationDestinationSpec, config *sourcegraph.NotificationDestination) (*sourcegraph.NotificationDestination, sourcegraph.Response, error) {
+			if dest.ID != 3 || config.Disabled {
+				t.Errorf("got update of destination %d (disabled=%v), want destination 3 enabled", dest.ID, config.Disabled)
+			}
+			applied = append(applied, "update dest "+config.Webhook.URL)
+			return config, nil, nil
+		},
+		DeleteDestination_: func(dest sourcegraph.NotificationDestinationSpec) (sourcegraph.Response, error) {
+			if dest.ID != 2 {
+				t.Errorf("got delete of destination %d, want 2", dest.ID)
+			}
+			applied = append(applied, "delete dest")
+			return nil, nil
+		},
+	}
+	if err := Apply(c, changes); err != nil {
+		t.Fatal(err)
+	}
+	wantApplied := []string{
+		"settings old/r",
+		"update dest https://update",
+		"create dest https://create",
+		"delete dest",
+		"create https://example.com/r.git",
+	}
+	if !reflect.DeepEqual(applied, wantApplied) {
+		t.Errorf("got applied %q, want %q", applied, wantApplied)
+	}
+}
+
+func TestExport_roundTrip(t *testing.T) {
+	settings := map[string]*sourcegraph.RepoSettings{
+		"a/r": {Enabled: boolPtr(true), BuildPushes: boolPtr(false)},
+		"b/r": {Enabled: boolPtr(false)},
+	}
+	dests := map[string][]*sourcegraph.NotificationDestination{
+		"a/r": {{ID: 1, Type: sourcegraph.NotificationWebhook, Webhook: &sourcegraph.WebhookDestination{URL: "https://hook"}}},
+	}
+
+	c := sourcegraph.NewMockClient()
+	c.Repos = sourcegraph.MockReposService{
+		List_: func(opt *sourcegraph.RepoListOptions) ([]*sourcegraph.Repo, sourcegraph.Response, error) {
+			pages := [][]*sourcegraph.Repo{{{URI: "a/r", VCS: "git"}}, {{URI: "b/r", VCS: "git"}}}
+			if opt.Page > len(pages) {
+				return nil, nil, nil
+			}
+			return pages[opt.Page-1], nil, nil
+		},
+		Get_: func(repo sourcegraph.RepoSpec, opt *sourcegraph.RepoGetOptions) (*sourcegraph.Repo, sourcegraph.Response, error) {
+			return &sourcegraph.Repo{URI: repo.URI}, nil, nil
+		},
+		GetSettings_: func(repo sourcegraph.RepoSpec) (*sourcegraph.RepoSettings, sourcegraph.Response, error) {
+			s := *settings[repo.URI]
+			return &s, nil, nil
+		},
+	}
+	c.Notifications = sourcegraph.MockNotificationsService{
+		ListDestinations_: func(repo sourcegraph.RepoSpec, opt *sourcegraph.NotificationDestinationListOptions) ([]*sourcegraph.NotificationDestination, sourcegraph.Response, error) {
+			var ds []*sourcegraph.NotificationDestination
+			for _, d := range dests[repo.URI] {
+				d2 := *d
+				ds = append(ds, &d2)
+			}
+			return ds, nil, nil
+		},
+	}
+
+	doc, err := Export(c, &sourcegraph.RepoListOptions{ListOptions: sourcegraph.ListOptions{PerPage: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Repos) != 2 {
+		t.Fatalf("got %d repos, want 2", len(doc.Repos))
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	doc2, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(doc2, doc) {
+		t.Errorf("got %+v after round trip, want %+v", doc2, doc)
+	}
+
+	changes, err := Plan(c, doc2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("got changes %v for an exported document, want none", changes)
+	}
+}
+
+func TestDocument_roundTrip(t *testing.T) {
+	doc := &Document{Repos: []*Repo{{
+		URI:      "r",
+		Settings: sourcegraph.RepoSettings{Enabled: boolPtr(true)},
+		NotificationDestinations: []*sourcegraph.NotificationDestination{
+			{Type: sourcegraph.NotificationSlack, Slack: &sourcegraph.SlackDestination{WebhookURL: "https://slack", Channel: "#c"}},
+		},
+	}}}
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	doc2, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(doc2, doc) {
+		t.Errorf("got %+v, want %+v", doc2, doc)
+	}
+}
@@ -0,0 +1,212 @@
+// Package webhooks decodes and verifies the notifications that a
+// Sourcegraph server POSTs to webhook notification destinations (see
+// sourcegraph.WebhookDestination).
+//
+// A typical handler is:
+//
+//	func handleWebhook(w http.ResponseWriter, r *http.Request) {
+//		payload, err := webhooks.ParseWebhook(r, secret)
+//		if err != nil {
+//			http.Error(w, err.Error(), http.StatusBadRequest)
+//			return
+//		}
+//		switch p := payload.(type) {
+//		case *webhooks.BuildCompleted:
+//			...
+//		case *webhooks.PullRequestOpened:
+//			...
+//		}
+//	}
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+// Headers of webhook requests.
+const (
+	// EventHeader holds the event name (a sourcegraph.NotificationEvent).
+	EventHeader = "X-Sourcegraph-Event"
+
+	// DeliveryHeader holds a unique ID for each delivery, which is
+	// reused when a delivery is retried.
+	DeliveryHeader = "X-Sourcegraph-Delivery"
+
+	// SignatureHeader holds the signature of the payload ("sha256="
+	// followed by the hex-encoded HMAC-SHA256 of the request body,
+	// keyed with the destination's secret).
+	SignatureHeader = "X-Sourcegraph-Signature"
+)
+
+// MaxPayloadBytes is the maximum size of a webhook payload that
+// ParseWebhook reads.
+const MaxPayloadBytes = 5 << 20
+
+// ErrInvalidSignature is returned by ParseWebhook if the request's
+// signature is missing or does not match its payload.
+var ErrInvalidSignature = errors.New("webhook signature is missing or invalid")
+
+// ErrNoSecret is returned by ParseWebhook if it is called with an empty
+// secret.
+var ErrNoSecret = errors.New("no webhook secret given (use ParseUnsignedWebhook for destinations without a secret)")
+
+// An UnknownEventError is returned by ParseWebhook for events that it
+// has no payload type for (such as events added in newer versions of
+// the server). Handlers may ignore such events.
+type UnknownEventError struct {
+	Event sourcegraph.NotificationEvent
+}
+
+func (e *UnknownEventError) Error() string {
+	return fmt.Sprintf("unknown webhook event %q", e.Event)
+}
+
+// BuildStarted is the payload of sourcegraph.EventBuildStarted
+// notifications.
+type BuildStarted struct {
+	Repo  sourcegraph.RepoSpec
+	Build *sourcegraph.Build
+}
+
+// BuildCompleted is the payload of sourcegraph.EventBuildSucceeded and
+// sourcegraph.EventBuildFailed notifications. Build.Success or
+// Build.Failure indicates the outcome.
+type BuildCompleted struct {
+	Repo  sourcegraph.RepoSpec
+	Build *sourcegraph.Build
+}
+
+// RepoUpdated is the payload of sourcegraph.EventRepoPush
+// notifications.
+type RepoUpdated struct {
+	Repo *sourcegraph.Repo
+
+	Ref    string                 // the updated ref (e.g., "refs/heads/master")
+	Before string                 // the commit ID the ref pointed to before the push (empty if the ref is new)
+	After  string                 // the commit ID the ref points to after the push (empty if the ref was deleted)
+	Pusher sourcegraph.PersonSpec `json:",omitempty"`
+}
+
+// PullRequestOpened is the payload of
+// sourcegraph.EventPullRequestOpened notifications.
+type PullRequestOpened struct {
+	Repo        sourcegraph.RepoSpec
+	PullRequest *sourcegraph.PullRequest
+}
+
+// CommentCreated is the payload of sourcegraph.EventCommentCreated
+// notifications. Exactly one of Issue and PullRequest is set, and
+// exactly one of IssueComment and PullRequestComment is set (comments
+// on pull requests may be either review comments on the diff or
+// issue-style comments on the conversation).
+type CommentCreated struct {
+	Repo sourcegraph.RepoSpec
+
+	Issue       *sourcegraph.IssueSpec       `json:",omitempty"`
+	PullRequest *sourcegraph.PullRequestSpec `json:",omitempty"`
+
+	IssueComment       *sourcegraph.IssueComment       `json:",omitempty"`
+	PullRequestComment *sourcegraph.PullRequestComment `json:",omitempty"`
+}
+
+// newPayload returns a pointer to a new payload struct for event, or
+// nil if the event is unknown.
+func newPayload(event sourcegraph.NotificationEvent) interface{} {
+	switch event {
+	case sourcegraph.EventBuildStarted:
+		return &BuildStarted{}
+	case sourcegraph.EventBuildSucceeded, sourcegraph.EventBuildFailed:
+		return &BuildCompleted{}
+	case sourcegraph.EventRepoPush:
+		return &RepoUpdated{}
+	case sourcegraph.EventPullRequestOpened:
+		return &PullRequestOpened{}
+	case sourcegraph.EventCommentCreated:
+		return &CommentCreated{}
+	}
+	return nil
+}
+
+// ParseWebhook reads a webhook request, verifies its signature, and
+// returns its decoded payload (a pointer to one of the payload types in
+// this package, determined by the event name in the EventHeader).
+//
+// It returns ErrNoSecret if secret is empty, so that a missing secret
+// (e.g., an unset environment variable) doesn't silently disable
+// verification. Use ParseUnsignedWebhook for destinations that were
+// configured without a secret.
+func ParseWebhook(r *http.Request, secret []byte) (interface{}, error) {
+	if len(secret) == 0 {
+		return nil, ErrNoSecret
+	}
+	return parseWebhook(r, secret)
+}
+
+// ParseUnsignedWebhook is like ParseWebhook, but it doesn't verify the
+// request's signature. It should be used only for destinations that
+// were configured without a secret, whose requests anyone can forge.
+func ParseUnsignedWebhook(r *http.Request) (interface{}, error) {
+	return parseWebhook(r, nil)
+}
+
+// parseWebhook implements ParseWebhook and ParseUnsignedWebhook. The
+// signature is verified only if secret is non-empty.
+func parseWebhook(r *http.Request, secret []byte) (interface{}, error) {
+	if r.Method != "POST" {
+		return nil, fmt.Errorf("webhook request has method %s (want POST)", r.Method)
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, MaxPayloadBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secret) > 0 && !ValidSignature(r.Header.Get(SignatureHeader), body, secret) {
+		return nil, ErrInvalidSignature
+	}
+
+	event := sourcegraph.NotificationEvent(r.Header.Get(EventHeader))
+	if event == "" {
+		return nil, fmt.Errorf("webhook request has no %s header", EventHeader)
+	}
+	payload := newPayload(event)
+	if payload == nil {
+		return nil, &UnknownEventError{Event: event}
+	}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return nil, fmt.Errorf("decoding %s webhook payload: %s", event, err)
+	}
+	return payload, nil
+}
+
+// Sign returns the signature of a payload (in the format of the
+// SignatureHeader value). It may be used to test webhook handlers.
+func Sign(payload, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidSignature reports whether signature (a SignatureHeader value)
+// is the valid signature of payload with the given secret.
+func ValidSignature(signature string, payload, secret []byte) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
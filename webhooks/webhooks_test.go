@@ -0,0 +1,103 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fossas/go-sourcegraph/sourcegraph"
+)
+
+func newRequest(event sourcegraph.NotificationEvent, body, signature string) *http.Request {
+	r := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	if event != "" {
+		r.Header.Set(EventHeader, string(event))
+	}
+	if signature != "" {
+		r.Header.Set(SignatureHeader, signature)
+	}
+	return r
+}
+
+func TestParseWebhook(t *testing.T) {
+	secret := []byte("s3cret")
+	tests := []struct {
+		event sourcegraph.NotificationEvent
+		body  string
+		want  interface{}
+	}{
+		{
+			event: sourcegraph.EventBuildFailed,
+			body:  `{"Repo":{"URI":"r.com/x"},"Build":{"BID":1,"Failure":true}}`,
+			want:  &BuildCompleted{Repo: sourcegraph.RepoSpec{URI: "r.com/x"}, Build: &sourcegraph.Build{BID: 1, Failure: true}},
+		},
+		{
+			event: sourcegraph.EventRepoPush,
+			body:  `{"Repo":{"URI":"r.com/x"},"Ref":"refs/heads/master","Before":"a","After":"b"}`,
+			want:  &RepoUpdated{Repo: &sourcegraph.Repo{URI: "r.com/x"}, Ref: "refs/heads/master", Before: "a", After: "b"},
+		},
+		{
+			event: sourcegraph.EventPullRequestOpened,
+			body:  `{"Repo":{"URI":"r.com/x"},"PullRequest":{}}`,
+			want:  &PullRequestOpened{Repo: sourcegraph.RepoSpec{URI: "r.com/x"}, PullRequest: &sourcegraph.PullRequest{}},
+		},
+		{
+			event: sourcegraph.EventCommentCreated,
+			body:  `{"Repo":{"URI":"r.com/x"},"Issue":{"Repo":{"URI":"r.com/x"},"Number":2},"IssueComment":{}}`,
+			want: &CommentCreated{
+				Repo:         sourcegraph.RepoSpec{URI: "r.com/x"},
+				Issue:        &sourcegraph.IssueSpec{Repo: sourcegraph.RepoSpec{URI: "r.com/x"}, Number: 2},
+				IssueComment: &sourcegraph.IssueComment{},
+			},
+		},
+	}
+	for _, test := range tests {
+		payload, err := ParseWebhook(newRequest(test.event, test.body, Sign([]byte(test.body), secret)), secret)
+		if err != nil {
+			t.Errorf("%s: ParseWebhook returned error: %v", test.event, err)
+			continue
+		}
+		if !reflect.DeepEqual(payload, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.event, payload, test.want)
+		}
+	}
+}
+
+func TestParseWebhook_signature(t *testing.T) {
+	secret := []byte("s3cret")
+	body := `{"Repo":{"URI":"r.com/x"}}`
+
+	for _, sig := range []string{"", "sha256=00", Sign([]byte(body), []byte("other")), strings.TrimPrefix(Sign([]byte(body), secret), "sha256=")} {
+		if _, err := ParseWebhook(newRequest(sourcegraph.EventRepoPush, body, sig), secret); err != ErrInvalidSignature {
+			t.Errorf("signature %q: got error %v, want ErrInvalidSignature", sig, err)
+		}
+	}
+
+	// An empty secret is an error, not a reason to skip verification.
+	if _, err := ParseWebhook(newRequest(sourcegraph.EventRepoPush, body, ""), nil); err != ErrNoSecret {
+		t.Errorf("got error %v with no secret, want ErrNoSecret", err)
+	}
+}
+
+func TestParseUnsignedWebhook(t *testing.T) {
+	payload, err := ParseUnsignedWebhook(newRequest(sourcegraph.EventRepoPush, `{"Repo":{"URI":"r.com/x"}}`, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := payload.(*RepoUpdated); !ok || p.Repo.URI != "r.com/x" {
+		t.Errorf("got payload %+v, want *RepoUpdated for r.com/x", payload)
+	}
+}
+
+func TestParseWebhook_unknownEvent(t *testing.T) {
+	_, err := ParseUnsignedWebhook(newRequest("repo.exploded", "{}", ""))
+	if e, ok := err.(*UnknownEventError); !ok || e.Event != "repo.exploded" {
+		t.Errorf("got error %v, want *UnknownEventError", err)
+	}
+
+	if _, err := ParseUnsignedWebhook(newRequest("", "{}", "")); err == nil {
+		t.Error("got nil error for request with no event, want error")
+	}
+}